@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/cheggaaa/pb"
+	"github.com/minio/mc/pkg/console"
 	"github.com/minio/mc/pkg/probe"
 )
 
@@ -68,16 +69,32 @@ func (a *accounter) write(current int64) float64 {
 // writer update new accounting data for a specified refreshRate.
 func (a *accounter) writer() {
 	a.Update()
+	lastProgress := time.Now()
 	for {
 		select {
 		case <-a.isFinished:
 			return
 		case <-time.After(a.refreshRate):
 			a.Update()
+			// Progress lines stand in for the progress bar in CI mode.
+			if globalCI && !globalJSON && time.Since(lastProgress) >= globalProgressInterval {
+				lastProgress = time.Now()
+				console.Println(a.progress().String())
+			}
 		}
 	}
 }
 
+// progress - returns the stats captured so far.
+func (a *accounter) progress() accountStat {
+	current := atomic.LoadInt64(&a.current)
+	return accountStat{
+		Total:       a.Total,
+		Transferred: current,
+		Speed:       a.write(current),
+	}
+}
+
 // accountStat cantainer for current stats captured.
 type accountStat struct {
 	Status      string  `json:"status"`
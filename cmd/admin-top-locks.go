@@ -84,7 +84,7 @@ func (u lockMessage) String() string {
 		Field{"Type", typeFieldMaxLen},
 		Field{"Owner", ownerFieldMaxLen},
 		Field{"Resource", resourceFieldMaxLen},
-	).buildRow(timeDiff, u.Lock.Type, u.Lock.Owner, u.Lock.Resource))
+	).fitWidth(getTermWidth()).buildRow(timeDiff, u.Lock.Type, u.Lock.Owner, u.Lock.Resource))
 }
 
 // JSON jsonified top oldest locks message.
@@ -137,7 +137,7 @@ func printHeaders() {
 		Field{"Type", typeFieldMaxLen},
 		Field{"Owner", ownerFieldMaxLen},
 		Field{"Resource", resourceFieldMaxLen},
-	).buildRow("Time", "Type", "Owner", "Resource")))
+	).fitWidth(getTermWidth()).buildRow("Time", "Type", "Owner", "Resource")))
 }
 
 // Prints oldest locks.
@@ -0,0 +1,208 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/credentials"
+)
+
+const (
+	// Lifetime of the credentials of an assumed role.
+	assumeRoleDuration = time.Hour
+
+	// The role is assumed again this long before its credentials expire,
+	// requests of long running commands are not signed with expired ones.
+	assumeRoleRenewal = 5 * time.Minute
+)
+
+// AWS STS endpoint, other servers are asked on their own endpoint.
+const awsSTSEndpoint = "https://sts.amazonaws.com"
+
+// stsCredentials - temporary credentials of an assumed role.
+type stsCredentials struct {
+	AccessKeyID     string    `xml:"AccessKeyId"`
+	SecretAccessKey string    `xml:"SecretAccessKey"`
+	SessionToken    string    `xml:"SessionToken"`
+	Expiration      time.Time `xml:"Expiration"`
+}
+
+// assumeRoleResponse - response of the STS AssumeRole API.
+type assumeRoleResponse struct {
+	XMLName xml.Name `xml:"AssumeRoleResponse"`
+	Result  struct {
+		Credentials stsCredentials `xml:"Credentials"`
+	} `xml:"AssumeRoleResult"`
+}
+
+// stsErrorResponse - error returned by the STS API.
+type stsErrorResponse struct {
+	XMLName xml.Name `xml:"ErrorResponse"`
+	Error   struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	} `xml:"Error"`
+}
+
+// Credentials of the role assumed for each host and base access key,
+// shared by the clients of the command.
+var (
+	assumedRolesMutex sync.Mutex
+	assumedRoles      = make(map[string]*credentials.Credentials)
+)
+
+// assumeRoleProvider - assumes the role set by ‘--role-arn’ with the
+// base credentials of an alias, again before the credentials expire.
+type assumeRoleProvider struct {
+	endpoint   string
+	accessKey  string
+	secretKey  string
+	signerType credentials.SignatureType
+	expiration time.Time
+}
+
+// Retrieve implements credentials.Provider.
+func (p *assumeRoleProvider) Retrieve() (credentials.Value, error) {
+	creds, err := assumeRole(p.endpoint, p.accessKey, p.secretKey, globalRoleARN, globalExternalID)
+	if err != nil {
+		return credentials.Value{}, err.ToGoError()
+	}
+	p.expiration = creds.Expiration
+	return credentials.Value{
+		AccessKeyID:     creds.AccessKeyID,
+		SecretAccessKey: creds.SecretAccessKey,
+		SessionToken:    creds.SessionToken,
+		SignerType:      p.signerType,
+	}, nil
+}
+
+// IsExpired implements credentials.Provider.
+func (p *assumeRoleProvider) IsExpired() bool {
+	return !UTCNow().Before(p.expiration.Add(-assumeRoleRenewal))
+}
+
+// stsEndpoint - returns the STS endpoint of the S3 endpoint hostURL.
+func stsEndpoint(hostURL string) string {
+	u := newClientURL(hostURL)
+	if isAmazon(u.Host) {
+		return awsSTSEndpoint
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// assumeRoleConfig - signs the requests of s3Config with the credentials
+// of the role set by ‘--role-arn’, assumed with its own. They are renewed
+// before they expire.
+func assumeRoleConfig(s3Config *Config) *probe.Error {
+	endpoint := stsEndpoint(s3Config.HostURL)
+	if s3Config.Endpoint != "" {
+		endpoint = stsEndpoint(s3Config.Endpoint)
+	}
+	key := endpoint + "/" + s3Config.AccessKey
+
+	assumedRolesMutex.Lock()
+	defer assumedRolesMutex.Unlock()
+	creds, ok := assumedRoles[key]
+	if !ok {
+		provider := &assumeRoleProvider{
+			endpoint:   endpoint,
+			accessKey:  s3Config.AccessKey,
+			secretKey:  s3Config.SecretKey,
+			signerType: credentials.SignatureV4,
+		}
+		if strings.EqualFold(s3Config.Signature, "S3v2") {
+			provider.signerType = credentials.SignatureV2
+		}
+		creds = credentials.New(provider)
+		// Roles which cannot be assumed fail the command right away.
+		if _, e := creds.Get(); e != nil {
+			return probe.NewError(e).Trace(endpoint, globalRoleARN)
+		}
+		assumedRoles[key] = creds
+	}
+	s3Config.Credentials = creds
+	return nil
+}
+
+// assumeRole - calls the STS AssumeRole API of endpoint with the base
+// credentials accessKey and secretKey, externalID is optional.
+func assumeRole(endpoint, accessKey, secretKey, roleARN, externalID string) (stsCredentials, *probe.Error) {
+	form := url.Values{}
+	form.Set("Action", "AssumeRole")
+	form.Set("Version", "2011-06-15")
+	form.Set("RoleArn", roleARN)
+	form.Set("RoleSessionName", "mc-"+newRandomID(8))
+	form.Set("DurationSeconds", fmt.Sprint(int(assumeRoleDuration.Seconds())))
+	if externalID != "" {
+		form.Set("ExternalId", externalID)
+	}
+	body := []byte(form.Encode())
+
+	req, e := http.NewRequest(http.MethodPost, endpoint+"/", bytes.NewReader(body))
+	if e != nil {
+		return stsCredentials{}, probe.NewError(e)
+	}
+	sha256Sum := sha256.Sum256(body)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum[:]))
+	req.ContentLength = int64(len(body))
+	signedHeaders := []string{"content-type", "host", "x-amz-content-sha256", "x-amz-date"}
+	signV4(req, accessKey, secretKey, "us-east-1", "sts", signedHeaders, UTCNow())
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{RootCAs: globalRootCAs, InsecureSkipVerify: globalInsecure},
+		},
+		Timeout: time.Minute,
+	}
+	resp, e := client.Do(req)
+	if e != nil {
+		return stsCredentials{}, probe.NewError(e)
+	}
+	defer resp.Body.Close()
+
+	data := io.LimitReader(resp.Body, maxErrorResponseSize)
+	if resp.StatusCode != http.StatusOK {
+		var errResp stsErrorResponse
+		if e = xml.NewDecoder(data).Decode(&errResp); e != nil || errResp.Error.Code == "" {
+			return stsCredentials{}, probe.NewError(fmt.Errorf("Unable to assume role `%s`: %s", roleARN, resp.Status))
+		}
+		return stsCredentials{}, probe.NewError(fmt.Errorf("Unable to assume role `%s`: %s", roleARN, strings.TrimSuffix(errResp.Error.Message, ".")))
+	}
+	var roleResp assumeRoleResponse
+	if e = xml.NewDecoder(data).Decode(&roleResp); e != nil {
+		return stsCredentials{}, probe.NewError(e)
+	}
+	if roleResp.Result.Credentials.AccessKeyID == "" {
+		return stsCredentials{}, probe.NewError(fmt.Errorf("Unable to assume role `%s`: no credentials returned", roleARN))
+	}
+	return roleResp.Result.Credentials, nil
+}
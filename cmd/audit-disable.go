@@ -0,0 +1,55 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var auditDisableCmd = cli.Command{
+	Name:            "disable",
+	Usage:           "stop recording requests in the audit log, its entries are kept",
+	Action:          mainAuditDisable,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}}
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Stop recording requests, the audit log can still be shown.
+     $ {{.HelpName}}
+
+`,
+}
+
+// mainAuditDisable is the handle for "mc audit disable" command.
+func mainAuditDisable(ctx *cli.Context) error {
+	if ctx.Args().Present() {
+		cli.ShowCommandHelpAndExit(ctx, "disable", 1) // last argument is exit code
+	}
+	console.SetColor("AuditMessage", color.New(color.FgGreen))
+	setAudit(false)
+	return nil
+}
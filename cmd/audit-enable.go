@@ -0,0 +1,55 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var auditEnableCmd = cli.Command{
+	Name:            "enable",
+	Usage:           "record all mutating requests in the audit log",
+	Action:          mainAuditEnable,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}}
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Record all requests changing buckets, objects and servers from now on.
+     $ {{.HelpName}}
+
+`,
+}
+
+// mainAuditEnable is the handle for "mc audit enable" command.
+func mainAuditEnable(ctx *cli.Context) error {
+	if ctx.Args().Present() {
+		cli.ShowCommandHelpAndExit(ctx, "enable", 1) // last argument is exit code
+	}
+	console.SetColor("AuditMessage", color.New(color.FgGreen))
+	setAudit(true)
+	return nil
+}
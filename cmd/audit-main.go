@@ -0,0 +1,96 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var auditCmd = cli.Command{
+	Name:            "audit",
+	Usage:           "record and show mutating requests in a local audit log",
+	HideHelpCommand: true,
+	Action:          mainAudit,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	Subcommands: []cli.Command{
+		auditEnableCmd,
+		auditDisableCmd,
+		auditShowCmd,
+	},
+}
+
+// mainAudit is the handle for "mc audit" command.
+func mainAudit(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "enable", "disable", "show" have their own main.
+}
+
+// auditMessage container for audit message structure.
+type auditMessage struct {
+	op      string
+	Status  string      `json:"status"`
+	Enabled bool        `json:"enabled"`
+	Entry   *auditEntry `json:"entry,omitempty"`
+}
+
+// String colorized audit message.
+func (a auditMessage) String() string {
+	switch a.op {
+	case "show":
+		e := a.Entry
+		result := fmt.Sprintf("%d", e.Status)
+		if e.Error != "" {
+			result = e.Error
+		}
+		return console.Colorize("Time", fmt.Sprintf("[%s] ", e.Time.Local().Format(printDate))) +
+			console.Colorize("User", e.User+" ") +
+			console.Colorize("Command", "`"+e.Command+"` ") +
+			e.Method + " " + e.URL + " " + console.Colorize("Result", result)
+	default:
+		if a.Enabled {
+			return console.Colorize("AuditMessage", "Audit log is enabled.")
+		}
+		return console.Colorize("AuditMessage", "Audit log is disabled.")
+	}
+}
+
+// JSON jsonified audit message.
+func (a auditMessage) JSON() string {
+	a.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(a, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// setAudit - turns the audit log on or off in the config file.
+func setAudit(enabled bool) {
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
+
+	conf.Audit = enabled
+	err = saveMcConfig(conf)
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to update audit setting in config version `"+globalMCConfigVersion+"`.")
+
+	printMsg(auditMessage{op: "set", Enabled: enabled})
+}
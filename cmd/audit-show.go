@@ -0,0 +1,118 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/ioutils"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var auditShowFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "since",
+		Usage: "show requests sent since a date (YYYY-MM-DD or RFC3339) or for a duration (e.g. 7d, 12h)",
+	},
+}
+
+var auditShowCmd = cli.Command{
+	Name:            "show",
+	Usage:           "show requests recorded in the audit log",
+	Action:          mainAuditShow,
+	Before:          setGlobalsFromContext,
+	Flags:           append(auditShowFlags, globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS]
+
+  The log is verified while shown, the command fails if any entry was
+  changed or removed.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show all recorded requests.
+     $ {{.HelpName}}
+
+  2. Show the requests sent in the last 7 days.
+     $ {{.HelpName}} --since 7d
+
+  3. Show the requests sent since October 1st, 2019 in JSON.
+     $ {{.HelpName}} --since 2019-10-01 --json
+
+`,
+}
+
+// parseSince - returns the time given as a date or as a duration back
+// from now.
+func parseSince(since string) (time.Time, *probe.Error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, e := time.ParseInLocation(layout, since, time.Local); e == nil {
+			return t, nil
+		}
+	}
+	d, e := ioutils.ParseDurationTime(since)
+	if e != nil {
+		return time.Time{}, probe.NewError(e).Trace(since)
+	}
+	return UTCNow().Add(-d), nil
+}
+
+// mainAuditShow is the handle for "mc audit show" command.
+func mainAuditShow(ctx *cli.Context) error {
+	if ctx.Args().Present() {
+		cli.ShowCommandHelpAndExit(ctx, "show", 1) // last argument is exit code
+	}
+	var since time.Time
+	if s := ctx.String("since"); s != "" {
+		var err *probe.Error
+		since, err = parseSince(s)
+		fatalIf(err, "Unable to parse --since `"+s+"`.")
+	}
+
+	console.SetColor("Time", color.New(color.FgGreen))
+	console.SetColor("User", color.New(color.FgCyan))
+	console.SetColor("Command", color.New(color.Bold))
+	console.SetColor("Result", color.New(color.FgYellow))
+
+	entries, brokenAt, err := readAuditLog()
+	fatalIf(err, "Unable to read the audit log.")
+
+	for i := range entries {
+		if entries[i].Time.Before(since) {
+			continue
+		}
+		printMsg(auditMessage{op: "show", Entry: &entries[i]})
+	}
+	if brokenAt > 0 {
+		fatalIf(errDummy().Trace(strconv.Itoa(brokenAt)),
+			fmt.Sprintf("Audit log was modified, entry %d does not follow the previous one.", brokenAt))
+	}
+	if !isAuditEnabled() {
+		console.Infoln("Audit log is disabled, enable it with 'mc audit enable'.")
+	}
+	return nil
+}
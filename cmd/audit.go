@@ -0,0 +1,291 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/lock"
+)
+
+// auditEntry - a mutating request recorded in the audit log. Every
+// entry holds the hash of the previous one, entries changed or removed
+// afterwards break the chain.
+type auditEntry struct {
+	Time      time.Time `json:"time"`
+	User      string    `json:"user"`
+	AccessKey string    `json:"accessKey"`
+	Alias     string    `json:"alias,omitempty"`
+	Command   string    `json:"command"`
+	Method    string    `json:"method"`
+	URL       string    `json:"url"`
+	Status    int       `json:"status,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Prev      string    `json:"prev"`
+	Hash      string    `json:"hash"`
+}
+
+// sum - returns the hash of the entry, computed without its hash.
+func (e auditEntry) sum() string {
+	e.Hash = ""
+	data, _ := json.Marshal(e)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Serializes writes to the audit log within mc, the log file is locked
+// against other mc processes.
+var auditLogMutex sync.Mutex
+
+// Reported once when the audit log cannot be written.
+var auditLogFailed sync.Once
+
+// Audit setting of the configuration, read once.
+var (
+	auditEnabled     bool
+	auditEnabledOnce sync.Once
+)
+
+// isAuditEnabled - reports if mutating requests are recorded.
+func isAuditEnabled() bool {
+	auditEnabledOnce.Do(func() {
+		conf, err := loadMcConfig()
+		auditEnabled = err == nil && conf.Audit
+	})
+	return auditEnabled
+}
+
+// getAuditLogFile - returns the path of the audit log.
+func getAuditLogFile() (string, *probe.Error) {
+	configDir, err := getMcConfigDir()
+	if err != nil {
+		return "", err.Trace()
+	}
+	return filepath.Join(configDir, globalAuditLogFile), nil
+}
+
+// isMutatingRequest - reports if req changes anything on the server,
+// POST requests running queries are left out.
+func isMutatingRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		_, isSelect := req.URL.Query()["select"]
+		return !isSelect
+	}
+	return false
+}
+
+// auditUser - returns the local user running mc.
+func auditUser() string {
+	if u, e := user.Current(); e == nil {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME")
+}
+
+// auditCommand - returns the command and sub commands named by args,
+// flags and arguments are left out as they may hold secrets.
+func auditCommand(args []string, cmds []cli.Command) string {
+	names := []string{filepath.Base(args[0])}
+	for _, arg := range args[1:] {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		cmd := findCommand(cmds, arg)
+		if cmd == nil {
+			break
+		}
+		names = append(names, cmd.Name)
+		cmds = cmd.Subcommands
+	}
+	return strings.Join(names, " ")
+}
+
+// findCommand - returns the command of cmds called name, nil if none.
+func findCommand(cmds []cli.Command, name string) *cli.Command {
+	for i := range cmds {
+		if isBuiltinCommand(cmds[i:i+1], name) {
+			return &cmds[i]
+		}
+	}
+	return nil
+}
+
+// lastAuditHash - returns the hash of the last entry of the audit log.
+func lastAuditHash(f *os.File) (string, error) {
+	st, e := f.Stat()
+	if e != nil {
+		return "", e
+	}
+	// Entries are much smaller than this.
+	offset := st.Size() - 64*1024
+	if offset < 0 {
+		offset = 0
+	}
+	data := make([]byte, st.Size()-offset)
+	if _, e = f.ReadAt(data, offset); e != nil && e != io.EOF {
+		return "", e
+	}
+	lines := bytes.Split(bytes.TrimSpace(data), []byte("\n"))
+	var entry auditEntry
+	if e = json.Unmarshal(lines[len(lines)-1], &entry); e != nil && st.Size() > 0 {
+		return "", e
+	}
+	return entry.Hash, nil
+}
+
+// writeAuditEntry - appends entry to the audit log. The log is locked
+// until the entry is written, concurrent mc runs would break the chain.
+func writeAuditEntry(entry auditEntry) *probe.Error {
+	auditLogMutex.Lock()
+	defer auditLogMutex.Unlock()
+
+	auditFile, err := getAuditLogFile()
+	if err != nil {
+		return err.Trace()
+	}
+	f, e := lock.LockedOpenFile(auditFile, os.O_RDWR|os.O_CREATE, 0600)
+	if e != nil {
+		return probe.NewError(e).Trace(auditFile)
+	}
+	defer f.Close()
+
+	if entry.Prev, e = lastAuditHash(f.File); e != nil {
+		return probe.NewError(e).Trace(auditFile)
+	}
+	// Locked files cannot be opened for appending.
+	if _, e = f.Seek(0, io.SeekEnd); e != nil {
+		return probe.NewError(e).Trace(auditFile)
+	}
+	entry.Hash = entry.sum()
+	data, e := json.Marshal(entry)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	if _, e = f.Write(append(data, '\n')); e != nil {
+		return probe.NewError(e).Trace(auditFile)
+	}
+	return nil
+}
+
+// readAuditLog - reads all entries of the audit log, reporting the
+// line of the first entry breaking the chain, 0 if none.
+func readAuditLog() (entries []auditEntry, brokenAt int, err *probe.Error) {
+	auditFile, err := getAuditLogFile()
+	if err != nil {
+		return nil, 0, err.Trace()
+	}
+	f, e := lock.LockedOpenFile(auditFile, os.O_RDONLY, 0)
+	if e != nil {
+		if os.IsNotExist(e) {
+			return nil, 0, nil
+		}
+		return nil, 0, probe.NewError(e).Trace(auditFile)
+	}
+	defer f.Close()
+
+	prev := ""
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		var entry auditEntry
+		if e = json.Unmarshal(scanner.Bytes(), &entry); e != nil {
+			return nil, 0, probe.NewError(e).Trace(auditFile)
+		}
+		if brokenAt == 0 && (entry.Prev != prev || entry.Hash != entry.sum()) {
+			brokenAt = line
+		}
+		prev = entry.Hash
+		entries = append(entries, entry)
+	}
+	if e = scanner.Err(); e != nil {
+		return nil, 0, probe.NewError(e).Trace(auditFile)
+	}
+	return entries, brokenAt, nil
+}
+
+// auditTransport - records mutating requests sent for an alias in the
+// audit log along with their result.
+type auditTransport struct {
+	alias     string
+	accessKey string
+	transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, e := t.transport.RoundTrip(req)
+	if !isMutatingRequest(req) {
+		return resp, e
+	}
+	u := *req.URL
+	u.User = nil
+	u.RawQuery = auditQuery(req)
+	entry := auditEntry{
+		Time:      UTCNow(),
+		User:      auditUser(),
+		AccessKey: t.accessKey,
+		Alias:     t.alias,
+		Command:   auditCommand(os.Args, commands),
+		Method:    req.Method,
+		URL:       u.String(),
+	}
+	if e != nil {
+		entry.Error = e.Error()
+	} else {
+		entry.Status = resp.StatusCode
+	}
+	if err := writeAuditEntry(entry); err != nil {
+		auditLogFailed.Do(func() {
+			console.Errorln("Unable to write the audit log: " + err.ToGoError().Error())
+		})
+	}
+	return resp, e
+}
+
+// auditQuery - returns the names of the query parameters of req, they
+// tell the operation apart while values may hold signatures.
+func auditQuery(req *http.Request) string {
+	var names []string
+	for name := range req.URL.Query() {
+		if !strings.HasPrefix(strings.ToLower(name), "x-amz-") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, "&")
+}
@@ -0,0 +1,88 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/minio/cli"
+)
+
+func TestAuditCommand(t *testing.T) {
+	cmds := []cli.Command{
+		{Name: "rm"},
+		{Name: "admin", Subcommands: []cli.Command{
+			{Name: "user", Subcommands: []cli.Command{{Name: "add"}}},
+		}},
+	}
+	testCases := []struct {
+		args    []string
+		command string
+	}{
+		{[]string{"/usr/bin/mc", "rm", "--force", "s3/bucket/object"}, "mc rm"},
+		{[]string{"mc", "--json", "admin", "user", "add", "myminio", "newuser", "secret"}, "mc admin user add"},
+		{[]string{"mc", "backup"}, "mc"},
+	}
+	for i, testCase := range testCases {
+		if command := auditCommand(testCase.args, cmds); command != testCase.command {
+			t.Errorf("Test %d: expected `%s`, got `%s`", i+1, testCase.command, command)
+		}
+	}
+}
+
+func TestAuditLogChain(t *testing.T) {
+	configDir, e := ioutil.TempDir(os.TempDir(), "audit-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(configDir)
+	defer setMcConfigDir("")
+	setMcConfigDir(configDir)
+
+	for _, method := range []string{"PUT", "DELETE", "POST"} {
+		if err := writeAuditEntry(auditEntry{Time: UTCNow(), Method: method, URL: "https://play.min.io/bucket"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries, brokenAt, err := readAuditLog()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 || brokenAt != 0 {
+		t.Fatalf("expected 3 chained entries, got %d broken at %d", len(entries), brokenAt)
+	}
+
+	// Changing an entry breaks the chain from there.
+	auditFile := filepath.Join(configDir, globalAuditLogFile)
+	data, e := ioutil.ReadFile(auditFile)
+	if e != nil {
+		t.Fatal(e)
+	}
+	data = []byte(strings.Replace(string(data), "DELETE", "PUT", 1))
+	if e = ioutil.WriteFile(auditFile, data, 0600); e != nil {
+		t.Fatal(e)
+	}
+	if _, brokenAt, err = readAuditLog(); err != nil {
+		t.Fatal(err)
+	}
+	if brokenAt != 2 {
+		t.Fatalf("expected the chain to break at entry 2, got %d", brokenAt)
+	}
+}
@@ -0,0 +1,286 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+// backup specific flags.
+var (
+	backupFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "chunk-size",
+			Usage: "size of each chunk object",
+			Value: "512MiB",
+		},
+		cli.StringFlag{
+			Name:  "compress",
+			Usage: "compress the archive, only 'gzip' is supported",
+		},
+		cli.StringFlag{
+			Name:  "encrypt",
+			Usage: "encrypt/decrypt objects (using server-side encryption with server managed keys)",
+		},
+	}
+)
+
+// Back up a local folder.
+var backupCmd = cli.Command{
+	Name:            "backup",
+	Usage:           "archive a local folder into chunk objects",
+	Action:          mainBackup,
+	Before:          setGlobalsFromContext,
+	Flags:           append(append(backupFlags, ioFlags...), globalFlags...),
+	HideHelpCommand: true,
+	Subcommands: []cli.Command{
+		backupRestoreCmd,
+	},
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] SOURCE TARGET
+  {{.HelpName}} restore [FLAGS] SOURCE TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+   MC_ENCRYPT:      list of comma delimited prefixes
+   MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+
+EXAMPLES:
+   1. Back up a local folder to Amazon S3 cloud storage as a compressed archive.
+      $ {{.HelpName}} --compress gzip /var/lib/postgresql s3/backups/postgresql/2019-08-01
+
+   2. Back up a local folder in 1GiB chunks, encrypted with a customer provided key.
+      $ {{.HelpName}} --chunk-size 1GiB --encrypt-key "s3/backups/=32byteslongsecretkeymustbegiven1" ~/photos s3/backups/photos
+
+   3. Restore a backup into a local folder.
+      $ {{.HelpName}} restore s3/backups/postgresql/2019-08-01 /var/lib/postgresql
+`,
+}
+
+// Name of the manifest object describing a backup.
+const backupManifestName = "manifest.json"
+
+// Version of the backup manifest.
+const backupManifestVersion = "1"
+
+// backupManifest - describes the chunks a backup is made of.
+type backupManifest struct {
+	Version   string    `json:"version"`
+	Time      time.Time `json:"time"`
+	Source    string    `json:"source"`
+	Compress  string    `json:"compress,omitempty"`
+	ChunkSize int64     `json:"chunkSize"`
+	Size      int64     `json:"size"`
+	Chunks    []string  `json:"chunks"`
+}
+
+// backupMessage container for backup messages.
+type backupMessage struct {
+	Status string `json:"status"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Chunks int    `json:"chunks"`
+	Size   int64  `json:"size"`
+}
+
+// String colorized backup message.
+func (b backupMessage) String() string {
+	return console.Colorize("Backup", fmt.Sprintf("`%s` -> `%s` in %d chunk(s), %s.",
+		b.Source, b.Target, b.Chunks, humanize.IBytes(uint64(b.Size))))
+}
+
+// JSON jsonified backup message.
+func (b backupMessage) JSON() string {
+	b.Status = "success"
+	backupMessageBytes, e := json.MarshalIndent(b, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(backupMessageBytes)
+}
+
+// checkBackupSyntax - validate all the passed arguments
+func checkBackupSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "backup", 1) // last argument is exit code
+	}
+	chunkSize, e := humanize.ParseBytes(ctx.String("chunk-size"))
+	if e != nil {
+		fatalIf(probe.NewError(e).Trace(ctx.String("chunk-size")), "Unable to parse chunk size.")
+	}
+	if chunkSize == 0 || chunkSize > math.MaxInt64 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("chunk-size")), "Chunk size must be greater than zero.")
+	}
+	fatalIf(checkContentEncoding(ctx.String("compress")), "Unable to validate compression.")
+
+	source := ctx.Args().Get(0)
+	st, e := os.Stat(source)
+	if e != nil {
+		fatalIf(probe.NewError(e).Trace(source), "Unable to stat source `"+source+"`.")
+	}
+	if !st.IsDir() {
+		fatalIf(errInvalidArgument().Trace(source), "Source `"+source+"` is not a folder.")
+	}
+}
+
+// writeTar - writes all entries under the folder into a tar stream.
+func writeTar(w io.Writer, dir string) error {
+	tw := tar.NewWriter(w)
+	e := filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name, e := filepath.Rel(dir, path)
+		if e != nil || name == "." {
+			return e
+		}
+		var link string
+		if fi.Mode()&os.ModeSymlink != 0 {
+			if link, e = os.Readlink(path); e != nil {
+				return e
+			}
+		}
+		header, e := tar.FileInfoHeader(fi, link)
+		if e != nil {
+			return e
+		}
+		header.Name = filepath.ToSlash(name)
+		if e = tw.WriteHeader(header); e != nil {
+			return e
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+		f, e := os.Open(path)
+		if e != nil {
+			return e
+		}
+		defer f.Close()
+		_, e = io.Copy(tw, f)
+		return e
+	})
+	if e != nil {
+		return e
+	}
+	return tw.Close()
+}
+
+// doBackup - streams the folder archive into chunk objects under target.
+func doBackup(source, target string, chunkSize int64, compress string, sse encrypt.ServerSide) (backupManifest, *probe.Error) {
+	manifest := backupManifest{
+		Version:   backupManifestVersion,
+		Time:      UTCNow(),
+		Source:    source,
+		Compress:  compress,
+		ChunkSize: chunkSize,
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		var w io.WriteCloser = pw
+		if compress == contentEncodingGzip {
+			w = gzip.NewWriter(pw)
+		}
+		e := writeTar(w, source)
+		if e == nil && w != pw {
+			e = w.Close()
+		}
+		pw.CloseWithError(e)
+	}()
+	defer pr.Close()
+
+	archive := bufio.NewReader(pr)
+	for {
+		// Stop at the end of the archive, never upload an empty chunk.
+		if _, e := archive.Peek(1); e != nil {
+			if e == io.EOF {
+				break
+			}
+			return manifest, probe.NewError(e).Trace(source)
+		}
+		chunkName := fmt.Sprintf("chunk.%06d", len(manifest.Chunks))
+		chunkURL := urlJoinPath(target, chunkName)
+		n, err := putTargetStreamWithURL(chunkURL, io.LimitReader(archive, chunkSize), -1, sse)
+		if err != nil {
+			return manifest, err.Trace(chunkURL)
+		}
+		manifest.Chunks = append(manifest.Chunks, chunkName)
+		manifest.Size += n
+	}
+
+	manifestBytes, e := json.MarshalIndent(manifest, "", " ")
+	if e != nil {
+		return manifest, probe.NewError(e)
+	}
+	manifestURL := urlJoinPath(target, backupManifestName)
+	if _, err := putTargetStreamWithURL(manifestURL, bytes.NewReader(manifestBytes), int64(len(manifestBytes)), sse); err != nil {
+		return manifest, err.Trace(manifestURL)
+	}
+	return manifest, nil
+}
+
+// mainBackup is the entry point for backup command.
+func mainBackup(ctx *cli.Context) error {
+	// Parse encryption keys per command.
+	encKeyDB, err := getEncKeys(ctx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	// check 'backup' cli arguments.
+	checkBackupSyntax(ctx)
+
+	// Additional command specific theme customization.
+	console.SetColor("Backup", color.New(color.FgGreen, color.Bold))
+
+	source := ctx.Args().Get(0)
+	target := ctx.Args().Get(1)
+	chunkSize, _ := humanize.ParseBytes(ctx.String("chunk-size"))
+
+	alias, _ := url2Alias(target)
+	sse := getSSE(target, encKeyDB[alias])
+
+	manifest, err := doBackup(source, target, int64(chunkSize), ctx.String("compress"), sse)
+	fatalIf(err.Trace(source, target), "Unable to back up `"+source+"`.")
+
+	printMsg(backupMessage{
+		Source: source,
+		Target: target,
+		Chunks: len(manifest.Chunks),
+		Size:   manifest.Size,
+	})
+	return nil
+}
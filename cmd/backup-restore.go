@@ -0,0 +1,306 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var backupRestoreFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "encrypt",
+		Usage: "encrypt/decrypt objects (using server-side encryption with server managed keys)",
+	},
+}
+
+// Restore a backup into a local folder.
+var backupRestoreCmd = cli.Command{
+	Name:   "restore",
+	Usage:  "restore a backup into a local folder",
+	Action: mainBackupRestore,
+	Before: setGlobalsFromContext,
+	Flags:  append(append(backupRestoreFlags, ioFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] SOURCE TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+   MC_ENCRYPT:      list of comma delimited prefixes
+   MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+
+EXAMPLES:
+   1. Restore a backup into a local folder.
+      $ {{.HelpName}} s3/backups/postgresql/2019-08-01 /var/lib/postgresql
+
+   2. Restore a backup encrypted with a customer provided key.
+      $ {{.HelpName}} --encrypt-key "s3/backups/=32byteslongsecretkeymustbegiven1" s3/backups/photos ~/photos
+`,
+}
+
+// backupRestoreMessage container for restore messages.
+type backupRestoreMessage struct {
+	Status string `json:"status"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Files  int    `json:"files"`
+	Size   int64  `json:"size"`
+}
+
+// String colorized restore message.
+func (r backupRestoreMessage) String() string {
+	return console.Colorize("Restore", fmt.Sprintf("`%s` -> `%s`, %d file(s), %s.",
+		r.Source, r.Target, r.Files, humanize.IBytes(uint64(r.Size))))
+}
+
+// JSON jsonified restore message.
+func (r backupRestoreMessage) JSON() string {
+	r.Status = "success"
+	restoreMessageBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(restoreMessageBytes)
+}
+
+// checkBackupRestoreSyntax - validate all the passed arguments
+func checkBackupRestoreSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "restore", 1) // last argument is exit code
+	}
+}
+
+// chunkReader - reads all chunks of a backup one after the other,
+// each chunk is only opened once the previous one is exhausted.
+type chunkReader struct {
+	source   string
+	chunks   []string
+	encKeyDB map[string][]prefixSSEPair
+	current  io.ReadCloser
+}
+
+// Read implements io.Reader.
+func (c *chunkReader) Read(p []byte) (int, error) {
+	for {
+		if c.current == nil {
+			if len(c.chunks) == 0 {
+				return 0, io.EOF
+			}
+			chunkURL := urlJoinPath(c.source, c.chunks[0])
+			reader, err := getSourceStreamFromURL(chunkURL, c.encKeyDB)
+			if err != nil {
+				return 0, err.Trace(chunkURL).ToGoError()
+			}
+			c.current = reader
+			c.chunks = c.chunks[1:]
+		}
+		n, e := c.current.Read(p)
+		if e == io.EOF {
+			c.current.Close()
+			c.current = nil
+			if n == 0 {
+				continue
+			}
+			e = nil
+		}
+		return n, e
+	}
+}
+
+// Close implements io.Closer.
+func (c *chunkReader) Close() error {
+	if c.current != nil {
+		return c.current.Close()
+	}
+	return nil
+}
+
+// readBackupManifest - reads the manifest of a backup.
+func readBackupManifest(source string, encKeyDB map[string][]prefixSSEPair) (backupManifest, *probe.Error) {
+	var manifest backupManifest
+	manifestURL := urlJoinPath(source, backupManifestName)
+	reader, err := getSourceStreamFromURL(manifestURL, encKeyDB)
+	if err != nil {
+		return manifest, err.Trace(manifestURL)
+	}
+	defer reader.Close()
+	if e := json.NewDecoder(reader).Decode(&manifest); e != nil {
+		return manifest, probe.NewError(e).Trace(manifestURL)
+	}
+	if manifest.Version != backupManifestVersion {
+		return manifest, probe.NewError(fmt.Errorf("Unsupported backup manifest version `%s`", manifest.Version)).Trace(manifestURL)
+	}
+	return manifest, nil
+}
+
+// checkRestoreLinks - returns an error if a folder of name under dir is
+// a symbolic link, entries would be written wherever it points to.
+func checkRestoreLinks(dir, name string) error {
+	path := dir
+	for _, part := range strings.Split(filepath.Dir(name), string(filepath.Separator)) {
+		if part == "." || part == "" {
+			continue
+		}
+		path = filepath.Join(path, part)
+		fi, e := os.Lstat(path)
+		if os.IsNotExist(e) {
+			return nil
+		}
+		if e != nil {
+			return e
+		}
+		if fi.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("Invalid path `%s` in archive, `%s` is a symbolic link", name, path)
+		}
+	}
+	return nil
+}
+
+// restoreTarEntry - creates a single archive entry under dir.
+func restoreTarEntry(dir string, header *tar.Header, r io.Reader) error {
+	name := filepath.FromSlash(header.Name)
+	// Never write outside of the target folder.
+	if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) ||
+		strings.Contains(name, string(filepath.Separator)+".."+string(filepath.Separator)) {
+		return fmt.Errorf("Invalid path `%s` in archive", header.Name)
+	}
+	// Never write through links restored before.
+	if e := checkRestoreLinks(dir, name); e != nil {
+		return e
+	}
+	path := filepath.Join(dir, name)
+	mode := os.FileMode(header.Mode).Perm()
+
+	switch header.Typeflag {
+	case tar.TypeDir:
+		return os.MkdirAll(path, mode|0700)
+	case tar.TypeSymlink:
+		if e := os.MkdirAll(filepath.Dir(path), 0755); e != nil {
+			return e
+		}
+		os.Remove(path)
+		return os.Symlink(header.Linkname, path)
+	case tar.TypeReg, tar.TypeRegA:
+		if e := os.MkdirAll(filepath.Dir(path), 0755); e != nil {
+			return e
+		}
+		// Links in place of the file are replaced, not followed.
+		if fi, e := os.Lstat(path); e == nil && fi.Mode()&os.ModeSymlink != 0 {
+			if e = os.Remove(path); e != nil {
+				return e
+			}
+		}
+		f, e := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+		if e != nil {
+			return e
+		}
+		if _, e = io.Copy(f, r); e != nil {
+			f.Close()
+			return e
+		}
+		if e = f.Close(); e != nil {
+			return e
+		}
+		return os.Chtimes(path, header.ModTime, header.ModTime)
+	}
+	// Other entry types are not backed up, ignore them.
+	return nil
+}
+
+// doBackupRestore - extracts the backup at source into the target folder.
+func doBackupRestore(source, target string, encKeyDB map[string][]prefixSSEPair) (msg backupRestoreMessage, err *probe.Error) {
+	msg = backupRestoreMessage{Source: source, Target: target}
+
+	manifest, err := readBackupManifest(source, encKeyDB)
+	if err != nil {
+		return msg, err.Trace(source)
+	}
+
+	chunks := &chunkReader{source: source, chunks: manifest.Chunks, encKeyDB: encKeyDB}
+	defer chunks.Close()
+
+	var archive io.Reader = chunks
+	switch manifest.Compress {
+	case "":
+	case contentEncodingGzip:
+		gr, e := gzip.NewReader(chunks)
+		if e != nil {
+			return msg, probe.NewError(e).Trace(source)
+		}
+		defer gr.Close()
+		archive = gr
+	default:
+		return msg, probe.NewError(fmt.Errorf("Unsupported backup compression `%s`", manifest.Compress)).Trace(source)
+	}
+
+	tr := tar.NewReader(archive)
+	for {
+		header, e := tr.Next()
+		if e == io.EOF {
+			break
+		}
+		if e != nil {
+			return msg, probe.NewError(e).Trace(source)
+		}
+		if e = restoreTarEntry(target, header, tr); e != nil {
+			return msg, probe.NewError(e).Trace(target, header.Name)
+		}
+		if header.Typeflag == tar.TypeReg || header.Typeflag == tar.TypeRegA {
+			msg.Files++
+			msg.Size += header.Size
+		}
+	}
+	return msg, nil
+}
+
+// mainBackupRestore is the entry point for backup restore command.
+func mainBackupRestore(ctx *cli.Context) error {
+	// Parse encryption keys per command.
+	encKeyDB, err := getEncKeys(ctx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	// check 'backup restore' cli arguments.
+	checkBackupRestoreSyntax(ctx)
+
+	// Additional command specific theme customization.
+	console.SetColor("Restore", color.New(color.FgGreen, color.Bold))
+
+	source := ctx.Args().Get(0)
+	target := ctx.Args().Get(1)
+
+	msg, err := doBackupRestore(source, target, encKeyDB)
+	fatalIf(err.Trace(source, target), "Unable to restore `"+source+"`.")
+
+	printMsg(msg)
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/tar"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRestoreTarEntryLinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symbolic links need privileges on Microsoft Windows")
+	}
+	dir, e := ioutil.TempDir("", "mc-restore-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	target, outside := filepath.Join(dir, "target"), filepath.Join(dir, "outside")
+	if e = os.Mkdir(outside, 0700); e != nil {
+		t.Fatal(e)
+	}
+
+	restore := func(header *tar.Header, data string) error {
+		header.Size = int64(len(data))
+		return restoreTarEntry(target, header, strings.NewReader(data))
+	}
+	// A link to a folder outside of target, then entries through it.
+	if e = restore(&tar.Header{Name: "link", Typeflag: tar.TypeSymlink, Linkname: outside}, ""); e != nil {
+		t.Fatal(e)
+	}
+	if e = restore(&tar.Header{Name: "link/file", Typeflag: tar.TypeReg, Mode: 0600}, "data"); e == nil {
+		t.Fatal("Expected an error restoring through a link")
+	}
+	if e = restore(&tar.Header{Name: "link/folder/file", Typeflag: tar.TypeReg, Mode: 0600}, "data"); e == nil {
+		t.Fatal("Expected an error restoring through a link")
+	}
+	if entries, _ := ioutil.ReadDir(outside); len(entries) != 0 {
+		t.Fatalf("Expected nothing written outside of target, got %d entries", len(entries))
+	}
+
+	// A file replaces a link of the same name.
+	if e = restore(&tar.Header{Name: "file", Typeflag: tar.TypeSymlink, Linkname: filepath.Join(outside, "file")}, ""); e != nil {
+		t.Fatal(e)
+	}
+	if e = restore(&tar.Header{Name: "file", Typeflag: tar.TypeReg, Mode: 0600}, "data"); e != nil {
+		t.Fatal(e)
+	}
+	if fi, e := os.Lstat(filepath.Join(target, "file")); e != nil || !fi.Mode().IsRegular() {
+		t.Fatalf("Expected a regular file, got %v, %v", fi, e)
+	}
+	if _, e = os.Stat(filepath.Join(outside, "file")); !os.IsNotExist(e) {
+		t.Fatal("Expected nothing written outside of target")
+	}
+}
@@ -0,0 +1,300 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/mc/pkg/probe"
+	"golang.org/x/time/rate"
+)
+
+// parseBandwidth - returns the bytes per second of a limit such as
+// '10MiB/s', 0 if limit is empty.
+func parseBandwidth(limit string) (uint64, *probe.Error) {
+	if limit == "" {
+		return 0, nil
+	}
+	bytes, e := humanize.ParseBytes(strings.TrimSuffix(limit, "/s"))
+	if e != nil {
+		return 0, probe.NewError(e).Trace(limit)
+	}
+	if bytes == 0 {
+		return 0, errInvalidArgument().Trace(limit)
+	}
+	return bytes, nil
+}
+
+// checkBandwidthLimit - validates a bandwidth limit.
+func checkBandwidthLimit(limit string) *probe.Error {
+	_, err := parseBandwidth(limit)
+	return err
+}
+
+// newBandwidthLimiter - returns the limiter of transfers to the bytes
+// per second of limit, shared by all of them, nil if not limited.
+func newBandwidthLimiter(limit string) *rate.Limiter {
+	bytes, err := parseBandwidth(limit)
+	if err != nil || bytes == 0 {
+		return nil
+	}
+	return newRateLimiter(bytes)
+}
+
+// newRateLimiter - returns a limiter to bytes per second.
+func newRateLimiter(bytes uint64) *rate.Limiter {
+	// Up to a second of transfer is let through at once.
+	burst := bytes
+	if burst > math.MaxInt32 {
+		burst = math.MaxInt32
+	}
+	return rate.NewLimiter(rate.Limit(bytes), int(burst))
+}
+
+// parseUploadLimits - returns the bytes per second of uploads by target
+// alias of limits such as '10MiB/s' or 'local=100MiB/s,dr=5MiB/s', the
+// limit of targets not listed is keyed by "".
+func parseUploadLimits(limits string) (map[string]uint64, *probe.Error) {
+	if limits == "" {
+		return nil, nil
+	}
+	bandwidths := make(map[string]uint64)
+	for _, entry := range strings.Split(limits, ",") {
+		var alias, limit string
+		if parts := strings.SplitN(strings.TrimSpace(entry), "=", 2); len(parts) == 2 {
+			alias, limit = parts[0], parts[1]
+			if alias == "" {
+				return nil, probe.NewError(fmt.Errorf("`%s` is not of the form ALIAS=RATE", entry))
+			}
+		} else {
+			limit = parts[0]
+		}
+		if _, ok := bandwidths[alias]; ok {
+			return nil, errInvalidArgument().Trace(entry)
+		}
+		bytes, err := parseBandwidth(limit)
+		if err != nil {
+			return nil, err.Trace(entry)
+		}
+		if bytes == 0 {
+			return nil, errInvalidArgument().Trace(entry)
+		}
+		bandwidths[alias] = bytes
+	}
+	return bandwidths, nil
+}
+
+// checkUploadLimits - validates the bandwidth limits of uploads.
+func checkUploadLimits(limits string) *probe.Error {
+	_, err := parseUploadLimits(limits)
+	return err
+}
+
+// newUploadLimiters - returns the limiters of uploads by target alias,
+// each shared by all uploads to its target, nil if not limited.
+func newUploadLimiters(limits string) map[string]*rate.Limiter {
+	bandwidths, err := parseUploadLimits(limits)
+	if err != nil || len(bandwidths) == 0 {
+		return nil
+	}
+	limiters := make(map[string]*rate.Limiter, len(bandwidths))
+	for alias, bytes := range bandwidths {
+		limiters[alias] = newRateLimiter(bytes)
+	}
+	return limiters
+}
+
+// uploadLimiter - returns the limiter of uploads to targetAlias, that of
+// targets not listed if it is not, nil if not limited.
+func (opts uploadOptions) uploadLimiter(targetAlias string) *rate.Limiter {
+	if limiter, ok := opts.uploadLimits[targetAlias]; ok {
+		return limiter
+	}
+	return opts.uploadLimits[""]
+}
+
+// bandwidthWindow - the bandwidth of a daily window of time, in bytes
+// per second, 0 if not limited. Windows ending before they start span
+// midnight.
+type bandwidthWindow struct {
+	start, end time.Duration
+	bytes      uint64
+}
+
+// contains - returns true if the time of day t falls in the window.
+func (w bandwidthWindow) contains(t time.Duration) bool {
+	if w.start <= w.end {
+		return t >= w.start && t < w.end
+	}
+	return t >= w.start || t < w.end
+}
+
+// parseTimeOfDay - returns the time since midnight of 'HH:MM'.
+func parseTimeOfDay(s string) (time.Duration, *probe.Error) {
+	t, e := time.Parse("15:04", s)
+	if e != nil {
+		return 0, probe.NewError(e).Trace(s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// parseBandwidthSchedule - returns the windows of a schedule such as
+// '08:00-18:00=5MiB,18:00-08:00=0', a bandwidth of 0 is not limited.
+func parseBandwidthSchedule(schedule string) ([]bandwidthWindow, *probe.Error) {
+	if schedule == "" {
+		return nil, nil
+	}
+	var windows []bandwidthWindow
+	for _, entry := range strings.Split(schedule, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), "=", 2)
+		if len(parts) != 2 {
+			return nil, probe.NewError(fmt.Errorf("`%s` is not of the form HH:MM-HH:MM=RATE", entry))
+		}
+		times := strings.SplitN(parts[0], "-", 2)
+		if len(times) != 2 {
+			return nil, probe.NewError(fmt.Errorf("`%s` is not of the form HH:MM-HH:MM", parts[0]))
+		}
+		var w bandwidthWindow
+		var err *probe.Error
+		if w.start, err = parseTimeOfDay(times[0]); err != nil {
+			return nil, err.Trace(entry)
+		}
+		if w.end, err = parseTimeOfDay(times[1]); err != nil {
+			return nil, err.Trace(entry)
+		}
+		if w.start == w.end {
+			return nil, errInvalidArgument().Trace(entry)
+		}
+		if parts[1] != "0" {
+			if w.bytes, err = parseBandwidth(parts[1]); err != nil {
+				return nil, err.Trace(entry)
+			}
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+// checkBandwidthSchedule - validates a bandwidth schedule.
+func checkBandwidthSchedule(schedule string) *probe.Error {
+	_, err := parseBandwidthSchedule(schedule)
+	return err
+}
+
+// scheduledBandwidth - returns the bandwidth of the first window holding
+// the time of day of t, 0 if none does.
+func scheduledBandwidth(windows []bandwidthWindow, t time.Time) uint64 {
+	timeOfDay := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	for _, w := range windows {
+		if w.contains(timeOfDay) {
+			return w.bytes
+		}
+	}
+	return 0
+}
+
+// scheduledLimit - the limit of a limiter for a bandwidth, 0 is not limited.
+func scheduledLimit(bytes uint64) rate.Limit {
+	if bytes == 0 {
+		return rate.Inf
+	}
+	return rate.Limit(bytes)
+}
+
+// newScheduledBandwidthLimiter - returns the limiter of transfers to the
+// bandwidth of the window of the schedule in local time, updated every
+// minute, nil if no window is limited.
+func newScheduledBandwidthLimiter(schedule string) *rate.Limiter {
+	windows, err := parseBandwidthSchedule(schedule)
+	if err != nil {
+		return nil
+	}
+	// Up to a second of the fastest window is let through at once.
+	var burst uint64
+	for _, w := range windows {
+		if w.bytes > burst {
+			burst = w.bytes
+		}
+	}
+	if burst == 0 {
+		return nil
+	}
+	if burst > math.MaxInt32 {
+		burst = math.MaxInt32
+	}
+	limiter := rate.NewLimiter(scheduledLimit(scheduledBandwidth(windows, time.Now())), int(burst))
+	go func() {
+		for now := range time.Tick(time.Minute) {
+			limiter.SetLimit(scheduledLimit(scheduledBandwidth(windows, now)))
+		}
+	}()
+	return limiter
+}
+
+// bandwidthLimitReader - a progress hook holding back reads to the rate
+// of its limiters, up to the hook it wraps, if any.
+type bandwidthLimitReader struct {
+	ctx      context.Context
+	limiters []*rate.Limiter
+	hook     io.Reader
+}
+
+// Read implements io.Reader.
+func (r bandwidthLimitReader) Read(p []byte) (int, error) {
+	for _, limiter := range r.limiters {
+		// Waits cannot be for more than the burst at once.
+		for n := len(p); n > 0; {
+			chunk := n
+			if chunk > limiter.Burst() {
+				chunk = limiter.Burst()
+			}
+			if e := limiter.WaitN(r.ctx, chunk); e != nil {
+				return 0, e
+			}
+			n -= chunk
+		}
+	}
+	if r.hook == nil {
+		return len(p), nil
+	}
+	return r.hook.Read(p)
+}
+
+// limitBandwidth - returns progress held back to the bandwidth of
+// uploads to targetAlias and downloads from object storage, as limited.
+func (opts uploadOptions) limitBandwidth(ctx context.Context, progress io.Reader, sourceType clientURLType, targetAlias string, targetType clientURLType) io.Reader {
+	var limiters []*rate.Limiter
+	if opts.downloadLimit != nil && sourceType == objectStorage {
+		limiters = append(limiters, opts.downloadLimit)
+	}
+	if uploadLimit := opts.uploadLimiter(targetAlias); uploadLimit != nil && targetType == objectStorage {
+		limiters = append(limiters, uploadLimit)
+	}
+	if opts.scheduleLimit != nil && (sourceType == objectStorage || targetType == objectStorage) {
+		limiters = append(limiters, opts.scheduleLimit)
+	}
+	if len(limiters) == 0 {
+		return progress
+	}
+	return bandwidthLimitReader{ctx: ctx, limiters: limiters, hook: progress}
+}
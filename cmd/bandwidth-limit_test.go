@@ -0,0 +1,146 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBandwidth(t *testing.T) {
+	testCases := []struct {
+		limit   string
+		bytes   uint64
+		success bool
+	}{
+		{"", 0, true},
+		{"10MiB/s", 10 << 20, true},
+		{"10MiB", 10 << 20, true},
+		{"1.5KB/s", 1500, true},
+		{"0/s", 0, false},
+		{"fast", 0, false},
+	}
+	for i, testCase := range testCases {
+		bytes, err := parseBandwidth(testCase.limit)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %t, got error %v", i+1, testCase.success, err)
+		}
+		if bytes != testCase.bytes {
+			t.Fatalf("Test %d: expected %d, got %d", i+1, testCase.bytes, bytes)
+		}
+	}
+}
+
+func TestParseUploadLimits(t *testing.T) {
+	testCases := []struct {
+		limits  string
+		alias   string
+		bytes   uint64
+		success bool
+	}{
+		{"", "", 0, true},
+		{"10MiB/s", "", 10 << 20, true},
+		{"dr=5MiB/s,100MiB/s", "dr", 5 << 20, true},
+		{"dr=5MiB/s,100MiB/s", "", 100 << 20, true},
+		{"local=100MiB/s, dr=5MiB/s", "dr", 5 << 20, true},
+		{"dr=5MiB/s", "local", 0, true},
+		{"dr=5MiB/s,dr=1MiB/s", "", 0, false},
+		{"10MiB/s,5MiB/s", "", 0, false},
+		{"=5MiB/s", "", 0, false},
+		{"dr=fast", "", 0, false},
+		{"dr=0", "", 0, false},
+	}
+	for i, testCase := range testCases {
+		bandwidths, err := parseUploadLimits(testCase.limits)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %t, got error %v", i+1, testCase.success, err)
+		}
+		if bytes := bandwidths[testCase.alias]; bytes != testCase.bytes {
+			t.Fatalf("Test %d: expected %d, got %d", i+1, testCase.bytes, bytes)
+		}
+	}
+}
+
+func TestUploadLimiter(t *testing.T) {
+	opts := uploadOptions{uploadLimits: newUploadLimiters("dr=5MiB/s,100MiB/s")}
+	if limiter := opts.uploadLimiter("dr"); limiter == nil || limiter.Limit() != 5<<20 {
+		t.Fatalf("Expected uploads to dr to be limited to 5MiB/s, got %v", limiter)
+	}
+	if limiter := opts.uploadLimiter("local"); limiter == nil || limiter.Limit() != 100<<20 {
+		t.Fatalf("Expected uploads to local to be limited to 100MiB/s, got %v", limiter)
+	}
+
+	opts = uploadOptions{uploadLimits: newUploadLimiters("dr=5MiB/s")}
+	if limiter := opts.uploadLimiter("local"); limiter != nil {
+		t.Fatalf("Expected uploads to local not to be limited, got %v", limiter)
+	}
+	if limiter := (uploadOptions{}).uploadLimiter("dr"); limiter != nil {
+		t.Fatalf("Expected uploads not to be limited, got %v", limiter)
+	}
+}
+
+func TestParseBandwidthSchedule(t *testing.T) {
+	testCases := []struct {
+		schedule string
+		windows  int
+		success  bool
+	}{
+		{"", 0, true},
+		{"08:00-18:00=5MiB,18:00-08:00=0", 2, true},
+		{"22:30-06:00=1MiB/s", 1, true},
+		{"08:00-08:00=5MiB", 0, false},
+		{"08:00-18:00", 0, false},
+		{"8am-6pm=5MiB", 0, false},
+		{"08:00-18:00=fast", 0, false},
+	}
+	for i, testCase := range testCases {
+		windows, err := parseBandwidthSchedule(testCase.schedule)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %t, got error %v", i+1, testCase.success, err)
+		}
+		if len(windows) != testCase.windows {
+			t.Fatalf("Test %d: expected %d windows, got %d", i+1, testCase.windows, len(windows))
+		}
+	}
+}
+
+func TestScheduledBandwidth(t *testing.T) {
+	windows, err := parseBandwidthSchedule("08:00-18:00=5MiB,22:00-06:00=1MiB")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		clock string
+		bytes uint64
+	}{
+		{"08:00", 5 << 20},
+		{"17:59", 5 << 20},
+		{"18:00", 0},
+		{"23:15", 1 << 20},
+		{"03:00", 1 << 20},
+		{"06:00", 0},
+	}
+	for i, testCase := range testCases {
+		clock, e := time.Parse("15:04", testCase.clock)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if bytes := scheduledBandwidth(windows, clock); bytes != testCase.bytes {
+			t.Errorf("Test %d: expected %d at %s, got %d", i+1, testCase.bytes, testCase.clock, bytes)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var bucketAttrsInfoCmd = cli.Command{
+	Name:            "info",
+	Usage:           "show tags and object ownership of a bucket",
+	Action:          mainBucketAttrsInfo,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the tags and object ownership of bucket 'mybucket'.
+     $ {{.HelpName}} s3/mybucket
+
+`,
+}
+
+// mainBucketAttrsInfo is the handle for "mc bucket attrs info" command.
+func mainBucketAttrsInfo(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "info", 1) // last argument is exit code
+	}
+	targetURL := ctx.Args().Get(0)
+
+	console.SetColor("Attrs", color.New(color.FgGreen, color.Bold))
+
+	clnt := newBucketClient(targetURL)
+	tags, err := clnt.GetBucketTags()
+	fatalIf(err.Trace(targetURL), "Unable to get tags of `"+targetURL+"`.")
+	ownership, err := clnt.GetObjectOwnership()
+	fatalIf(err.Trace(targetURL), "Unable to get object ownership of `"+targetURL+"`.")
+
+	printMsg(bucketAttrsMessage{
+		op:              "info",
+		URL:             targetURL,
+		Tags:            tags,
+		ObjectOwnership: ownership,
+	})
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var bucketAttrsSetFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "tags",
+		Usage: "replace the tags of the bucket with key1=value1,key2=value2, removes them if empty",
+	},
+	cli.StringFlag{
+		Name:  "object-ownership",
+		Usage: "set object ownership to 'BucketOwnerEnforced', 'BucketOwnerPreferred' or 'ObjectWriter'",
+	},
+}
+
+var bucketAttrsSetCmd = cli.Command{
+	Name:            "set",
+	Usage:           "set tags and object ownership of a bucket",
+	Action:          mainBucketAttrsSet,
+	Before:          setGlobalsFromContext,
+	Flags:           append(bucketAttrsSetFlags, globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Tag bucket 'mybucket' and have its owner own all of its objects, ACLs are disabled.
+     $ {{.HelpName}} --tags env=prod,team=data --object-ownership BucketOwnerEnforced s3/mybucket
+
+  2. Remove all tags of bucket 'mybucket'.
+     $ {{.HelpName}} --tags "" s3/mybucket
+
+`,
+}
+
+// mainBucketAttrsSet is the handle for "mc bucket attrs set" command.
+func mainBucketAttrsSet(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 || (!ctx.IsSet("tags") && !ctx.IsSet("object-ownership")) {
+		cli.ShowCommandHelpAndExit(ctx, "set", 1) // last argument is exit code
+	}
+	targetURL := ctx.Args().Get(0)
+
+	tags, err := parseBucketTags(ctx.String("tags"))
+	fatalIf(err, "Unable to parse tags, at most %d tags of the form key1=value1,key2=value2 are allowed.", maxBucketTags)
+	ownership := ctx.String("object-ownership")
+	if ctx.IsSet("object-ownership") && !isValidObjectOwnership(ownership) {
+		fatalIf(errInvalidArgument().Trace(ownership), "Unknown object ownership `"+ownership+"`, must be one of "+strings.Join(objectOwnerships, ", ")+".")
+	}
+
+	console.SetColor("Attrs", color.New(color.FgGreen, color.Bold))
+
+	clnt := newBucketClient(targetURL)
+	if ctx.IsSet("tags") {
+		fatalIf(clnt.SetBucketTags(tags).Trace(targetURL), "Unable to set tags of `"+targetURL+"`.")
+	}
+	if ctx.IsSet("object-ownership") {
+		fatalIf(clnt.SetObjectOwnership(ownership).Trace(targetURL), "Unable to set object ownership of `"+targetURL+"`.")
+	}
+
+	printMsg(bucketAttrsMessage{op: "set", URL: targetURL})
+	return nil
+}
+
+// isValidObjectOwnership - returns true if ownership is one of
+// objectOwnerships.
+func isValidObjectOwnership(ownership string) bool {
+	for _, o := range objectOwnerships {
+		if o == ownership {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Limits of the tags of a bucket.
+const (
+	maxBucketTags        = 50
+	maxBucketTagKeyLen   = 128
+	maxBucketTagValueLen = 256
+)
+
+var bucketAttrsCmd = cli.Command{
+	Name:            "attrs",
+	Usage:           "manage tags and object ownership of buckets",
+	HideHelpCommand: true,
+	Action:          mainBucketAttrs,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	Subcommands: []cli.Command{
+		bucketAttrsSetCmd,
+		bucketAttrsInfoCmd,
+	},
+}
+
+// mainBucketAttrs is the handle for "mc bucket attrs" command.
+func mainBucketAttrs(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "set", "info" have their own main.
+}
+
+// bucketAttrsMessage container for bucket attributes messages.
+type bucketAttrsMessage struct {
+	op              string
+	Status          string            `json:"status"`
+	URL             string            `json:"url"`
+	Tags            map[string]string `json:"tags,omitempty"`
+	ObjectOwnership string            `json:"objectOwnership,omitempty"`
+}
+
+// String colorized bucket attributes message.
+func (b bucketAttrsMessage) String() string {
+	switch b.op {
+	case "set":
+		return console.Colorize("Attrs", fmt.Sprintf("Attributes of `%s` are set.", b.URL))
+	case "info":
+		tags := "none"
+		if len(b.Tags) > 0 {
+			tags = formatBucketTags(b.Tags)
+		}
+		ownership := b.ObjectOwnership
+		if ownership == "" {
+			ownership = "not set"
+		}
+		message := console.Colorize("Attrs", fmt.Sprintf("Tags             : %s\n", tags))
+		return message + console.Colorize("Attrs", fmt.Sprintf("Object ownership : %s", ownership))
+	default:
+		return ""
+	}
+}
+
+// JSON jsonified bucket attributes message.
+func (b bucketAttrsMessage) JSON() string {
+	b.Status = "success"
+	bucketAttrsMessageBytes, e := json.MarshalIndent(b, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(bucketAttrsMessageBytes)
+}
+
+// parseBucketTags - parses tags of the form key1=value1,key2=value2, no
+// tags if empty.
+func parseBucketTags(tagsStr string) (map[string]string, *probe.Error) {
+	tags := make(map[string]string)
+	if tagsStr == "" {
+		return tags, nil
+	}
+	for _, tag := range strings.Split(tagsStr, ",") {
+		kv := strings.SplitN(tag, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, errInvalidArgument().Trace(tag)
+		}
+		if len(kv[0]) > maxBucketTagKeyLen || len(kv[1]) > maxBucketTagValueLen {
+			return nil, errInvalidArgument().Trace(tag)
+		}
+		if _, ok := tags[kv[0]]; ok {
+			return nil, errInvalidArgument().Trace(tag)
+		}
+		tags[kv[0]] = kv[1]
+	}
+	if len(tags) > maxBucketTags {
+		return nil, errInvalidArgument().Trace(tagsStr)
+	}
+	return tags, nil
+}
+
+// formatBucketTags - formats tags as parsed by parseBucketTags.
+func formatBucketTags(tags map[string]string) string {
+	var kvs []string
+	for key, value := range tags {
+		kvs = append(kvs, key+"="+value)
+	}
+	sort.Strings(kvs)
+	return strings.Join(kvs, ",")
+}
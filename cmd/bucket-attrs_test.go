@@ -0,0 +1,52 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseBucketTags(t *testing.T) {
+	testCases := []struct {
+		tags     string
+		expected map[string]string
+		success  bool
+	}{
+		{"", map[string]string{}, true},
+		{"env=prod", map[string]string{"env": "prod"}, true},
+		{"env=prod,team=data", map[string]string{"env": "prod", "team": "data"}, true},
+		{"expr=a=b,empty=", map[string]string{"expr": "a=b", "empty": ""}, true},
+		{"env", nil, false},
+		{"=prod", nil, false},
+		{"env=prod,env=dev", nil, false},
+		{strings.Repeat("k", maxBucketTagKeyLen+1) + "=v", nil, false},
+		{"k=" + strings.Repeat("v", maxBucketTagValueLen+1), nil, false},
+	}
+	for i, testCase := range testCases {
+		tags, err := parseBucketTags(testCase.tags)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %t, got %v", i+1, testCase.success, err)
+		}
+		if err == nil && !reflect.DeepEqual(tags, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, tags)
+		}
+		if err == nil && formatBucketTags(tags) != formatBucketTags(testCase.expected) {
+			t.Errorf("Test %d: tags do not format alike", i+1)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import "github.com/minio/cli"
+
+var bucketCmd = cli.Command{
+	Name:            "bucket",
+	Usage:           "manage settings of buckets",
+	HideHelpCommand: true,
+	Action:          mainBucket,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	Subcommands: []cli.Command{
+		bucketAttrsCmd,
+	},
+}
+
+// mainBucket is the handle for "mc bucket" command.
+func mainBucket(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "attrs" have their own main.
+}
+
+// newBucketClient - returns the client of the bucket at urlStr, only
+// object storage has bucket settings.
+func newBucketClient(urlStr string) *s3Client {
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Unable to initialize `"+urlStr+"`.")
+
+	s3Clnt, ok := client.(*s3Client)
+	if !ok {
+		fatalIf(errInvalidArgument().Trace(urlStr), "`"+urlStr+"` is not a bucket on object storage.")
+	}
+	return s3Clnt
+}
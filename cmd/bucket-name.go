@@ -0,0 +1,87 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net"
+	"strings"
+)
+
+// Longest and shortest bucket names allowed by S3.
+const (
+	minBucketNameLen = 3
+	maxBucketNameLen = 63
+)
+
+// isBucketNameChar - reports if r may be part of a bucket name.
+func isBucketNameChar(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '-'
+}
+
+// bucketNameProblem - returns why bucket breaks the S3 naming rules for
+// new buckets, empty if it does not.
+func bucketNameProblem(bucket string) string {
+	switch {
+	case len(bucket) < minBucketNameLen || len(bucket) > maxBucketNameLen:
+		return "must be between 3 and 63 characters long"
+	case strings.ToLower(bucket) != bucket:
+		return "cannot have uppercase letters"
+	case strings.IndexFunc(bucket, func(r rune) bool { return !isBucketNameChar(r) }) >= 0:
+		return "can only have lowercase letters, numbers, dots and hyphens"
+	case strings.Trim(bucket, ".-") != bucket:
+		return "must begin and end with a letter or number"
+	case strings.Contains(bucket, "..") || strings.Contains(bucket, ".-") || strings.Contains(bucket, "-."):
+		return "cannot have a dot next to another dot or a hyphen"
+	case net.ParseIP(bucket) != nil:
+		return "cannot be formatted as an IP address"
+	case strings.HasPrefix(bucket, "xn--"):
+		return "cannot begin with `xn--`"
+	case strings.HasSuffix(bucket, "-s3alias"):
+		return "cannot end with `-s3alias`"
+	}
+	return ""
+}
+
+// suggestBucketName - returns a valid bucket name close to bucket, empty
+// if none is found: letters are lowercased, other characters not
+// allowed are replaced by hyphens.
+func suggestBucketName(bucket string) string {
+	name := strings.Map(func(r rune) rune {
+		if isBucketNameChar(r) {
+			return r
+		}
+		return '-'
+	}, strings.ToLower(bucket))
+	for prev := ""; prev != name; {
+		prev = name
+		for _, sep := range []string{"..", ".-", "-."} {
+			name = strings.Replace(name, sep, sep[:1], -1)
+		}
+	}
+	if net.ParseIP(name) != nil {
+		name = strings.Replace(name, ".", "-", -1)
+	}
+	name = strings.TrimSuffix(strings.TrimPrefix(name, "xn--"), "-s3alias")
+	if len(name) > maxBucketNameLen {
+		name = name[:maxBucketNameLen]
+	}
+	name = strings.Trim(name, ".-")
+	if name == bucket || bucketNameProblem(name) != "" {
+		return ""
+	}
+	return name
+}
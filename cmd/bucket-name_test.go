@@ -0,0 +1,72 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBucketNameProblem(t *testing.T) {
+	testCases := []struct {
+		bucket string
+		valid  bool
+	}{
+		{"mybucket", true},
+		{"my-bucket.2019", true},
+		{"my--bucket", true},
+		{"ab", false},
+		{strings.Repeat("a", 64), false},
+		{"MyBucket", false},
+		{"my_bucket", false},
+		{"-mybucket", false},
+		{"mybucket.", false},
+		{"my..bucket", false},
+		{"my.-bucket", false},
+		{"192.168.1.1", false},
+		{"xn--bucket", false},
+		{"mybucket-s3alias", false},
+	}
+	for i, testCase := range testCases {
+		problem := bucketNameProblem(testCase.bucket)
+		if valid := problem == ""; valid != testCase.valid {
+			t.Errorf("Test %d: expected `%s` valid %t, got %t (%s)", i+1, testCase.bucket, testCase.valid, valid, problem)
+		}
+	}
+}
+
+func TestSuggestBucketName(t *testing.T) {
+	testCases := []struct {
+		bucket     string
+		suggestion string
+	}{
+		{"My_Bucket", "my-bucket"},
+		{"_backups_", "backups"},
+		{"photos..2019", "photos.2019"},
+		{"a.-.b", "a.b"},
+		{"192.168.1.1", "192-168-1-1"},
+		{"xn--bucket", "bucket"},
+		{strings.Repeat("a", 62) + "_b", strings.Repeat("a", 62)},
+		{"mybucket", ""},
+		{"A", ""},
+	}
+	for i, testCase := range testCases {
+		if suggestion := suggestBucketName(testCase.bucket); suggestion != testCase.suggestion {
+			t.Errorf("Test %d: expected suggestion `%s` for `%s`, got `%s`", i+1, testCase.suggestion, testCase.bucket, suggestion)
+		}
+	}
+}
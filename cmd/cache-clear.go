@@ -0,0 +1,118 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var cacheClearFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "older-than",
+		Usage: "only clear objects cached more than L days, M hours and N minutes ago",
+	},
+}
+
+var cacheClear = cli.Command{
+	Name:            "clear",
+	Usage:           "remove cached objects",
+	Action:          mainCacheClear,
+	Before:          setGlobalsFromContext,
+	Flags:           append(cacheClearFlags, globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Remove all cached objects.
+     $ {{.HelpName}}
+  2. Remove objects cached more than 7 days ago.
+     $ {{.HelpName}} --older-than 7d
+`,
+}
+
+// clearCacheMessage container for clearing cache messages.
+type clearCacheMessage struct {
+	Status  string `json:"status"`
+	Entries int    `json:"entries"`
+	Size    int64  `json:"size"`
+}
+
+// String colorized clear cache message.
+func (c clearCacheMessage) String() string {
+	return console.Colorize("ClearCache", fmt.Sprintf("Removed %d cached object(s), %s freed.", c.Entries, humanize.IBytes(uint64(c.Size))))
+}
+
+// JSON jsonified clear cache message.
+func (c clearCacheMessage) JSON() string {
+	c.Status = "success"
+	clearCacheJSONBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(clearCacheJSONBytes)
+}
+
+// checkCacheClearSyntax - Check syntax of 'cache clear'.
+func checkCacheClearSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 0 {
+		cli.ShowCommandHelpAndExit(ctx, "clear", 1) // last argument is exit code
+	}
+}
+
+// mainCacheClear - Main cache clear.
+func mainCacheClear(ctx *cli.Context) error {
+	// Check command arguments
+	checkCacheClearSyntax(ctx)
+
+	// Additional command specific theme customization.
+	console.SetColor("ClearCache", color.New(color.FgGreen, color.Bold))
+
+	olderThan := ctx.String("older-than")
+
+	entries, err := listCacheEntries()
+	fatalIf(err.Trace(), "Unable to list cached objects.")
+
+	var msg clearCacheMessage
+	for _, entry := range entries {
+		// Skip entries newer than --older-than parameter if specified
+		if olderThan != "" && isOlder(entry.modTime, olderThan) {
+			continue
+		}
+		if e := os.Remove(entry.path); e != nil {
+			errorIf(probe.NewError(e).Trace(entry.path), "Unable to remove cached object.")
+			continue
+		}
+		msg.Entries++
+		msg.Size += entry.size
+	}
+	printMsg(msg)
+	return nil
+}
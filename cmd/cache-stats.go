@@ -0,0 +1,111 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var cacheStats = cli.Command{
+	Name:   "stats",
+	Usage:  "show read cache usage",
+	Before: setGlobalsFromContext,
+	Action: mainCacheStats,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}}
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show number of cached objects and their total size.
+     $ {{.HelpName}}
+`,
+}
+
+// cacheStatsMessage container for read cache usage.
+type cacheStatsMessage struct {
+	Status  string    `json:"status"`
+	Path    string    `json:"path"`
+	Entries int       `json:"entries"`
+	Size    int64     `json:"size"`
+	Oldest  time.Time `json:"oldest,omitempty"`
+}
+
+// String colorized cache stats message.
+func (c cacheStatsMessage) String() string {
+	msg := console.Colorize("CachePath", c.Path) + ": "
+	msg += console.Colorize("CacheEntries", fmt.Sprintf("%d object(s)", c.Entries)) + ", "
+	msg += console.Colorize("CacheSize", humanize.IBytes(uint64(c.Size)))
+	if !c.Oldest.IsZero() {
+		msg += ", oldest cached " + c.Oldest.Local().Format(printDate)
+	}
+	return msg
+}
+
+// JSON jsonified cache stats message.
+func (c cacheStatsMessage) JSON() string {
+	c.Status = "success"
+	cacheStatsJSONBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(cacheStatsJSONBytes)
+}
+
+func checkCacheStatsSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 0 {
+		cli.ShowCommandHelpAndExit(ctx, "stats", 1) // last argument is exit code
+	}
+}
+
+func mainCacheStats(ctx *cli.Context) error {
+	// Check 'cache stats'.
+	checkCacheStatsSyntax(ctx)
+
+	// Additional command specific theme customization.
+	console.SetColor("CachePath", color.New(color.Bold))
+	console.SetColor("CacheEntries", color.New(color.FgGreen))
+	console.SetColor("CacheSize", color.New(color.FgYellow))
+
+	cacheDir, err := getCacheDir()
+	fatalIf(err.Trace(), "Unable to get cache folder.")
+
+	entries, err := listCacheEntries()
+	fatalIf(err.Trace(), "Unable to list cached objects.")
+
+	msg := cacheStatsMessage{Path: cacheDir, Entries: len(entries)}
+	for _, entry := range entries {
+		msg.Size += entry.size
+		if msg.Oldest.IsZero() || entry.modTime.Before(msg.Oldest) {
+			msg.Oldest = entry.modTime
+		}
+	}
+	printMsg(msg)
+	return nil
+}
@@ -0,0 +1,262 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/mc/pkg/ioutils"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+// Remote objects read by commands supporting --cache are kept locally
+// for this long, caching is disabled when it is zero.
+var globalCacheExpiry time.Duration
+
+const (
+	// Size of the read cache above which the oldest objects are removed.
+	cacheMaxSize = 10 * humanize.GiByte
+
+	// Temporary files of reads interrupted this long ago are removed.
+	cacheTmpExpiry = 24 * time.Hour
+)
+
+// Stale cached objects are removed once per run.
+var cacheEvictOnce sync.Once
+
+// setCacheExpiry - enables the read cache for the given duration.
+func setCacheExpiry(expiry string) *probe.Error {
+	if expiry == "" {
+		globalCacheExpiry = 0
+		return nil
+	}
+	d, e := ioutils.ParseDurationTime(expiry)
+	if e != nil {
+		return probe.NewError(e).Trace(expiry)
+	}
+	globalCacheExpiry = d
+	return nil
+}
+
+// getCacheDir - get read cache directory.
+func getCacheDir() (string, *probe.Error) {
+	configDir, err := getMcConfigDir()
+	if err != nil {
+		return "", err.Trace()
+	}
+	return filepath.Join(configDir, globalCacheDir), nil
+}
+
+// getCacheFile - returns the cache file for an object version, entries
+// are keyed by URL and ETag so that a modified object is never served.
+func getCacheFile(urlStr, etag string) (string, *probe.Error) {
+	cacheDir, err := getCacheDir()
+	if err != nil {
+		return "", err.Trace()
+	}
+	sum := sha256.Sum256([]byte(urlStr + "\x00" + strings.Trim(etag, "\"")))
+	return filepath.Join(cacheDir, hex.EncodeToString(sum[:])), nil
+}
+
+// getCachedSource - returns a reader for the object, served from the
+// read cache when a fresh entry for its current ETag exists, otherwise
+// the object is downloaded and stored in the cache while being read.
+func getCachedSource(clnt Client, sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
+	// Never store decrypted SSE-C objects on disk.
+	if sse != nil {
+		return clnt.Get(sse)
+	}
+	cacheEvictOnce.Do(func() {
+		errorIf(evictCacheEntries(globalCacheExpiry, cacheMaxSize).Trace(), "Unable to remove stale cached objects.")
+	})
+	st, err := clnt.Stat(false, false, nil)
+	if err != nil {
+		return nil, err.Trace(clnt.GetURL().String())
+	}
+	cacheFile, err := getCacheFile(clnt.GetURL().String(), st.ETag)
+	if err != nil {
+		return nil, err.Trace(clnt.GetURL().String())
+	}
+	if fi, e := os.Stat(cacheFile); e == nil && fi.Size() == st.Size && time.Since(fi.ModTime()) < globalCacheExpiry {
+		if f, e := os.Open(cacheFile); e == nil {
+			return f, nil
+		}
+	}
+
+	reader, err := clnt.Get(nil)
+	if err != nil {
+		return nil, err.Trace(clnt.GetURL().String())
+	}
+	if e := os.MkdirAll(filepath.Dir(cacheFile), 0700); e != nil {
+		// Caching is best effort, serve the object regardless.
+		return reader, nil
+	}
+	tmpFile, e := ioutil.TempFile(filepath.Dir(cacheFile), ".tmp-")
+	if e != nil {
+		return reader, nil
+	}
+	return &cacheFillReader{
+		ReadCloser: reader,
+		tmpFile:    tmpFile,
+		cacheFile:  cacheFile,
+		size:       st.Size,
+	}, nil
+}
+
+// cacheFillReader - copies everything read from an object into a
+// temporary file, which becomes the cache entry once the object has
+// been read completely.
+type cacheFillReader struct {
+	io.ReadCloser
+	tmpFile   *os.File
+	cacheFile string
+	size      int64
+	written   int64
+	failed    bool
+}
+
+// Read implements io.Reader.
+func (c *cacheFillReader) Read(p []byte) (n int, err error) {
+	n, err = c.ReadCloser.Read(p)
+	if n > 0 && !c.failed {
+		if _, e := c.tmpFile.Write(p[:n]); e != nil {
+			c.failed = true
+		}
+		c.written += int64(n)
+	}
+	return n, err
+}
+
+// Close implements io.Closer, partially read objects are not cached.
+func (c *cacheFillReader) Close() error {
+	e := c.ReadCloser.Close()
+	c.tmpFile.Close()
+	if c.failed || c.written != c.size || os.Rename(c.tmpFile.Name(), c.cacheFile) != nil {
+		os.Remove(c.tmpFile.Name())
+	}
+	return e
+}
+
+// cacheEntry - a single read cache entry.
+type cacheEntry struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// listCacheEntries - list all read cache entries.
+func listCacheEntries() ([]cacheEntry, *probe.Error) {
+	cacheDir, err := getCacheDir()
+	if err != nil {
+		return nil, err.Trace()
+	}
+	files, e := ioutil.ReadDir(cacheDir)
+	if e != nil {
+		if os.IsNotExist(e) {
+			return nil, nil
+		}
+		return nil, probe.NewError(e).Trace(cacheDir)
+	}
+	var entries []cacheEntry
+	for _, fi := range files {
+		if !fi.Mode().IsRegular() || strings.HasPrefix(fi.Name(), ".tmp-") {
+			continue
+		}
+		entries = append(entries, cacheEntry{
+			path:    filepath.Join(cacheDir, fi.Name()),
+			size:    fi.Size(),
+			modTime: fi.ModTime(),
+		})
+	}
+	return entries, nil
+}
+
+// evictCacheEntries - removes cached objects older than maxAge, which
+// are no longer served, then the oldest ones until the cache holds no
+// more than maxSize bytes. Temporary files of interrupted reads are
+// removed after cacheTmpExpiry.
+func evictCacheEntries(maxAge time.Duration, maxSize int64) *probe.Error {
+	cacheDir, err := getCacheDir()
+	if err != nil {
+		return err.Trace()
+	}
+	files, e := ioutil.ReadDir(cacheDir)
+	if e != nil {
+		if os.IsNotExist(e) {
+			return nil
+		}
+		return probe.NewError(e).Trace(cacheDir)
+	}
+	remove := func(fi os.FileInfo) *probe.Error {
+		path := filepath.Join(cacheDir, fi.Name())
+		// Other runs may remove it first.
+		if e := os.Remove(path); e != nil && !os.IsNotExist(e) {
+			return probe.NewError(e).Trace(path)
+		}
+		return nil
+	}
+
+	// Oldest first.
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+	var kept []os.FileInfo
+	var size int64
+	for _, fi := range files {
+		if !fi.Mode().IsRegular() {
+			continue
+		}
+		age := time.Since(fi.ModTime())
+		if strings.HasPrefix(fi.Name(), ".tmp-") {
+			if age >= cacheTmpExpiry {
+				if err = remove(fi); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if age >= maxAge {
+			if err = remove(fi); err != nil {
+				return err
+			}
+			continue
+		}
+		kept = append(kept, fi)
+		size += fi.Size()
+	}
+	for _, fi := range kept {
+		if size <= maxSize {
+			break
+		}
+		if err = remove(fi); err != nil {
+			return err
+		}
+		size -= fi.Size()
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEvictCacheEntries(t *testing.T) {
+	configDir, e := ioutil.TempDir(os.TempDir(), "cache-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(configDir)
+	defer setMcConfigDir("")
+	setMcConfigDir(configDir)
+
+	cacheDir := filepath.Join(configDir, globalCacheDir)
+	if e = os.MkdirAll(cacheDir, 0700); e != nil {
+		t.Fatal(e)
+	}
+	now := time.Now()
+	files := []struct {
+		name string
+		age  time.Duration
+	}{
+		{"recent", time.Hour},
+		{"older", 2 * time.Hour},
+		{"expired", 48 * time.Hour},
+		{".tmp-interrupted", 48 * time.Hour},
+		{".tmp-reading", time.Minute},
+	}
+	for _, file := range files {
+		path := filepath.Join(cacheDir, file.name)
+		if e = ioutil.WriteFile(path, []byte("0123456789"), 0600); e != nil {
+			t.Fatal(e)
+		}
+		if e = os.Chtimes(path, now.Add(-file.age), now.Add(-file.age)); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	// Expired objects go first, then the oldest above the size limit.
+	if err := evictCacheEntries(24*time.Hour, 15); err != nil {
+		t.Fatal(err)
+	}
+	infos, e := ioutil.ReadDir(cacheDir)
+	if e != nil {
+		t.Fatal(e)
+	}
+	var kept []string
+	for _, fi := range infos {
+		kept = append(kept, fi.Name())
+	}
+	sort.Strings(kept)
+	if strings.Join(kept, ",") != ".tmp-reading,recent" {
+		t.Fatalf("expected .tmp-reading and recent to be kept, got %v", kept)
+	}
+}
@@ -33,11 +33,18 @@ import (
 	"github.com/minio/mc/pkg/probe"
 )
 
+// cat specific flags.
 var (
-	// This is kept dummy for future purposes
-	// and also to add ioFlags and globalFlags
-	// in CLI registration.
-	catFlags = []cli.Flag{}
+	catFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "cache",
+			Usage: "serve remote objects from a local cache kept for L days, M hours and N minutes",
+		},
+		cli.BoolFlag{
+			Name:  "no-decompress",
+			Usage: "display encoded objects as stored, without decompressing them",
+		},
+	}
 )
 
 // Display contents of a file.
@@ -71,6 +78,12 @@ EXAMPLES:
 
    4. Save an encrypted object from Amazon S3 cloud storage to a local file.
       $ {{.HelpName}} --encrypt-key 's3/mysql-backups=32byteslongsecretkeymustbegiven1' s3/mysql-backups/backups-201810.gz > /mnt/data/recent.gz
+
+   5. Display a reference configuration, downloading it again only if it changed or was cached more than a day ago.
+      $ {{.HelpName}} --cache 1d s3/configs/reference.json
+
+   6. Save a gzip encoded object from Amazon S3 cloud storage as stored, without decompressing it.
+      $ {{.HelpName}} --no-decompress s3/logs/nginx/access.log > access.log.gz
 `,
 }
 
@@ -137,7 +150,7 @@ func checkCatSyntax(ctx *cli.Context) {
 }
 
 // catURL displays contents of a URL to stdout.
-func catURL(sourceURL string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+func catURL(sourceURL string, noDecompress bool, encKeyDB map[string][]prefixSSEPair) *probe.Error {
 	var reader io.ReadCloser
 	size := int64(-1)
 	switch sourceURL {
@@ -150,7 +163,7 @@ func catURL(sourceURL string, encKeyDB map[string][]prefixSSEPair) *probe.Error
 		// downloaded object is equal to the original one. FS files
 		// are ignored since some of them have zero size though they
 		// have contents like files under /proc.
-		client, content, err := url2Stat(sourceURL, false, encKeyDB)
+		client, content, err := url2Stat(sourceURL, !noDecompress, encKeyDB)
 		if err == nil && client.GetURL().Type == objectStorage {
 			size = content.Size
 		}
@@ -158,6 +171,17 @@ func catURL(sourceURL string, encKeyDB map[string][]prefixSSEPair) *probe.Error
 			return err.Trace(sourceURL)
 		}
 		defer reader.Close()
+		// Decompress objects uploaded with a content encoding.
+		if content != nil && !noDecompress {
+			decompressReader, ok, err := newDecompressReader(reader, content.Metadata["Content-Encoding"])
+			if err != nil {
+				return err.Trace(sourceURL)
+			}
+			if ok {
+				defer decompressReader.Close()
+				reader, size = decompressReader, -1
+			}
+		}
 	}
 	return catOut(reader, size).Trace(sourceURL)
 }
@@ -216,6 +240,8 @@ func mainCat(ctx *cli.Context) error {
 	// check 'cat' cli arguments.
 	checkCatSyntax(ctx)
 
+	fatalIf(setCacheExpiry(ctx.String("cache")), "Unable to parse cache expiry.")
+
 	// Set command flags from context.
 	stdinMode := false
 	if !ctx.Args().Present() {
@@ -242,7 +268,7 @@ func mainCat(ctx *cli.Context) error {
 
 	// Convert arguments to URLs: expand alias, fix format.
 	for _, url := range args {
-		fatalIf(catURL(url, encKeyDB).Trace(url), "Unable to read from `"+url+"`.")
+		fatalIf(catURL(url, ctx.Bool("no-decompress"), encKeyDB).Trace(url), "Unable to read from `"+url+"`.")
 	}
 
 	return nil
@@ -0,0 +1,240 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/credentials"
+)
+
+const (
+	// Objects smaller than this are uploaded in a single request, the
+	// only uploads sent with a checksum.
+	checksumMaxSize = 64 * humanize.MiByte
+
+	// Header asking for the checksum stored with an object.
+	checksumModeHeader = "X-Amz-Checksum-Mode"
+
+	checksumHeaderPrefix = "x-amz-checksum-"
+)
+
+// objectChecksum - additional checksum stored with an object, its value
+// is base64 encoded.
+type objectChecksum struct {
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"value"`
+}
+
+// header - returns the header carrying the checksum.
+func (c objectChecksum) header() string {
+	return http.CanonicalHeaderKey(checksumHeaderPrefix + c.Algorithm)
+}
+
+// isComposite - returns true for checksums of multipart uploads, they
+// are computed over the checksums of the parts as VALUE-PARTS.
+func (c objectChecksum) isComposite() bool {
+	return strings.Contains(c.Value, "-")
+}
+
+// newChecksumHash - returns the hash of algorithm, nil if unsupported.
+func newChecksumHash(algorithm string) hash.Hash {
+	switch algorithm {
+	case "crc32":
+		return crc32.NewIEEE()
+	case "crc32c":
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	case "sha1":
+		return sha1.New()
+	case "sha256":
+		return sha256.New()
+	}
+	return nil
+}
+
+// checkChecksumAlgorithm - validates the value passed to --checksum.
+func checkChecksumAlgorithm(algorithm string) *probe.Error {
+	if algorithm == "" || newChecksumHash(algorithm) != nil {
+		return nil
+	}
+	return probe.NewError(fmt.Errorf("Unknown checksum algorithm `%s`, please use crc32, crc32c, sha1 or sha256", algorithm))
+}
+
+// parseChecksumHeader - returns the checksum carried by a header, ok is
+// false for other headers.
+func parseChecksumHeader(name, value string) (checksum objectChecksum, ok bool) {
+	name = strings.ToLower(name)
+	if !strings.HasPrefix(name, checksumHeaderPrefix) {
+		return checksum, false
+	}
+	algorithm := strings.TrimPrefix(name, checksumHeaderPrefix)
+	if newChecksumHash(algorithm) == nil {
+		return checksum, false
+	}
+	return objectChecksum{Algorithm: algorithm, Value: value}, true
+}
+
+// takeChecksum - removes the checksum headers from metadata, returns the
+// checksum found in them.
+func takeChecksum(metadata map[string]string) (checksum objectChecksum) {
+	for k, v := range metadata {
+		if !strings.HasPrefix(strings.ToLower(k), checksumHeaderPrefix) {
+			continue
+		}
+		if c, ok := parseChecksumHeader(k, v); ok {
+			checksum = c
+		}
+		delete(metadata, k)
+	}
+	return checksum
+}
+
+// fileChecksum - computes the checksum of file with algorithm, file is
+// rewound afterwards.
+func fileChecksum(file *os.File, algorithm string) (objectChecksum, *probe.Error) {
+	h := newChecksumHash(algorithm)
+	if _, e := io.Copy(h, file); e != nil {
+		return objectChecksum{}, probe.NewError(e).Trace(file.Name())
+	}
+	if _, e := file.Seek(0, io.SeekStart); e != nil {
+		return objectChecksum{}, probe.NewError(e).Trace(file.Name())
+	}
+	return objectChecksum{Algorithm: algorithm, Value: base64.StdEncoding.EncodeToString(h.Sum(nil))}, nil
+}
+
+// checksumReader - verifies an object read to its end against the
+// checksum stored with it, found in the response to its first read
+// rather than with a request of its own. Objects read at other offsets,
+// or with a composite checksum, are not verified.
+type checksumReader struct {
+	*minio.Object
+	checksum objectChecksum
+	hash     hash.Hash
+	verify   bool
+}
+
+// newChecksumReader - returns object verified against its checksum.
+func newChecksumReader(object *minio.Object) *checksumReader {
+	return &checksumReader{
+		Object: object,
+		verify: true,
+	}
+}
+
+// findChecksum - sets the checksum to verify from the headers of the
+// first response, returns false if there is none.
+func (r *checksumReader) findChecksum() bool {
+	// Stat returns the headers of the first read once done.
+	st, e := r.Object.Stat()
+	if e != nil {
+		return false
+	}
+	for k, v := range st.Metadata {
+		if checksum, ok := parseChecksumHeader(k, strings.Join(v, "")); ok && !checksum.isComposite() {
+			r.checksum = checksum
+			r.hash = newChecksumHash(checksum.Algorithm)
+			return true
+		}
+	}
+	return false
+}
+
+// Read implements io.Reader.
+func (r *checksumReader) Read(b []byte) (int, error) {
+	n, e := r.Object.Read(b)
+	if !r.verify {
+		return n, e
+	}
+	if r.hash == nil {
+		if e != nil && e != io.EOF {
+			return n, e
+		}
+		if r.verify = r.findChecksum(); !r.verify {
+			return n, e
+		}
+	}
+	r.hash.Write(b[:n])
+	if e == io.EOF {
+		r.verify = false
+		if computed := base64.StdEncoding.EncodeToString(r.hash.Sum(nil)); computed != r.checksum.Value {
+			return n, ChecksumMismatch{Algorithm: r.checksum.Algorithm, Expected: r.checksum.Value, Computed: computed}
+		}
+	}
+	return n, e
+}
+
+// ReadAt implements io.ReaderAt.
+func (r *checksumReader) ReadAt(b []byte, offset int64) (int, error) {
+	r.verify = false
+	return r.Object.ReadAt(b, offset)
+}
+
+// Seek implements io.Seeker.
+func (r *checksumReader) Seek(offset int64, whence int) (int64, error) {
+	r.verify = false
+	return r.Object.Seek(offset, whence)
+}
+
+type checksumContextKey struct{}
+
+// withUploadChecksum - returns ctx sending checksum with the upload done
+// with it.
+func withUploadChecksum(ctx context.Context, checksum objectChecksum) context.Context {
+	return context.WithValue(ctx, checksumContextKey{}, checksum)
+}
+
+// checksumTransport - sends the checksum found in the context of single
+// request uploads for the server to verify and store. Only AWS signature
+// V4 requests without chunk signed payloads can be signed again with it.
+type checksumTransport struct {
+	creds     *credentials.Credentials
+	transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t checksumTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	checksum, ok := req.Context().Value(checksumContextKey{}).(objectChecksum)
+	if ok && isSinglePutRequest(req) {
+		now, e := time.Parse(iso8601Format, req.Header.Get("X-Amz-Date"))
+		if e != nil {
+			now = UTCNow()
+		}
+		req.Header.Set(checksum.header(), checksum.Value)
+		if !resignV4(req, t.creds, now, checksum.header()) {
+			req.Header.Del(checksum.header())
+		}
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// isSinglePutRequest - returns true if req uploads a whole object.
+func isSinglePutRequest(req *http.Request) bool {
+	return req.Method == http.MethodPut && req.URL.RawQuery == "" && req.Header.Get("X-Amz-Copy-Source") == ""
+}
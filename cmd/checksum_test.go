@@ -0,0 +1,78 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestFileChecksum(t *testing.T) {
+	file, e := ioutil.TempFile("", "checksum")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+	if _, e = file.WriteString("hello, world"); e != nil {
+		t.Fatal(e)
+	}
+
+	testCases := []struct {
+		algorithm string
+		value     string
+	}{
+		{"crc32", "/6tyOg=="},
+		{"crc32c", "aZmkHw=="},
+		{"sha1", "t+I+wpryKwtOQdox6GjVciYSHIQ="},
+		{"sha256", "Ccp+TqpuiunH0mEWcSkYSINkTQffuny/vEyKLgg2DVs="},
+	}
+	for i, testCase := range testCases {
+		if _, e = file.Seek(0, 0); e != nil {
+			t.Fatal(e)
+		}
+		checksum, err := fileChecksum(file, testCase.algorithm)
+		if err != nil {
+			t.Fatalf("Test %d: %s", i+1, err.ToGoError())
+		}
+		if checksum.Value != testCase.value {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.value, checksum.Value)
+		}
+	}
+}
+
+func TestTakeChecksum(t *testing.T) {
+	testCases := []struct {
+		metadata  map[string]string
+		checksum  objectChecksum
+		remaining int
+	}{
+		{map[string]string{"Content-Type": "text/plain"}, objectChecksum{}, 1},
+		{map[string]string{"X-Amz-Checksum-Crc32c": "aZmkHw==", "X-Amz-Meta-Owner": "jazz"}, objectChecksum{"crc32c", "aZmkHw=="}, 1},
+		// Headers describing the checksum are removed as well.
+		{map[string]string{"X-Amz-Checksum-Sha256": "abc=-3", "X-Amz-Checksum-Type": "COMPOSITE"}, objectChecksum{"sha256", "abc=-3"}, 0},
+	}
+	for i, testCase := range testCases {
+		checksum := takeChecksum(testCase.metadata)
+		if checksum != testCase.checksum {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.checksum, checksum)
+		}
+		if len(testCase.metadata) != testCase.remaining {
+			t.Errorf("Test %d: expected %d headers left, got %d", i+1, testCase.remaining, len(testCase.metadata))
+		}
+	}
+}
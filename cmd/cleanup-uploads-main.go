@@ -0,0 +1,108 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+// cleanup-uploads specific flags.
+var (
+	cleanupUploadsFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "older-than",
+			Usage: "abort uploads initiated more than L days, M hours and N minutes ago",
+		},
+		cli.BoolFlag{
+			Name:  "fake",
+			Usage: "only list the uploads which would be aborted",
+		},
+	}
+)
+
+// abort incomplete multipart uploads.
+var cleanupUploadsCmd = cli.Command{
+	Name:   "cleanup-uploads",
+	Usage:  "abort incomplete uploads",
+	Action: mainCleanupUploads,
+	Before: setGlobalsFromContext,
+	Flags:  append(cleanupUploadsFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET [TARGET ...]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Abort all incomplete uploads in bucket 'jazz-songs'.
+      $ {{.HelpName}} s3/jazz-songs
+
+   2. Abort incomplete uploads under 'backups/' initiated more than 7 days ago.
+      $ {{.HelpName}} --older-than 7d s3/sql-backups/backups/
+
+   3. List incomplete uploads which would be aborted, without aborting them.
+      $ {{.HelpName}} --fake --older-than 7d s3/sql-backups
+
+   4. Review incomplete uploads with their initiation time and size.
+      $ mc ls --recursive --incomplete s3/sql-backups
+`,
+}
+
+// checkCleanupUploadsSyntax - validate all the passed arguments
+func checkCleanupUploadsSyntax(ctx *cli.Context) {
+	if !ctx.Args().Present() {
+		exitCode := 1
+		cli.ShowCommandHelpAndExit(ctx, "cleanup-uploads", exitCode)
+	}
+	for _, url := range ctx.Args() {
+		if strings.TrimSpace(url) == "" {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...), "Unable to validate empty argument.")
+		}
+		_, path := url2Alias(url)
+		if strings.Trim(path, "/") == "" {
+			fatalIf(errInvalidArgument().Trace(url), "Unable to cleanup `"+url+"`, a bucket name is required.")
+		}
+	}
+}
+
+// mainCleanupUploads - is a handler for mc cleanup-uploads command
+func mainCleanupUploads(ctx *cli.Context) error {
+	// check 'cleanup-uploads' cli arguments.
+	checkCleanupUploadsSyntax(ctx)
+
+	// Set color.
+	console.SetColor("Remove", color.New(color.FgGreen, color.Bold))
+
+	isIncomplete := true
+	isFake := ctx.Bool("fake")
+	olderThan := ctx.String("older-than")
+
+	var cErr error
+	for _, url := range ctx.Args() {
+		if e := removeRecursive(url, isIncomplete, isFake, olderThan, "", "", nil, nil); e != nil && cErr == nil {
+			cErr = e
+		}
+	}
+	return cErr
+}
@@ -89,6 +89,12 @@ func newAdminFactory() func(config *Config) (*madmin.AdminClient, *probe.Error)
 				TLSClientConfig:       tlsConfig,
 			}
 
+			if isReadOnly() {
+				transport = readOnlyTransport{transport: transport}
+			}
+			if isAuditEnabled() {
+				transport = auditTransport{alias: config.Alias, accessKey: config.AccessKey, transport: transport}
+			}
 			if config.Debug {
 				transport = httptracer.GetNewTraceTransport(newTraceV4(), transport)
 			}
@@ -125,6 +131,7 @@ func newAdminClient(aliasedURL string) (*madmin.AdminClient, *probe.Error) {
 	}
 
 	s3Config := newS3Config(urlStrFull, hostCfg)
+	s3Config.Alias = alias
 
 	s3Client, err := s3AdminNew(s3Config)
 	if err != nil {
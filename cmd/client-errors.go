@@ -59,9 +59,13 @@ func (e BucketNameEmpty) Error() string {
 // BucketInvalid - bucket name invalid.
 type BucketInvalid struct {
 	Bucket string
+	Reason string
 }
 
 func (e BucketInvalid) Error() string {
+	if e.Reason != "" {
+		return "Bucket name " + e.Bucket + " not valid, bucket names " + e.Reason + "."
+	}
 	return "Bucket name " + e.Bucket + " not valid."
 }
 
@@ -92,6 +96,26 @@ func (e ObjectOnGlacier) Error() string {
 	return "Object `" + e.Object + "` is on Glacier storage."
 }
 
+// SourceChanged - source differs from the one recorded in a session.
+type SourceChanged struct {
+	Source string
+}
+
+func (e SourceChanged) Error() string {
+	return "Source `" + e.Source + "` changed since the session was saved."
+}
+
+// SpecialFile - source is a fifo, socket or device, which cannot be
+// read like a regular file.
+type SpecialFile struct {
+	Path string
+	Kind string
+}
+
+func (e SpecialFile) Error() string {
+	return "`" + e.Path + "` is a " + e.Kind + ", not a regular file."
+}
+
 // BucketNameTopLevel - generic error
 type BucketNameTopLevel struct{}
 
@@ -153,6 +177,16 @@ func (e ObjectMissing) Error() string {
 	return "Object does not exist"
 }
 
+// ObjectRemoveFailed - an object of a removal in bulk was not removed.
+type ObjectRemoveFailed struct {
+	Path  string
+	Cause error
+}
+
+func (e ObjectRemoveFailed) Error() string {
+	return e.Cause.Error()
+}
+
 // UnexpectedShortWrite - write wrote less bytes than expected.
 type UnexpectedShortWrite struct {
 	InputSize int
@@ -183,6 +217,55 @@ func (e UnexpectedExcessRead) Error() string {
 	return msg
 }
 
+// StreamTooLarge - stream of unknown length does not fit in the parts
+// of a multipart upload.
+type StreamTooLarge struct {
+	TotalWritten int64
+}
+
+func (e StreamTooLarge) Error() string {
+	return fmt.Sprintf("Input stream exceeds the maximum number of parts after `%d` bytes.", e.TotalWritten)
+}
+
+// HookFailed - command run before or after a transfer failed.
+type HookFailed struct {
+	Hook    string
+	Command string
+	Err     error
+}
+
+func (e HookFailed) Error() string {
+	return fmt.Sprintf("%s hook `%s` failed: %v", e.Hook, e.Command, e.Err)
+}
+
+// ChecksumMismatch - object read does not match its stored checksum.
+type ChecksumMismatch struct {
+	Algorithm, Expected, Computed string
+}
+
+func (e ChecksumMismatch) Error() string {
+	return fmt.Sprintf("%s checksum mismatch: expected `%s`, computed `%s`", e.Algorithm, e.Expected, e.Computed)
+}
+
+// FilterFailed - command transforming an object body failed.
+type FilterFailed struct {
+	Command string
+	Err     error
+}
+
+func (e FilterFailed) Error() string {
+	return fmt.Sprintf("filter `%s` failed: %v", e.Command, e.Err)
+}
+
+// ReadOnlyMode - request changing a server refused in read-only mode.
+type ReadOnlyMode struct {
+	Method string
+}
+
+func (e ReadOnlyMode) Error() string {
+	return fmt.Sprintf("`%s` requests are refused in read-only mode", e.Method)
+}
+
 // SameFile - source and destination are same files.
 type SameFile struct {
 	Source, Destination string
@@ -106,6 +106,25 @@ func isIgnoredFile(filename string) bool {
 	return false
 }
 
+// specialFileKind returns the kind of mode if it is a special file, a
+// fifo, socket or device which cannot be read like a regular file, an
+// empty string otherwise.
+func specialFileKind(mode os.FileMode) string {
+	switch {
+	case mode&os.ModeNamedPipe != 0:
+		return "fifo"
+	case mode&os.ModeSocket != 0:
+		return "socket"
+	case mode&os.ModeCharDevice != 0:
+		return "character device"
+	case mode&os.ModeDevice != 0:
+		return "block device"
+	case mode&os.ModeIrregular != 0:
+		return "irregular file"
+	}
+	return ""
+}
+
 // URL get url.
 func (f *fsClient) GetURL() clientURL {
 	return *f.PathURL
@@ -413,6 +432,12 @@ func (f *fsClient) get() (io.ReadCloser, *probe.Error) {
 		err := f.toClientError(e, f.PathURL.Path)
 		return nil, err.Trace(f.PathURL.Path)
 	}
+	// Opening a fifo blocks until it has a writer.
+	if st, e := os.Stat(f.PathURL.Path); e == nil {
+		if kind := specialFileKind(st.Mode()); kind != "" {
+			return nil, probe.NewError(SpecialFile{Path: f.PathURL.Path, Kind: kind})
+		}
+	}
 	fileData, e := os.Open(f.PathURL.Path)
 	if e != nil {
 		err := f.toClientError(e, f.PathURL.Path)
@@ -457,8 +482,8 @@ func (f *fsClient) Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *c
 
 // List - list files and folders.
 func (f *fsClient) List(isRecursive, isIncomplete bool, showDir DirOpt) <-chan *clientContent {
-	contentCh := make(chan *clientContent)
-	filteredCh := make(chan *clientContent)
+	contentCh := make(chan *clientContent, listBufferSize)
+	filteredCh := make(chan *clientContent, listBufferSize)
 
 	if isRecursive {
 		if showDir == DirNone {
@@ -664,7 +689,7 @@ func (f *fsClient) listInRoutine(contentCh chan<- *clientContent) {
 					continue
 				}
 			}
-			if fi.Mode().IsRegular() || fi.Mode().IsDir() {
+			if fi.Mode().IsRegular() || fi.Mode().IsDir() || specialFileKind(fi.Mode()) != "" {
 				pathURL = *f.PathURL
 				pathURL.Path = filepath.Join(pathURL.Path, fi.Name())
 
@@ -850,7 +875,8 @@ func (f *fsClient) listRecursiveInRoutine(contentCh chan *clientContent) {
 				return e
 			}
 		}
-		if fi.Mode().IsRegular() {
+		// Special files are listed for callers to skip them.
+		if fi.Mode().IsRegular() || specialFileKind(fi.Mode()) != "" {
 			contentCh <- &clientContent{
 				URL:  *newClientURL(fp),
 				Time: fi.ModTime(),
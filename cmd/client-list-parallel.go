@@ -0,0 +1,78 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync"
+)
+
+// listParallel - recursively lists clnt with the given number of
+// workers, each top level folder is listed separately by the next free
+// worker. Contents are not returned in lexical order.
+func listParallel(alias string, clnt Client, isIncomplete bool, workers int) <-chan *clientContent {
+	isRecursive := true
+	if workers <= 1 {
+		return clnt.List(isRecursive, isIncomplete, DirNone)
+	}
+
+	contentCh := make(chan *clientContent, listBufferSize)
+	prefixCh := make(chan string)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for prefix := range prefixCh {
+				prefixClnt, err := newClientFromAlias(alias, prefix)
+				if err != nil {
+					contentCh <- &clientContent{Err: err.Trace(alias, prefix)}
+					continue
+				}
+				for content := range prefixClnt.List(isRecursive, isIncomplete, DirNone) {
+					contentCh <- content
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer func() {
+			close(prefixCh)
+			wg.Wait()
+			close(contentCh)
+		}()
+		for content := range clnt.List(!isRecursive, isIncomplete, DirNone) {
+			if content.Err == nil && content.Type.IsDir() {
+				prefixCh <- content.URL.String()
+				continue
+			}
+			contentCh <- content
+		}
+	}()
+
+	return contentCh
+}
+
+// setListWorkers - sets the number of prefixes clnt lists concurrently
+// in its recursive listings, which stay sorted. Only object storage is
+// listed so, the default is kept unless workers is positive.
+func setListWorkers(clnt Client, workers int) {
+	if s3Clnt, ok := clnt.(*s3Client); ok && workers > 0 {
+		s3Clnt.listWorkers = workers
+	}
+}
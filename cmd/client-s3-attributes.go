@@ -0,0 +1,164 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+// Attributes asked for with GetObjectAttributes.
+const objectAttributesAsked = "ETag,Checksum,ObjectParts,StorageClass,ObjectSize"
+
+// Most parts listed by GetObjectAttributes in one call.
+const objectAttributesMaxParts = 10000
+
+// errAttributesNotSupported - the endpoint has no GetObjectAttributes.
+var errAttributesNotSupported = errors.New("GetObjectAttributes is not supported")
+
+// attributesChecksum - checksums of GetObjectAttributes, at most one of
+// them is set.
+type attributesChecksum struct {
+	ChecksumCRC32  string
+	ChecksumCRC32C string
+	ChecksumSHA1   string
+	ChecksumSHA256 string
+}
+
+// checksum - returns the checksum set, if any.
+func (c attributesChecksum) checksum() objectChecksum {
+	switch {
+	case c.ChecksumCRC32 != "":
+		return objectChecksum{Algorithm: "crc32", Value: c.ChecksumCRC32}
+	case c.ChecksumCRC32C != "":
+		return objectChecksum{Algorithm: "crc32c", Value: c.ChecksumCRC32C}
+	case c.ChecksumSHA1 != "":
+		return objectChecksum{Algorithm: "sha1", Value: c.ChecksumSHA1}
+	case c.ChecksumSHA256 != "":
+		return objectChecksum{Algorithm: "sha256", Value: c.ChecksumSHA256}
+	}
+	return objectChecksum{}
+}
+
+// objectPart - part of a multipart upload listed by GetObjectAttributes.
+type objectPart struct {
+	PartNumber int
+	Size       int64
+	attributesChecksum
+}
+
+// objectAttributes - response of GetObjectAttributes.
+type objectAttributes struct {
+	XMLName     xml.Name `xml:"GetObjectAttributesResponse"`
+	ETag        string
+	Checksum    attributesChecksum
+	ObjectParts struct {
+		TotalPartsCount int
+		Parts           []objectPart `xml:"Part"`
+	}
+	StorageClass string
+	ObjectSize   int64
+}
+
+// hasAllParts - returns true if the size of every part is known.
+func (a *objectAttributes) hasAllParts() bool {
+	parts := a.ObjectParts.Parts
+	if a.ObjectParts.TotalPartsCount == 0 || len(parts) != a.ObjectParts.TotalPartsCount {
+		return false
+	}
+	var size int64
+	for _, part := range parts {
+		size += part.Size
+	}
+	return size == a.ObjectSize
+}
+
+// getObjectAttributes - returns the size, checksum and parts of the
+// object of c with a single request, errAttributesNotSupported when the
+// endpoint has no GetObjectAttributes.
+func (c *s3Client) getObjectAttributes(sse encrypt.ServerSide) (*clientContent, *objectAttributes, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	header := http.Header{}
+	header.Set("X-Amz-Object-Attributes", objectAttributesAsked)
+	header.Set("X-Amz-Max-Parts", strconv.Itoa(objectAttributesMaxParts))
+	if sse != nil && sse.Type() == encrypt.SSEC {
+		sse.Marshal(header)
+	}
+	resp, e := c.signedRequest(http.MethodGet, bucket, object, url.Values{"attributes": {""}}, header, nil)
+	if e == errRequestNotSigned {
+		return nil, nil, probe.NewError(errAttributesNotSupported)
+	}
+	if e != nil {
+		return nil, nil, probe.NewError(e)
+	}
+	defer resp.Body.Close()
+	body := io.LimitReader(resp.Body, maxErrorResponseSize)
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp minio.ErrorResponse
+		if e = xml.NewDecoder(body).Decode(&errResp); e != nil {
+			return nil, nil, probe.NewError(errAttributesNotSupported)
+		}
+		switch errResp.Code {
+		case "NoSuchKey":
+			return nil, nil, probe.NewError(ObjectMissing{})
+		case "NoSuchBucket":
+			return nil, nil, probe.NewError(BucketDoesNotExist{Bucket: bucket})
+		case "AccessDenied":
+			return nil, nil, probe.NewError(PathInsufficientPermission{Path: c.targetURL.String()})
+		case "NotImplemented", "MethodNotAllowed", "InvalidArgument", "InvalidRequest":
+			return nil, nil, probe.NewError(errAttributesNotSupported)
+		}
+		return nil, nil, probe.NewError(errResp)
+	}
+	// Endpoints ignoring the query return the object itself instead.
+	attrs := &objectAttributes{}
+	if e = xml.NewDecoder(body).Decode(attrs); e != nil {
+		return nil, nil, probe.NewError(errAttributesNotSupported)
+	}
+
+	content := &clientContent{
+		URL:      *c.targetURL,
+		Size:     attrs.ObjectSize,
+		ETag:     strings.Trim(attrs.ETag, "\""),
+		Type:     os.FileMode(0664),
+		Metadata: map[string]string{},
+	}
+	content.Time, _ = http.ParseTime(resp.Header.Get("Last-Modified"))
+	if attrs.StorageClass != "" {
+		content.Metadata["X-Amz-Storage-Class"] = attrs.StorageClass
+	}
+	if checksum := attrs.Checksum.checksum(); checksum.Algorithm != "" {
+		content.Metadata[checksum.header()] = checksum.Value
+	}
+	// ETags of server encrypted objects are not their MD5.
+	for name := range resp.Header {
+		if strings.HasPrefix(strings.ToLower(name), "x-amz-server-side-encryption") {
+			content.Metadata[name] = resp.Header.Get(name)
+		}
+	}
+	return content, attrs, nil
+}
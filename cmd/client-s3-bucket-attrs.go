@@ -0,0 +1,179 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+// Error codes of buckets without tags or object ownership settings.
+const (
+	tagSetNotFound            = "NoSuchTagSet"
+	ownershipControlsNotFound = "OwnershipControlsNotFoundError"
+)
+
+// Object ownership settings of a bucket.
+var objectOwnerships = []string{"BucketOwnerEnforced", "BucketOwnerPreferred", "ObjectWriter"}
+
+// bucketTag - tag of a bucket.
+type bucketTag struct {
+	Key   string
+	Value string
+}
+
+// bucketTagging - tags of a bucket, as sent and returned by
+// PutBucketTagging and GetBucketTagging, or of an object as returned by
+// GetObjectTagging.
+type bucketTagging struct {
+	XMLName xml.Name    `xml:"Tagging"`
+	XMLNS   string      `xml:"xmlns,attr,omitempty"`
+	TagSet  []bucketTag `xml:"TagSet>Tag"`
+}
+
+// ownershipRule - object ownership setting of a bucket.
+type ownershipRule struct {
+	ObjectOwnership string
+}
+
+// ownershipControls - object ownership setting of a bucket, as sent and
+// returned by PutBucketOwnershipControls and GetBucketOwnershipControls.
+type ownershipControls struct {
+	XMLName xml.Name        `xml:"OwnershipControls"`
+	XMLNS   string          `xml:"xmlns,attr,omitempty"`
+	Rules   []ownershipRule `xml:"Rule"`
+}
+
+// GetBucketTags - returns the tags of the bucket.
+func (c *s3Client) GetBucketTags() (map[string]string, *probe.Error) {
+	resp, err := c.bucketConfigRequest(http.MethodGet, "tagging", tagSetNotFound, nil)
+	if err != nil || resp == nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var tagging bucketTagging
+	if e := xml.NewDecoder(resp.Body).Decode(&tagging); e != nil {
+		return nil, probe.NewError(e)
+	}
+	tags := make(map[string]string, len(tagging.TagSet))
+	for _, tag := range tagging.TagSet {
+		tags[tag.Key] = tag.Value
+	}
+	return tags, nil
+}
+
+// GetObjectTags - returns the tags of the object.
+func (c *s3Client) GetObjectTags() (map[string]string, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	resp, e := c.signedRequest(http.MethodGet, bucket, object, url.Values{"tagging": {""}}, nil, nil)
+	if e == errRequestNotSigned {
+		return nil, probe.NewError(APINotImplemented{API: "Object tagging", APIType: "S3v2"})
+	}
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp minio.ErrorResponse
+		if e = xml.NewDecoder(io.LimitReader(resp.Body, maxErrorResponseSize)).Decode(&errResp); e != nil {
+			return nil, probe.NewError(e)
+		}
+		if errResp.Code == "NoSuchKey" {
+			return nil, probe.NewError(ObjectMissing{})
+		}
+		return nil, c.requestError(bucket, "Object tagging", errResp)
+	}
+	var tagging bucketTagging
+	if e = xml.NewDecoder(resp.Body).Decode(&tagging); e != nil {
+		return nil, probe.NewError(e)
+	}
+	tags := make(map[string]string, len(tagging.TagSet))
+	for _, tag := range tagging.TagSet {
+		tags[tag.Key] = tag.Value
+	}
+	return tags, nil
+}
+
+// SetBucketTags - replaces the tags of the bucket, they are removed if
+// tags is empty.
+func (c *s3Client) SetBucketTags(tags map[string]string) *probe.Error {
+	if len(tags) == 0 {
+		resp, err := c.bucketConfigRequest(http.MethodDelete, "tagging", tagSetNotFound, nil)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+	tagging := bucketTagging{XMLNS: "http://s3.amazonaws.com/doc/2006-03-01/"}
+	for key, value := range tags {
+		tagging.TagSet = append(tagging.TagSet, bucketTag{Key: key, Value: value})
+	}
+	sort.Slice(tagging.TagSet, func(i, j int) bool { return tagging.TagSet[i].Key < tagging.TagSet[j].Key })
+	body, e := xml.Marshal(tagging)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	resp, err := c.bucketConfigRequest(http.MethodPut, "tagging", tagSetNotFound, body)
+	if resp != nil {
+		resp.Body.Close()
+	}
+	return err
+}
+
+// GetObjectOwnership - returns the object ownership setting of the
+// bucket, empty if not set.
+func (c *s3Client) GetObjectOwnership() (string, *probe.Error) {
+	resp, err := c.bucketConfigRequest(http.MethodGet, "ownershipControls", ownershipControlsNotFound, nil)
+	if err != nil || resp == nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var controls ownershipControls
+	if e := xml.NewDecoder(resp.Body).Decode(&controls); e != nil {
+		return "", probe.NewError(e)
+	}
+	if len(controls.Rules) == 0 {
+		return "", nil
+	}
+	return controls.Rules[0].ObjectOwnership, nil
+}
+
+// SetObjectOwnership - sets the object ownership setting of the bucket,
+// one of objectOwnerships.
+func (c *s3Client) SetObjectOwnership(ownership string) *probe.Error {
+	controls := ownershipControls{
+		XMLNS: "http://s3.amazonaws.com/doc/2006-03-01/",
+		Rules: []ownershipRule{{ObjectOwnership: ownership}},
+	}
+	body, e := xml.Marshal(controls)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	resp, err := c.bucketConfigRequest(http.MethodPut, "ownershipControls", ownershipControlsNotFound, body)
+	if resp != nil {
+		resp.Body.Close()
+	}
+	return err
+}
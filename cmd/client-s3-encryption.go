@@ -0,0 +1,105 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"encoding/xml"
+	"net/http"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Algorithms of default bucket encryption.
+const (
+	sseS3Algorithm  = "AES256"
+	sseKMSAlgorithm = "aws:kms"
+)
+
+// Error code of buckets without default encryption.
+const encryptionNotFound = "ServerSideEncryptionConfigurationNotFoundError"
+
+// encryptionRule - default encryption of the objects of a bucket.
+type encryptionRule struct {
+	ApplyServerSideEncryptionByDefault struct {
+		SSEAlgorithm   string
+		KMSMasterKeyID string `xml:",omitempty"`
+	}
+}
+
+// bucketEncryption - default encryption configuration of a bucket, as
+// sent and returned by PutBucketEncryption and GetBucketEncryption.
+type bucketEncryption struct {
+	XMLName xml.Name         `xml:"ServerSideEncryptionConfiguration"`
+	XMLNS   string           `xml:"xmlns,attr,omitempty"`
+	Rules   []encryptionRule `xml:"Rule"`
+}
+
+// GetEncryption - returns the default encryption algorithm of the bucket
+// and its KMS key, empty if objects are not encrypted by default.
+func (c *s3Client) GetEncryption() (algorithm, keyID string, err *probe.Error) {
+	resp, err := c.bucketConfigRequest(http.MethodGet, "encryption", encryptionNotFound, nil)
+	if err != nil || resp == nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	var config bucketEncryption
+	if e := xml.NewDecoder(resp.Body).Decode(&config); e != nil {
+		return "", "", probe.NewError(e)
+	}
+	if len(config.Rules) == 0 {
+		return "", "", nil
+	}
+	rule := config.Rules[0].ApplyServerSideEncryptionByDefault
+	return rule.SSEAlgorithm, rule.KMSMasterKeyID, nil
+}
+
+// SetEncryption - sets the default encryption of the bucket, keyID is
+// the KMS key of aws:kms, the default key of the server if empty.
+func (c *s3Client) SetEncryption(algorithm, keyID string) *probe.Error {
+	var rule encryptionRule
+	rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm = algorithm
+	rule.ApplyServerSideEncryptionByDefault.KMSMasterKeyID = keyID
+	config := bucketEncryption{
+		XMLNS: "http://s3.amazonaws.com/doc/2006-03-01/",
+		Rules: []encryptionRule{rule},
+	}
+	body, e := xml.Marshal(config)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	resp, err := c.bucketConfigRequest(http.MethodPut, "encryption", encryptionNotFound, body)
+	if err != nil {
+		return err
+	}
+	if resp != nil {
+		resp.Body.Close()
+	}
+	return nil
+}
+
+// ClearEncryption - removes the default encryption of the bucket, new
+// objects are stored as sent.
+func (c *s3Client) ClearEncryption() *probe.Error {
+	resp, err := c.bucketConfigRequest(http.MethodDelete, "encryption", encryptionNotFound, nil)
+	if err != nil {
+		return err
+	}
+	if resp != nil {
+		resp.Body.Close()
+	}
+	return nil
+}
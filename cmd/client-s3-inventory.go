@@ -0,0 +1,261 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// inventoryManifest - manifest.json of an S3 Inventory report, only CSV
+// reports are supported.
+type inventoryManifest struct {
+	SourceBucket      string `json:"sourceBucket"`
+	DestinationBucket string `json:"destinationBucket"`
+	FileFormat        string `json:"fileFormat"`
+	FileSchema        string `json:"fileSchema"`
+	Files             []struct {
+		Key string `json:"key"`
+	} `json:"files"`
+
+	// Alias the report is read from, and the host serving it.
+	alias, host string
+}
+
+// readInventoryManifest - reads and validates the manifest of an S3
+// Inventory report stored at manifestURL.
+func readInventoryManifest(manifestURL string) (*inventoryManifest, *probe.Error) {
+	alias, _ := url2Alias(manifestURL)
+	if alias == "" {
+		return nil, probe.NewError(fmt.Errorf("Inventory manifest `%s` is not on object storage", manifestURL))
+	}
+	clnt, err := newClient(manifestURL)
+	if err != nil {
+		return nil, err.Trace(manifestURL)
+	}
+	reader, err := clnt.Get(nil)
+	if err != nil {
+		return nil, err.Trace(manifestURL)
+	}
+	defer reader.Close()
+
+	manifest := &inventoryManifest{alias: alias, host: clnt.GetURL().Host}
+	if e := json.NewDecoder(reader).Decode(manifest); e != nil {
+		return nil, probe.NewError(e).Trace(manifestURL)
+	}
+	if !strings.EqualFold(manifest.FileFormat, "CSV") {
+		return nil, probe.NewError(fmt.Errorf("Unsupported inventory format `%s`, only CSV is supported", manifest.FileFormat))
+	}
+	if manifest.SourceBucket == "" {
+		return nil, probe.NewError(fmt.Errorf("Inventory manifest `%s` has no source bucket", manifestURL))
+	}
+	return manifest, nil
+}
+
+// inventoryEntry - an object listed in an inventory report.
+type inventoryEntry struct {
+	key  string
+	size int64
+	etag string
+	time time.Time
+}
+
+// inventoryColumns - positions of the used columns in the CSV files of a
+// report, -1 if missing.
+type inventoryColumns struct {
+	key, size, etag, time, isLatest, isDeleteMarker int
+}
+
+// columns - parses the file schema of the manifest.
+func (m *inventoryManifest) columns() (inventoryColumns, *probe.Error) {
+	columns := inventoryColumns{-1, -1, -1, -1, -1, -1}
+	for i, name := range strings.Split(m.FileSchema, ",") {
+		switch strings.TrimSpace(name) {
+		case "Key":
+			columns.key = i
+		case "Size":
+			columns.size = i
+		case "ETag":
+			columns.etag = i
+		case "LastModifiedDate":
+			columns.time = i
+		case "IsLatest":
+			columns.isLatest = i
+		case "IsDeleteMarker":
+			columns.isDeleteMarker = i
+		}
+	}
+	if columns.key < 0 || columns.size < 0 {
+		return columns, probe.NewError(fmt.Errorf("Inventory schema `%s` lacks Key or Size", m.FileSchema))
+	}
+	return columns, nil
+}
+
+// readEntries - reads all current objects under prefix from the CSV
+// files of the report, sorted by key.
+func (m *inventoryManifest) readEntries(prefix string) ([]inventoryEntry, *probe.Error) {
+	columns, err := m.columns()
+	if err != nil {
+		return nil, err
+	}
+	bucket := strings.TrimPrefix(m.DestinationBucket, "arn:aws:s3:::")
+
+	var entries []inventoryEntry
+	for _, file := range m.Files {
+		fileURL := urlJoinPath(m.alias, urlJoinPath(bucket, file.Key))
+		fileEntries, err := readInventoryFile(fileURL, columns, prefix)
+		if err != nil {
+			return nil, err.Trace(fileURL)
+		}
+		entries = append(entries, fileEntries...)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+	return entries, nil
+}
+
+// readInventoryFile - reads the objects under prefix from a gzipped CSV
+// inventory file.
+func readInventoryFile(fileURL string, columns inventoryColumns, prefix string) ([]inventoryEntry, *probe.Error) {
+	clnt, err := newClient(fileURL)
+	if err != nil {
+		return nil, err.Trace(fileURL)
+	}
+	reader, err := clnt.Get(nil)
+	if err != nil {
+		return nil, err.Trace(fileURL)
+	}
+	defer reader.Close()
+
+	gzReader, e := gzip.NewReader(reader)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	csvReader := csv.NewReader(gzReader)
+	csvReader.FieldsPerRecord = -1
+
+	var entries []inventoryEntry
+	for {
+		record, e := csvReader.Read()
+		if e == io.EOF {
+			return entries, nil
+		}
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		field := func(i int) string {
+			if i < 0 || i >= len(record) {
+				return ""
+			}
+			return record[i]
+		}
+		if field(columns.isLatest) == "false" || field(columns.isDeleteMarker) == "true" {
+			continue
+		}
+		// Keys are URL encoded in inventory reports.
+		key, e := url.QueryUnescape(field(columns.key))
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		size, e := strconv.ParseInt(field(columns.size), 10, 64)
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		entry := inventoryEntry{key: key, size: size, etag: field(columns.etag)}
+		if t := field(columns.time); t != "" {
+			if entry.time, e = time.Parse(time.RFC3339Nano, t); e != nil {
+				return nil, probe.NewError(e)
+			}
+		}
+		entries = append(entries, entry)
+	}
+}
+
+// inventoryClient - lists a bucket from an S3 Inventory report instead
+// of listing objects, all other operations are sent to the bucket.
+type inventoryClient struct {
+	*s3Client
+	manifest *inventoryManifest
+}
+
+// isInventoried - reports if clnt points to the bucket of the report, on
+// the host the report is read from.
+func (m *inventoryManifest) isInventoried(clnt Client) bool {
+	s3Clnt, ok := clnt.(*s3Client)
+	if !ok || s3Clnt.targetURL.Host != m.host {
+		return false
+	}
+	bucket, _ := s3Clnt.url2BucketAndObject()
+	return bucket == m.SourceBucket
+}
+
+// withInventory - returns the source and target clients, the one pointing
+// to the bucket of the inventory report lists from it. Only the source is
+// listed from the report when both point to it.
+func withInventory(sourceClnt, targetClnt Client, manifest *inventoryManifest) (Client, Client) {
+	switch {
+	case manifest == nil:
+	case manifest.isInventoried(sourceClnt):
+		sourceClnt = &inventoryClient{s3Client: sourceClnt.(*s3Client), manifest: manifest}
+	case manifest.isInventoried(targetClnt):
+		targetClnt = &inventoryClient{s3Client: targetClnt.(*s3Client), manifest: manifest}
+	}
+	return sourceClnt, targetClnt
+}
+
+// List - lists recursively from the inventory report, reports
+// do not include incomplete uploads nor folders.
+func (c *inventoryClient) List(isRecursive, isIncomplete bool, showDir DirOpt) <-chan *clientContent {
+	if !isRecursive || isIncomplete || showDir != DirNone {
+		return c.s3Client.List(isRecursive, isIncomplete, showDir)
+	}
+	contentCh := make(chan *clientContent, listBufferSize)
+	go func() {
+		defer close(contentCh)
+		bucket, prefix := c.url2BucketAndObject()
+		entries, err := c.manifest.readEntries(prefix)
+		if err != nil {
+			contentCh <- &clientContent{Err: err.Trace(bucket, prefix)}
+			return
+		}
+		for _, entry := range entries {
+			content := &clientContent{}
+			url := *c.targetURL
+			url.Path = c.joinPath(bucket, entry.key)
+			content.URL = url
+			content.Size = entry.size
+			content.ETag = entry.etag
+			content.Time = entry.time
+			content.Type = os.FileMode(0664)
+			contentCh <- content
+		}
+	}()
+	return contentCh
+}
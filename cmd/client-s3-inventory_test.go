@@ -0,0 +1,52 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestWithInventory(t *testing.T) {
+	manifest := &inventoryManifest{SourceBucket: "mybucket", host: "s3.amazonaws.com"}
+	s3Clnt := func(urlStr string) Client {
+		return &s3Client{targetURL: newClientURL(urlStr)}
+	}
+
+	testCases := []struct {
+		source, target             Client
+		sourceListed, targetListed bool
+	}{
+		// Report of the source bucket.
+		{s3Clnt("https://s3.amazonaws.com/mybucket/logs"), s3Clnt("https://play.min.io/mybucket/logs"), true, false},
+		// Report of the target bucket.
+		{s3Clnt("https://play.min.io/mybucket/logs"), s3Clnt("https://s3.amazonaws.com/mybucket/logs"), false, true},
+		// Same bucket name on another host.
+		{s3Clnt("https://play.min.io/mybucket"), s3Clnt("https://play.min.io/otherbucket"), false, false},
+		// Both pointing to the bucket, only the source is listed from it.
+		{s3Clnt("https://s3.amazonaws.com/mybucket/a"), s3Clnt("https://s3.amazonaws.com/mybucket/b"), true, false},
+		// Local folders.
+		{&fsClient{PathURL: newClientURL("/var/lib/mybucket")}, s3Clnt("https://s3.amazonaws.com/otherbucket"), false, false},
+	}
+
+	for i, testCase := range testCases {
+		source, target := withInventory(testCase.source, testCase.target, manifest)
+		if _, ok := source.(*inventoryClient); ok != testCase.sourceListed {
+			t.Fatalf("Test %d: expected source listed from the report %t, got %t", i+1, testCase.sourceListed, ok)
+		}
+		if _, ok := target.(*inventoryClient); ok != testCase.targetListed {
+			t.Fatalf("Test %d: expected target listed from the report %t, got %t", i+1, testCase.targetListed, ok)
+		}
+	}
+}
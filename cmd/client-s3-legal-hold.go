@@ -0,0 +1,114 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+// Status of the legal hold of an object.
+const (
+	legalHoldOn  = "ON"
+	legalHoldOff = "OFF"
+)
+
+// legalHold - body of PutObjectLegalHold and GetObjectLegalHold.
+type legalHold struct {
+	XMLName xml.Name `xml:"LegalHold"`
+	Status  string
+}
+
+// legalHoldRequest - sends a request of the legal hold of the object of
+// c, api naming it in errors.
+func (c *s3Client) legalHoldRequest(method, api string, body []byte) (*http.Response, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return nil, probe.NewError(BucketNameEmpty{})
+	}
+	if object == "" {
+		return nil, probe.NewError(ObjectMissing{})
+	}
+	resp, e := c.signedRequest(method, bucket, object, url.Values{"legal-hold": {""}}, nil, body)
+	if e == errRequestNotSigned {
+		return nil, probe.NewError(APINotImplemented{API: api, APIType: "S3v2"})
+	}
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	var errResp minio.ErrorResponse
+	if e = xml.NewDecoder(io.LimitReader(resp.Body, maxErrorResponseSize)).Decode(&errResp); e != nil {
+		return nil, probe.NewError(e)
+	}
+	switch errResp.Code {
+	case "NoSuchKey":
+		return nil, probe.NewError(ObjectMissing{})
+	case "NoSuchObjectLockConfiguration":
+		// Objects which were never held.
+		if method == http.MethodGet {
+			return nil, nil
+		}
+	}
+	return nil, c.requestError(bucket, api, errResp)
+}
+
+// setLegalHold - sets the legal hold of the object of c to status, ON or
+// OFF. Only objects of buckets with object lock can be held.
+func (c *s3Client) setLegalHold(status string) *probe.Error {
+	body, e := xml.Marshal(legalHold{Status: status})
+	if e != nil {
+		return probe.NewError(e)
+	}
+	resp, err := c.legalHoldRequest(http.MethodPut, "PutObjectLegalHold", body)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// getLegalHold - returns the status of the legal hold of the object of
+// c, OFF if it was never held.
+func (c *s3Client) getLegalHold() (string, *probe.Error) {
+	resp, err := c.legalHoldRequest(http.MethodGet, "GetObjectLegalHold", nil)
+	if err != nil {
+		return "", err
+	}
+	if resp == nil {
+		return legalHoldOff, nil
+	}
+	defer resp.Body.Close()
+
+	hold := legalHold{}
+	if e := xml.NewDecoder(io.LimitReader(resp.Body, maxErrorResponseSize)).Decode(&hold); e != nil {
+		return "", probe.NewError(e)
+	}
+	if hold.Status != legalHoldOn {
+		return legalHoldOff, nil
+	}
+	return legalHoldOn, nil
+}
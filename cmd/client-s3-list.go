@@ -0,0 +1,140 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+// Number of prefixes listed concurrently by recursive listings, unless
+// set with setListWorkers.
+const listPrefixWorkers = 8
+
+// Maximum number of keys returned by a page of a listing.
+const listPageSize = 1000
+
+// objectToContent - converts an object listed in bucket b.
+func (c *s3Client) objectToContent(b string, object minio.ObjectInfo) *clientContent {
+	if object.Err != nil {
+		return &clientContent{Err: probe.NewError(object.Err)}
+	}
+	url := *c.targetURL
+	// Join bucket and incoming object key.
+	url.Path = c.joinPath(b, object.Key)
+	return &clientContent{
+		URL:  url,
+		Size: object.Size,
+		ETag: object.ETag,
+		Time: object.LastModified,
+		Type: os.FileMode(0664),
+	}
+}
+
+// orderListing - sends the objects and prefixes of a non recursive
+// listing to send in key order. Each page of a listing holds its objects
+// first and its prefixes after them, both sorted, so the objects are held
+// back until the prefixes of their page are known.
+func orderListing(objectCh <-chan minio.ObjectInfo, separator string, send func(object minio.ObjectInfo, isPrefix bool)) {
+	var pending []minio.ObjectInfo
+	afterPrefix := false
+	for object := range objectCh {
+		if object.Err != nil {
+			for _, p := range pending {
+				send(p, false)
+			}
+			send(object, false)
+			return
+		}
+		if strings.HasSuffix(object.Key, separator) && object.Key != "" {
+			i := sort.Search(len(pending), func(i int) bool {
+				return pending[i].Key > object.Key
+			})
+			for _, p := range pending[:i] {
+				send(p, false)
+			}
+			pending = pending[i:]
+			send(object, true)
+			afterPrefix = true
+			continue
+		}
+		// An object after a prefix starts a new page, all the objects
+		// held back are before it.
+		if afterPrefix {
+			for _, p := range pending {
+				send(p, false)
+			}
+			pending, afterPrefix = nil, false
+		}
+		pending = append(pending, object)
+		// A page holds at most listPageSize keys, older objects are
+		// from previous pages and cannot be preceded by a prefix anymore.
+		if len(pending) > listPageSize {
+			send(pending[0], false)
+			pending = pending[1:]
+		}
+	}
+	for _, p := range pending {
+		send(p, false)
+	}
+}
+
+// listRecursiveParallel - lists all objects under prefix o of bucket b
+// in sorted order. The prefixes found right under o are listed
+// concurrently, their listings are sent one after the other which keeps
+// the result sorted.
+func (c *s3Client) listRecursiveParallel(b, o string, contentCh chan *clientContent) {
+	workers := c.listWorkers
+	if workers <= 0 {
+		workers = listPrefixWorkers
+	}
+	// Listings in the order they are sent, bounds the number of
+	// listings running ahead of the one being sent.
+	orderedCh := make(chan chan *clientContent, workers)
+	go func() {
+		defer close(orderedCh)
+		isRecursive := false
+		objectCh := c.listObjectWrapper(b, o, isRecursive, nil)
+		orderListing(objectCh, string(c.targetURL.Separator), func(object minio.ObjectInfo, isPrefix bool) {
+			ch := make(chan *clientContent, listBufferSize)
+			orderedCh <- ch
+			// Directory markers named like the listed prefix are
+			// objects, listing them again would never end.
+			if !isPrefix || object.Key == o {
+				ch <- c.objectToContent(b, object)
+				close(ch)
+				return
+			}
+			go func(prefix string) {
+				defer close(ch)
+				isRecursive := true
+				for object := range c.listObjectWrapper(b, prefix, isRecursive, nil) {
+					ch <- c.objectToContent(b, object)
+				}
+			}(object.Key)
+		})
+	}()
+
+	for ch := range orderedCh {
+		for content := range ch {
+			contentCh <- content
+		}
+	}
+}
@@ -0,0 +1,103 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	minio "github.com/minio/minio-go/v6"
+)
+
+func TestOrderListing(t *testing.T) {
+	testCases := []struct {
+		listed   []string
+		expected []string
+	}{
+		// A single page, objects first then prefixes.
+		{[]string{"a", "c", "e", "b/", "d/"}, []string{"a", "b/", "c", "d/", "e"}},
+		// Two pages, each with its objects first.
+		{[]string{"a", "d", "b/", "c/", "f", "h", "e/", "g/"}, []string{"a", "b/", "c/", "d", "e/", "f", "g/", "h"}},
+		// Pages without prefixes.
+		{[]string{"a", "b", "c"}, []string{"a", "b", "c"}},
+		// Pages without objects.
+		{[]string{"a/", "b/", "c/"}, []string{"a/", "b/", "c/"}},
+		// Keys sorting before and after their prefix.
+		{[]string{"b-x", "b0", "b/"}, []string{"b-x", "b/", "b0"}},
+	}
+
+	for i, testCase := range testCases {
+		objectCh := make(chan minio.ObjectInfo, len(testCase.listed))
+		for _, key := range testCase.listed {
+			objectCh <- minio.ObjectInfo{Key: key}
+		}
+		close(objectCh)
+
+		var sent []string
+		orderListing(objectCh, "/", func(object minio.ObjectInfo, isPrefix bool) {
+			if isPrefix != (object.Key[len(object.Key)-1] == '/') {
+				t.Fatalf("Test %d: unexpected prefix %t for `%s`", i+1, isPrefix, object.Key)
+			}
+			sent = append(sent, object.Key)
+		})
+		if !reflect.DeepEqual(sent, testCase.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, sent)
+		}
+	}
+}
+
+func TestOrderListingLargePage(t *testing.T) {
+	// Objects beyond a page are sent in order.
+	objectCh := make(chan minio.ObjectInfo)
+	go func() {
+		defer close(objectCh)
+		for i := 0; i < 3*listPageSize; i++ {
+			objectCh <- minio.ObjectInfo{Key: fmt.Sprintf("%06d", i)}
+		}
+	}()
+
+	sent := 0
+	orderListing(objectCh, "/", func(object minio.ObjectInfo, isPrefix bool) {
+		if expected := fmt.Sprintf("%06d", sent); object.Key != expected {
+			t.Fatalf("Expected `%s`, got `%s`", expected, object.Key)
+		}
+		sent++
+	})
+	if sent != 3*listPageSize {
+		t.Fatalf("Expected %d objects, got %d", 3*listPageSize, sent)
+	}
+}
+
+func TestSetListWorkers(t *testing.T) {
+	testCases := []struct {
+		workers  int
+		expected int
+	}{
+		{0, 0},
+		{-1, 0},
+		{1, 1},
+		{32, 32},
+	}
+	for i, testCase := range testCases {
+		clnt := &s3Client{}
+		setListWorkers(clnt, testCase.workers)
+		if clnt.listWorkers != testCase.expected {
+			t.Fatalf("Test %d: expected %d list workers, got %d", i+1, testCase.expected, clnt.listWorkers)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+// pointInTimeClient - reads a versioned bucket as it was at a point in
+// time, from the versions of its objects. All other operations are sent
+// to the bucket.
+type pointInTimeClient struct {
+	*s3Client
+	at time.Time
+}
+
+// parsePointInTime - parses the value of --at, a date or a time in
+// RFC3339 format. Returns the zero time if value is empty.
+func parsePointInTime(value string) (time.Time, *probe.Error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return parseDiffTime(value)
+}
+
+// withPointInTime - returns clnt reading its bucket as it was at t, or
+// clnt itself if t is zero. Only buckets on S3 have versions.
+func withPointInTime(clnt Client, at time.Time) (Client, *probe.Error) {
+	if at.IsZero() {
+		return clnt, nil
+	}
+	s3Clnt, ok := clnt.(*s3Client)
+	if !ok {
+		return nil, probe.NewError(APINotImplemented{API: "ListObjectVersions", APIType: "filesystem"}).Trace(clnt.GetURL().String())
+	}
+	return &pointInTimeClient{s3Client: s3Clnt, at: at}, nil
+}
+
+// newPointInTimeClient - returns the client of the aliased URL urlStr,
+// reading its bucket as it was at t unless t is zero.
+func newPointInTimeClient(urlStr string, at time.Time) (Client, *probe.Error) {
+	clnt, err := newClient(urlStr)
+	if err != nil {
+		return nil, err
+	}
+	return withPointInTime(clnt, at)
+}
+
+// versionAt - returns the version of versions, newest first, which was
+// current at t, false if the object did not exist or was deleted then.
+func versionAt(versions []objectVersion, at time.Time) (objectVersion, bool) {
+	for _, version := range versions {
+		if !version.LastModified.After(at) {
+			return version, !version.IsDeleteMarker
+		}
+	}
+	return objectVersion{}, false
+}
+
+// versionsAt - returns the versions current at the point in time of the
+// objects under the path of c, by key.
+func (c *pointInTimeClient) versionsAt() <-chan objectVersion {
+	versionsCh := make(chan objectVersion)
+	go func() {
+		defer close(versionsCh)
+		var versions []objectVersion
+		for version := range c.listObjectVersions() {
+			if version.Err != nil {
+				versionsCh <- version
+				return
+			}
+			if len(versions) > 0 && versions[0].Key != version.Key {
+				if current, ok := versionAt(versions, c.at); ok {
+					versionsCh <- current
+				}
+				versions = nil
+			}
+			versions = append(versions, version)
+		}
+		if current, ok := versionAt(versions, c.at); ok {
+			versionsCh <- current
+		}
+	}()
+	return versionsCh
+}
+
+// firstVersionAt - returns the version current at the point in time of
+// the first object under prefix which existed then, key being the only
+// object looked at if isKey.
+func (c *pointInTimeClient) firstVersionAt(bucket, prefix string, isKey bool) (objectVersion, bool, *probe.Error) {
+	var keyMarker, versionIDMarker string
+	var versions []objectVersion
+	for {
+		result, err := c.listObjectVersionsPage(bucket, prefix, keyMarker, versionIDMarker)
+		if err != nil {
+			return objectVersion{}, false, err.Trace(bucket, prefix)
+		}
+		for _, version := range result.versions() {
+			if len(versions) > 0 && versions[0].Key != version.Key {
+				if current, ok := versionAt(versions, c.at); ok || isKey {
+					return current, ok, nil
+				}
+				versions = nil
+			}
+			// Keys sort after the prefix they start with.
+			if isKey && version.Key != prefix {
+				return objectVersion{}, false, nil
+			}
+			versions = append(versions, version)
+		}
+		if !result.IsTruncated {
+			current, ok := versionAt(versions, c.at)
+			return current, ok, nil
+		}
+		keyMarker, versionIDMarker = result.NextKeyMarker, result.NextVersionIDMarker
+	}
+}
+
+// versionContent - returns the content of a version of an object of
+// bucket.
+func (c *pointInTimeClient) versionContent(bucket string, version objectVersion) *clientContent {
+	url := *c.targetURL
+	url.Path = c.joinPath(bucket, version.Key)
+	return &clientContent{
+		URL:       url,
+		Time:      version.LastModified,
+		Size:      version.Size,
+		ETag:      strings.Trim(version.ETag, "\""),
+		Type:      os.FileMode(0664),
+		Metadata:  map[string]string{},
+		VersionID: version.VersionID,
+	}
+}
+
+// List - lists the objects which existed at the point in time, folders
+// of listings which are not recursive are found from the keys under them.
+// Incomplete uploads have no versions, they are listed as they are.
+func (c *pointInTimeClient) List(isRecursive, isIncomplete bool, showDir DirOpt) <-chan *clientContent {
+	if isIncomplete {
+		return c.s3Client.List(isRecursive, isIncomplete, showDir)
+	}
+	contentCh := make(chan *clientContent, listBufferSize)
+	go func() {
+		defer close(contentCh)
+		bucket, prefix := c.url2BucketAndObject()
+		if bucket == "" {
+			contentCh <- &clientContent{Err: probe.NewError(BucketNameEmpty{})}
+			return
+		}
+		var lastDir string
+		for version := range c.versionsAt() {
+			if version.Err != nil {
+				contentCh <- &clientContent{Err: version.Err}
+				return
+			}
+			if !isRecursive {
+				if i := strings.Index(version.Key[len(prefix):], "/"); i >= 0 {
+					dir := version.Key[:len(prefix)+i+1]
+					if dir != lastDir {
+						url := *c.targetURL
+						url.Path = c.joinPath(bucket, dir)
+						contentCh <- &clientContent{URL: url, Type: os.ModeDir}
+						lastDir = dir
+					}
+					continue
+				}
+			}
+			contentCh <- c.versionContent(bucket, version)
+		}
+	}()
+	return contentCh
+}
+
+// Stat - returns the object or the folder as it was at the point in
+// time, with the metadata of its version if isFetchMeta.
+func (c *pointInTimeClient) Stat(isIncomplete, isFetchMeta bool, sse encrypt.ServerSide) (*clientContent, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	if isIncomplete || bucket == "" || object == "" {
+		return c.s3Client.Stat(isIncomplete, isFetchMeta, sse)
+	}
+	if !strings.HasSuffix(object, "/") {
+		version, ok, err := c.firstVersionAt(bucket, object, true)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			content := c.versionContent(bucket, version)
+			content.URL = *c.targetURL
+			if isFetchMeta {
+				metadata, err := c.statObjectVersion(version.VersionID, sse)
+				if err != nil {
+					return nil, err.Trace(c.targetURL.String())
+				}
+				content.Metadata = metadata
+			}
+			return content, nil
+		}
+	}
+	_, ok, err := c.firstVersionAt(bucket, strings.TrimSuffix(object, "/")+"/", false)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, probe.NewError(ObjectMissing{})
+	}
+	return &clientContent{URL: *c.targetURL, Type: os.ModeDir}, nil
+}
+
+// Get - returns the version of the object which was current at the
+// point in time.
+func (c *pointInTimeClient) Get(sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	version, ok, err := c.firstVersionAt(bucket, object, true)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, probe.NewError(ObjectMissing{})
+	}
+	reader, _, err := c.getObjectVersion(version.VersionID, sse)
+	return reader, err
+}
+
+// url2StatAt - url2Stat of urlStr as it was at t, unless t is zero.
+func url2StatAt(urlStr string, at time.Time, isFetchMeta bool, encKeyDB map[string][]prefixSSEPair) (Client, *clientContent, *probe.Error) {
+	if at.IsZero() {
+		return url2Stat(urlStr, isFetchMeta, encKeyDB)
+	}
+	client, err := newPointInTimeClient(urlStr, at)
+	if err != nil {
+		return nil, nil, err.Trace(urlStr)
+	}
+	alias, _ := url2Alias(urlStr)
+	sse := getSSE(urlStr, encKeyDB[alias])
+
+	content, err := client.Stat(false, isFetchMeta, sse)
+	if err != nil {
+		return nil, nil, err.Trace(urlStr)
+	}
+	return client, content, nil
+}
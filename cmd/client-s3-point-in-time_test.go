@@ -0,0 +1,63 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVersionAt(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2019, time.March, d, 0, 0, 0, 0, time.UTC)
+	}
+	// Newest first.
+	versions := []objectVersion{
+		{Key: "a.txt", VersionID: "v3", LastModified: day(20)},
+		{Key: "a.txt", VersionID: "m1", LastModified: day(10), IsDeleteMarker: true},
+		{Key: "a.txt", VersionID: "v1", LastModified: day(5)},
+	}
+
+	testCases := []struct {
+		at        time.Time
+		versionID string
+		ok        bool
+	}{
+		// Before the object was written.
+		{day(1), "", false},
+		// The first version, up to and including its time.
+		{day(5), "v1", true},
+		{day(9), "v1", true},
+		// Deleted then.
+		{day(15), "m1", false},
+		// The latest version.
+		{day(20), "v3", true},
+		{day(25), "v3", true},
+	}
+	for i, testCase := range testCases {
+		version, ok := versionAt(versions, testCase.at)
+		if ok != testCase.ok {
+			t.Fatalf("Test %d: expected found to be %t, got %t", i+1, testCase.ok, ok)
+		}
+		if version.VersionID != testCase.versionID {
+			t.Fatalf("Test %d: expected version %q, got %q", i+1, testCase.versionID, version.VersionID)
+		}
+	}
+	if _, ok := versionAt(nil, day(25)); ok {
+		t.Fatalf("Expected no version of an object without versions")
+	}
+}
@@ -0,0 +1,144 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+// errRequestNotSigned - requests of APIs minio-go does not have are
+// only signed with signature V4.
+var errRequestNotSigned = errors.New("only supported with signature V4")
+
+// signedRequest - sends a request signed with signature V4 for object of
+// bucket, or bucket alone if object is empty. Used for APIs minio-go
+// does not have, responses are left to the caller.
+func (c *s3Client) signedRequest(method, bucket, object string, query url.Values, header http.Header, body []byte) (*http.Response, error) {
+	if c.transport == nil || strings.EqualFold(c.signature, "S3v2") {
+		return nil, errRequestNotSigned
+	}
+	region, e := c.api.GetBucketLocation(bucket)
+	if e != nil {
+		return nil, e
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	u := *c.api.EndpointURL()
+	if c.virtualStyle {
+		u.Host = bucket + "." + u.Host
+		u.Path = "/" + object
+	} else {
+		u.Path = "/" + bucket + "/" + object
+	}
+	u.RawQuery = query.Encode()
+	req, e := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if e != nil {
+		return nil, e
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if body == nil {
+		req.Header.Set("X-Amz-Content-Sha256", emptySHA256)
+	} else {
+		sha256Sum := sha256.Sum256(body)
+		md5Sum := md5.Sum(body)
+		req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum[:]))
+		req.Header.Set("Content-Md5", base64.StdEncoding.EncodeToString(md5Sum[:]))
+		req.ContentLength = int64(len(body))
+	}
+	creds, e := c.creds.Get()
+	if e != nil {
+		return nil, e
+	}
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+	var signedHeaders []string
+	for name := range req.Header {
+		signedHeaders = append(signedHeaders, strings.ToLower(name))
+	}
+	signedHeaders = append(signedHeaders, "host", "x-amz-date")
+	sort.Strings(signedHeaders)
+	signV4(req, creds.AccessKeyID, creds.SecretAccessKey, region, "s3", signedHeaders, UTCNow())
+
+	return c.transport.RoundTrip(req)
+}
+
+// bucketConfigRequest - sends a request of the configuration held by
+// subresource of the bucket of c, like encryption or tagging. Returns no
+// response if the bucket has no such configuration, notFound being the
+// error code of the server then.
+func (c *s3Client) bucketConfigRequest(method, subresource, notFound string, body []byte) (*http.Response, *probe.Error) {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return nil, probe.NewError(BucketNameEmpty{})
+	}
+	api := "Bucket " + subresource
+	resp, e := c.signedRequest(method, bucket, "", url.Values{subresource: {""}}, nil, body)
+	if e == errRequestNotSigned {
+		return nil, probe.NewError(APINotImplemented{API: api, APIType: "S3v2"})
+	}
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNoContent {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	var errResp minio.ErrorResponse
+	if e = xml.NewDecoder(io.LimitReader(resp.Body, maxErrorResponseSize)).Decode(&errResp); e != nil {
+		return nil, probe.NewError(e)
+	}
+	if errResp.Code == notFound {
+		return nil, nil
+	}
+	return nil, c.requestError(bucket, api, errResp)
+}
+
+// requestError - maps the error response of a request of api sent for
+// bucket to the errors of mc.
+func (c *s3Client) requestError(bucket, api string, errResp minio.ErrorResponse) *probe.Error {
+	switch errResp.Code {
+	case "NoSuchBucket":
+		return probe.NewError(BucketDoesNotExist{Bucket: bucket})
+	case "AccessDenied":
+		return probe.NewError(PathInsufficientPermission{Path: c.targetURL.String()})
+	case "NotImplemented", "MethodNotAllowed":
+		return probe.NewError(APINotImplemented{
+			API:     api,
+			APIType: c.targetURL.Scheme + "://" + c.targetURL.Host,
+		})
+	}
+	return probe.NewError(errResp)
+}
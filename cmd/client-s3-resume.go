@@ -0,0 +1,345 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/hookreader"
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+const (
+	// Sources at least this large are uploaded part by part with
+	// their progress recorded, so that the upload can be resumed.
+	resumableUploadThreshold = 128 * 1024 * 1024
+
+	// Smallest part size used for resumable uploads.
+	minResumablePartSize = 64 * 1024 * 1024
+
+	// Maximum number of parts allowed in a multipart upload.
+	maxUploadParts = 10000
+
+	// Number of parts of a resumable upload uploaded at once, also the
+	// number of part buffers shared by all of them.
+	resumableUploadWorkers = 4
+)
+
+// partBuffers - a pool of a fixed number of buffers, parts are read
+// into them while uploaded so that the memory used by all resumable
+// uploads is bounded.
+type partBuffers struct {
+	bufs chan []byte
+}
+
+// newPartBuffers - returns a pool of n buffers, allocated on first use.
+func newPartBuffers(n int) *partBuffers {
+	p := &partBuffers{bufs: make(chan []byte, n)}
+	for i := 0; i < n; i++ {
+		p.bufs <- nil
+	}
+	return p
+}
+
+// get - waits for a buffer of the pool, returned with size bytes.
+func (p *partBuffers) get(size int64) []byte {
+	buf := <-p.bufs
+	if int64(cap(buf)) < size {
+		buf = make([]byte, size)
+	}
+	return buf[:size]
+}
+
+// put - returns buf to the pool.
+func (p *partBuffers) put(buf []byte) {
+	p.bufs <- buf
+}
+
+var resumablePartBuffers = newPartBuffers(resumableUploadWorkers)
+
+// uploadPart - a completed part of a multipart upload.
+type uploadPart struct {
+	PartNumber int    `json:"partNumber"`
+	ETag       string `json:"etag"`
+	Size       int64  `json:"size"`
+	MD5        string `json:"md5"`
+}
+
+// pendingUpload - state of a multipart upload which can be resumed.
+type pendingUpload struct {
+	UploadID   string       `json:"uploadId"`
+	PartSize   int64        `json:"partSize"`
+	SourceSize int64        `json:"sourceSize"`
+	SourceTime time.Time    `json:"sourceModTime"`
+	Parts      []uploadPart `json:"parts"`
+}
+
+// partJob - a part of a resumable upload left to upload.
+type partJob struct {
+	number int
+	offset int64
+	size   int64
+}
+
+// uploadStore - persists pending multipart uploads by target URL.
+type uploadStore interface {
+	loadUpload(target string) (pendingUpload, bool)
+	saveUpload(target string, upload pendingUpload) *probe.Error
+	deleteUpload(target string) *probe.Error
+}
+
+// resumablePartSize - returns the part size for an object of the given
+// size, rounded up to a MiB and large enough to stay within part limits.
+func resumablePartSize(size int64) int64 {
+	partSize := (size + maxUploadParts - 1) / maxUploadParts
+	partSize = (partSize + 1024*1024 - 1) / (1024 * 1024) * (1024 * 1024)
+	if partSize < minResumablePartSize {
+		partSize = minResumablePartSize
+	}
+	return partSize
+}
+
+// isSourceChanged - returns true if the source does not match the one
+// the upload was started with.
+func (p pendingUpload) isSourceChanged(size int64, modTime time.Time) bool {
+	return p.SourceSize != size || !p.SourceTime.Equal(modTime)
+}
+
+// verifiedParts - returns the recorded parts which are still present
+// on the server with the same ETag.
+func (p pendingUpload) verifiedParts(uploaded []minio.ObjectPart) []uploadPart {
+	etags := make(map[int]string, len(uploaded))
+	for _, part := range uploaded {
+		etags[part.PartNumber] = strings.Trim(part.ETag, "\"")
+	}
+	var parts []uploadPart
+	for _, part := range p.Parts {
+		if etag, ok := etags[part.PartNumber]; ok && etag == strings.Trim(part.ETag, "\"") {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// listUploadedParts - list all parts uploaded so far for an upload id.
+func listUploadedParts(core minio.Core, bucket, object, uploadID string) ([]minio.ObjectPart, error) {
+	var parts []minio.ObjectPart
+	partNumberMarker := 0
+	for {
+		result, e := core.ListObjectParts(bucket, object, uploadID, partNumberMarker, maxUploadParts)
+		if e != nil {
+			return nil, e
+		}
+		parts = append(parts, result.ObjectParts...)
+		if !result.IsTruncated {
+			return parts, nil
+		}
+		partNumberMarker = result.NextPartNumberMarker
+	}
+}
+
+// countUploadedParts - returns the number of parts uploaded so far for an
+// incomplete upload listed.
+func (c *s3Client) countUploadedParts(content *clientContent) (int, *probe.Error) {
+	bucket, object := c.splitPath(content.URL.Path)
+	parts, e := listUploadedParts(minio.Core{Client: c.api}, bucket, object, content.UploadID)
+	if e != nil {
+		return 0, probe.NewError(e).Trace(content.URL.String())
+	}
+	return len(parts), nil
+}
+
+// putResumable - upload a seekable source as a multipart upload, each
+// completed part is recorded in the store so that an interrupted upload
+// continues from the last completed part instead of starting over.
+func (c *s3Client) putResumable(ctx context.Context, reader io.ReaderAt, size int64, modTime time.Time, metadata map[string]string, progress io.Reader, sse encrypt.ServerSide, store uploadStore) (int64, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return 0, probe.NewError(BucketNameEmpty{})
+	}
+	target := c.targetURL.String()
+	core := minio.Core{Client: c.api}
+
+	upload, ok := store.loadUpload(target)
+	if ok && upload.isSourceChanged(size, modTime) {
+		// Source was modified since the upload started, parts
+		// uploaded so far cannot be reused.
+		core.AbortMultipartUpload(bucket, object, upload.UploadID)
+		ok = false
+	}
+	if ok {
+		uploaded, e := listUploadedParts(core, bucket, object, upload.UploadID)
+		if e != nil {
+			// Upload is gone, most likely aborted, start over.
+			ok = false
+		} else {
+			upload.Parts = upload.verifiedParts(uploaded)
+		}
+	}
+	if !ok {
+		uploadID, e := core.NewMultipartUpload(bucket, object, newPutObjectOptions(metadata, nil, sse))
+		if e != nil {
+			return 0, c.toPutError(e, size, 0)
+		}
+		upload = pendingUpload{
+			UploadID:   uploadID,
+			PartSize:   resumablePartSize(size),
+			SourceSize: size,
+			SourceTime: modTime,
+		}
+		if err := store.saveUpload(target, upload); err != nil {
+			return 0, err.Trace(target)
+		}
+	}
+
+	completed := make(map[int]uploadPart, len(upload.Parts))
+	for _, part := range upload.Parts {
+		completed[part.PartNumber] = part
+	}
+
+	// Only SSE-C keys have to be sent along with each part.
+	var partSSE encrypt.ServerSide
+	if sse != nil && sse.Type() == encrypt.SSEC {
+		partSSE = sse
+	}
+
+	// Parts uploaded by a previous run are only accounted for.
+	var jobs []partJob
+	var written int64
+	for partNumber, offset := 1, int64(0); offset < size; partNumber, offset = partNumber+1, offset+upload.PartSize {
+		partSize := upload.PartSize
+		if size-offset < partSize {
+			partSize = size - offset
+		}
+		if part, ok := completed[partNumber]; ok && part.Size == partSize {
+			if progress != nil {
+				io.CopyN(ioutil.Discard, progress, partSize)
+			}
+			written += partSize
+			continue
+		}
+		jobs = append(jobs, partJob{number: partNumber, offset: offset, size: partSize})
+	}
+
+	// The other parts are uploaded by a few workers at once, the first
+	// failure stops them.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var mutex sync.Mutex
+	var err *probe.Error
+	fail := func(e *probe.Error) {
+		mutex.Lock()
+		if err == nil {
+			err = e
+		}
+		mutex.Unlock()
+		cancel()
+	}
+	jobCh := make(chan partJob)
+	var wg sync.WaitGroup
+	for i := 0; i < resumableUploadWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobCh {
+				if ctx.Err() != nil {
+					continue
+				}
+				part, e := c.putResumablePart(core, bucket, object, upload.UploadID, reader, job, size, progress, partSSE)
+				if e != nil {
+					fail(e)
+					continue
+				}
+				mutex.Lock()
+				written += job.size
+				completed[job.number] = part
+				upload.Parts = append(upload.Parts, part)
+				e = store.saveUpload(target, upload)
+				mutex.Unlock()
+				if e != nil {
+					fail(e.Trace(target))
+				}
+			}
+		}()
+	}
+	for _, job := range jobs {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case jobCh <- job:
+		case <-ctx.Done():
+		}
+	}
+	close(jobCh)
+	wg.Wait()
+	if err != nil {
+		return written, err
+	}
+	if e := ctx.Err(); e != nil {
+		return written, probe.NewError(e)
+	}
+
+	var parts []minio.CompletePart
+	for _, part := range completed {
+		parts = append(parts, minio.CompletePart{PartNumber: part.PartNumber, ETag: part.ETag})
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+	if _, e := core.CompleteMultipartUpload(bucket, object, upload.UploadID, parts); e != nil {
+		return written, c.toPutError(e, size, written)
+	}
+	if err := store.deleteUpload(target); err != nil {
+		return written, err.Trace(target)
+	}
+	return written, nil
+}
+
+// putResumablePart - uploads the part of job, read from reader into a
+// buffer of the pool.
+func (c *s3Client) putResumablePart(core minio.Core, bucket, object, uploadID string, reader io.ReaderAt, job partJob, size int64, progress io.Reader, sse encrypt.ServerSide) (uploadPart, *probe.Error) {
+	buf := resumablePartBuffers.get(job.size)
+	defer resumablePartBuffers.put(buf)
+
+	if _, e := io.ReadFull(io.NewSectionReader(reader, job.offset, job.size), buf); e != nil {
+		return uploadPart{}, probe.NewError(e)
+	}
+	sum := md5.Sum(buf)
+	objPart, e := core.PutObjectPart(bucket, object, uploadID, job.number,
+		hookreader.NewHook(bytes.NewReader(buf), progress), job.size,
+		base64.StdEncoding.EncodeToString(sum[:]), "", sse)
+	if e != nil {
+		return uploadPart{}, c.toPutError(e, size, job.offset)
+	}
+	return uploadPart{
+		PartNumber: job.number,
+		ETag:       objPart.ETag,
+		Size:       job.size,
+		MD5:        hex.EncodeToString(sum[:]),
+	}, nil
+}
@@ -0,0 +1,77 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+
+	minio "github.com/minio/minio-go/v6"
+)
+
+func TestResumablePartSize(t *testing.T) {
+	testCases := []struct {
+		size     int64
+		partSize int64
+	}{
+		{resumableUploadThreshold, minResumablePartSize},
+		{minResumablePartSize * maxUploadParts, minResumablePartSize},
+		{1024 * 1024 * 1024 * 1024, 105 * 1024 * 1024},
+	}
+	for i, testCase := range testCases {
+		partSize := resumablePartSize(testCase.size)
+		if partSize != testCase.partSize {
+			t.Fatalf("Test %d: expected %d, got %d", i+1, testCase.partSize, partSize)
+		}
+		if (testCase.size+partSize-1)/partSize > maxUploadParts {
+			t.Fatalf("Test %d: part size %d exceeds the maximum number of parts", i+1, partSize)
+		}
+	}
+}
+
+func TestPendingUploadVerifiedParts(t *testing.T) {
+	upload := pendingUpload{
+		Parts: []uploadPart{
+			{PartNumber: 1, ETag: "etag1"},
+			{PartNumber: 2, ETag: "etag2"},
+			{PartNumber: 3, ETag: "etag3"},
+		},
+	}
+	uploaded := []minio.ObjectPart{
+		{PartNumber: 1, ETag: `"etag1"`},
+		{PartNumber: 2, ETag: "other"},
+	}
+	parts := upload.verifiedParts(uploaded)
+	if len(parts) != 1 || parts[0].PartNumber != 1 {
+		t.Fatalf("Expected only part 1 to be verified, got %v", parts)
+	}
+}
+
+func TestPartBuffers(t *testing.T) {
+	p := newPartBuffers(2)
+	a := p.get(10)
+	b := p.get(20)
+	if len(a) != 10 || len(b) != 20 {
+		t.Fatalf("Expected buffers of 10 and 20 bytes, got %d and %d", len(a), len(b))
+	}
+	if len(p.bufs) != 0 {
+		t.Fatalf("Expected no buffer left in the pool, got %d", len(p.bufs))
+	}
+	p.put(b)
+	if c := p.get(5); len(c) != 5 || cap(c) != 20 {
+		t.Fatalf("Expected the buffer of 20 bytes to be reused, got %d of %d", len(c), cap(c))
+	}
+}
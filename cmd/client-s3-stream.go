@@ -0,0 +1,135 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"io"
+
+	"github.com/minio/mc/pkg/hookreader"
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+const (
+	// Part size streams of unknown length start with, streams
+	// shorter than this are uploaded with a single request.
+	minStreamPartSize = 16 * 1024 * 1024
+
+	// Part size doubles every that many parts, so that streams of up
+	// to 5TiB fit within maxUploadParts while short streams only
+	// buffer a small part in memory.
+	streamPartSizeStep = 1000
+
+	// Largest part size allowed by S3.
+	maxStreamPartSize = 5 * 1024 * 1024 * 1024
+)
+
+// streamPartSize - returns the size of part partNumber of a stream of
+// unknown length.
+func streamPartSize(partNumber int) int64 {
+	partSize := int64(minStreamPartSize) << uint((partNumber-1)/streamPartSizeStep)
+	if partSize > maxStreamPartSize {
+		partSize = maxStreamPartSize
+	}
+	return partSize
+}
+
+// putStream - upload a stream of unknown length, read until EOF. Parts
+// are read and uploaded one at a time, the upload is aborted on error.
+func (c *s3Client) putStream(ctx context.Context, reader io.Reader, metadata map[string]string, progress io.Reader, sse encrypt.ServerSide) (int64, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return 0, probe.NewError(BucketNameEmpty{})
+	}
+
+	buf := make([]byte, streamPartSize(1))
+	n, e := io.ReadFull(reader, buf)
+	if e != nil && e != io.EOF && e != io.ErrUnexpectedEOF {
+		return 0, probe.NewError(e)
+	}
+	if e != nil {
+		// Whole stream fits in a part.
+		opts := newPutObjectOptions(metadata, progress, sse)
+		written, e := c.api.PutObjectWithContext(ctx, bucket, object, bytes.NewReader(buf[:n]), int64(n), opts)
+		if e != nil {
+			return written, c.toPutError(e, int64(n), written)
+		}
+		return written, nil
+	}
+
+	core := minio.Core{Client: c.api}
+	uploadID, e := core.NewMultipartUpload(bucket, object, newPutObjectOptions(metadata, nil, sse))
+	if e != nil {
+		return 0, c.toPutError(e, -1, 0)
+	}
+
+	// Only SSE-C keys have to be sent along with each part.
+	var partSSE encrypt.ServerSide
+	if sse != nil && sse.Type() == encrypt.SSEC {
+		partSSE = sse
+	}
+
+	var parts []minio.CompletePart
+	var written int64
+	for partNumber := 1; ; partNumber++ {
+		if partNumber > 1 {
+			if partSize := streamPartSize(partNumber); int64(len(buf)) != partSize {
+				buf = make([]byte, partSize)
+			}
+			n, e = io.ReadFull(reader, buf)
+			if e == io.EOF {
+				break
+			}
+			if e != nil && e != io.ErrUnexpectedEOF {
+				core.AbortMultipartUpload(bucket, object, uploadID)
+				return written, probe.NewError(e)
+			}
+		}
+		if partNumber > maxUploadParts {
+			core.AbortMultipartUpload(bucket, object, uploadID)
+			return written, probe.NewError(StreamTooLarge{TotalWritten: written})
+		}
+		if e := ctx.Err(); e != nil {
+			core.AbortMultipartUpload(bucket, object, uploadID)
+			return written, probe.NewError(e)
+		}
+		sum := md5.Sum(buf[:n])
+		objPart, e := core.PutObjectPart(bucket, object, uploadID, partNumber,
+			hookreader.NewHook(bytes.NewReader(buf[:n]), progress), int64(n),
+			base64.StdEncoding.EncodeToString(sum[:]), "", partSSE)
+		if e != nil {
+			core.AbortMultipartUpload(bucket, object, uploadID)
+			return written, c.toPutError(e, -1, written)
+		}
+		written += int64(n)
+		parts = append(parts, minio.CompletePart{PartNumber: partNumber, ETag: objPart.ETag})
+		if n < len(buf) {
+			// Short read, end of stream.
+			break
+		}
+	}
+
+	if _, e = core.CompleteMultipartUpload(bucket, object, uploadID, parts); e != nil {
+		core.AbortMultipartUpload(bucket, object, uploadID)
+		return written, c.toPutError(e, -1, written)
+	}
+	return written, nil
+}
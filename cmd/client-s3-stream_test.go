@@ -0,0 +1,45 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import "testing"
+
+func TestStreamPartSize(t *testing.T) {
+	testCases := []struct {
+		partNumber int
+		partSize   int64
+	}{
+		{1, minStreamPartSize},
+		{streamPartSizeStep, minStreamPartSize},
+		{streamPartSizeStep + 1, 2 * minStreamPartSize},
+		{maxUploadParts, maxStreamPartSize},
+	}
+	for i, testCase := range testCases {
+		partSize := streamPartSize(testCase.partNumber)
+		if partSize != testCase.partSize {
+			t.Fatalf("Test %d: expected %d, got %d", i+1, testCase.partSize, partSize)
+		}
+	}
+
+	// Parts must be able to hold the largest object allowed.
+	var total int64
+	for partNumber := 1; partNumber <= maxUploadParts; partNumber++ {
+		total += streamPartSize(partNumber)
+	}
+	if total < 5*1024*1024*1024*1024 {
+		t.Fatalf("Expected parts to hold at least 5TiB, got %d bytes", total)
+	}
+}
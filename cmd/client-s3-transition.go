@@ -0,0 +1,138 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+// Largest object copied with a single CopyObject request.
+const maxCopyObjectSize = 5 * 1024 * 1024 * 1024
+
+// errTransitionTooLarge - larger objects can only be copied in parts.
+var errTransitionTooLarge = errors.New("objects larger than 5GiB cannot be copied in a single request")
+
+// Headers of an object kept when its metadata is replaced.
+var transitionKeptHeaders = []string{
+	"Cache-Control",
+	"Content-Disposition",
+	"Content-Encoding",
+	"Content-Language",
+	"Content-Type",
+	"Expires",
+	"X-Amz-Server-Side-Encryption",
+	"X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id",
+	"X-Amz-Website-Redirect-Location",
+}
+
+// objectStorageClass - returns the storage class in the headers of an
+// object, objects without one are stored in STANDARD.
+func objectStorageClass(header http.Header) string {
+	if class := header.Get("X-Amz-Storage-Class"); class != "" {
+		return strings.ToUpper(class)
+	}
+	return "STANDARD"
+}
+
+// transitionObject - moves the object of c to storageClass in place,
+// copying it onto itself with its metadata replaced by the one it has.
+// Returns false if the object is in storageClass already.
+func (c *s3Client) transitionObject(storageClass string, sse encrypt.ServerSide) (bool, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return false, probe.NewError(BucketNameEmpty{})
+	}
+	if object == "" {
+		return false, probe.NewError(ObjectMissing{})
+	}
+	opts := minio.StatObjectOptions{}
+	opts.ServerSideEncryption = sse
+	info, e := c.api.StatObject(bucket, object, opts)
+	if e != nil {
+		errResp := minio.ToErrorResponse(e)
+		if errResp.Code == "NoSuchKey" {
+			return false, probe.NewError(ObjectMissing{})
+		}
+		return false, c.requestError(bucket, "HeadObject", errResp)
+	}
+	if objectStorageClass(info.Metadata) == storageClass {
+		return false, nil
+	}
+	if info.Size > maxCopyObjectSize {
+		return false, probe.NewError(errTransitionTooLarge).Trace(c.targetURL.String())
+	}
+
+	header := http.Header{}
+	for _, name := range transitionKeptHeaders {
+		if value := info.Metadata.Get(name); value != "" {
+			header.Set(name, value)
+		}
+	}
+	for name, values := range info.Metadata {
+		if strings.HasPrefix(strings.ToLower(name), "x-amz-meta-") {
+			header[name] = values
+		}
+	}
+	source := url.URL{Path: "/" + bucket + "/" + object}
+	header.Set("X-Amz-Copy-Source", source.EscapedPath())
+	// The object is not replaced if it changed since it was looked at.
+	header.Set("X-Amz-Copy-Source-If-Match", info.ETag)
+	header.Set("X-Amz-Metadata-Directive", "REPLACE")
+	header.Set("X-Amz-Storage-Class", storageClass)
+	if sse != nil && sse.Type() == encrypt.SSEC {
+		sse.Marshal(header)
+		encrypt.SSECopy(sse).Marshal(header)
+	}
+
+	resp, e := c.signedRequest(http.MethodPut, bucket, object, nil, header, nil)
+	if e == errRequestNotSigned {
+		return false, probe.NewError(APINotImplemented{API: "CopyObject", APIType: "S3v2"})
+	}
+	if e != nil {
+		return false, probe.NewError(e)
+	}
+	defer resp.Body.Close()
+
+	// Copies may fail after the server answered with 200 OK, the error
+	// is in the body then.
+	body, e := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorResponseSize))
+	if e != nil {
+		return false, probe.NewError(e)
+	}
+	if resp.StatusCode != http.StatusOK || bytes.Contains(body, []byte("<Error>")) {
+		var errResp minio.ErrorResponse
+		if e = xml.Unmarshal(body, &errResp); e != nil {
+			return false, probe.NewError(e)
+		}
+		if errResp.Code == "NoSuchKey" {
+			return false, probe.NewError(ObjectMissing{})
+		}
+		return false, c.requestError(bucket, "CopyObject", errResp)
+	}
+	return true, nil
+}
@@ -0,0 +1,312 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+// objectVersion - a version or a delete marker of an object.
+type objectVersion struct {
+	Key            string
+	VersionID      string
+	LastModified   time.Time
+	Size           int64
+	ETag           string
+	IsDeleteMarker bool
+
+	Err *probe.Error
+}
+
+// listVersionsResult - a page of ListObjectVersions.
+type listVersionsResult struct {
+	XMLName             xml.Name `xml:"ListVersionsResult"`
+	IsTruncated         bool
+	NextKeyMarker       string
+	NextVersionIDMarker string `xml:"NextVersionIdMarker"`
+	Versions            []struct {
+		Key          string
+		VersionID    string `xml:"VersionId"`
+		LastModified time.Time
+		Size         int64
+		ETag         string
+	} `xml:"Version"`
+	DeleteMarkers []struct {
+		Key          string
+		VersionID    string `xml:"VersionId"`
+		LastModified time.Time
+	} `xml:"DeleteMarker"`
+}
+
+// listObjectVersions - lists all versions and delete markers of the
+// objects under the path of c, by key and newest first for each key.
+func (c *s3Client) listObjectVersions() <-chan objectVersion {
+	versionsCh := make(chan objectVersion)
+	go func() {
+		defer close(versionsCh)
+		bucket, prefix := c.url2BucketAndObject()
+		if bucket == "" {
+			versionsCh <- objectVersion{Err: probe.NewError(BucketNameEmpty{})}
+			return
+		}
+		var keyMarker, versionIDMarker string
+		for {
+			result, err := c.listObjectVersionsPage(bucket, prefix, keyMarker, versionIDMarker)
+			if err != nil {
+				versionsCh <- objectVersion{Err: err.Trace(bucket, prefix)}
+				return
+			}
+			for _, version := range result.versions() {
+				versionsCh <- version
+			}
+			if !result.IsTruncated {
+				return
+			}
+			keyMarker, versionIDMarker = result.NextKeyMarker, result.NextVersionIDMarker
+		}
+	}()
+	return versionsCh
+}
+
+// listObjectVersionsPage - returns the page of versions listed after
+// keyMarker and versionIDMarker.
+func (c *s3Client) listObjectVersionsPage(bucket, prefix, keyMarker, versionIDMarker string) (*listVersionsResult, *probe.Error) {
+	query := url.Values{"versions": {""}}
+	if prefix != "" {
+		query.Set("prefix", prefix)
+	}
+	if keyMarker != "" {
+		query.Set("key-marker", keyMarker)
+		query.Set("version-id-marker", versionIDMarker)
+	}
+	resp, e := c.signedRequest(http.MethodGet, bucket, "", query, nil, nil)
+	if e == errRequestNotSigned {
+		return nil, probe.NewError(APINotImplemented{API: "ListObjectVersions", APIType: "S3v2"})
+	}
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp minio.ErrorResponse
+		if e = xml.NewDecoder(io.LimitReader(resp.Body, maxErrorResponseSize)).Decode(&errResp); e != nil {
+			return nil, probe.NewError(e)
+		}
+		return nil, c.requestError(bucket, "ListObjectVersions", errResp)
+	}
+
+	result := &listVersionsResult{}
+	if e = xml.NewDecoder(resp.Body).Decode(result); e != nil {
+		return nil, probe.NewError(e)
+	}
+	return result, nil
+}
+
+// versions - returns the versions and delete markers of the page by key
+// and newest first, they are decoded apart from each other.
+func (r *listVersionsResult) versions() []objectVersion {
+	var versions []objectVersion
+	for _, v := range r.Versions {
+		versions = append(versions, objectVersion{
+			Key:          v.Key,
+			VersionID:    v.VersionID,
+			LastModified: v.LastModified,
+			Size:         v.Size,
+			ETag:         v.ETag,
+		})
+	}
+	for _, m := range r.DeleteMarkers {
+		versions = append(versions, objectVersion{
+			Key:            m.Key,
+			VersionID:      m.VersionID,
+			LastModified:   m.LastModified,
+			IsDeleteMarker: true,
+		})
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		if versions[i].Key != versions[j].Key {
+			return versions[i].Key < versions[j].Key
+		}
+		return versions[i].LastModified.After(versions[j].LastModified)
+	})
+	return versions
+}
+
+// Most versions removed with a single DeleteObjects request.
+const maxRemovedVersions = 1000
+
+// removedVersion - a version named in DeleteObjects.
+type removedVersion struct {
+	Key       string
+	VersionID string `xml:"VersionId"`
+}
+
+// removeVersionsRequest - body of DeleteObjects naming the versions
+// removed, only those not removed are listed in its response.
+type removeVersionsRequest struct {
+	XMLName xml.Name `xml:"Delete"`
+	Quiet   bool
+	Objects []removedVersion `xml:"Object"`
+}
+
+// removeVersionsResult - response of DeleteObjects.
+type removeVersionsResult struct {
+	XMLName xml.Name `xml:"DeleteResult"`
+	Errors  []struct {
+		Key       string
+		VersionID string `xml:"VersionId"`
+		Code      string
+		Message   string
+	} `xml:"Error"`
+}
+
+// removeObjectVersions - removes versions of the bucket of c, at most
+// maxRemovedVersions of them, with a single request. Returns the
+// versions which could not be removed with their error.
+func (c *s3Client) removeObjectVersions(versions []objectVersion) ([]objectVersion, *probe.Error) {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return nil, probe.NewError(BucketNameEmpty{})
+	}
+	request := removeVersionsRequest{Quiet: true}
+	for _, version := range versions {
+		request.Objects = append(request.Objects, removedVersion{Key: version.Key, VersionID: version.VersionID})
+	}
+	body, e := xml.Marshal(request)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+
+	resp, e := c.signedRequest(http.MethodPost, bucket, "", url.Values{"delete": {""}}, nil, body)
+	if e == errRequestNotSigned {
+		return nil, probe.NewError(APINotImplemented{API: "DeleteObjects", APIType: "S3v2"})
+	}
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp minio.ErrorResponse
+		if e = xml.NewDecoder(io.LimitReader(resp.Body, maxErrorResponseSize)).Decode(&errResp); e != nil {
+			return nil, probe.NewError(e)
+		}
+		return nil, c.requestError(bucket, "DeleteObjects", errResp)
+	}
+
+	result := &removeVersionsResult{}
+	if e = xml.NewDecoder(resp.Body).Decode(result); e != nil {
+		return nil, probe.NewError(e)
+	}
+	var failed []objectVersion
+	for _, errResp := range result.Errors {
+		failed = append(failed, objectVersion{
+			Key:       errResp.Key,
+			VersionID: errResp.VersionID,
+			Err: c.requestError(bucket, "DeleteObjects", minio.ErrorResponse{
+				Code:    errResp.Code,
+				Message: errResp.Message,
+				Key:     errResp.Key,
+			}),
+		})
+	}
+	return failed, nil
+}
+
+// objectVersionRequest - sends a GET or HEAD request of a version of the
+// object of c.
+func (c *s3Client) objectVersionRequest(method, versionID string, sse encrypt.ServerSide) (*http.Response, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return nil, probe.NewError(BucketNameEmpty{})
+	}
+	header := http.Header{}
+	if sse != nil && sse.Type() == encrypt.SSEC {
+		sse.Marshal(header)
+	}
+	resp, e := c.signedRequest(method, bucket, object, url.Values{"versionId": {versionID}}, header, nil)
+	if e == errRequestNotSigned {
+		return nil, probe.NewError(APINotImplemented{API: "GetObject with versionId", APIType: "S3v2"})
+	}
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	// Responses of HEAD requests have no body.
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, probe.NewError(ObjectMissing{})
+	}
+	var errResp minio.ErrorResponse
+	if e = xml.NewDecoder(io.LimitReader(resp.Body, maxErrorResponseSize)).Decode(&errResp); e != nil {
+		return nil, probe.NewError(errors.New(resp.Status))
+	}
+	return nil, c.requestError(bucket, "GetObject", errResp)
+}
+
+// versionMetadata - returns the metadata of an object version found in
+// the headers of a response.
+func versionMetadata(header http.Header) map[string]string {
+	metadata := map[string]string{}
+	for _, name := range contentHeaders {
+		if value := header.Get(name); value != "" {
+			metadata[name] = value
+		}
+	}
+	for name := range header {
+		if strings.HasPrefix(strings.ToLower(name), "x-amz-meta-") {
+			metadata[name] = header.Get(name)
+		}
+	}
+	return metadata
+}
+
+// getObjectVersion - returns the body of a version of the object of c,
+// along with its metadata.
+func (c *s3Client) getObjectVersion(versionID string, sse encrypt.ServerSide) (io.ReadCloser, map[string]string, *probe.Error) {
+	resp, err := c.objectVersionRequest(http.MethodGet, versionID, sse)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Body, versionMetadata(resp.Header), nil
+}
+
+// statObjectVersion - returns the metadata of a version of the object of
+// c.
+func (c *s3Client) statObjectVersion(versionID string, sse encrypt.ServerSide) (map[string]string, *probe.Error) {
+	resp, err := c.objectVersionRequest(http.MethodHead, versionID, sse)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return versionMetadata(resp.Header), nil
+}
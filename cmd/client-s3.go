@@ -42,6 +42,7 @@ import (
 	"github.com/minio/minio-go/v6/pkg/policy"
 	"github.com/minio/minio-go/v6/pkg/s3utils"
 	"github.com/minio/minio/pkg/mimedb"
+	"golang.org/x/net/http2"
 )
 
 // S3 client
@@ -50,6 +51,16 @@ type s3Client struct {
 	targetURL    *clientURL
 	api          *minio.Client
 	virtualStyle bool
+
+	// Credentials and transport of api, requests minio-go has no call
+	// for are signed and sent with them.
+	creds     *credentials.Credentials
+	signature string
+	transport http.RoundTripper
+
+	// Prefixes listed concurrently by recursive listings, 0 for
+	// listPrefixWorkers.
+	listWorkers int
 }
 
 const (
@@ -83,6 +94,7 @@ var cseHeaders = []string{
 // newFactory encloses New function with client cache.
 func newFactory() func(config *Config) (Client, *probe.Error) {
 	clientCache := make(map[uint32]*minio.Client)
+	transportCache := make(map[uint32]http.RoundTripper)
 	mutex := &sync.Mutex{}
 
 	// Return New function.
@@ -102,8 +114,15 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 		// Save the target URL.
 		s3Clnt.targetURL = targetURL
 
-		// Save if target supports virtual host style.
+		// Requests may be sent to another endpoint of the alias.
 		hostName := targetURL.Host
+		if config.Endpoint != "" {
+			endpointURL := newClientURL(config.Endpoint)
+			hostName = endpointURL.Host
+			useTLS = endpointURL.Scheme != "http"
+		}
+
+		// Save if target supports virtual host style.
 		s3Clnt.virtualStyle = isVirtualHostStyle(hostName, config.Lookup)
 		isS3AcceleratedEndpoint := isAmazonAccelerated(hostName)
 
@@ -113,9 +132,11 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 				hostName = googleHostName
 			}
 		}
+		creds := configCredentials(config)
+
 		// Generate a hash out of s3Conf.
 		confHash := fnv.New32a()
-		confHash.Write([]byte(hostName + config.AccessKey + config.SecretKey))
+		confHash.Write([]byte(hostName + config.AccessKey + config.SecretKey + config.Alias))
 		confSum := confHash.Sum32()
 
 		// Lookup previous cache by hash.
@@ -124,12 +145,6 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 		var api *minio.Client
 		var found bool
 		if api, found = clientCache[confSum]; !found {
-			// if Signature version '4' use NewV4 directly.
-			creds := credentials.NewStaticV4(config.AccessKey, config.SecretKey, "")
-			// if Signature version '2' use NewV2 directly.
-			if strings.ToUpper(config.Signature) == "S3V2" {
-				creds = credentials.NewStaticV2(config.AccessKey, config.SecretKey, "")
-			}
 			// Not found. Instantiate a new MinIO
 			var e error
 
@@ -166,30 +181,28 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 			}
 
 			if useTLS {
-				// Keep TLS config.
-				tlsConfig := &tls.Config{
-					RootCAs: globalRootCAs,
-					// Can't use SSLv3 because of POODLE and BEAST
-					// Can't use TLSv1.0 because of POODLE and BEAST using CBC cipher
-					// Can't use TLSv1.1 because of RC4 cipher usage
-					MinVersion: tls.VersionTLS12,
-				}
-				if config.Insecure {
-					tlsConfig.InsecureSkipVerify = true
+				if err := configureTLSTransport(tr, config.Insecure, config.HTTP1); err != nil {
+					return nil, err
 				}
-				tr.TLSClientConfig = tlsConfig
-
-				// Because we create a custom TLSClientConfig, we have to opt-in to HTTP/2.
-				// See https://github.com/golang/go/issues/14275
-				//
-				// TODO: Enable http2.0 when upstream issues related to HTTP/2 are fixed.
-				//
-				// if e = http2.ConfigureTransport(tr); e != nil {
-				// 	return nil, probe.NewError(e)
-				// }
 			}
 
-			var transport http.RoundTripper = tr
+			var transport http.RoundTripper = withRequestLimit(tr)
+			if !strings.EqualFold(config.Signature, "S3v2") {
+				transport = clockSkewTransport{creds: creds, transport: transport}
+				transport = checksumTransport{creds: creds, transport: transport}
+			}
+			if config.Endpoint != "" {
+				transport = endpointHealthTransport{endpoint: config.Endpoint, transport: transport}
+			}
+			if config.Alias != "" {
+				transport = statsTransport{alias: config.Alias, transport: transport}
+			}
+			if isReadOnly() {
+				transport = readOnlyTransport{transport: transport}
+			}
+			if isAuditEnabled() {
+				transport = auditTransport{alias: config.Alias, accessKey: config.AccessKey, transport: transport}
+			}
 			if config.Debug {
 				if strings.EqualFold(config.Signature, "S3v4") {
 					transport = httptracer.GetNewTraceTransport(newTraceV4(), transport)
@@ -200,6 +213,7 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 
 			// Set the new transport.
 			api.SetCustomTransport(transport)
+			transportCache[confSum] = transport
 
 			// If Amazon Accelerated URL is requested enable it.
 			if isS3AcceleratedEndpoint {
@@ -215,11 +229,58 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 
 		// Store the new api object.
 		s3Clnt.api = api
+		s3Clnt.creds = creds
+		s3Clnt.signature = config.Signature
+		s3Clnt.transport = transportCache[confSum]
 
 		return s3Clnt, nil
 	}
 }
 
+// configCredentials - returns the credentials requests of config are
+// signed with, those of an assumed role or its keys.
+func configCredentials(config *Config) *credentials.Credentials {
+	if config.Credentials != nil {
+		return config.Credentials
+	}
+	// if Signature version '2' use NewV2 directly.
+	if strings.ToUpper(config.Signature) == "S3V2" {
+		return credentials.NewStaticV2(config.AccessKey, config.SecretKey, config.SessionToken)
+	}
+	// if Signature version '4' use NewV4 directly.
+	return credentials.NewStaticV4(config.AccessKey, config.SecretKey, config.SessionToken)
+}
+
+// configureTLSTransport - sets the TLS config of tr, HTTP/2 is enabled
+// unless http1.
+func configureTLSTransport(tr *http.Transport, insecure, http1 bool) *probe.Error {
+	// Keep TLS config.
+	tlsConfig := &tls.Config{
+		RootCAs: globalRootCAs,
+		// Can't use SSLv3 because of POODLE and BEAST
+		// Can't use TLSv1.0 because of POODLE and BEAST using CBC cipher
+		// Can't use TLSv1.1 because of RC4 cipher usage
+		MinVersion: tls.VersionTLS12,
+	}
+	if insecure {
+		tlsConfig.InsecureSkipVerify = true
+	}
+	tr.TLSClientConfig = tlsConfig
+
+	// Because we create a custom TLSClientConfig, we have to opt-in to HTTP/2.
+	// See https://github.com/golang/go/issues/14275
+	//
+	// Concurrent requests are multiplexed over a single connection per host,
+	// new connections are only opened once the server's limit of concurrent
+	// streams is reached.
+	if !http1 {
+		if e := http2.ConfigureTransport(tr); e != nil {
+			return probe.NewError(e)
+		}
+	}
+	return nil
+}
+
 // s3New returns an initialized s3Client structure. If debug is enabled,
 // it also enables an internal trace transport.
 var s3New = newFactory()
@@ -694,6 +755,7 @@ func (c *s3Client) Get(sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
 	bucket, object := c.url2BucketAndObject()
 	opts := minio.GetObjectOptions{}
 	opts.ServerSideEncryption = sse
+	opts.Set(checksumModeHeader, "ENABLED")
 	reader, e := c.api.GetObject(bucket, object, opts)
 	if e != nil {
 		errResponse := minio.ToErrorResponse(e)
@@ -712,7 +774,8 @@ func (c *s3Client) Get(sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
 		}
 		return nil, probe.NewError(e)
 	}
-	return reader, nil
+	// Objects read to their end are verified against their checksum.
+	return newChecksumReader(reader), nil
 }
 
 // Copy - copy object, uses server side copy API. Also uses an abstracted API
@@ -763,6 +826,24 @@ func (c *s3Client) Copy(source string, size int64, progress io.Reader, srcSSE, t
 // Put - upload an object with custom metadata.
 func (c *s3Client) Put(ctx context.Context, reader io.Reader, size int64, metadata map[string]string, progress io.Reader, sse encrypt.ServerSide) (int64, *probe.Error) {
 	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return 0, probe.NewError(BucketNameEmpty{})
+	}
+	if size < 0 {
+		// Length is unknown, read until EOF.
+		return c.putStream(ctx, reader, metadata, progress, sse)
+	}
+	opts := newPutObjectOptions(metadata, progress, sse)
+	n, e := c.api.PutObjectWithContext(ctx, bucket, object, reader, size, opts)
+	if e != nil {
+		return n, c.toPutError(e, size, n)
+	}
+	return n, nil
+}
+
+// newPutObjectOptions - moves the standard headers found in metadata
+// into their own upload options, the rest is sent as user metadata.
+func newPutObjectOptions(metadata map[string]string, progress io.Reader, sse encrypt.ServerSide) minio.PutObjectOptions {
 	contentType, ok := metadata["Content-Type"]
 	if ok {
 		delete(metadata, "Content-Type")
@@ -795,10 +876,7 @@ func (c *s3Client) Put(ctx context.Context, reader io.Reader, size int64, metada
 	if ok {
 		delete(metadata, "X-Amz-Storage-Class")
 	}
-	if bucket == "" {
-		return 0, probe.NewError(BucketNameEmpty{})
-	}
-	opts := minio.PutObjectOptions{
+	return minio.PutObjectOptions{
 		UserMetadata:         metadata,
 		Progress:             progress,
 		NumThreads:           defaultMultipartThreadsNum,
@@ -810,46 +888,47 @@ func (c *s3Client) Put(ctx context.Context, reader io.Reader, size int64, metada
 		StorageClass:         strings.ToUpper(storageClass),
 		ServerSideEncryption: sse,
 	}
-	n, e := c.api.PutObjectWithContext(ctx, bucket, object, reader, size, opts)
-	if e != nil {
-		errResponse := minio.ToErrorResponse(e)
-		if errResponse.Code == "UnexpectedEOF" || e == io.EOF {
-			return n, probe.NewError(UnexpectedEOF{
-				TotalSize:    size,
-				TotalWritten: n,
-			})
-		}
-		if errResponse.Code == "AccessDenied" {
-			return n, probe.NewError(PathInsufficientPermission{
-				Path: c.targetURL.String(),
-			})
-		}
-		if errResponse.Code == "MethodNotAllowed" {
-			return n, probe.NewError(ObjectAlreadyExists{
-				Object: object,
-			})
-		}
-		if errResponse.Code == "XMinioObjectExistsAsDirectory" {
-			return n, probe.NewError(ObjectAlreadyExistsAsDirectory{
-				Object: object,
-			})
-		}
-		if errResponse.Code == "NoSuchBucket" {
-			return n, probe.NewError(BucketDoesNotExist{
-				Bucket: bucket,
-			})
-		}
-		if errResponse.Code == "InvalidBucketName" {
-			return n, probe.NewError(BucketInvalid{
-				Bucket: bucket,
-			})
-		}
-		if errResponse.Code == "NoSuchKey" {
-			return n, probe.NewError(ObjectMissing{})
-		}
-		return n, probe.NewError(e)
-	}
-	return n, nil
+}
+
+// toPutError - converts an upload error into its client error.
+func (c *s3Client) toPutError(e error, size, written int64) *probe.Error {
+	bucket, object := c.url2BucketAndObject()
+	errResponse := minio.ToErrorResponse(e)
+	if errResponse.Code == "UnexpectedEOF" || e == io.EOF {
+		return probe.NewError(UnexpectedEOF{
+			TotalSize:    size,
+			TotalWritten: written,
+		})
+	}
+	if errResponse.Code == "AccessDenied" {
+		return probe.NewError(PathInsufficientPermission{
+			Path: c.targetURL.String(),
+		})
+	}
+	if errResponse.Code == "MethodNotAllowed" {
+		return probe.NewError(ObjectAlreadyExists{
+			Object: object,
+		})
+	}
+	if errResponse.Code == "XMinioObjectExistsAsDirectory" {
+		return probe.NewError(ObjectAlreadyExistsAsDirectory{
+			Object: object,
+		})
+	}
+	if errResponse.Code == "NoSuchBucket" {
+		return probe.NewError(BucketDoesNotExist{
+			Bucket: bucket,
+		})
+	}
+	if errResponse.Code == "InvalidBucketName" {
+		return probe.NewError(BucketInvalid{
+			Bucket: bucket,
+		})
+	}
+	if errResponse.Code == "NoSuchKey" {
+		return probe.NewError(ObjectMissing{})
+	}
+	return probe.NewError(e)
 }
 
 // Remove incomplete uploads.
@@ -879,6 +958,15 @@ func (c *s3Client) Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *c
 	var objectsCh chan string
 	var statusCh <-chan minio.RemoveObjectError
 
+	// Failures tell the path of their object as found in contentCh.
+	var pathPrefix string
+	removeFailed := func(removeStatus minio.RemoveObjectError) *probe.Error {
+		if removeStatus.Err == nil {
+			return nil
+		}
+		return probe.NewError(ObjectRemoveFailed{Path: pathPrefix + removeStatus.ObjectName, Cause: removeStatus.Err})
+	}
+
 	go func() {
 		defer close(errorCh)
 		for content := range contentCh {
@@ -907,7 +995,7 @@ func (c *s3Client) Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *c
 					close(objectsCh)
 				}
 				for removeStatus := range statusCh {
-					errorCh <- probe.NewError(removeStatus.Err)
+					errorCh <- removeFailed(removeStatus)
 				}
 				// Remove bucket if it qualifies.
 				if isRemoveBucket && !isIncomplete {
@@ -926,6 +1014,7 @@ func (c *s3Client) Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *c
 			}
 
 			if objectName != "" {
+				pathPrefix = strings.TrimSuffix(content.URL.Path, objectName)
 				// Send object name once but continuously checks for pending
 				// errors in parallel, the reason is that minio-go RemoveObjects
 				// can block if there is any pending error not received yet.
@@ -935,7 +1024,7 @@ func (c *s3Client) Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *c
 					case objectsCh <- objectName:
 						sent = true
 					case removeStatus := <-statusCh:
-						errorCh <- probe.NewError(removeStatus.Err)
+						errorCh <- removeFailed(removeStatus)
 					}
 				}
 			} else {
@@ -953,7 +1042,7 @@ func (c *s3Client) Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *c
 		// Write remove objects status to errorCh
 		if statusCh != nil {
 			for removeStatus := range statusCh {
-				errorCh <- probe.NewError(removeStatus.Err)
+				errorCh <- removeFailed(removeStatus)
 			}
 		}
 		// Remove last bucket if it qualifies.
@@ -972,12 +1061,18 @@ func (c *s3Client) MakeBucket(region string, ignoreExisting bool) *probe.Error {
 	if bucket == "" {
 		return probe.NewError(BucketNameEmpty{})
 	}
+	// New buckets are checked here rather than rejected by the server,
+	// existing buckets may predate the naming rules.
+	reason := bucketNameProblem(bucket)
 	if object != "" {
 		if strings.HasSuffix(object, "/") {
 		retry:
 			if _, e := c.api.PutObject(bucket, object, bytes.NewReader([]byte("")), 0, minio.PutObjectOptions{}); e != nil {
 				switch minio.ToErrorResponse(e).Code {
 				case "NoSuchBucket":
+					if reason != "" {
+						return probe.NewError(BucketInvalid{Bucket: bucket, Reason: reason})
+					}
 					e = c.api.MakeBucket(bucket, region)
 					if e != nil {
 						return probe.NewError(e)
@@ -990,6 +1085,9 @@ func (c *s3Client) MakeBucket(region string, ignoreExisting bool) *probe.Error {
 		}
 		return probe.NewError(BucketNameTopLevel{})
 	}
+	if reason != "" {
+		return probe.NewError(BucketInvalid{Bucket: bucket, Reason: reason})
+	}
 
 	e := c.api.MakeBucket(bucket, region)
 	if e != nil {
@@ -1214,6 +1312,7 @@ func (c *s3Client) Stat(isIncomplete, isFetchMeta bool, sse encrypt.ServerSide)
 // getObjectStat returns the metadata of an object from a HEAD call.
 func (c *s3Client) getObjectStat(bucket, object string, opts minio.StatObjectOptions) (*clientContent, *probe.Error) {
 	objectMetadata := &clientContent{}
+	opts.Set(checksumModeHeader, "ENABLED")
 	objectStat, e := c.api.StatObject(bucket, object, opts)
 	if e != nil {
 		errResponse := minio.ToErrorResponse(e)
@@ -1356,7 +1455,7 @@ func (c *s3Client) List(isRecursive, isIncomplete bool, showDir DirOpt) <-chan *
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	contentCh := make(chan *clientContent)
+	contentCh := make(chan *clientContent, listBufferSize)
 	if isIncomplete {
 		if isRecursive {
 			if showDir == DirNone {
@@ -1419,6 +1518,7 @@ func (c *s3Client) listIncompleteInRoutine(contentCh chan *clientContent) {
 					content.Size = object.Size
 					content.Time = object.Initiated
 					content.Type = os.ModeTemporary
+					content.UploadID = object.UploadID
 				}
 				contentCh <- content
 			}
@@ -1447,6 +1547,7 @@ func (c *s3Client) listIncompleteInRoutine(contentCh chan *clientContent) {
 				content.Size = object.Size
 				content.Time = object.Initiated
 				content.Type = os.ModeTemporary
+				content.UploadID = object.UploadID
 			}
 			contentCh <- content
 		}
@@ -1482,6 +1583,7 @@ func (c *s3Client) listIncompleteRecursiveInRoutine(contentCh chan *clientConten
 				content.Size = object.Size
 				content.Time = object.Initiated
 				content.Type = os.ModeTemporary
+				content.UploadID = object.UploadID
 				contentCh <- content
 			}
 		}
@@ -1502,6 +1604,7 @@ func (c *s3Client) listIncompleteRecursiveInRoutine(contentCh chan *clientConten
 			content.Size = object.Size
 			content.Time = object.Initiated
 			content.Type = os.ModeTemporary
+			content.UploadID = object.UploadID
 			contentCh <- content
 		}
 	}
@@ -1522,6 +1625,7 @@ func (c *s3Client) objectMultipartInfo2ClientContent(bucket string, entry minio.
 		content.Type = os.ModeDir
 	} else {
 		content.Type = os.ModeTemporary
+		content.UploadID = entry.UploadID
 	}
 
 	return content
@@ -1886,25 +1990,7 @@ func (c *s3Client) listRecursiveInRoutine(contentCh chan *clientContent) {
 			}
 		}
 	default:
-		isRecursive := true
-		for object := range c.listObjectWrapper(b, o, isRecursive, nil) {
-			if object.Err != nil {
-				contentCh <- &clientContent{
-					Err: probe.NewError(object.Err),
-				}
-				continue
-			}
-			content := &clientContent{}
-			url := *c.targetURL
-			// Join bucket and incoming object key.
-			url.Path = c.joinPath(b, object.Key)
-			content.URL = url
-			content.Size = object.Size
-			content.ETag = object.ETag
-			content.Time = object.LastModified
-			content.Type = os.FileMode(0664)
-			contentCh <- content
-		}
+		c.listRecursiveParallel(b, o, contentCh)
 	}
 }
 
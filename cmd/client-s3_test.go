@@ -20,10 +20,14 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"sync"
+	"testing"
+	"time"
 
 	minio "github.com/minio/minio-go/v6"
 	. "gopkg.in/check.v1"
@@ -261,3 +265,76 @@ func (s *TestSuite) TestSelectCompressionType(c *C) {
 		c.Assert(cType, DeepEquals, test.compressionType)
 	}
 }
+
+func TestConfigureTLSTransportMultiplexes(t *testing.T) {
+	const requests = 64
+
+	var mutex sync.Mutex
+	remoteAddrs := map[string]bool{}
+	inFlight := make(chan struct{}, requests)
+	release := make(chan struct{})
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor != 2 {
+			http.Error(w, r.Proto, http.StatusHTTPVersionNotSupported)
+			return
+		}
+		mutex.Lock()
+		remoteAddrs[r.RemoteAddr] = true
+		mutex.Unlock()
+		if r.URL.Path == "/concurrent" {
+			// Hold each request until all of them are in flight.
+			inFlight <- struct{}{}
+			select {
+			case <-release:
+			case <-time.After(10 * time.Second):
+			}
+		}
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	tr := &http.Transport{}
+	if err := configureTLSTransport(tr, true, false); err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: tr}
+	get := func(path string) error {
+		resp, e := client.Get(server.URL + path)
+		if e != nil {
+			return e
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("%s: %s", path, resp.Status)
+		}
+		return nil
+	}
+
+	// The first request opens the connection the others share.
+	if e := get("/"); e != nil {
+		t.Fatal(e)
+	}
+	errCh := make(chan error, requests)
+	for i := 0; i < requests; i++ {
+		go func() {
+			errCh <- get("/concurrent")
+		}()
+	}
+	for i := 0; i < requests; i++ {
+		select {
+		case <-inFlight:
+		case <-time.After(10 * time.Second):
+			t.Fatalf("Expected %d concurrent requests in flight, got %d", requests, i)
+		}
+	}
+	close(release)
+	for i := 0; i < requests; i++ {
+		if e := <-errCh; e != nil {
+			t.Fatal(e)
+		}
+	}
+	if len(remoteAddrs) != 1 {
+		t.Fatalf("Expected all requests to share one connection, got %d", len(remoteAddrs))
+	}
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/credentials"
 	"github.com/minio/minio-go/v6/pkg/encrypt"
 )
 
@@ -42,6 +43,12 @@ const (
 // Default number of multipart workers for a Put operation.
 const defaultMultipartThreadsNum = 4
 
+// Number of listed entries buffered between a listing and its consumer,
+// large enough to hold one listing page so that the next page is fetched
+// while the current one is still being printed, small enough to keep
+// memory flat on very large buckets.
+const listBufferSize = 1000
+
 // Client - client interface
 type Client interface {
 	// Common operations
@@ -91,21 +98,28 @@ type clientContent struct {
 	ETag              string
 	Expires           time.Time
 	EncryptionHeaders map[string]string
+	UploadID          string // Of incomplete uploads listed
+	VersionID         string // Of versions listed as of a point in time
 	Err               *probe.Error
 }
 
 // Config - see http://docs.amazonwebservices.com/AmazonS3/latest/dev/index.html?RESTAuthentication.html
 type Config struct {
-	AccessKey   string
-	SecretKey   string
-	Signature   string
-	HostURL     string
-	AppName     string
-	AppVersion  string
-	AppComments []string
-	Debug       bool
-	Insecure    bool
-	Lookup      minio.BucketLookupType
+	AccessKey    string
+	SecretKey    string
+	SessionToken string                   // Token of temporary credentials, if any
+	Credentials  *credentials.Credentials // Renewed credentials used instead of the keys, if any
+	Signature    string
+	HostURL      string
+	Endpoint     string // URL requests are sent to, if other than HostURL
+	Alias        string // Alias transfers are accounted to, if any
+	AppName      string
+	AppVersion   string
+	AppComments  []string
+	Debug        bool
+	Insecure     bool
+	HTTP1        bool // Disables HTTP/2
+	Lookup       minio.BucketLookupType
 }
 
 // SelectObjectOpts - opts entered for select API
@@ -0,0 +1,132 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/minio-go/v6/pkg/credentials"
+)
+
+// Error responses are small, only their start is inspected.
+const maxErrorResponseSize = 64 * 1024
+
+// clockSkew - offset of the local clock from the servers, learnt from
+// the first request refused for a skewed time.
+var clockSkew = struct {
+	sync.Mutex
+	offset time.Duration
+	known  bool
+}{}
+
+// getClockOffset - returns the time to add to the local clock.
+func getClockOffset() (time.Duration, bool) {
+	clockSkew.Lock()
+	defer clockSkew.Unlock()
+	return clockSkew.offset, clockSkew.known
+}
+
+// setClockOffset - saves the offset of the local clock, warns the first
+// time it is detected.
+func setClockOffset(offset time.Duration) {
+	clockSkew.Lock()
+	defer clockSkew.Unlock()
+	if !clockSkew.known {
+		console.Errorln(fmt.Sprintf("Local clock is off by %s from the server, signing requests with the server time. Please synchronize the local clock.",
+			offset.Round(time.Second)))
+	}
+	clockSkew.offset = offset
+	clockSkew.known = true
+}
+
+// clockSkewTransport - signs requests with the server time once the
+// local clock is known to be skewed. Only AWS signature V4 requests
+// without chunk signed payloads are signed again.
+type clockSkewTransport struct {
+	creds     *credentials.Credentials
+	transport http.RoundTripper
+}
+
+// cloneRequest - returns a copy of req with its own headers, a round
+// tripper must not change the requests it is given.
+func cloneRequest(req *http.Request) *http.Request {
+	clone := new(http.Request)
+	*clone = *req
+	clone.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		clone.Header[k] = append([]string(nil), v...)
+	}
+	return clone
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t clockSkewTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if offset, ok := getClockOffset(); ok {
+		if signed := cloneRequest(req); resignV4(signed, t.creds, UTCNow().Add(offset)) {
+			req = signed
+		}
+	}
+	resp, e := t.transport.RoundTrip(req)
+	if e != nil || resp.StatusCode != http.StatusForbidden {
+		return resp, e
+	}
+
+	body, e := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorResponseSize))
+	if e != nil {
+		resp.Body.Close()
+		return nil, e
+	}
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+	if !bytes.Contains(body, []byte("<Code>RequestTimeTooSkewed</Code>")) {
+		return resp, nil
+	}
+	serverTime, e := http.ParseTime(resp.Header.Get("Date"))
+	if e != nil {
+		return resp, nil
+	}
+	setClockOffset(serverTime.Sub(UTCNow()))
+
+	// Requests are sent again signed with the server time, those with
+	// a body only if it can be read again.
+	retry := cloneRequest(req)
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return resp, nil
+		}
+		if retry.Body, e = req.GetBody(); e != nil {
+			return resp, nil
+		}
+	}
+	if !resignV4(retry, t.creds, serverTime) {
+		if retry.Body != nil && retry.Body != req.Body {
+			retry.Body.Close()
+		}
+		return resp, nil
+	}
+	resp.Body.Close()
+	return t.transport.RoundTrip(retry)
+}
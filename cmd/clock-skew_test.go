@@ -0,0 +1,87 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v6/pkg/credentials"
+)
+
+// roundTripFunc - an http.RoundTripper calling itself.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestClockSkewTransport(t *testing.T) {
+	defer func() {
+		clockSkew.offset, clockSkew.known = 0, false
+	}()
+	serverTime := UTCNow().Add(time.Hour).Truncate(time.Second)
+
+	var bodies []string
+	var dates []string
+	transport := clockSkewTransport{
+		creds: credentials.NewStaticV4("minio", "minio123", ""),
+		transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body, _ := ioutil.ReadAll(req.Body)
+			req.Body.Close()
+			bodies = append(bodies, string(body))
+			dates = append(dates, req.Header.Get("X-Amz-Date"))
+			if len(bodies) > 1 {
+				return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+			}
+			return &http.Response{
+				StatusCode: http.StatusForbidden,
+				Header:     http.Header{"Date": []string{serverTime.Format(http.TimeFormat)}},
+				Body:       ioutil.NopCloser(strings.NewReader("<Error><Code>RequestTimeTooSkewed</Code></Error>")),
+			}, nil
+		}),
+	}
+
+	req, e := http.NewRequest(http.MethodPut, "http://localhost:9000/bucket/object", bytes.NewReader([]byte("data")))
+	if e != nil {
+		t.Fatal(e)
+	}
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	signV4(req, "minio", "minio123", "us-east-1", "s3", []string{"host", "x-amz-content-sha256", "x-amz-date"}, UTCNow())
+	auth := req.Header.Get("Authorization")
+
+	resp, e := transport.RoundTrip(req)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected the request sent again, got status %d", resp.StatusCode)
+	}
+	if len(bodies) != 2 || bodies[0] != "data" || bodies[1] != "data" {
+		t.Fatalf("Expected the body sent twice, got %q", bodies)
+	}
+	if dates[1] != serverTime.Format(iso8601Format) {
+		t.Fatalf("Expected the request signed at %s, got %s", serverTime.Format(iso8601Format), dates[1])
+	}
+	if req.Header.Get("Authorization") != auth {
+		t.Fatal("Expected the request given left unchanged")
+	}
+}
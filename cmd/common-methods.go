@@ -22,12 +22,15 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"golang.org/x/net/http/httpguts"
+	"golang.org/x/time/rate"
 	"gopkg.in/h2non/filetype.v1"
 
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/hookreader"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v6/pkg/encrypt"
 )
@@ -116,13 +119,38 @@ func getSourceStreamFromURL(urlStr string, encKeyDB map[string][]prefixSSEPair)
 	return reader, err
 }
 
+// getSourceVersionStream gets a reader of a version of the object at
+// URL, of its latest version if versionID is empty.
+func getSourceVersionStream(alias string, urlStr string, versionID string, sse encrypt.ServerSide) (reader io.ReadCloser, metadata map[string]string, err *probe.Error) {
+	if versionID == "" {
+		return getSourceStream(alias, urlStr, true, sse)
+	}
+	sourceClnt, err := newClientFromAlias(alias, urlStr)
+	if err != nil {
+		return nil, nil, err.Trace(alias, urlStr)
+	}
+	s3Clnt, ok := sourceClnt.(*s3Client)
+	if !ok {
+		return nil, nil, probe.NewError(APINotImplemented{API: "GetObject with versionId", APIType: "filesystem"}).Trace(alias, urlStr)
+	}
+	reader, metadata, err = s3Clnt.getObjectVersion(versionID, sse)
+	if err != nil {
+		return nil, nil, err.Trace(alias, urlStr)
+	}
+	return reader, metadata, nil
+}
+
 // getSourceStream gets a reader from URL.
 func getSourceStream(alias string, urlStr string, fetchStat bool, sse encrypt.ServerSide) (reader io.ReadCloser, metadata map[string]string, err *probe.Error) {
 	sourceClnt, err := newClientFromAlias(alias, urlStr)
 	if err != nil {
 		return nil, nil, err.Trace(alias, urlStr)
 	}
-	reader, err = sourceClnt.Get(sse)
+	if globalCacheExpiry > 0 && sourceClnt.GetURL().Type == objectStorage {
+		reader, err = getCachedSource(sourceClnt, sse)
+	} else {
+		reader, err = sourceClnt.Get(sse)
+	}
 	if err != nil {
 		return nil, nil, err.Trace(alias, urlStr)
 	}
@@ -193,6 +221,28 @@ func putTargetStreamWithURL(urlStr string, reader io.Reader, size int64, sse enc
 	return putTargetStream(context.Background(), alias, urlStrFull, reader, size, metadata, nil, sse)
 }
 
+// putTargetStreamResumable writes to URL from a local file, recording
+// the upload progress in uploads when the target supports it.
+func putTargetStreamResumable(ctx context.Context, alias string, urlStr string, file *os.File, size int64, metadata map[string]string, progress io.Reader, sse encrypt.ServerSide, uploads uploadStore) (int64, *probe.Error) {
+	targetClnt, err := newClientFromAlias(alias, urlStr)
+	if err != nil {
+		return 0, err.Trace(alias, urlStr)
+	}
+	s3Clnt, ok := targetClnt.(*s3Client)
+	if !ok {
+		return putTargetStream(ctx, alias, urlStr, file, size, metadata, progress, sse)
+	}
+	st, e := file.Stat()
+	if e != nil {
+		return 0, probe.NewError(e).Trace(file.Name())
+	}
+	n, err := s3Clnt.putResumable(ctx, file, st.Size(), st.ModTime(), metadata, progress, sse, uploads)
+	if err != nil {
+		return n, err.Trace(alias, urlStr)
+	}
+	return n, nil
+}
+
 // copySourceToTargetURL copies to targetURL from source.
 func copySourceToTargetURL(alias string, urlStr string, source string, size int64, progress io.Reader, srcSSE, tgtSSE encrypt.ServerSide, metadata map[string]string) *probe.Error {
 	targetClnt, err := newClientFromAlias(alias, urlStr)
@@ -206,6 +256,17 @@ func copySourceToTargetURL(alias string, urlStr string, source string, size int6
 	return nil
 }
 
+// Standard headers of objects describing their content, kept along
+// when their metadata is copied.
+var contentHeaders = []string{
+	"Cache-Control",
+	"Content-Disposition",
+	"Content-Encoding",
+	"Content-Language",
+	"Content-Type",
+	"Expires",
+}
+
 // createUserMetadata - returns a map of user defined function
 // by combining the usermetadata of object and  values passed by attr keyword
 func createUserMetadata(sourceAlias, sourceURLStr string, srcSSE encrypt.ServerSide, urls URLs) (map[string]string, *probe.Error) {
@@ -228,13 +289,56 @@ func createUserMetadata(sourceAlias, sourceURLStr string, srcSSE encrypt.ServerS
 	for k, v := range urls.TargetContent.UserMetadata {
 		metadata[k] = v
 	}
+
+	// The storage class asked for target applies to copies as well.
+	if class := urls.TargetContent.Metadata["X-Amz-Storage-Class"]; class != "" {
+		metadata["X-Amz-Storage-Class"] = class
+	}
+
+	// The metadata of copies is replaced as soon as any is given, the
+	// content headers of source are kept along.
+	if len(metadata) > 0 {
+		for _, name := range contentHeaders {
+			if value, ok := st.Metadata[name]; ok {
+				metadata[name] = value
+			}
+		}
+	}
 	return metadata, nil
 }
 
+// uploadOptions - optional behavior of uploadSourceToTargetURL.
+type uploadOptions struct {
+	// Session of a copy, the progress of its large multipart uploads
+	// is recorded in it. Nil without a session.
+	uploads uploadStore
+	// Content encoding used to compress uploads to object storage.
+	compress string
+	// Keep encoded objects encoded when writing to the local filesystem.
+	noDecompress bool
+	// Commands run around each object, if any.
+	hooks transferHooks
+	// Command transforming the body of each object, if any.
+	filter string
+	// Algorithm of the checksum sent with uploads to object storage.
+	checksum string
+	// Bandwidth of uploads to object storage by target alias, "" for
+	// targets not listed, and of downloads from object storage, shared
+	// by all transfers, if limited.
+	uploadLimits  map[string]*rate.Limiter
+	downloadLimit *rate.Limiter
+	// Bandwidth of all transfers from or to object storage by time of
+	// day, if limited.
+	scheduleLimit *rate.Limiter
+	// Modification time and mode of local files are stored with their
+	// objects, and restored on local files.
+	preserve bool
+}
+
 // uploadSourceToTargetURL - uploads to targetURL from source.
 // optionally optimizes copy for object sizes <= 5GiB by using
 // server side copy operation.
-func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader, encKeyDB map[string][]prefixSSEPair) URLs {
+func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader, opts uploadOptions, encKeyDB map[string][]prefixSSEPair) URLs {
 	sourceAlias := urls.SourceAlias
 	sourceURL := urls.SourceContent.URL
 	targetAlias := urls.TargetAlias
@@ -247,8 +351,9 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 	srcSSE := getSSE(sourcePath, encKeyDB[sourceAlias])
 	tgtSSE := getSSE(targetPath, encKeyDB[targetAlias])
 
-	// Optimize for server side copy if the host is same.
-	if sourceAlias == targetAlias {
+	// Optimize for server side copy if the host is same, filtered
+	// bodies and earlier versions have to go through mc.
+	if sourceAlias == targetAlias && opts.filter == "" && urls.SourceContent.VersionID == "" {
 
 		metadata, err := createUserMetadata(sourceAlias, sourceURL.String(), srcSSE, urls)
 		if err != nil {
@@ -260,10 +365,20 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 		if err != nil {
 			return urls.WithError(err.Trace(sourceURL.String()))
 		}
+		if opts.preserve && targetURL.Type == fileSystem {
+			attrs, err := sourceFileAttrs(urls.SourceContent, metadata)
+			if err == nil {
+				err = attrs.apply(targetURL.Path)
+			}
+			if err != nil {
+				return urls.WithError(err.Trace(targetURL.String()))
+			}
+		}
 	} else {
 
-		// Proceed with regular stream copy.
-		reader, metadata, err := getSourceStream(sourceAlias, sourceURL.String(), true, srcSSE)
+		// Proceed with regular stream copy, as fast as allowed.
+		progress = opts.limitBandwidth(ctx, progress, sourceURL.Type, targetAlias, targetURL.Type)
+		reader, metadata, err := getSourceVersionStream(sourceAlias, sourceURL.String(), urls.SourceContent.VersionID, srcSSE)
 		if err != nil {
 			return urls.WithError(err.Trace(sourceURL.String()))
 		}
@@ -284,14 +399,109 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 			delete(metadata, "X-Amz-Server-Side-Encryption-Customer-Algorithm")
 			delete(metadata, "X-Amz-Server-Side-Encryption-Customer-Key-Md5")
 		}
-		_, err = putTargetStream(ctx, targetAlias, targetURL.String(), reader, length, metadata, progress, tgtSSE)
+
+		// Attributes of local files are stored with their objects.
+		var attrs fileAttrs
+		if opts.preserve {
+			if attrs, err = sourceFileAttrs(urls.SourceContent, metadata); err != nil {
+				return urls.WithError(err.Trace(sourceURL.String()))
+			}
+			if sourceURL.Type == fileSystem && targetURL.Type == objectStorage {
+				metadata[mcAttrsMetaKey] = attrs.String()
+			}
+		}
+
+		// Compress uploads to object storage, decompress downloads to the
+		// local filesystem. Progress is then tracked on the source stream
+		// since the size of the transferred stream is not known upfront.
+		var stream io.Reader = reader
+		size := length
+		if file, ok := reader.(*os.File); ok && isGrowingFile(file, length) {
+			// Read pipes and files written to while listed until EOF.
+			size = -1
+		}
+		contentEncoding := metadata["Content-Encoding"]
+		if !opts.noDecompress && targetURL.Type == fileSystem && contentEncoding != "" {
+			decompressReader, ok, err := newDecompressReader(hookreader.NewHook(stream, progress), contentEncoding)
+			if err != nil {
+				return urls.WithError(err.Trace(sourceURL.String()))
+			}
+			if ok {
+				defer decompressReader.Close()
+				stream, size, progress = decompressReader, -1, nil
+			}
+		}
+		// Filters see decompressed bodies and have their output
+		// compressed, its length is not known upfront either.
+		if opts.filter != "" {
+			filterReader, err := newFilterReader(opts.filter, hookreader.NewHook(stream, progress))
+			if err != nil {
+				return urls.WithError(err.Trace(sourceURL.String()))
+			}
+			defer filterReader.Close()
+			stream, size, progress = filterReader, -1, nil
+		}
+		if opts.compress != "" && targetURL.Type == objectStorage && contentEncoding == "" {
+			compressReader := newCompressReader(hookreader.NewHook(stream, progress))
+			defer compressReader.Close()
+			metadata["Content-Encoding"] = opts.compress
+			// Kept to compare the object with its source when mirrored again.
+			if size >= 0 {
+				metadata[mcOriginalSizeMetaKey] = strconv.FormatInt(size, 10)
+			}
+			stream, size, progress = compressReader, -1, nil
+		}
+
+		// Unchanged uploads done in a single request are sent with the
+		// checksum stored with their source, or computed from their
+		// local file.
+		checksum := takeChecksum(metadata)
+		isChecksummed := false
+		if opts.checksum != "" && targetURL.Type == objectStorage && size >= 0 && size < checksumMaxSize {
+			if checksum.Algorithm == "" || checksum.isComposite() {
+				checksum = objectChecksum{}
+				if file, ok := stream.(*os.File); ok {
+					if checksum, err = fileChecksum(file, opts.checksum); err != nil {
+						return urls.WithError(err.Trace(sourceURL.String()))
+					}
+				}
+			}
+			if checksum.Algorithm != "" {
+				ctx = withUploadChecksum(ctx, checksum)
+				isChecksummed = true
+			}
+		}
+
+		// Large local files are uploaded resumably when copied with a
+		// session to record their progress in, unless sent with a
+		// checksum in a single request.
+		if file, ok := stream.(*os.File); ok && opts.uploads != nil && !isChecksummed && size >= resumableUploadThreshold {
+			_, err = putTargetStreamResumable(ctx, targetAlias, targetURL.String(), file, length, metadata, progress, tgtSSE, opts.uploads)
+		} else {
+			_, err = putTargetStream(ctx, targetAlias, targetURL.String(), stream, size, metadata, progress, tgtSSE)
+		}
 		if err != nil {
 			return urls.WithError(err.Trace(targetURL.String()))
 		}
+		if opts.preserve && targetURL.Type == fileSystem {
+			if err = attrs.apply(targetURL.Path); err != nil {
+				return urls.WithError(err.Trace(targetURL.String()))
+			}
+		}
 	}
 	return urls.WithError(nil)
 }
 
+// isGrowingFile - returns true if file is not a regular file, such as a
+// named pipe, or if it grew past the size it was listed with.
+func isGrowingFile(file *os.File, listedSize int64) bool {
+	st, e := file.Stat()
+	if e != nil {
+		return false
+	}
+	return !st.Mode().IsRegular() || st.Size() > listedSize
+}
+
 // newClientFromAlias gives a new client interface for matching
 // alias entry in the mc config file. If no matching host config entry
 // is found, fs client is returned.
@@ -312,6 +522,14 @@ func newClientFromAlias(alias, urlStr string) (Client, *probe.Error) {
 	}
 
 	s3Config := newS3Config(urlStr, hostCfg)
+	s3Config.Alias = alias
+
+	// The role is assumed with the credentials of the alias.
+	if globalRoleARN != "" && !globalAnonymous {
+		if err = assumeRoleConfig(s3Config); err != nil {
+			return nil, err.Trace(alias, urlStr)
+		}
+	}
 
 	s3Client, err := s3New(s3Config)
 	if err != nil {
@@ -150,15 +150,44 @@ var completeCmds = map[string]complete.Predictor{
 	"/head":   complete.PredictOr(s3Completer, fsCompleter),
 	"/diff":   complete.PredictOr(s3Completer, fsCompleter),
 	"/find":   complete.PredictOr(s3Completer, fsCompleter),
+	"/scrub":  complete.PredictOr(s3Completer, fsCompleter),
 	"/mirror": complete.PredictOr(s3Completer, fsCompleter),
 	"/pipe":   complete.PredictOr(s3Completer, fsCompleter),
 	"/stat":   complete.PredictOr(s3Completer, fsCompleter),
+	"/du":     complete.PredictOr(s3Completer, fsCompleter),
+	"/sum":    fsCompleter,
 	"/watch":  complete.PredictOr(s3Completer, fsCompleter),
 	"/policy": complete.PredictOr(s3Completer, fsCompleter),
 
+	"/transition": s3Completer,
+
 	"/mb":  aliasCompleter,
 	"/sql": s3Completer,
 
+	"/cleanup-uploads": s3Completer,
+
+	"/trash/list":    complete.PredictOr(s3Completer, fsCompleter),
+	"/trash/restore": complete.PredictOr(s3Completer, fsCompleter),
+	"/trash/empty":   complete.PredictOr(s3Completer, fsCompleter),
+
+	"/encrypt/set":   s3Completer,
+	"/encrypt/clear": s3Completer,
+	"/encrypt/info":  s3Completer,
+
+	"/bucket/attrs/set":  s3Completer,
+	"/bucket/attrs/info": s3Completer,
+
+	"/profile/create": nil,
+	"/profile/use":    nil,
+	"/profile/list":   nil,
+
+	"/audit/enable":  nil,
+	"/audit/disable": nil,
+	"/audit/show":    nil,
+
+	"/backup":         complete.PredictOr(s3Completer, fsCompleter),
+	"/backup/restore": complete.PredictOr(s3Completer, fsCompleter),
+
 	"/admin/info":       aliasCompleter,
 	"/admin/heal":       s3Completer,
 	"/admin/credential": aliasCompleter,
@@ -193,6 +222,12 @@ var completeCmds = map[string]complete.Predictor{
 	"/session/clear":  nil,
 	"/session/list":   nil,
 	"/session/resume": nil,
+	"/session/export": nil,
+
+	"/stats/show":  aliasCompleter,
+	"/stats/reset": aliasCompleter,
+
+	"/sign": nil,
 
 	"/share/download": nil,
 	"/share/list":     nil,
@@ -202,8 +237,17 @@ var completeCmds = map[string]complete.Predictor{
 	"/config/host/list":   aliasCompleter,
 	"/config/host/remove": aliasCompleter,
 
+	"/config/shortcut/add":    nil,
+	"/config/shortcut/list":   nil,
+	"/config/shortcut/remove": nil,
+
+	"/config/read-only": nil,
+	"/config/protect":   nil,
+
 	"/update":  nil,
 	"/version": nil,
+
+	"/generate-docs": nil,
 }
 
 // flagsToCompleteFlags transforms a cli.Flag to complete.Flags
@@ -36,6 +36,15 @@ var hostAddFlags = []cli.Flag{
 		Name:  "api",
 		Usage: "API signature. Valid options are '[S3v4, S3v2]'",
 	},
+	cli.StringSliceFlag{
+		Name:  "endpoint",
+		Usage: "URL of another server or site serving the same data with the same credentials, may be repeated",
+	},
+	cli.StringFlag{
+		Name:  "failover",
+		Value: "standby",
+		Usage: "use of additional endpoints. Valid options are '[standby,round-robin,latency]'",
+	},
 }
 var configHostAddCmd = cli.Command{
 	Name:            "add",
@@ -80,6 +89,19 @@ EXAMPLES:
 								minio minio123 --api "s3v4" --lookup "dns"
 		 $ set -o history
 
+  5. Add a replicated MinIO deployment under "myminio" alias, failing over to the standby site when the primary site is unreachable.
+     $ set +o history
+     $ {{.HelpName}} myminio https://site1.example.com \
+                 minio minio123 --endpoint https://site2.example.com
+     $ set -o history
+
+  6. Add a MinIO deployment replicated across three sites under "myminio" alias, sending requests to the site
+     which answered the startup probe fastest.
+     $ set +o history
+     $ {{.HelpName}} myminio https://site1.example.com minio minio123 --failover latency \
+                 --endpoint https://site2.example.com --endpoint https://site3.example.com
+     $ set -o history
+
 `,
 }
 
@@ -125,6 +147,17 @@ func checkConfigHostAddSyntax(ctx *cli.Context) {
 		fatalIf(errInvalidArgument().Trace(bucketLookup),
 			"Unrecognized bucket lookup. Valid options are `[dns,auto, path]`.")
 	}
+
+	for _, endpoint := range ctx.StringSlice("endpoint") {
+		if !isValidHostURL(endpoint) {
+			fatalIf(errInvalidURL(endpoint), "Invalid endpoint URL.")
+		}
+	}
+
+	if failover := ctx.String("failover"); !isValidFailover(failover) {
+		fatalIf(errInvalidArgument().Trace(failover),
+			"Unrecognized failover. Valid options are `[standby,round-robin,latency]`.")
+	}
 }
 
 // addHost - add a host config.
@@ -146,6 +179,8 @@ func addHost(alias string, hostCfgV9 hostConfigV9) {
 		SecretKey: hostCfgV9.SecretKey,
 		API:       hostCfgV9.API,
 		Lookup:    hostCfgV9.Lookup,
+		Endpoints: hostCfgV9.Endpoints,
+		Failover:  hostCfgV9.Failover,
 	})
 }
 
@@ -237,12 +272,23 @@ func mainConfigHostAdd(ctx *cli.Context) error {
 	s3Config, err := buildS3Config(url, accessKey, secretKey, api, lookup)
 	fatalIf(err.Trace(ctx.Args()...), "Unable to initialize new config from the provided credentials.")
 
+	var endpoints []string
+	var failover string
+	for _, endpoint := range ctx.StringSlice("endpoint") {
+		endpoints = append(endpoints, trimTrailingSeparator(endpoint))
+	}
+	if len(endpoints) > 0 {
+		failover = ctx.String("failover")
+	}
+
 	addHost(ctx.Args().Get(0), hostConfigV9{
 		URL:       s3Config.HostURL,
 		AccessKey: s3Config.AccessKey,
 		SecretKey: s3Config.SecretKey,
 		API:       s3Config.Signature,
 		Lookup:    lookup,
+		Endpoints: endpoints,
+		Failover:  failover,
 	}) // Add a host with specified credentials.
 	return nil
 }
@@ -78,6 +78,8 @@ func mainConfigHostList(ctx *cli.Context) error {
 	console.SetColor("SecretKey", color.New(color.FgCyan))
 	console.SetColor("API", color.New(color.FgBlue))
 	console.SetColor("Lookup", color.New(color.FgCyan))
+	console.SetColor("Endpoints", color.New(color.FgYellow))
+	console.SetColor("Failover", color.New(color.FgCyan))
 
 	args := ctx.Args()
 	listHosts(args.Get(0)) // List all configured hosts.
@@ -130,6 +132,8 @@ func listHosts(alias string) {
 				SecretKey:   v.SecretKey,
 				API:         v.API,
 				Lookup:      v.Lookup,
+				Endpoints:   v.Endpoints,
+				Failover:    v.Failover,
 			})
 			return
 		}
@@ -147,6 +151,8 @@ func listHosts(alias string) {
 			SecretKey:   v.SecretKey,
 			API:         v.API,
 			Lookup:      v.Lookup,
+			Endpoints:   v.Endpoints,
+			Failover:    v.Failover,
 		})
 	}
 
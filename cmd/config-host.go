@@ -17,6 +17,8 @@
 package cmd
 
 import (
+	"strings"
+
 	"github.com/minio/cli"
 	json "github.com/minio/mc/pkg/colorjson"
 	"github.com/minio/mc/pkg/console"
@@ -48,13 +50,15 @@ func mainConfigHost(ctx *cli.Context) error {
 type hostMessage struct {
 	op          string
 	prettyPrint bool
-	Status      string `json:"status"`
-	Alias       string `json:"alias"`
-	URL         string `json:"URL"`
-	AccessKey   string `json:"accessKey,omitempty"`
-	SecretKey   string `json:"secretKey,omitempty"`
-	API         string `json:"api,omitempty"`
-	Lookup      string `json:"lookup,omitempty"`
+	Status      string   `json:"status"`
+	Alias       string   `json:"alias"`
+	URL         string   `json:"URL"`
+	AccessKey   string   `json:"accessKey,omitempty"`
+	SecretKey   string   `json:"secretKey,omitempty"`
+	API         string   `json:"api,omitempty"`
+	Lookup      string   `json:"lookup,omitempty"`
+	Endpoints   []string `json:"endpoints,omitempty"`
+	Failover    string   `json:"failover,omitempty"`
 }
 
 // Print the config information of one alias, when prettyPrint flag
@@ -64,15 +68,21 @@ func (h hostMessage) String() string {
 	switch h.op {
 	case "list":
 		// Create a new pretty table with cols configuration
-		t := newPrettyRecord(2,
-			Row{"Alias", "Alias"},
-			Row{"URL", "URL"},
-			Row{"AccessKey", "AccessKey"},
-			Row{"SecretKey", "SecretKey"},
-			Row{"API", "API"},
-			Row{"Lookup", "Lookup"},
-		)
-		return t.buildRecord(h.Alias, h.URL, h.AccessKey, h.SecretKey, h.API, h.Lookup)
+		rows := []Row{
+			{"Alias", "Alias"},
+			{"URL", "URL"},
+			{"AccessKey", "AccessKey"},
+			{"SecretKey", "SecretKey"},
+			{"API", "API"},
+			{"Lookup", "Lookup"},
+		}
+		contents := []string{h.Alias, h.URL, h.AccessKey, h.SecretKey, h.API, h.Lookup}
+		if len(h.Endpoints) > 0 {
+			rows = append(rows, Row{"Endpoints", "Endpoints"}, Row{"Failover", "Failover"})
+			contents = append(contents, strings.Join(h.Endpoints, ", "), h.Failover)
+		}
+		t := newPrettyRecord(2, rows...)
+		return t.buildRecord(contents...)
 	case "remove":
 		return console.Colorize("HostMessage", "Removed `"+h.Alias+"` successfully.")
 	case "add":
@@ -42,6 +42,10 @@ var configCmd = cli.Command{
 	Flags:           append(configFlags, globalFlags...),
 	Subcommands: []cli.Command{
 		configHostCmd,
+		configShortcutCmd,
+		configReadOnlyCmd,
+		configProtectCmd,
+		configParallelCmd,
 	},
 }
 
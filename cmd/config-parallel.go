@@ -0,0 +1,108 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var configParallelCmd = cli.Command{
+	Name:            "parallel",
+	Usage:           "set the number of objects mirrored in parallel by default",
+	Action:          mainConfigParallel,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [N]
+
+  'mirror' copies N objects at a time unless '--parallel' is given, up to 1024. With 0 the number
+  of objects copied at a time is scaled with the transfer speed. Without argument, shows the default.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Mirror 256 objects at a time by default, for a link of high latency.
+     $ {{.HelpName}} 256
+
+  2. Scale the number of objects mirrored at a time with the transfer speed again.
+     $ {{.HelpName}} 0
+
+  3. Show the number of objects mirrored in parallel by default.
+     $ {{.HelpName}}
+
+`,
+}
+
+// parallelMessage container for default parallelism messages.
+type parallelMessage struct {
+	Status   string `json:"status"`
+	Parallel int    `json:"parallel"`
+}
+
+// String colorized default parallelism message.
+func (p parallelMessage) String() string {
+	if p.Parallel == 0 {
+		return console.Colorize("ParallelMessage", "Objects mirrored in parallel are scaled with the transfer speed.")
+	}
+	return console.Colorize("ParallelMessage", fmt.Sprintf("%d objects are mirrored in parallel.", p.Parallel))
+}
+
+// JSON jsonified default parallelism message.
+func (p parallelMessage) JSON() string {
+	p.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(p, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// mainConfigParallel is the handle for "mc config parallel" command.
+func mainConfigParallel(ctx *cli.Context) error {
+	args := ctx.Args()
+	if len(args) > 1 {
+		cli.ShowCommandHelpAndExit(ctx, "parallel", 1) // last argument is exit code
+	}
+	console.SetColor("ParallelMessage", color.New(color.FgGreen))
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
+
+	if len(args) == 1 {
+		n, e := strconv.Atoi(args.First())
+		if e != nil || n < 0 || n > maxTransferWorkers {
+			fatalIf(errInvalidArgument().Trace(args.First()),
+				fmt.Sprintf("The number of objects mirrored in parallel must be between 0 and %d.", maxTransferWorkers))
+		}
+		conf.Parallel = n
+		err = saveMcConfig(conf)
+		fatalIf(err.Trace(globalMCConfigVersion), "Unable to update parallel setting in config version `"+globalMCConfigVersion+"`.")
+	}
+
+	printMsg(parallelMessage{Parallel: conf.Parallel})
+	return nil
+}
@@ -0,0 +1,136 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var configProtectFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "remove",
+		Usage: "stop protecting the given prefixes",
+	},
+}
+
+var configProtectCmd = cli.Command{
+	Name:            "protect",
+	Usage:           "refuse destructive flags on aliases and prefixes of this configuration",
+	Action:          mainConfigProtect,
+	Before:          setGlobalsFromContext,
+	Flags:           append(configProtectFlags, globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [--remove] [PATTERN ...]
+
+  'rm --recursive', 'rm --force', 'rb --force' and 'mirror --remove' are refused on paths matching
+  a pattern, on paths under them and on their parents, unless '--i-know-what-i-am-doing' is given.
+  Without argument, shows the protected patterns.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Protect all buckets of alias 'prod'.
+     $ {{.HelpName}} "prod/*"
+
+  2. Protect the buckets of alias 's3' whose name begin with 'backup-'.
+     $ {{.HelpName}} "s3/backup-*"
+
+  3. Stop protecting the buckets of alias 'prod'.
+     $ {{.HelpName}} --remove "prod/*"
+
+  4. Show the protected patterns.
+     $ {{.HelpName}}
+
+`,
+}
+
+// protectMessage container for protected prefixes messages.
+type protectMessage struct {
+	Status   string   `json:"status"`
+	Patterns []string `json:"patterns"`
+}
+
+// String colorized protected prefixes message.
+func (p protectMessage) String() string {
+	if len(p.Patterns) == 0 {
+		return console.Colorize("ProtectMessage", "No prefixes are protected.")
+	}
+	lines := make([]string, 0, len(p.Patterns))
+	for _, pattern := range p.Patterns {
+		lines = append(lines, console.Colorize("ProtectMessage", "Protected `"+pattern+"`."))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// JSON jsonified protected prefixes message.
+func (p protectMessage) JSON() string {
+	p.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(p, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// mainConfigProtect is the handle for "mc config protect" command.
+func mainConfigProtect(ctx *cli.Context) error {
+	args := ctx.Args()
+	if ctx.Bool("remove") && len(args) == 0 {
+		cli.ShowCommandHelpAndExit(ctx, "protect", 1) // last argument is exit code
+	}
+	console.SetColor("ProtectMessage", color.New(color.FgGreen))
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
+
+	if len(args) > 0 {
+		patterns := make(map[string]bool)
+		for _, pattern := range conf.Protected {
+			patterns[pattern] = true
+		}
+		for _, pattern := range args {
+			pattern = strings.TrimSuffix(pattern, "/")
+			if pattern == "" {
+				fatalIf(errInvalidArgument(), "Unable to protect an empty pattern.")
+			}
+			patterns[pattern] = !ctx.Bool("remove")
+		}
+		conf.Protected = nil
+		for pattern, isProtected := range patterns {
+			if isProtected {
+				conf.Protected = append(conf.Protected, pattern)
+			}
+		}
+		sort.Strings(conf.Protected)
+		err = saveMcConfig(conf)
+		fatalIf(err.Trace(globalMCConfigVersion), "Unable to update protected prefixes in config version `"+globalMCConfigVersion+"`.")
+	}
+
+	printMsg(protectMessage{Patterns: conf.Protected})
+	return nil
+}
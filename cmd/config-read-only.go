@@ -0,0 +1,105 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var configReadOnlyCmd = cli.Command{
+	Name:            "read-only",
+	Usage:           "refuse requests changing servers with the aliases of this configuration",
+	Action:          mainConfigReadOnly,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [on | off]
+
+  Uploads, removals and changes of policies, notifications and server settings are refused,
+  listing and reading objects are not. Without argument, shows whether read-only mode is on.
+  Use the global flag '--read-only' for a single command instead.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Make the profile 'audit' read-only, every command using it only reads from servers.
+     $ mc --profile audit {{.HelpName}} on
+
+  2. Show whether read-only mode is on.
+     $ {{.HelpName}}
+
+`,
+}
+
+// readOnlyMessage container for read-only mode messages.
+type readOnlyMessage struct {
+	Status   string `json:"status"`
+	ReadOnly bool   `json:"readOnly"`
+}
+
+// String colorized read-only mode message.
+func (r readOnlyMessage) String() string {
+	if r.ReadOnly {
+		return console.Colorize("ReadOnlyMessage", "Read-only mode is on.")
+	}
+	return console.Colorize("ReadOnlyMessage", "Read-only mode is off.")
+}
+
+// JSON jsonified read-only mode message.
+func (r readOnlyMessage) JSON() string {
+	r.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// mainConfigReadOnly is the handle for "mc config read-only" command.
+func mainConfigReadOnly(ctx *cli.Context) error {
+	args := ctx.Args()
+	if len(args) > 1 {
+		cli.ShowCommandHelpAndExit(ctx, "read-only", 1) // last argument is exit code
+	}
+	console.SetColor("ReadOnlyMessage", color.New(color.FgGreen))
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
+
+	if len(args) == 1 {
+		switch args.First() {
+		case "on":
+			conf.ReadOnly = true
+		case "off":
+			conf.ReadOnly = false
+		default:
+			cli.ShowCommandHelpAndExit(ctx, "read-only", 1) // last argument is exit code
+		}
+		err = saveMcConfig(conf)
+		fatalIf(err.Trace(globalMCConfigVersion), "Unable to update read-only setting in config version `"+globalMCConfigVersion+"`.")
+	}
+
+	printMsg(readOnlyMessage{ReadOnly: conf.ReadOnly})
+	return nil
+}
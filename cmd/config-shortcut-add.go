@@ -0,0 +1,99 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var configShortcutAddCmd = cli.Command{
+	Name:            "add",
+	Usage:           "add a new command shortcut to configuration file",
+	Action:          mainConfigShortcutAdd,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} NAME COMMAND
+
+COMMAND:
+  Command line run by 'mc NAME', quoted as in a shell. Arguments given to
+  'mc NAME' are appended to it.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Add a shortcut "backup" mirroring /data to Amazon S3, run it with 'mc backup'.
+     $ {{.HelpName}} backup "mirror --overwrite --remove --exclude '*.tmp' /data s3/backup"
+
+  2. Add a shortcut "logs" listing the logs bucket, 'mc logs --recursive' lists it recursively.
+     $ {{.HelpName}} logs "ls s3/logs"
+
+`,
+}
+
+// checkConfigShortcutAddSyntax - verifies input arguments to 'config shortcut add'.
+func checkConfigShortcutAddSyntax(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) != 2 {
+		fatalIf(errInvalidArgument().Trace(args...),
+			"Incorrect number of arguments for shortcut add command.")
+	}
+
+	name, command := args.Get(0), args.Get(1)
+	if !isValidAlias(name) {
+		fatalIf(errInvalidArgument().Trace(name), "Invalid shortcut name `"+name+"`.")
+	}
+	if isBuiltinCommand(commands, name) {
+		fatalIf(errInvalidArgument().Trace(name), "Shortcut `"+name+"` would be hidden by the `"+name+"` command.")
+	}
+	shortcutArgs, err := splitShortcut(command)
+	fatalIf(err.Trace(command), "Unable to parse the command of shortcut `"+name+"`.")
+	if len(shortcutArgs) == 0 || !isBuiltinCommand(commands, shortcutArgs[0]) {
+		fatalIf(errInvalidArgument().Trace(command), "Shortcuts must run a command of mc, `"+command+"` does not.")
+	}
+}
+
+// mainConfigShortcutAdd is the handle for "mc config shortcut add" command.
+func mainConfigShortcutAdd(ctx *cli.Context) error {
+	checkConfigShortcutAddSyntax(ctx)
+
+	console.SetColor("ShortcutMessage", color.New(color.FgGreen))
+
+	args := ctx.Args()
+	name, command := args.Get(0), args.Get(1)
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
+
+	if conf.Shortcuts == nil {
+		conf.Shortcuts = make(map[string]string)
+	}
+	conf.Shortcuts[name] = command
+
+	err = saveMcConfig(conf)
+	fatalIf(err.Trace(name), "Unable to update shortcuts in config version `"+globalMCConfigVersion+"`.")
+
+	printMsg(shortcutMessage{op: "add", Name: name, Command: command})
+	return nil
+}
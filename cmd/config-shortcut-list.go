@@ -0,0 +1,84 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var configShortcutListCmd = cli.Command{
+	Name:            "list",
+	ShortName:       "ls",
+	Usage:           "list command shortcuts in configuration file",
+	Action:          mainConfigShortcutList,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [NAME]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. List all shortcuts.
+     $ {{.HelpName}}
+
+  2. Show the command run by the shortcut "backup".
+     $ {{.HelpName}} backup
+
+`,
+}
+
+// mainConfigShortcutList is the handle for "mc config shortcut list" command.
+func mainConfigShortcutList(ctx *cli.Context) error {
+	args := ctx.Args()
+	if len(args) > 1 {
+		fatalIf(errInvalidArgument().Trace(args...),
+			"Incorrect number of arguments for shortcut list command.")
+	}
+
+	console.SetColor("ShortcutName", color.New(color.FgCyan, color.Bold))
+	console.SetColor("ShortcutCommand", color.New(color.FgWhite))
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
+
+	var names []string
+	if len(args) == 1 {
+		if _, ok := conf.Shortcuts[args.Get(0)]; !ok {
+			fatalIf(errInvalidArgument().Trace(args.Get(0)), "No shortcut named `"+args.Get(0)+"`.")
+		}
+		names = append(names, args.Get(0))
+	} else {
+		for name := range conf.Shortcuts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	for _, name := range names {
+		printMsg(shortcutMessage{op: "list", Name: name, Command: conf.Shortcuts[name]})
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var configShortcutRemoveCmd = cli.Command{
+	Name:            "remove",
+	ShortName:       "rm",
+	Usage:           "remove a command shortcut from configuration file",
+	Action:          mainConfigShortcutRemove,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} NAME
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Remove the shortcut "backup" from config.
+     $ {{.HelpName}} backup
+
+`,
+}
+
+// mainConfigShortcutRemove is the handle for "mc config shortcut remove" command.
+func mainConfigShortcutRemove(ctx *cli.Context) error {
+	args := ctx.Args()
+	if len(args) != 1 {
+		fatalIf(errInvalidArgument().Trace(args...),
+			"Incorrect number of arguments for shortcut remove command.")
+	}
+
+	console.SetColor("ShortcutMessage", color.New(color.FgGreen))
+
+	name := args.Get(0)
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
+
+	if _, ok := conf.Shortcuts[name]; !ok {
+		fatalIf(errInvalidArgument().Trace(name), "No shortcut named `"+name+"`.")
+	}
+	delete(conf.Shortcuts, name)
+
+	err = saveMcConfig(conf)
+	fatalIf(err.Trace(name), "Unable to update shortcuts in config version `"+globalMCConfigVersion+"`.")
+
+	printMsg(shortcutMessage{op: "remove", Name: name})
+	return nil
+}
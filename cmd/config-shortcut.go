@@ -0,0 +1,226 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var configShortcutCmd = cli.Command{
+	Name:   "shortcut",
+	Usage:  "add, list and remove command shortcuts in configuration file",
+	Action: mainConfigShortcut,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	Subcommands: []cli.Command{
+		configShortcutAddCmd,
+		configShortcutRemoveCmd,
+		configShortcutListCmd,
+	},
+	HideHelpCommand: true,
+}
+
+// mainConfigShortcut is the handle for "mc config shortcut" command.
+func mainConfigShortcut(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "add", "remove", "list" have their own main.
+}
+
+// shortcutMessage container for content message structure
+type shortcutMessage struct {
+	op      string
+	Status  string `json:"status"`
+	Name    string `json:"name"`
+	Command string `json:"command,omitempty"`
+}
+
+// String colorized shortcut message.
+func (s shortcutMessage) String() string {
+	switch s.op {
+	case "list":
+		return console.Colorize("ShortcutName", s.Name) + " = " + console.Colorize("ShortcutCommand", s.Command)
+	case "remove":
+		return console.Colorize("ShortcutMessage", "Removed `"+s.Name+"` successfully.")
+	case "add":
+		return console.Colorize("ShortcutMessage", "Added `"+s.Name+"` successfully.")
+	default:
+		return ""
+	}
+}
+
+// JSON jsonified shortcut message.
+func (s shortcutMessage) JSON() string {
+	s.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// isBuiltinCommand - reports if name is one of cmds.
+func isBuiltinCommand(cmds []cli.Command, name string) bool {
+	for _, cmd := range cmds {
+		if cmd.Name == name || cmd.ShortName == name {
+			return true
+		}
+		for _, alias := range cmd.Aliases {
+			if alias == name {
+				return true
+			}
+		}
+	}
+	return name == "help"
+}
+
+// splitShortcut - splits the command line of a shortcut into arguments
+// the way a POSIX shell does, with single and double quotes and
+// backslash escapes. Nothing else is expanded.
+func splitShortcut(command string) ([]string, *probe.Error) {
+	var args []string
+	var arg strings.Builder
+	inArg, quote, escaped := false, rune(0), false
+	for _, r := range command {
+		switch {
+		case escaped:
+			arg.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, probe.NewError(errors.New("Unterminated quote or escape in shortcut"))
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
+
+// isBoolGlobalFlag - reports if the global flag named name takes no value.
+func isBoolGlobalFlag(name string) bool {
+	switch name {
+	case "help", "h", "version", "v":
+		return true
+	}
+	for _, flag := range append(mcFlags, globalFlags...) {
+		boolFlag, ok := flag.(cli.BoolFlag)
+		if !ok {
+			continue
+		}
+		for _, flagName := range strings.Split(boolFlag.Name, ",") {
+			if strings.TrimSpace(flagName) == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// expandShortcut - replaces the command of args by the command line of
+// the shortcut of the same name, the arguments given after it are
+// appended. Commands of mc always take precedence over shortcuts and
+// args are returned as they are if the configuration cannot be read.
+func expandShortcut(args []string) []string {
+	var configDir, profile string
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			if isBuiltinCommand(appCmds, arg) {
+				return args
+			}
+			return expandShortcutAt(args, i, configDir, profile)
+		}
+		name := strings.TrimLeft(arg, "-")
+		if j := strings.Index(name, "="); j >= 0 {
+			switch name[:j] {
+			case "config-dir", "C":
+				configDir = name[j+1:]
+			case "profile":
+				profile = name[j+1:]
+			}
+			continue
+		}
+		if !isBoolGlobalFlag(name) && i+1 < len(args) {
+			switch name {
+			case "config-dir", "C":
+				configDir = args[i+1]
+			case "profile":
+				profile = args[i+1]
+			}
+			i++
+		}
+	}
+	return args
+}
+
+// expandShortcutAt - expands args[i] if it is a shortcut of the profile
+// in use.
+func expandShortcutAt(args []string, i int, configDir, profile string) []string {
+	if configDir == "" {
+		configDir = mustGetMcConfigDir()
+	}
+	profile, err := resolveProfile(configDir, profile)
+	if err != nil {
+		return args
+	}
+	setMcConfigDir(getProfileDir(configDir, profile))
+	defer setMcConfigDir("")
+
+	if !isMcConfigExists() {
+		return args
+	}
+	config, err := loadMcConfigFactory()()
+	if err != nil {
+		return args
+	}
+	command, ok := config.Shortcuts[args[i]]
+	if !ok {
+		return args
+	}
+	shortcutArgs, err := splitShortcut(command)
+	if err != nil {
+		return args
+	}
+	expanded := append([]string{}, args[:i]...)
+	expanded = append(expanded, shortcutArgs...)
+	return append(expanded, args[i+1:]...)
+}
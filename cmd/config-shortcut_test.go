@@ -0,0 +1,46 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitShortcut(t *testing.T) {
+	testCases := []struct {
+		command string
+		args    []string
+		success bool
+	}{
+		{"ls s3/logs", []string{"ls", "s3/logs"}, true},
+		{"mirror --exclude '*.tmp' /data  s3/backup", []string{"mirror", "--exclude", "*.tmp", "/data", "s3/backup"}, true},
+		{`cp "my file.txt" s3/docs/`, []string{"cp", "my file.txt", "s3/docs/"}, true},
+		{`cp my\ file.txt ''`, []string{"cp", "my file.txt", ""}, true},
+		{`cp 'it''s' s3/docs/`, []string{"cp", "its", "s3/docs/"}, true},
+		{"cp 'unterminated", nil, false},
+	}
+	for i, testCase := range testCases {
+		args, err := splitShortcut(testCase.command)
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %t, got %v", i+1, testCase.success, err)
+		}
+		if testCase.success && !reflect.DeepEqual(args, testCase.args) {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.args, args)
+		}
+	}
+}
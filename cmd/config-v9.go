@@ -42,12 +42,32 @@ type hostConfigV9 struct {
 	SecretKey string `json:"secretKey"`
 	API       string `json:"api"`
 	Lookup    string `json:"lookup"`
+
+	// Endpoints serving the same data with the same credentials as URL,
+	// used as configured by Failover.
+	Endpoints []string `json:"endpoints,omitempty"`
+	Failover  string   `json:"failover,omitempty"`
 }
 
 // configV8 config version.
 type configV9 struct {
 	Version string                  `json:"version"`
 	Hosts   map[string]hostConfigV9 `json:"hosts"`
+
+	// Command lines run by 'mc NAME', see 'mc config shortcut'.
+	Shortcuts map[string]string `json:"shortcuts,omitempty"`
+
+	// Record mutating requests in the audit log, see 'mc audit'.
+	Audit bool `json:"audit,omitempty"`
+
+	// Refuse mutating requests, see 'mc config read-only'.
+	ReadOnly bool `json:"readOnly,omitempty"`
+
+	// Refuse destructive flags on these prefixes, see 'mc config protect'.
+	Protected []string `json:"protected,omitempty"`
+
+	// Objects mirrored in parallel by default, see 'mc config parallel'.
+	Parallel int `json:"parallel,omitempty"`
 }
 
 // newConfigV9 - new config version.
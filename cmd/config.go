@@ -23,6 +23,8 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"sort"
+	"strings"
 
 	"github.com/minio/mc/pkg/probe"
 
@@ -279,6 +281,16 @@ func expandAliasFromEnv(envURL string) (*hostConfigV9, *probe.Error) {
 
 // expandAlias expands aliased URL if any match is found, returns as is otherwise.
 func expandAlias(aliasedURL string) (alias string, urlStr string, hostCfg *hostConfigV9, err *probe.Error) {
+	// Anonymous requests may name any server by its URL.
+	if globalAnonymous && urlRgx.MatchString(aliasedURL) {
+		return anonymousAlias(aliasedURL)
+	}
+
+	// Buckets and objects may be named by their s3://, gs:// or minio:// URL.
+	if schemeURLRgx.MatchString(aliasedURL) {
+		return schemeAlias(aliasedURL)
+	}
+
 	// Extract alias from the URL.
 	alias, path := url2Alias(aliasedURL)
 
@@ -307,6 +319,144 @@ func expandAlias(aliasedURL string) (alias string, urlStr string, hostCfg *hostC
 	return "", aliasedURL, nil, nil // No matching entry found. Return original URL as is.
 }
 
+// anonymousAlias - expands the URL urlStr of a server without an alias,
+// its scheme and host are used as alias.
+func anonymousAlias(urlStr string) (alias string, expandedURL string, hostCfg *hostConfigV9, err *probe.Error) {
+	u, e := url.Parse(urlStr)
+	if e != nil || u.Host == "" {
+		return "", "", nil, errInvalidAliasedURL(urlStr).Trace(urlStr)
+	}
+	alias = u.Scheme + "://" + u.Host
+	return alias, urlStr, &hostConfigV9{URL: alias, API: "S3v4", Lookup: "auto"}, nil
+}
+
+// schemeURLRgx - verify if aliased url is a URL of a bucket or object in
+// the s3://bucket/key, gs://bucket/key or minio://host/bucket/key scheme,
+// with its double slash possibly cleaned into one by path joins.
+var schemeURLRgx = regexp.MustCompile("^(s3|gs|minio):/")
+
+// parseSchemeURL - splits the URL urlStr matching schemeURLRgx into its
+// scheme, host for minio:// and bucket/key path. Its scheme, followed by
+// the host for minio://, is used as alias so that alias and path of
+// listed contents join back into the URL.
+func parseSchemeURL(urlStr string) (scheme, alias, host, path string, err *probe.Error) {
+	i := strings.Index(urlStr, ":/")
+	scheme, path = urlStr[:i], strings.TrimLeft(urlStr[i+2:], "/")
+	if scheme != "minio" {
+		return scheme, scheme + ":/", "", path, nil
+	}
+	parts := strings.SplitN(path, "/", 2)
+	if parts[0] == "" {
+		return "", "", "", "", errInvalidAliasedURL(urlStr).Trace(urlStr)
+	}
+	host, path = parts[0], ""
+	if len(parts) == 2 {
+		path = parts[1]
+	}
+	return scheme, scheme + "://" + host, host, path, nil
+}
+
+// servesScheme - returns true if the server at hostURL serves the URLs of
+// scheme, Amazon S3 for s3://, Google Cloud Storage for gs:// and the
+// server at host for minio://.
+func servesScheme(scheme, host, hostURL string) bool {
+	u, e := url.Parse(hostURL)
+	if e != nil {
+		return false
+	}
+	switch scheme {
+	case "s3":
+		return isAmazon(u.Host)
+	case "gs":
+		return isGoogle(u.Host)
+	}
+	return u.Host == host
+}
+
+// schemeEnvHost - returns the config of the server of scheme with the
+// credentials of the environment variables of its tools, nil if they
+// are not set.
+func schemeEnvHost(scheme, host string) *hostConfigV9 {
+	var cfg hostConfigV9
+	switch scheme {
+	case "s3":
+		cfg = hostConfigV9{
+			URL:       "https://s3.amazonaws.com",
+			AccessKey: os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			API:       "S3v4",
+			Lookup:    "dns",
+		}
+	case "gs":
+		cfg = hostConfigV9{
+			URL:       "https://storage.googleapis.com",
+			AccessKey: os.Getenv("GOOGLE_ACCESS_KEY_ID"),
+			SecretKey: os.Getenv("GOOGLE_SECRET_ACCESS_KEY"),
+			API:       "S3v2",
+			Lookup:    "dns",
+		}
+	default:
+		cfg = hostConfigV9{
+			URL:       "https://" + host,
+			AccessKey: os.Getenv("MINIO_ACCESS_KEY"),
+			SecretKey: os.Getenv("MINIO_SECRET_KEY"),
+			API:       "S3v4",
+			Lookup:    "auto",
+		}
+	}
+	if cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil
+	}
+	return &cfg
+}
+
+// schemeAlias - expands the URL urlStr matching schemeURLRgx with the
+// first alias serving it, in alphabetical order, of the MC_HOST_<alias>
+// environment variables first and of the config next. Aliases left with
+// the credentials of the default config are skipped. Without any, the
+// credentials are taken from the environment variables of the scheme.
+func schemeAlias(urlStr string) (alias string, expandedURL string, hostCfg *hostConfigV9, err *probe.Error) {
+	scheme, alias, host, path, err := parseSchemeURL(urlStr)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	var envHosts []string
+	for _, env := range os.Environ() {
+		if strings.HasPrefix(env, mcEnvHostPrefix) {
+			envHosts = append(envHosts, env)
+		}
+	}
+	sort.Strings(envHosts)
+	for _, env := range envHosts {
+		envCfg, envErr := expandAliasFromEnv(env[strings.Index(env, "=")+1:])
+		if envErr == nil && servesScheme(scheme, host, envCfg.URL) {
+			return alias, urlJoinPath(envCfg.URL, path), envCfg, nil
+		}
+	}
+
+	if mcCfg, cfgErr := loadMcConfig(); cfgErr == nil {
+		var aliases []string
+		for name := range mcCfg.Hosts {
+			aliases = append(aliases, name)
+		}
+		sort.Strings(aliases)
+		for _, name := range aliases {
+			cfg := mcCfg.Hosts[name]
+			if cfg.AccessKey == defaultAccessKey {
+				continue
+			}
+			if servesScheme(scheme, host, cfg.URL) {
+				return alias, urlJoinPath(cfg.URL, path), &cfg, nil
+			}
+		}
+	}
+	if envCfg := schemeEnvHost(scheme, host); envCfg != nil {
+		return alias, urlJoinPath(envCfg.URL, path), envCfg, nil
+	}
+	return "", "", nil, errNoMatchingHost(urlStr).Trace(urlStr)
+}
+
 // mustExpandAlias expands aliased URL if any match is found, returns as is otherwise.
 func mustExpandAlias(aliasedURL string) (alias string, urlStr string, hostCfg *hostConfigV9) {
 	alias, urlStr, hostCfg, _ = expandAlias(aliasedURL)
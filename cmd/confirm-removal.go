@@ -0,0 +1,110 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	isatty "github.com/mattn/go-isatty"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Removals of more objects than this require a confirmation by default.
+const defaultConfirmThreshold = 1000
+
+// Flags of commands removing many objects at once.
+var removalConfirmFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "yes, y",
+		Usage: "remove without asking for confirmation",
+	},
+	cli.IntFlag{
+		Name:  "confirm-threshold",
+		Usage: "ask for confirmation before removing more than N objects, 0 always asks",
+		Value: defaultConfirmThreshold,
+	},
+}
+
+// removalPreview - objects that would be removed, reported before
+// asking for a confirmation.
+type removalPreview struct {
+	count int64
+	size  int64
+}
+
+// add - accounts an object to remove.
+func (p *removalPreview) add(content *clientContent) {
+	p.count++
+	p.size += content.Size
+}
+
+// skipConfirmation - reports if removals go on without a confirmation,
+// no preview is needed then.
+func skipConfirmation(ctx *cli.Context) bool {
+	return ctx.Bool("yes") || ctx.Bool("fake")
+}
+
+// previewRecursiveRemoval - counts the objects a recursive remove of
+// clnt would remove.
+func previewRecursiveRemoval(clnt Client, isIncomplete bool, olderThan, newerThan string) (removalPreview, *probe.Error) {
+	var preview removalPreview
+	for content := range clnt.List(true, isIncomplete, DirLast) {
+		if content.Err != nil {
+			switch content.Err.ToGoError().(type) {
+			case PathInsufficientPermission:
+				continue
+			}
+			return preview, content.Err.Trace(clnt.GetURL().String())
+		}
+		if !content.Time.IsZero() {
+			if olderThan != "" && isOlder(content.Time, olderThan) {
+				continue
+			}
+			if newerThan != "" && isNewer(content.Time, newerThan) {
+				continue
+			}
+		}
+		preview.add(content)
+	}
+	return preview, nil
+}
+
+// confirmRemoval - shows the preview of a removal above the threshold
+// and asks to type the target to go on, such removals are refused when
+// no one can answer.
+func confirmRemoval(ctx *cli.Context, target string, preview removalPreview, canPrompt bool) {
+	if preview.count <= int64(ctx.Int("confirm-threshold")) {
+		return
+	}
+	summary := fmt.Sprintf("%d objects (%s) are going to be removed from `%s`.",
+		preview.count, humanize.IBytes(uint64(preview.size)), target)
+	if !canPrompt || globalJSON || !isatty.IsTerminal(os.Stdin.Fd()) {
+		fatalIf(errDummy().Trace(target), summary+" Please review carefully and retry this command with ‘--yes’ flag.")
+	}
+	console.Infoln(summary)
+	console.Infof("Type `%s` to confirm: ", target)
+	answer, e := bufio.NewReader(os.Stdin).ReadString('\n')
+	if e != nil || strings.TrimSpace(answer) != target {
+		fatalIf(errDummy().Trace(target), "Removal of `"+target+"` was not confirmed.")
+	}
+}
@@ -0,0 +1,103 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Content encodings supported for on the fly compression.
+const contentEncodingGzip = "gzip"
+
+// mcOriginalSizeMetaKey - user metadata holding the size of an object
+// before it was compressed on upload.
+const mcOriginalSizeMetaKey = "X-Amz-Meta-Mc-Original-Size"
+
+// checkContentEncoding - validates the value passed to --compress.
+func checkContentEncoding(encoding string) *probe.Error {
+	switch encoding {
+	case "", contentEncodingGzip:
+		return nil
+	case "zstd":
+		return probe.NewError(errors.New("zstd compression is not supported yet, please use gzip"))
+	}
+	return probe.NewError(fmt.Errorf("Unknown content encoding `%s`", encoding))
+}
+
+// newCompressReader - returns the gzip compressed stream of r, the
+// compression runs until r is exhausted or the returned reader is closed.
+func newCompressReader(r io.Reader) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		gw := gzip.NewWriter(pw)
+		_, e := io.Copy(gw, r)
+		if e == nil {
+			e = gw.Close()
+		}
+		pw.CloseWithError(e)
+	}()
+	return pr
+}
+
+// newDecompressReader - returns the decoded stream of r if the content
+// encoding is supported, ok is false otherwise.
+func newDecompressReader(r io.Reader, encoding string) (reader io.ReadCloser, ok bool, err *probe.Error) {
+	if encoding != contentEncodingGzip {
+		return nil, false, nil
+	}
+	gr, e := gzip.NewReader(r)
+	if e != nil {
+		return nil, false, probe.NewError(e)
+	}
+	return gr, true, nil
+}
+
+// isDecompressedCopy - reports if source and target, of different sizes,
+// are the same content compressed on one side only. The object among
+// them holds the size it had before its compression, the local file
+// was copied from or to it unless older.
+func isDecompressedCopy(sourceAlias, targetAlias string, source, target *clientContent, encKeyDB map[string][]prefixSSEPair) bool {
+	if source.Time.After(target.Time) {
+		return false
+	}
+	object, objectAlias, file := target, targetAlias, source
+	switch {
+	case source.URL.Type == fileSystem && target.URL.Type == objectStorage:
+	case source.URL.Type == objectStorage && target.URL.Type == fileSystem:
+		object, objectAlias, file = source, sourceAlias, target
+	default:
+		return false
+	}
+	clnt, err := newClientFromAlias(objectAlias, object.URL.String())
+	if err != nil {
+		return false
+	}
+	sse := getSSE(filepath.ToSlash(filepath.Join(objectAlias, clnt.GetURL().Path)), encKeyDB[objectAlias])
+	st, err := clnt.Stat(false, true, sse)
+	if err != nil || st.Metadata["Content-Encoding"] == "" {
+		return false
+	}
+	size, e := strconv.ParseInt(st.Metadata[mcOriginalSizeMetaKey], 10, 64)
+	return e == nil && size == file.Size
+}
@@ -0,0 +1,122 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	humanize "github.com/dustin/go-humanize"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+const (
+	// Objects of this size and larger are uploaded in parts.
+	estimatePartSize = 64 * humanize.MiByte
+
+	// Maximum number of parts of an upload.
+	estimateMaxParts = 10000
+
+	// Objects returned by a listing request.
+	estimateListPageSize = 1000
+)
+
+// parsePrice - parses a price given on the command line.
+func parsePrice(price string) (float64, *probe.Error) {
+	if price == "" {
+		return 0, nil
+	}
+	f, e := strconv.ParseFloat(price, 64)
+	if e != nil {
+		return 0, probe.NewError(e).Trace(price)
+	}
+	if f < 0 {
+		return 0, errInvalidArgument().Trace(price)
+	}
+	return f, nil
+}
+
+// estimateRequests - returns the number of requests needed to copy an
+// object of the given size: a single PUT, or initiating, uploading
+// each part and completing a multipart upload.
+func estimateRequests(size int64) int64 {
+	if size < estimatePartSize {
+		return 1
+	}
+	partSize := int64(estimatePartSize)
+	for size > partSize*estimateMaxParts {
+		partSize += estimatePartSize
+	}
+	parts := (size + partSize - 1) / partSize
+	return parts + 2
+}
+
+// costEstimate - accumulates the cost of the objects of a planned copy.
+type costEstimate struct {
+	pricePerGB         float64
+	pricePer1KRequests float64
+
+	objects  int64
+	bytes    int64
+	requests int64
+}
+
+// add - accounts an object of the given size.
+func (c *costEstimate) add(size int64) {
+	c.objects++
+	c.bytes += size
+	c.requests += estimateRequests(size)
+}
+
+// message - returns the estimate, listing requests included.
+func (c *costEstimate) message() costEstimateMessage {
+	requests := c.requests + (c.objects+estimateListPageSize-1)/estimateListPageSize
+	cost := float64(c.bytes)/float64(humanize.GiByte)*c.pricePerGB +
+		float64(requests)/1000*c.pricePer1KRequests
+	return costEstimateMessage{
+		Objects:  c.objects,
+		Bytes:    c.bytes,
+		Requests: requests,
+		Cost:     cost,
+	}
+}
+
+// costEstimateMessage container for the estimated cost of a copy.
+type costEstimateMessage struct {
+	Status   string  `json:"status"`
+	Objects  int64   `json:"objects"`
+	Bytes    int64   `json:"bytes"`
+	Requests int64   `json:"requests"`
+	Cost     float64 `json:"cost"`
+}
+
+// String colorized cost estimate message.
+func (c costEstimateMessage) String() string {
+	return console.Colorize("Copy", fmt.Sprintf("Estimated cost: %.2f for %d object(s), %s and about %d request(s).",
+		c.Cost, c.Objects, humanize.IBytes(uint64(c.Bytes)), c.Requests))
+}
+
+// JSON jsonified cost estimate message.
+func (c costEstimateMessage) JSON() string {
+	c.Status = "success"
+	costJSONBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(costJSONBytes)
+}
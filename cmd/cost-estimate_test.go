@@ -0,0 +1,42 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+func TestEstimateRequests(t *testing.T) {
+	testCases := []struct {
+		size     int64
+		requests int64
+	}{
+		{0, 1},
+		{humanize.MiByte, 1},
+		{64 * humanize.MiByte, 3},
+		{100 * humanize.MiByte, 4},
+		// 1 TiB needs 128 MiB parts to fit in 10000 parts.
+		{humanize.TiByte, 8194},
+	}
+	for i, testCase := range testCases {
+		if requests := estimateRequests(testCase.size); requests != testCase.requests {
+			t.Errorf("Test %d: expected %d, got %d", i+1, testCase.requests, requests)
+		}
+	}
+}
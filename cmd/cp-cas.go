@@ -0,0 +1,134 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// casMessage container for objects copied to a content-addressed store.
+type casMessage struct {
+	Status  string `json:"status"`
+	Source  string `json:"source"`
+	Target  string `json:"target"`
+	SHA256  string `json:"sha256"`
+	Size    int64  `json:"size"`
+	Skipped bool   `json:"skipped"`
+}
+
+// String colorized content-addressed copy message.
+func (c casMessage) String() string {
+	if c.Skipped {
+		return console.Colorize("Copy", fmt.Sprintf("`%s` exists as `%s`, skipped.", printableKey(c.Source), printableKey(c.Target)))
+	}
+	return console.Colorize("Copy", fmt.Sprintf("`%s` -> `%s`", printableKey(c.Source), printableKey(c.Target)))
+}
+
+// JSON jsonified content-addressed copy message.
+func (c casMessage) JSON() string {
+	c.Status = "success"
+	casMessageBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(casMessageBytes)
+}
+
+// casKey - returns the key of content of SHA-256 sum under a
+// content-addressed store, its first two hex digits name a prefix.
+func casKey(sum string) string {
+	return sum[:2] + "/" + sum[2:]
+}
+
+// sha256Sum - returns the SHA-256 of the object at the aliased URL
+// urlStr, in hex.
+func sha256Sum(urlStr string, encKeyDB map[string][]prefixSSEPair) (string, *probe.Error) {
+	reader, err := getSourceStreamFromURL(urlStr, encKeyDB)
+	if err != nil {
+		return "", err.Trace(urlStr)
+	}
+	defer reader.Close()
+	h := sha256.New()
+	if _, e := io.Copy(h, reader); e != nil {
+		return "", probe.NewError(e).Trace(urlStr)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// doContentAddressed - copies sources under targetURL named by their
+// SHA-256, sources already stored are not uploaded again.
+func doContentAddressed(sourceURLs []string, targetURL string, isRecursive bool, olderThan, newerThan string, keyEnc keyEncoder, specials *specialFiles, encKeyDB map[string][]prefixSSEPair) error {
+	var retErr error
+	for cpURLs := range prepareCopyURLs(sourceURLs, targetURL, isRecursive, false, 0, time.Time{}, keyEnc, encKeyDB) {
+		if specials.skip(cpURLs.Error) {
+			continue
+		}
+		if cpURLs.Error != nil {
+			errorIf(cpURLs.Error.Trace(), "Unable to prepare URL for copying.")
+			retErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		source := cpURLs.SourceContent
+		if olderThan != "" && isOlder(source.Time, olderThan) {
+			continue
+		}
+		if newerThan != "" && isNewer(source.Time, newerThan) {
+			continue
+		}
+
+		sourcePath := filepath.ToSlash(filepath.Join(cpURLs.SourceAlias, source.URL.Path))
+		sum, err := sha256Sum(sourcePath, encKeyDB)
+		if err != nil {
+			errorIf(err.Trace(sourcePath), "Unable to read `"+sourcePath+"`.")
+			retErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		casURL := urlJoinPath(targetURL, casKey(sum))
+		msg := casMessage{Source: sourcePath, Target: casURL, SHA256: sum, Size: source.Size}
+
+		// Objects are named by their content, an object found is the
+		// same as its source.
+		if _, _, err = url2Stat(casURL, false, encKeyDB); err == nil {
+			msg.Skipped = true
+			printMsg(msg)
+			continue
+		}
+
+		targetAlias, expandedURL, _ := mustExpandAlias(casURL)
+		cpURLs.TargetAlias = targetAlias
+		cpURLs.TargetContent = &clientContent{URL: *newClientURL(expandedURL)}
+		if err = uploadSourceToTargetURL(context.Background(), cpURLs, nil, uploadOptions{}, encKeyDB).Error; err != nil {
+			errorIf(err.Trace(sourcePath, casURL), "Failed to copy `"+sourcePath+"`.")
+			retErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		printMsg(msg)
+	}
+	if msg := specials.message(); msg.Skipped > 0 {
+		printMsg(msg)
+	}
+	return retErr
+}
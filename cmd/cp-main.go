@@ -27,6 +27,7 @@ import (
 	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
@@ -49,10 +50,18 @@ var (
 			Name:  "newer-than",
 			Usage: "copy objects newer than L days, M hours and N minutes",
 		},
+		cli.StringFlag{
+			Name:  "at",
+			Usage: "copy objects of a versioned bucket as they were at a date or an RFC3339 time, e.g. '2024-03-01T00:00:00Z'",
+		},
 		cli.StringFlag{
 			Name:  "storage-class, sc",
 			Usage: "set storage class for new object(s) on target",
 		},
+		cli.StringFlag{
+			Name:  "acl",
+			Usage: "apply a canned ACL to new object(s) on target, e.g. 'bucket-owner-full-control'",
+		},
 		cli.StringFlag{
 			Name:  "encrypt",
 			Usage: "encrypt/decrypt objects (using server-side encryption with server managed keys)",
@@ -61,6 +70,103 @@ var (
 			Name:  "attr",
 			Usage: "add custom metadata for the object",
 		},
+		cli.StringFlag{
+			Name:  "cache",
+			Usage: "serve remote objects from a local cache kept for L days, M hours and N minutes",
+		},
+		cli.StringFlag{
+			Name:  "compress",
+			Usage: "compress uploads on the fly and set their Content-Encoding, only 'gzip' is supported",
+		},
+		cli.BoolFlag{
+			Name:  "no-decompress",
+			Usage: "do not decompress encoded objects written to the local filesystem",
+		},
+		cli.StringFlag{
+			Name:  "filter",
+			Usage: "pipe the body of each object through a command, its output is transferred instead",
+		},
+		cli.StringFlag{
+			Name:  "checksum",
+			Usage: "send a 'crc32', 'crc32c', 'sha1' or 'sha256' checksum with uploads smaller than 64MiB for the server to verify",
+		},
+		cli.StringFlag{
+			Name:  "limit-upload",
+			Usage: "limit the bandwidth of uploads to object storage, e.g. '10MiB/s', or by target alias, e.g. 'dr=5MiB/s,100MiB/s'",
+		},
+		cli.StringFlag{
+			Name:  "limit-download",
+			Usage: "limit the bandwidth of downloads from object storage, e.g. '10MiB/s'",
+		},
+		cli.StringFlag{
+			Name:  "limit-schedule",
+			Usage: "limit the bandwidth of transfers from or to object storage by local time of day, e.g. '08:00-18:00=5MiB,18:00-08:00=0', 0 is unlimited",
+		},
+		cli.StringFlag{
+			Name:  "max-duration",
+			Usage: "stop starting new copies after this long, e.g. '4h', the session is saved to be resumed",
+		},
+		cli.StringFlag{
+			Name:  "max-bytes",
+			Usage: "stop before copying more than this many bytes, e.g. '500GiB', the session is saved to be resumed",
+		},
+		cli.BoolFlag{
+			Name:  "no-target-dir",
+			Usage: "copy the contents of source folders into target, as if given with a trailing slash",
+		},
+		cli.StringFlag{
+			Name:  "overwrite-mode",
+			Usage: "existing object(s) on target to replace: 'never', 'always', 'if-newer' or 'if-different', always by default",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "only print the source and target of each copy, without copying",
+		},
+		cli.BoolFlag{
+			Name:  "estimate-cost",
+			Usage: "with --dry-run, estimate the cost of the copy from the prices below",
+		},
+		cli.StringFlag{
+			Name:  "price-per-gb",
+			Usage: "price of transferring one GiB, used by --estimate-cost",
+		},
+		cli.StringFlag{
+			Name:  "price-per-1k-requests",
+			Usage: "price of one thousand requests, used by --estimate-cost",
+		},
+		cli.IntFlag{
+			Name:  "list-workers",
+			Usage: "number of top level folders listed in parallel for recursive copies",
+		},
+		cli.IntFlag{
+			Name:  "transfer-workers",
+			Usage: "number of objects copied in parallel, scaled with the transfer speed by default",
+		},
+		cli.StringFlag{
+			Name:  "encode-chars",
+			Usage: "reversibly encode characters not allowed in local file names, use 'auto' for platform defaults",
+		},
+		cli.StringFlag{
+			Name:  "spool-volume-size",
+			Usage: "write objects one after another into tar volumes of this size in the local target, along with a catalog",
+		},
+		cli.BoolFlag{
+			Name:  "content-addressed",
+			Usage: "name objects under target by their SHA-256, skipping those already stored",
+		},
+		cli.StringFlag{
+			Name:  "special-files",
+			Value: specialFilesSkip,
+			Usage: "fifos, sockets and devices found in a local source: 'skip' them or report an 'error'",
+		},
+		cli.BoolFlag{
+			Name:  "preflight",
+			Usage: "scan local sources for unreadable files and folders first, and stop before copying if any are found",
+		},
+		cli.BoolFlag{
+			Name:  "strict",
+			Usage: "exit with an error if any source is skipped, such as unreadable files and special files",
+		},
 	}
 )
 
@@ -73,7 +179,7 @@ var cpCmd = cli.Command{
 	Usage:  "copy objects",
 	Action: mainCopy,
 	Before: setGlobalsFromContext,
-	Flags:  append(append(cpFlags, ioFlags...), globalFlags...),
+	Flags:  append(append(append(cpFlags, transferHookFlags...), ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -87,6 +193,13 @@ ENVIRONMENT VARIABLES:
    MC_ENCRYPT:      list of comma delimited prefixes
    MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
 
+HOOKS:
+   Commands given to --pre-exec and --post-exec are run by the shell with
+   MC_HOOK ("pre" or "post"), MC_SCOPE ("object" or "job"), MC_SOURCE,
+   MC_TARGET and MC_SIZE set. Post hooks also get MC_STATUS ("success" or
+   "error") and, for objects, MC_ERROR. Objects whose pre hook fails are
+   skipped, a job whose pre hook fails does not start.
+
 EXAMPLES:
    1. Copy a list of objects from local file system to Amazon S3 cloud storage.
       $ {{.HelpName}} Music/*.ogg s3/jukebox/
@@ -136,7 +249,7 @@ type copyMessage struct {
 
 // String colorized copy message
 func (c copyMessage) String() string {
-	return console.Colorize("Copy", fmt.Sprintf("`%s` -> `%s`", c.Source, c.Target))
+	return console.Colorize("Copy", fmt.Sprintf("`%s` -> `%s`", printableKey(c.Source), printableKey(c.Target)))
 }
 
 // JSON jsonified copy message
@@ -162,11 +275,12 @@ type ProgressReader interface {
 }
 
 // doCopy - Copy a singe file from source to destination
-func doCopy(ctx context.Context, cpURLs URLs, pg ProgressReader, encKeyDB map[string][]prefixSSEPair) URLs {
+func doCopy(ctx context.Context, cpURLs URLs, pg ProgressReader, opts uploadOptions, encKeyDB map[string][]prefixSSEPair) URLs {
 	if cpURLs.Error != nil {
 		cpURLs.Error = cpURLs.Error.Trace()
 		return cpURLs
 	}
+	cpURLs.started = UTCNow()
 
 	sourceAlias := cpURLs.SourceAlias
 	sourceURL := cpURLs.SourceContent.URL
@@ -187,7 +301,9 @@ func doCopy(ctx context.Context, cpURLs URLs, pg ProgressReader, encKeyDB map[st
 			TotalSize:  cpURLs.TotalSize,
 		})
 	}
-	return uploadSourceToTargetURL(ctx, cpURLs, pg, encKeyDB)
+	return opts.hooks.aroundObject(cpURLs, func() URLs {
+		return uploadSourceToTargetURL(ctx, cpURLs, pg, opts, encKeyDB)
+	})
 }
 
 // doCopyFake - Perform a fake copy to update the progress bar appropriately.
@@ -198,8 +314,89 @@ func doCopyFake(cpURLs URLs, pg Progress) URLs {
 	return cpURLs
 }
 
+// Policies for sources changed since a session was saved.
+const (
+	sourceChangeRequeue = "requeue"
+	sourceChangeSkip    = "skip"
+)
+
+// isSourceChanged - returns true if current is not the source content
+// recorded in a session. Objects are compared by ETag where known, their
+// times are only precise to the second when stated.
+func isSourceChanged(recorded, current *clientContent) bool {
+	if recorded.Size != current.Size {
+		return true
+	}
+	recordedETag, currentETag := strings.Trim(recorded.ETag, "\""), strings.Trim(current.ETag, "\"")
+	if recordedETag != "" && currentETag != "" {
+		return recordedETag != currentETag
+	}
+	if current.URL.Type == objectStorage {
+		return !recorded.Time.Truncate(time.Second).Equal(current.Time.Truncate(time.Second))
+	}
+	return !recorded.Time.Equal(current.Time)
+}
+
+// refreshSource - stats the source of cpURLs on resume, its recorded
+// size and time are replaced if it changed since the session was saved.
+func refreshSource(cpURLs *URLs, encKeyDB map[string][]prefixSSEPair) (changed bool, err *probe.Error) {
+	sourceAlias := cpURLs.SourceAlias
+	sourceURL := cpURLs.SourceContent.URL
+	clnt, err := newClientFromAlias(sourceAlias, sourceURL.String())
+	if err != nil {
+		return false, err.Trace(sourceURL.String())
+	}
+	sourcePath := filepath.ToSlash(filepath.Join(sourceAlias, sourceURL.Path))
+	current, err := clnt.Stat(false, false, getSSE(sourcePath, encKeyDB[sourceAlias]))
+	if err != nil {
+		return false, err.Trace(sourceURL.String())
+	}
+	if !isSourceChanged(cpURLs.SourceContent, current) {
+		return false, nil
+	}
+	cpURLs.SourceContent.Size = current.Size
+	cpURLs.SourceContent.Time = current.Time
+	cpURLs.SourceContent.ETag = current.ETag
+	return true, nil
+}
+
+// doCopyDryRun - prints the source and target of a copy without copying.
+func doCopyDryRun(cpURLs URLs) URLs {
+	if cpURLs.Error != nil {
+		cpURLs.Error = cpURLs.Error.Trace()
+		return cpURLs
+	}
+	printMsg(copyMessage{
+		Source:     filepath.ToSlash(filepath.Join(cpURLs.SourceAlias, cpURLs.SourceContent.URL.Path)),
+		Target:     filepath.ToSlash(filepath.Join(cpURLs.TargetAlias, cpURLs.TargetContent.URL.Path)),
+		Size:       cpURLs.SourceContent.Size,
+		TotalCount: cpURLs.TotalCount,
+		TotalSize:  cpURLs.TotalSize,
+	})
+	return cpURLs
+}
+
+// isTargetKept - reports if the target of cpURLs exists and is kept by
+// --overwrite-mode.
+func isTargetKept(cpURLs URLs, overwrite string, encKeyDB map[string][]prefixSSEPair) bool {
+	targetAlias := cpURLs.TargetAlias
+	targetURL := cpURLs.TargetContent.URL
+	clnt, err := newClientFromAlias(targetAlias, targetURL.String())
+	if err != nil {
+		return false
+	}
+	targetPath := filepath.ToSlash(filepath.Join(targetAlias, targetURL.Path))
+	targetContent, err := clnt.Stat(false, false, getSSE(targetPath, encKeyDB[targetAlias]))
+	if err != nil {
+		// Missing targets are copied, other errors are left to the copy.
+		return false
+	}
+	return !shouldOverwrite(overwrite, cpURLs.SourceContent, targetContent)
+}
+
 // doPrepareCopyURLs scans the source URL and prepares a list of objects for copying.
-func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy context.CancelFunc) {
+// Returns the number of sources which could not be prepared.
+func doPrepareCopyURLs(session *sessionV8, specials *specialFiles, trapCh <-chan bool, cancelCopy context.CancelFunc) (skipped int) {
 	// Separate source and target. 'cp' can take only one target,
 	// but any number of sources.
 	sourceURLs := session.Header.CommandArgs[:len(session.Header.CommandArgs)-1]
@@ -213,10 +410,13 @@ func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy contex
 
 	olderThan := session.Header.CommandStringFlags["older-than"]
 	newerThan := session.Header.CommandStringFlags["newer-than"]
+	overwrite := session.Header.CommandStringFlags["overwrite-mode"]
 	encryptKeys := session.Header.CommandStringFlags["encrypt-key"]
 	encrypt := session.Header.CommandStringFlags["encrypt"]
 	encKeyDB, err := parseAndValidateEncryptionKeys(encryptKeys, encrypt)
 	fatalIf(err, "Unable to parse encryption keys.")
+	keyEnc, err := newKeyEncoder(session.Header.CommandStringFlags["encode-chars"])
+	fatalIf(err, "Unable to parse characters to encode.")
 
 	// Create a session data file to store the processed URLs.
 	dataFP := session.NewDataWriter()
@@ -225,7 +425,11 @@ func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy contex
 	if !globalQuiet && !globalJSON { // set up progress bar
 		scanBar = scanBarFactory()
 	}
-	URLsCh := prepareCopyURLs(sourceURLs, targetURL, isRecursive, encKeyDB)
+	listWorkers := session.Header.CommandIntFlags["list-workers"]
+	noTargetDir := session.Header.CommandBoolFlags["no-target-dir"]
+	at, err := parsePointInTime(session.Header.CommandStringFlags["at"])
+	fatalIf(err, "Unable to parse ‘--at’.")
+	URLsCh := prepareCopyURLs(sourceURLs, targetURL, isRecursive, noTargetDir, listWorkers, at, keyEnc, encKeyDB)
 	done := false
 	for !done {
 		select {
@@ -234,6 +438,10 @@ func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy contex
 				done = true
 				break
 			}
+			// Special files left out by policy are only counted.
+			if specials.skip(cpURLs.Error) {
+				break
+			}
 			if cpURLs.Error != nil {
 				// Print in new line and adjust to top so that we don't print over the ongoing scan bar
 				if !globalQuiet && !globalJSON {
@@ -244,13 +452,14 @@ func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy contex
 				} else {
 					errorIf(cpURLs.Error.Trace(), "Unable to prepare URL for copying.")
 				}
+				skipped++
 				break
 			}
 
-			jsonData, e := json.Marshal(cpURLs)
-			if e != nil {
+			jsonData, err := session.marshalURLs(cpURLs)
+			if err != nil {
 				session.Delete()
-				fatalIf(probe.NewError(e), "Unable to prepare URL for copying. Error in JSON marshaling.")
+				fatalIf(err, "Unable to prepare URL for copying. Error in JSON marshaling.")
 			}
 
 			// Skip objects older than --older-than parameter if specified
@@ -263,6 +472,11 @@ func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy contex
 				continue
 			}
 
+			// Skip objects on target which are kept by --overwrite-mode
+			if overwrite != "" && overwrite != overwriteAlways && isTargetKept(cpURLs, overwrite, encKeyDB) {
+				continue
+			}
+
 			fmt.Fprintln(dataFP, string(jsonData))
 			if !globalQuiet && !globalJSON {
 				scanBar(cpURLs.SourceContent.URL.String())
@@ -277,43 +491,88 @@ func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy contex
 				console.Eraseline()
 			}
 			session.Delete() // If we are interrupted during the URL scanning, we drop the session.
+			saveTransferStats()
 			os.Exit(0)
 		}
 	}
-	session.Header.TotalBytes = totalBytes
-	session.Header.TotalObjects = totalObjects
-	session.Save()
+	session.setTotals(totalBytes, totalObjects)
+	return skipped
 }
 
-func doCopySession(session *sessionV8, encKeyDB map[string][]prefixSSEPair) error {
+func doCopySession(session *sessionV8, encKeyDB map[string][]prefixSSEPair, onSourceChange string) error {
 	trapCh := signalTrap(os.Interrupt, syscall.SIGTERM, syscall.SIGKILL)
 
 	ctx, cancelCopy := context.WithCancel(context.Background())
 	defer cancelCopy()
+
+	fatalIf(setCacheExpiry(session.Header.CommandStringFlags["cache"]), "Unable to parse cache expiry.")
+
+	hooks, err := newTransferHooks(session.Header.CommandStringFlags["pre-exec"],
+		session.Header.CommandStringFlags["post-exec"], session.Header.CommandStringFlags["exec-scope"])
+	fatalIf(err, "Unable to parse ‘--exec-scope’.")
+	opts := uploadOptions{
+		uploads:       session,
+		compress:      session.Header.CommandStringFlags["compress"],
+		noDecompress:  session.Header.CommandBoolFlags["no-decompress"],
+		hooks:         hooks,
+		filter:        session.Header.CommandStringFlags["filter"],
+		checksum:      session.Header.CommandStringFlags["checksum"],
+		uploadLimits:  newUploadLimiters(session.Header.CommandStringFlags["limit-upload"]),
+		downloadLimit: newBandwidthLimiter(session.Header.CommandStringFlags["limit-download"]),
+		scheduleLimit: newScheduledBandwidthLimiter(session.Header.CommandStringFlags["limit-schedule"]),
+	}
+
+	// Special files are only found while preparing URLs, they are not
+	// reported again on resume.
+	specials := newSpecialFiles(session.Header.CommandStringFlags["special-files"])
+	var skipped int
 	if !session.HasData() {
-		doPrepareCopyURLs(session, trapCh, cancelCopy)
+		skipped = doPrepareCopyURLs(session, specials, trapCh, cancelCopy)
 	}
 
+	// Each run of a session is limited on its own.
+	maxDuration, _ := parseMaxDuration(session.Header.CommandStringFlags["max-duration"])
+	maxBytes, _ := parseMaxBytes(session.Header.CommandStringFlags["max-bytes"])
+	limits := newRunLimits(maxDuration, maxBytes)
+
+	args := session.Header.CommandArgs
+	sources, target := args[:len(args)-1], args[len(args)-1]
+	fatalIf(hooks.beforeJob(sources, target, session.Header.TotalBytes), "Unable to start copying, the ‘--pre-exec’ hook failed.")
+
 	// Prepare URL scanner from session data file.
 	urlScanner := bufio.NewScanner(session.NewDataReader())
 	// isCopied returns true if an object has been already copied
 	// or not. This is useful when we resume from a session.
-	isCopied := isLastFactory(session.Header.LastCopied)
+	lastCopied, err := session.decodeKey(session.Header.LastCopied)
+	fatalIf(err.Trace(session.Header.LastCopied), "Unable to read the last copied object of the session.")
+	isCopied := isLastFactory(lastCopied)
 
 	// Store a progress bar or an accounter
 	var pg ProgressReader
 
+	dryRun := session.Header.CommandBoolFlags["dry-run"]
+
+	var estimate *costEstimate
+	if dryRun && session.Header.CommandBoolFlags["estimate-cost"] {
+		estimate = &costEstimate{}
+		estimate.pricePerGB, _ = parsePrice(session.Header.CommandStringFlags["price-per-gb"])
+		estimate.pricePer1KRequests, _ = parsePrice(session.Header.CommandStringFlags["price-per-1k-requests"])
+	}
+
 	// Enable progress bar reader only during default mode.
-	if !globalQuiet && !globalJSON { // set up progress bar
-		pg = newProgressBar(session.Header.TotalBytes)
+	if !globalQuiet && !globalJSON && !dryRun { // set up progress bar
+		pg = newProgressBar(session.Header.TotalBytes).SetTotalObjects(session.Header.TotalObjects)
 	} else {
 		pg = newAccounter(session.Header.TotalBytes)
 	}
 
+	// Transfers by top-level prefix of target.
+	summary := newPrefixStats(target)
+
 	var quitCh = make(chan struct{})
 	var statusCh = make(chan URLs)
 
-	parallel, queueCh := newParallelManager(statusCh)
+	parallel, queueCh := newParallelManager(statusCh, session.Header.CommandIntFlags["transfer-workers"])
 
 	go func() {
 		gracefulStop := func() {
@@ -340,11 +599,11 @@ func doCopySession(session *sessionV8, encKeyDB map[string][]prefixSSEPair) erro
 					return
 				}
 
-				var cpURLs URLs
 				// Unmarshal copyURLs from each line. This expects each line to be
 				// an entire JSON object.
-				if e := json.Unmarshal([]byte(urlScanner.Text()), &cpURLs); e != nil {
-					errorIf(probe.NewError(e), "Unable to unmarshal %s", urlScanner.Text())
+				cpURLs, err := session.unmarshalURLs(urlScanner.Bytes())
+				if err != nil {
+					errorIf(err, "Unable to unmarshal %s", urlScanner.Text())
 					continue
 				}
 
@@ -362,6 +621,14 @@ func doCopySession(session *sessionV8, encKeyDB map[string][]prefixSSEPair) erro
 					cpURLs.TargetContent.Metadata["X-Amz-Storage-Class"] = session.Header.CommandStringFlags["storage-class"]
 				}
 
+				// Check and handle canned ACL if passed in command line args
+				if acl := session.Header.CommandStringFlags["acl"]; acl != "" {
+					if cpURLs.TargetContent.Metadata == nil {
+						cpURLs.TargetContent.Metadata = make(map[string]string)
+					}
+					cpURLs.TargetContent.Metadata["X-Amz-Acl"] = acl
+				}
+
 				//	metaMap, metaSet := session.Header.UserMetaData
 
 				// Check and handle metadata if passed in command line args
@@ -376,13 +643,39 @@ func doCopySession(session *sessionV8, encKeyDB map[string][]prefixSSEPair) erro
 				}
 
 				// Verify if previously copied, notify progress bar.
-				if isCopied(cpURLs.SourceContent.URL.String()) {
+				if dryRun {
 					queueCh <- func() URLs {
-						return doCopyFake(cpURLs, pg)
+						return doCopyDryRun(cpURLs)
 					}
 				} else {
+					copied := isCopied(cpURLs.SourceContent.URL.String())
+					// No new copies are started once a limit is reached,
+					// those in flight are finished.
+					var size int64
+					if !copied {
+						size = cpURLs.SourceContent.Size
+					}
+					if !limits.allow(size) {
+						gracefulStop()
+						return
+					}
 					queueCh <- func() URLs {
-						return doCopy(ctx, cpURLs, pg, encKeyDB)
+						// Sources are checked again on resume, they may
+						// have changed since the session was saved.
+						if onSourceChange != "" && cpURLs.Error == nil {
+							changed, err := refreshSource(&cpURLs, encKeyDB)
+							if err != nil {
+								return cpURLs.WithError(err)
+							}
+							if changed && onSourceChange == sourceChangeSkip {
+								return cpURLs.WithError(probe.NewError(SourceChanged{Source: cpURLs.SourceContent.URL.String()}))
+							}
+							copied = copied && !changed
+						}
+						if copied {
+							return doCopyFake(cpURLs, pg)
+						}
+						return doCopy(ctx, cpURLs, pg, opts, encKeyDB)
 					}
 				}
 			}
@@ -407,9 +700,19 @@ loop:
 			if !ok {
 				break loop
 			}
+			if progressReader, ok := pg.(*progressBar); ok {
+				progressReader.AddObject()
+			}
+			if !dryRun {
+				summary.record(cpURLs)
+			}
 			if cpURLs.Error == nil {
-				session.Header.LastCopied = cpURLs.SourceContent.URL.String()
-				session.Save()
+				if estimate != nil {
+					estimate.add(cpURLs.SourceContent.Size)
+				}
+				lastCopied := cpURLs.SourceContent.URL
+				lastCopied.Path = session.encodeKey(lastCopied.Path)
+				session.setLastCopied(lastCopied.String())
 			} else {
 
 				// Set exit status for any copy error
@@ -422,7 +725,7 @@ loop:
 				}
 				errorIf(cpURLs.Error.Trace(cpURLs.SourceContent.URL.String()),
 					fmt.Sprintf("Failed to copy `%s`.", cpURLs.SourceContent.URL.String()))
-				if isErrIgnored(cpURLs.Error) {
+				if isErrIgnored(cpURLs.Error) || isHookFailed(cpURLs.Error) {
 					continue loop
 				}
 				// For critical errors we should exit. Session
@@ -443,6 +746,39 @@ loop:
 		}
 	}
 
+	if estimate != nil {
+		printMsg(estimate.message())
+	}
+
+	// The breakdown only tells something about several prefixes.
+	if msg := summary.message(); len(msg.Prefixes) > 1 {
+		printMsg(msg)
+	}
+	msg := specials.message()
+	if msg.Skipped > 0 {
+		printMsg(msg)
+	}
+	if msg.Failed > 0 {
+		retErr = exitStatus(globalErrorExitStatus)
+	}
+	// With --strict, sources left out while preparing fail the copy.
+	if session.Header.CommandBoolFlags["strict"] && skipped+msg.Skipped > 0 {
+		errorIf(errDummy().Trace(target), fmt.Sprintf("%d source(s) skipped, failing as ‘--strict’ is set.", skipped+msg.Skipped))
+		retErr = exitStatus(globalErrorExitStatus)
+	}
+
+	if err = hooks.afterJob(sources, target, session.Header.TotalBytes, retErr != nil); err != nil {
+		errorIf(err, "The ‘--post-exec’ hook failed.")
+		retErr = exitStatus(globalErrorExitStatus)
+	}
+
+	// The session is kept for the remaining objects to be resumed.
+	if limits.reached() && retErr == nil {
+		session.Close()
+		printMsg(limits.message(session.SessionID))
+		saveTransferStats()
+		os.Exit(globalRunLimitExitStatus)
+	}
 	return retErr
 }
 
@@ -473,15 +809,82 @@ func mainCopy(ctx *cli.Context) error {
 		fatalIf(err, "Unable to parse attribute %v", ctx.String("attr"))
 	}
 
+	// Validate compression.
+	fatalIf(checkContentEncoding(ctx.String("compress")), "Unable to validate compression.")
+
+	// Validate checksum algorithm.
+	fatalIf(checkChecksumAlgorithm(ctx.String("checksum")), "Unable to validate checksum algorithm.")
+
+	// Validate bandwidth limits.
+	fatalIf(checkUploadLimits(ctx.String("limit-upload")), "Unable to parse ‘--limit-upload’.")
+	fatalIf(checkBandwidthLimit(ctx.String("limit-download")), "Unable to parse ‘--limit-download’.")
+	fatalIf(checkBandwidthSchedule(ctx.String("limit-schedule")), "Unable to parse ‘--limit-schedule’.")
+
+	// Validate run limits.
+	_, err = parseMaxDuration(ctx.String("max-duration"))
+	fatalIf(err, "Unable to parse ‘--max-duration’.")
+	_, err = parseMaxBytes(ctx.String("max-bytes"))
+	fatalIf(err, "Unable to parse ‘--max-bytes’.")
+
+	// Validate age filters.
+	fatalIf(checkAgeFilters(ctx.String("older-than"), ctx.String("newer-than")), "Unable to validate ‘--older-than’ and ‘--newer-than’.")
+
+	// Validate cache expiry.
+	fatalIf(setCacheExpiry(ctx.String("cache")), "Unable to parse cache expiry.")
+
+	// Validate characters to encode for local targets.
+	keyEnc, err := newKeyEncoder(ctx.String("encode-chars"))
+	fatalIf(err, "Unable to parse characters to encode.")
+
+	// Validate spool volume size.
+	volumeSize, err := parseSpoolVolumeSize(ctx.String("spool-volume-size"))
+	fatalIf(err, "Unable to parse spool volume size.")
+
+	// Expand wildcards in remote source URLs.
+	URLs, err := expandGlobURLs(ctx.Args(), encKeyDB)
+	fatalIf(err, "Unable to expand wildcards in source arguments.")
+
+	if volumeSize > 0 && len(URLs) > 1 {
+		// Volumes are written to a local folder, created here for the
+		// target to be checked as one.
+		targetURL := URLs[len(URLs)-1]
+		if _, _, hostCfg, _ := expandAlias(targetURL); hostCfg != nil {
+			fatalIf(errInvalidArgument().Trace(targetURL), "Spool target `"+targetURL+"` must be a local folder.")
+		}
+		if ctx.Bool("dry-run") {
+			fatalIf(errInvalidArgument(), "--dry-run is not supported with --spool-volume-size.")
+		}
+		e := os.MkdirAll(targetURL, 0755)
+		fatalIf(probe.NewError(e).Trace(targetURL), "Unable to create spool folder `"+targetURL+"`.")
+	}
+
 	// check 'copy' cli arguments.
-	checkCopySyntax(ctx, encKeyDB)
+	checkCopySyntax(ctx, URLs, encKeyDB)
 
 	// Additional command speific theme customization.
 	console.SetColor("Copy", color.New(color.FgGreen, color.Bold))
+	console.SetColor("SpecialFiles", color.New(color.FgYellow))
+	console.SetColor("RunLimit", color.New(color.FgYellow))
+
+	// Unreadable local sources are found before anything is copied.
+	if ctx.Bool("preflight") {
+		checkPreflight(URLs[:len(URLs)-1])
+	}
 
 	recursive := ctx.Bool("recursive")
 	olderThan := ctx.String("older-than")
 	newerThan := ctx.String("newer-than")
+	if ctx.Bool("content-addressed") {
+		if volumeSize > 0 || ctx.Bool("dry-run") {
+			fatalIf(errInvalidArgument(), "--content-addressed cannot be used with --spool-volume-size or --dry-run.")
+		}
+		// Keys are only known once sources are read, copies are not resumed.
+		return doContentAddressed(URLs[:len(URLs)-1], URLs[len(URLs)-1], recursive, olderThan, newerThan, keyEnc, newSpecialFiles(ctx.String("special-files")), encKeyDB)
+	}
+	if volumeSize > 0 {
+		// Spools are written sequentially, they are not resumed.
+		return doSpool(URLs[:len(URLs)-1], URLs[len(URLs)-1], volumeSize, recursive, olderThan, newerThan, keyEnc, newSpecialFiles(ctx.String("special-files")), encKeyDB)
+	}
 	storageClass := ctx.String("storage-class")
 	sseKeys := os.Getenv("MC_ENCRYPT_KEY")
 	if key := ctx.String("encrypt-key"); key != "" {
@@ -494,9 +897,35 @@ func mainCopy(ctx *cli.Context) error {
 	session.Header.CommandBoolFlags["recursive"] = recursive
 	session.Header.CommandStringFlags["older-than"] = olderThan
 	session.Header.CommandStringFlags["newer-than"] = newerThan
+	session.Header.CommandStringFlags["at"] = ctx.String("at")
 	session.Header.CommandStringFlags["storage-class"] = storageClass
+	session.Header.CommandStringFlags["acl"] = ctx.String("acl")
 	session.Header.CommandStringFlags["encrypt-key"] = sseKeys
 	session.Header.CommandStringFlags["encrypt"] = sse
+	session.Header.CommandStringFlags["encode-chars"] = ctx.String("encode-chars")
+	session.Header.CommandStringFlags["cache"] = ctx.String("cache")
+	session.Header.CommandStringFlags["overwrite-mode"] = ctx.String("overwrite-mode")
+	session.Header.CommandStringFlags["compress"] = ctx.String("compress")
+	session.Header.CommandStringFlags["pre-exec"] = ctx.String("pre-exec")
+	session.Header.CommandStringFlags["filter"] = ctx.String("filter")
+	session.Header.CommandStringFlags["checksum"] = ctx.String("checksum")
+	session.Header.CommandStringFlags["limit-upload"] = ctx.String("limit-upload")
+	session.Header.CommandStringFlags["limit-download"] = ctx.String("limit-download")
+	session.Header.CommandStringFlags["limit-schedule"] = ctx.String("limit-schedule")
+	session.Header.CommandStringFlags["max-duration"] = ctx.String("max-duration")
+	session.Header.CommandStringFlags["max-bytes"] = ctx.String("max-bytes")
+	session.Header.CommandStringFlags["special-files"] = ctx.String("special-files")
+	session.Header.CommandStringFlags["post-exec"] = ctx.String("post-exec")
+	session.Header.CommandStringFlags["exec-scope"] = ctx.String("exec-scope")
+	session.Header.CommandBoolFlags["no-decompress"] = ctx.Bool("no-decompress")
+	session.Header.CommandBoolFlags["no-target-dir"] = ctx.Bool("no-target-dir")
+	session.Header.CommandBoolFlags["dry-run"] = ctx.Bool("dry-run")
+	session.Header.CommandBoolFlags["strict"] = ctx.Bool("strict")
+	session.Header.CommandBoolFlags["estimate-cost"] = ctx.Bool("estimate-cost")
+	session.Header.CommandStringFlags["price-per-gb"] = ctx.String("price-per-gb")
+	session.Header.CommandStringFlags["price-per-1k-requests"] = ctx.String("price-per-1k-requests")
+	session.Header.CommandIntFlags["list-workers"] = ctx.Int("list-workers")
+	session.Header.CommandIntFlags["transfer-workers"] = ctx.Int("transfer-workers")
 	session.Header.UserMetaData = userMetaMap
 
 	var e error
@@ -506,8 +935,8 @@ func mainCopy(ctx *cli.Context) error {
 	}
 
 	// extract URLs.
-	session.Header.CommandArgs = ctx.Args()
-	e = doCopySession(session, encKeyDB)
+	session.Header.CommandArgs = URLs
+	e = doCopySession(session, encKeyDB, "")
 	session.Delete()
 
 	return e
@@ -0,0 +1,307 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+const (
+	// Catalog of a spool, one JSON entry per line.
+	spoolCatalogName = "catalog.json"
+
+	// Volumes of a spool are numbered from 1.
+	spoolVolumeFormat = "volume-%05d.tar"
+
+	// Tar archives are written in blocks, the end of an archive takes
+	// two of them.
+	tarBlockSize = 512
+	tarTrailer   = 2 * tarBlockSize
+)
+
+// spoolMessage container for objects written to a spool volume.
+type spoolMessage struct {
+	Status string `json:"status"`
+	Source string `json:"source"`
+	Volume string `json:"volume"`
+	Size   int64  `json:"size"`
+}
+
+// String colorized spool message.
+func (s spoolMessage) String() string {
+	return console.Colorize("Copy", fmt.Sprintf("`%s` -> `%s`", printableKey(s.Source), s.Volume))
+}
+
+// JSON jsonified spool message.
+func (s spoolMessage) JSON() string {
+	s.Status = "success"
+	spoolMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(spoolMessageBytes)
+}
+
+// spoolCatalogEntry - locates an object in the volumes of a spool, at
+// the offset of its tar header.
+type spoolCatalogEntry struct {
+	Name         string    `json:"name"`
+	Source       string    `json:"source"`
+	Volume       string    `json:"volume"`
+	Offset       int64     `json:"offset"`
+	Size         int64     `json:"size"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+// parseSpoolVolumeSize - parses the value of --spool-volume-size, 0 if
+// not spooling.
+func parseSpoolVolumeSize(size string) (int64, *probe.Error) {
+	if size == "" {
+		return 0, nil
+	}
+	n, e := humanize.ParseBytes(size)
+	if e != nil {
+		return 0, probe.NewError(e).Trace(size)
+	}
+	if n < 16*tarBlockSize {
+		return 0, errInvalidArgument().Trace(size)
+	}
+	return int64(n), nil
+}
+
+// tarEntrySize - returns the size taken by a file of size in a tar
+// archive at most. Long or non ASCII names and sizes of 8GiB or more
+// are stored in an extra header.
+func tarEntrySize(name string, size int64) int64 {
+	blocks := func(n int64) int64 {
+		return (n + tarBlockSize - 1) / tarBlockSize * tarBlockSize
+	}
+	entrySize := tarBlockSize + blocks(size)
+	needsPAX := len(name) > 100 || size >= 1<<33
+	for i := 0; i < len(name) && !needsPAX; i++ {
+		needsPAX = name[i] >= 0x80
+	}
+	if needsPAX {
+		// Records of the extra header are made of a name and a value.
+		entrySize += tarBlockSize + blocks(int64(len(name))+2*tarBlockSize)
+	}
+	return entrySize
+}
+
+// countingWriter - counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, e := c.w.Write(p)
+	c.n += int64(n)
+	return n, e
+}
+
+// spoolWriter - writes objects one after another into tar volumes of
+// a fixed size, no object is split across volumes.
+type spoolWriter struct {
+	dir        string
+	volumeSize int64
+
+	volume  int
+	file    *os.File
+	counter *countingWriter
+	tw      *tar.Writer
+	catalog *os.File
+}
+
+// newSpoolWriter - starts a spool in dir, which must not hold one yet.
+func newSpoolWriter(dir string, volumeSize int64) (*spoolWriter, *probe.Error) {
+	if e := os.MkdirAll(dir, 0755); e != nil {
+		return nil, probe.NewError(e).Trace(dir)
+	}
+	catalogPath := filepath.Join(dir, spoolCatalogName)
+	catalog, e := os.OpenFile(catalogPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if e != nil {
+		if os.IsExist(e) {
+			return nil, probe.NewError(fmt.Errorf("`%s` already holds a spool", dir))
+		}
+		return nil, probe.NewError(e).Trace(catalogPath)
+	}
+	return &spoolWriter{dir: dir, volumeSize: volumeSize, catalog: catalog}, nil
+}
+
+// volumeName - returns the name of the current volume.
+func (s *spoolWriter) volumeName() string {
+	return fmt.Sprintf(spoolVolumeFormat, s.volume)
+}
+
+// closeVolume - ends the archive of the current volume and syncs it.
+func (s *spoolWriter) closeVolume() *probe.Error {
+	if s.tw == nil {
+		return nil
+	}
+	defer s.file.Close()
+	if e := s.tw.Close(); e != nil {
+		return probe.NewError(e).Trace(s.file.Name())
+	}
+	s.tw = nil
+	if e := s.file.Sync(); e != nil {
+		return probe.NewError(e).Trace(s.file.Name())
+	}
+	return nil
+}
+
+// add - writes the object read from r into the current volume, or the
+// next one if it does not fit. Returns the volume written to.
+func (s *spoolWriter) add(name, source string, content *clientContent, r io.Reader) (string, *probe.Error) {
+	if s.tw != nil {
+		// Pads the previous entry, for the offset of the next one.
+		if e := s.tw.Flush(); e != nil {
+			return "", probe.NewError(e).Trace(s.file.Name())
+		}
+	}
+	entrySize := tarEntrySize(name, content.Size)
+	if entrySize+tarTrailer > s.volumeSize {
+		return "", probe.NewError(fmt.Errorf("`%s` of %s does not fit in a volume of %s", source,
+			humanize.IBytes(uint64(content.Size)), humanize.IBytes(uint64(s.volumeSize))))
+	}
+	if s.tw != nil && s.counter.n+entrySize+tarTrailer > s.volumeSize {
+		if err := s.closeVolume(); err != nil {
+			return "", err.Trace(source)
+		}
+	}
+	if s.tw == nil {
+		s.volume++
+		file, e := os.OpenFile(filepath.Join(s.dir, s.volumeName()), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if e != nil {
+			return "", probe.NewError(e).Trace(s.dir)
+		}
+		s.file = file
+		s.counter = &countingWriter{w: file}
+		s.tw = tar.NewWriter(s.counter)
+	}
+
+	entry := spoolCatalogEntry{
+		Name:         name,
+		Source:       source,
+		Volume:       s.volumeName(),
+		Offset:       s.counter.n,
+		Size:         content.Size,
+		ETag:         content.ETag,
+		LastModified: content.Time,
+	}
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0644,
+		Size:     content.Size,
+		ModTime:  content.Time,
+	}
+	if e := s.tw.WriteHeader(header); e != nil {
+		return "", probe.NewError(e).Trace(source)
+	}
+	n, e := io.Copy(s.tw, r)
+	if e != nil {
+		return "", probe.NewError(e).Trace(source)
+	}
+	if n != content.Size {
+		return "", probe.NewError(UnexpectedEOF{TotalSize: content.Size, TotalWritten: n}).Trace(source)
+	}
+	// Entries are only cataloged once their volume holds them fully.
+	entryBytes, e := json.Marshal(entry)
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	if _, e = s.catalog.Write(append(entryBytes, '\n')); e != nil {
+		return "", probe.NewError(e).Trace(s.catalog.Name())
+	}
+	return entry.Volume, nil
+}
+
+// close - ends the last volume and the catalog.
+func (s *spoolWriter) close() *probe.Error {
+	defer s.catalog.Close()
+	if err := s.closeVolume(); err != nil {
+		return err.Trace()
+	}
+	if e := s.catalog.Sync(); e != nil {
+		return probe.NewError(e).Trace(s.catalog.Name())
+	}
+	return nil
+}
+
+// doSpool - copies the objects of sourceURLs one by one into the tar
+// volumes of a spool in targetURL, a local folder.
+func doSpool(sourceURLs []string, targetURL string, volumeSize int64, isRecursive bool, olderThan, newerThan string, keyEnc keyEncoder, specials *specialFiles, encKeyDB map[string][]prefixSSEPair) error {
+	spool, err := newSpoolWriter(targetURL, volumeSize)
+	fatalIf(err, "Unable to start spool in `"+targetURL+"`.")
+
+	var retErr error
+	for cpURLs := range prepareCopyURLs(sourceURLs, targetURL, isRecursive, false, 0, time.Time{}, keyEnc, encKeyDB) {
+		if specials.skip(cpURLs.Error) {
+			continue
+		}
+		if cpURLs.Error != nil {
+			errorIf(cpURLs.Error.Trace(), "Unable to prepare URL for copying.")
+			retErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		source := cpURLs.SourceContent
+		if olderThan != "" && isOlder(source.Time, olderThan) {
+			continue
+		}
+		if newerThan != "" && isNewer(source.Time, newerThan) {
+			continue
+		}
+		name, e := filepath.Rel(filepath.Clean(targetURL), filepath.Clean(cpURLs.TargetContent.URL.Path))
+		if e != nil {
+			errorIf(probe.NewError(e).Trace(source.URL.String()), "Unable to name `"+source.URL.String()+"` in spool.")
+			retErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+
+		sourcePath := filepath.ToSlash(filepath.Join(cpURLs.SourceAlias, source.URL.Path))
+		reader, err := getSourceStreamFromURL(sourcePath, encKeyDB)
+		if err != nil {
+			errorIf(err.Trace(sourcePath), "Unable to read `"+sourcePath+"`.")
+			retErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		volume, err := spool.add(filepath.ToSlash(name), sourcePath, source, reader)
+		reader.Close()
+		if err != nil {
+			// Volumes are written sequentially, a partly written object
+			// cannot be taken back.
+			spool.close()
+			fatalIf(err.Trace(sourcePath), "Unable to spool `"+sourcePath+"`.")
+		}
+		printMsg(spoolMessage{Source: sourcePath, Volume: volume, Size: source.Size})
+	}
+	fatalIf(spool.close(), "Unable to close spool in `"+targetURL+"`.")
+	if msg := specials.message(); msg.Skipped > 0 {
+		printMsg(msg)
+	}
+	return retErr
+}
@@ -0,0 +1,107 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"archive/tar"
+	"bufio"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSpoolWriter(t *testing.T) {
+	dir, e := ioutil.TempDir("", "spool")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	spool, err := newSpoolWriter(dir, 8*1024)
+	if err != nil {
+		t.Fatal(err.ToGoError())
+	}
+	testCases := []struct {
+		name   string
+		size   int64
+		volume string
+	}{
+		{"a.txt", 1000, "volume-00001.tar"},
+		{strings.Repeat("b", 120), 3000, "volume-00001.tar"},
+		{"c.txt", 3000, "volume-00002.tar"},
+		{"d.txt", 0, "volume-00002.tar"},
+		{"e.txt", 6000, "volume-00003.tar"},
+	}
+	for i, testCase := range testCases {
+		content := &clientContent{Size: testCase.size, Time: time.Unix(1500000000, 0)}
+		r := strings.NewReader(strings.Repeat(testCase.name[:1], int(testCase.size)))
+		volume, err := spool.add(testCase.name, testCase.name, content, r)
+		if err != nil {
+			t.Fatalf("Test %d: %s", i+1, err.ToGoError())
+		}
+		if volume != testCase.volume {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.volume, volume)
+		}
+	}
+	content := &clientContent{Size: 8 * 1024}
+	if _, err = spool.add("f.txt", "f.txt", content, strings.NewReader("")); err == nil {
+		t.Error("Expected object larger than a volume to fail")
+	}
+	if err = spool.close(); err != nil {
+		t.Fatal(err.ToGoError())
+	}
+
+	// Every entry of the catalog points at its tar header.
+	catalog, e := os.Open(filepath.Join(dir, spoolCatalogName))
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer catalog.Close()
+	scanner := bufio.NewScanner(catalog)
+	i := 0
+	for ; scanner.Scan(); i++ {
+		var entry spoolCatalogEntry
+		if e = json.Unmarshal(scanner.Bytes(), &entry); e != nil {
+			t.Fatal(e)
+		}
+		volume, e := os.Open(filepath.Join(dir, entry.Volume))
+		if e != nil {
+			t.Fatal(e)
+		}
+		if st, _ := volume.Stat(); st.Size() > 8*1024 {
+			t.Errorf("Test %d: volume %s of %d bytes", i+1, entry.Volume, st.Size())
+		}
+		if _, e = volume.Seek(entry.Offset, io.SeekStart); e != nil {
+			t.Fatal(e)
+		}
+		header, e := tar.NewReader(volume).Next()
+		volume.Close()
+		if e != nil {
+			t.Fatalf("Test %d: %s", i+1, e)
+		}
+		if header.Name != entry.Name || header.Size != entry.Size {
+			t.Errorf("Test %d: expected %s of %d bytes, got %s of %d bytes", i+1, entry.Name, entry.Size, header.Name, header.Size)
+		}
+	}
+	if i != len(testCases) {
+		t.Errorf("Expected %d catalog entries, got %d", len(testCases), i)
+	}
+}
@@ -18,29 +18,57 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/console"
 )
 
-func checkCopySyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
+func checkCopySyntax(ctx *cli.Context, URLs []string, encKeyDB map[string][]prefixSSEPair) {
 	if len(ctx.Args()) < 2 {
 		cli.ShowCommandHelpAndExit(ctx, "cp", 1) // last argument is exit code.
 	}
 
-	// extract URLs.
-	URLs := ctx.Args()
 	if len(URLs) < 2 {
-		fatalIf(errDummy().Trace(ctx.Args()...), fmt.Sprintf("Unable to parse source and target arguments."))
+		fatalIf(errDummy().Trace(URLs...), fmt.Sprintf("Unable to parse source and target arguments."))
 	}
 
 	srcURLs := URLs[:len(URLs)-1]
 	tgtURL := URLs[len(URLs)-1]
 	isRecursive := ctx.Bool("recursive")
 
+	if acl := ctx.String("acl"); acl != "" && !isValidCannedACL(acl) {
+		fatalIf(errInvalidArgument().Trace(acl), "Unknown canned ACL `"+acl+"`, must be one of "+strings.Join(cannedACLs, ", ")+".")
+	}
+
+	if overwrite := ctx.String("overwrite-mode"); overwrite != "" && !isValidOverwrite(overwrite) {
+		fatalIf(errInvalidArgument().Trace(overwrite), "Unknown overwrite mode `"+overwrite+"`, must be one of never, always, if-newer or if-different.")
+	}
+
+	if policy := ctx.String("special-files"); !isValidSpecialFilesPolicy(policy) {
+		fatalIf(errInvalidArgument().Trace(policy), "Unknown special files policy `"+policy+"`, must be one of skip or error.")
+	}
+
+	checkWorkersSyntax(ctx)
+
+	at, err := parsePointInTime(ctx.String("at"))
+	fatalIf(err.Trace(ctx.String("at")), "Unable to parse ‘--at’.")
+	if !at.IsZero() && (ctx.Bool("content-addressed") || ctx.String("spool-volume-size") != "") {
+		fatalIf(errInvalidArgument(), "--at cannot be used with --content-addressed or --spool-volume-size.")
+	}
+
+	if ctx.Bool("estimate-cost") && !ctx.Bool("dry-run") {
+		fatalIf(errInvalidArgument(), "--estimate-cost is only supported with --dry-run.")
+	}
+	for _, flag := range []string{"price-per-gb", "price-per-1k-requests"} {
+		_, err := parsePrice(ctx.String(flag))
+		fatalIf(err, "Unable to parse --"+flag+".")
+	}
+
 	// Verify if source(s) exists.
 	for _, srcURL := range srcURLs {
-		_, _, err := url2Stat(srcURL, false, encKeyDB)
+		_, _, err := url2StatAt(srcURL, at, false, encKeyDB)
 		if err != nil {
 			console.Fatalf("Unable to validate source %s\n", srcURL)
 		}
@@ -55,18 +83,18 @@ func checkCopySyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 	}
 
 	// Guess CopyURLsType based on source and target URLs.
-	copyURLsType, err := guessCopyURLType(srcURLs, tgtURL, isRecursive, encKeyDB)
+	copyURLsType, err := guessCopyURLType(srcURLs, tgtURL, isRecursive, at, encKeyDB)
 	if err != nil {
 		fatalIf(errInvalidArgument().Trace(), "Unable to guess the type of copy operation.")
 	}
 
 	switch copyURLsType {
 	case copyURLsTypeA: // File -> File.
-		checkCopySyntaxTypeA(srcURLs, tgtURL, encKeyDB)
+		checkCopySyntaxTypeA(srcURLs, tgtURL, at, encKeyDB)
 	case copyURLsTypeB: // File -> Folder.
-		checkCopySyntaxTypeB(srcURLs, tgtURL, encKeyDB)
+		checkCopySyntaxTypeB(srcURLs, tgtURL, at, encKeyDB)
 	case copyURLsTypeC: // Folder... -> Folder.
-		checkCopySyntaxTypeC(srcURLs, tgtURL, isRecursive, encKeyDB)
+		checkCopySyntaxTypeC(srcURLs, tgtURL, isRecursive, at, encKeyDB)
 	case copyURLsTypeD: // File1...FileN -> Folder.
 		checkCopySyntaxTypeD(srcURLs, tgtURL, encKeyDB)
 	default:
@@ -75,13 +103,13 @@ func checkCopySyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 }
 
 // checkCopySyntaxTypeA verifies if the source and target are valid file arguments.
-func checkCopySyntaxTypeA(srcURLs []string, tgtURL string, keys map[string][]prefixSSEPair) {
+func checkCopySyntaxTypeA(srcURLs []string, tgtURL string, at time.Time, keys map[string][]prefixSSEPair) {
 	// Check source.
 	if len(srcURLs) != 1 {
 		fatalIf(errInvalidArgument().Trace(), "Invalid number of source arguments.")
 	}
 	srcURL := srcURLs[0]
-	_, srcContent, err := url2Stat(srcURL, false, keys)
+	_, srcContent, err := url2StatAt(srcURL, at, false, keys)
 	fatalIf(err.Trace(srcURL), "Unable to stat source `"+srcURL+"`.")
 
 	if !srcContent.Type.IsRegular() {
@@ -90,13 +118,13 @@ func checkCopySyntaxTypeA(srcURLs []string, tgtURL string, keys map[string][]pre
 }
 
 // checkCopySyntaxTypeB verifies if the source is a valid file and target is a valid folder.
-func checkCopySyntaxTypeB(srcURLs []string, tgtURL string, keys map[string][]prefixSSEPair) {
+func checkCopySyntaxTypeB(srcURLs []string, tgtURL string, at time.Time, keys map[string][]prefixSSEPair) {
 	// Check source.
 	if len(srcURLs) != 1 {
 		fatalIf(errInvalidArgument().Trace(), "Invalid number of source arguments.")
 	}
 	srcURL := srcURLs[0]
-	_, srcContent, err := url2Stat(srcURL, false, keys)
+	_, srcContent, err := url2StatAt(srcURL, at, false, keys)
 	fatalIf(err.Trace(srcURL), "Unable to stat source `"+srcURL+"`.")
 
 	if !srcContent.Type.IsRegular() {
@@ -112,7 +140,7 @@ func checkCopySyntaxTypeB(srcURLs []string, tgtURL string, keys map[string][]pre
 }
 
 // checkCopySyntaxTypeC verifies if the source is a valid recursive dir and target is a valid folder.
-func checkCopySyntaxTypeC(srcURLs []string, tgtURL string, isRecursive bool, keys map[string][]prefixSSEPair) {
+func checkCopySyntaxTypeC(srcURLs []string, tgtURL string, isRecursive bool, at time.Time, keys map[string][]prefixSSEPair) {
 	// Check source.
 	if len(srcURLs) != 1 {
 		fatalIf(errInvalidArgument().Trace(), "Invalid number of source arguments.")
@@ -126,11 +154,12 @@ func checkCopySyntaxTypeC(srcURLs []string, tgtURL string, isRecursive bool, key
 	}
 
 	for _, srcURL := range srcURLs {
-		c, srcContent, err := url2Stat(srcURL, false, keys)
+		c, srcContent, err := url2StatAt(srcURL, at, false, keys)
 		// incomplete uploads are not necessary for copy operation, no need to verify for them.
 		isIncomplete := false
 		if err != nil {
-			if !isURLPrefixExists(srcURL, isIncomplete) {
+			// Prefixes are looked up in versions with --at.
+			if !at.IsZero() || !isURLPrefixExists(srcURL, isIncomplete) {
 				fatalIf(err.Trace(srcURL), "Unable to stat source `"+srcURL+"`.")
 			}
 			// No more check here, continue to the next source url
@@ -19,6 +19,7 @@ package cmd
 import (
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/minio/mc/pkg/probe"
 )
@@ -50,10 +51,10 @@ const (
 
 // guessCopyURLType guesses the type of clientURL. This approach all allows prepareURL
 // functions to accurately report failure causes.
-func guessCopyURLType(sourceURLs []string, targetURL string, isRecursive bool, keys map[string][]prefixSSEPair) (copyURLsType, *probe.Error) {
+func guessCopyURLType(sourceURLs []string, targetURL string, isRecursive bool, at time.Time, keys map[string][]prefixSSEPair) (copyURLsType, *probe.Error) {
 	if len(sourceURLs) == 1 { // 1 Source, 1 Target
 		sourceURL := sourceURLs[0]
-		_, sourceContent, err := url2Stat(sourceURL, false, keys)
+		_, sourceContent, err := url2StatAt(sourceURL, at, false, keys)
 		if err != nil {
 			return copyURLsTypeInvalid, err
 		}
@@ -82,13 +83,13 @@ func guessCopyURLType(sourceURLs []string, targetURL string, isRecursive bool, k
 
 // SINGLE SOURCE - Type A: copy(f, f) -> copy(f, f)
 // prepareCopyURLsTypeA - prepares target and source clientURLs for copying.
-func prepareCopyURLsTypeA(sourceURL string, targetURL string, encKeyDB map[string][]prefixSSEPair) URLs {
+func prepareCopyURLsTypeA(sourceURL string, targetURL string, at time.Time, encKeyDB map[string][]prefixSSEPair) URLs {
 	// Extract alias before fiddling with the clientURL.
 	sourceAlias, _, _ := mustExpandAlias(sourceURL)
 	// Find alias and expanded clientURL.
 	targetAlias, targetURL, _ := mustExpandAlias(targetURL)
 
-	_, sourceContent, err := url2Stat(sourceURL, false, encKeyDB)
+	_, sourceContent, err := url2StatAt(sourceURL, at, false, encKeyDB)
 	if err != nil {
 		// Source does not exist or insufficient privileges.
 		return URLs{Error: err.Trace(sourceURL)}
@@ -115,13 +116,13 @@ func makeCopyContentTypeA(sourceAlias string, sourceContent *clientContent, targ
 
 // SINGLE SOURCE - Type B: copy(f, d) -> copy(f, d/f) -> A
 // prepareCopyURLsTypeB - prepares target and source clientURLs for copying.
-func prepareCopyURLsTypeB(sourceURL string, targetURL string, encKeyDB map[string][]prefixSSEPair) URLs {
+func prepareCopyURLsTypeB(sourceURL string, targetURL string, at time.Time, keyEnc keyEncoder, encKeyDB map[string][]prefixSSEPair) URLs {
 	// Extract alias before fiddling with the clientURL.
 	sourceAlias, _, _ := mustExpandAlias(sourceURL)
 	// Find alias and expanded clientURL.
 	targetAlias, targetURL, _ := mustExpandAlias(targetURL)
 
-	_, sourceContent, err := url2Stat(sourceURL, false, encKeyDB)
+	_, sourceContent, err := url2StatAt(sourceURL, at, false, encKeyDB)
 	if err != nil {
 		// Source does not exist or insufficient privileges.
 		return URLs{Error: err.Trace(sourceURL)}
@@ -136,20 +137,21 @@ func prepareCopyURLsTypeB(sourceURL string, targetURL string, encKeyDB map[strin
 	}
 
 	// All OK.. We can proceed. Type B: source is a file, target is a folder and exists.
-	return makeCopyContentTypeB(sourceAlias, sourceContent, targetAlias, targetURL, encKeyDB)
+	return makeCopyContentTypeB(sourceAlias, sourceContent, targetAlias, targetURL, keyEnc, encKeyDB)
 }
 
 // makeCopyContentTypeB - CopyURLs content for copying.
-func makeCopyContentTypeB(sourceAlias string, sourceContent *clientContent, targetAlias string, targetURL string, encKeyDB map[string][]prefixSSEPair) URLs {
+func makeCopyContentTypeB(sourceAlias string, sourceContent *clientContent, targetAlias string, targetURL string, keyEnc keyEncoder, encKeyDB map[string][]prefixSSEPair) URLs {
 	// All OK.. We can proceed. Type B: source is a file, target is a folder and exists.
 	targetURLParse := newClientURL(targetURL)
-	targetURLParse.Path = filepath.ToSlash(filepath.Join(targetURLParse.Path, filepath.Base(sourceContent.URL.Path)))
+	targetName := keyEnc.translate(filepath.Base(sourceContent.URL.Path), sourceContent.URL.Type, targetURLParse.Type)
+	targetURLParse.Path = filepath.ToSlash(filepath.Join(targetURLParse.Path, targetName))
 	return makeCopyContentTypeA(sourceAlias, sourceContent, targetAlias, targetURLParse.String(), encKeyDB)
 }
 
 // SINGLE SOURCE - Type C: copy(d1..., d2) -> []copy(d1/f, d1/d2/f) -> []A
 // prepareCopyRecursiveURLTypeC - prepares target and source clientURLs for copying.
-func prepareCopyURLsTypeC(sourceURL, targetURL string, isRecursive bool, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+func prepareCopyURLsTypeC(sourceURL, targetURL string, isRecursive, noTargetDir bool, listWorkers int, at time.Time, keyEnc keyEncoder, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
 	// Extract alias before fiddling with the clientURL.
 	sourceAlias, _, _ := mustExpandAlias(sourceURL)
 	// Find alias and expanded clientURL.
@@ -157,35 +159,60 @@ func prepareCopyURLsTypeC(sourceURL, targetURL string, isRecursive bool, encKeyD
 	copyURLsCh := make(chan URLs)
 	go func(sourceURL, targetURL string, copyURLsCh chan URLs) {
 		defer close(copyURLsCh)
-		sourceClient, err := newClient(sourceURL)
+		sourceClient, err := newPointInTimeClient(sourceURL, at)
 		if err != nil {
 			// Source initialization failed.
 			copyURLsCh <- URLs{Error: err.Trace(sourceURL)}
 			return
 		}
 
+		// With --no-target-dir the contents of a source folder are
+		// copied into target as if it was given with a trailing slash.
+		sourceClientURL := sourceClient.GetURL()
+		sourceDirURL := sourceClientURL
+		if noTargetDir && !strings.HasSuffix(sourceDirURL.Path, string(sourceDirURL.Separator)) {
+			sourceDirURL.Path += string(sourceDirURL.Separator)
+		}
+
 		isIncomplete := false
-		for sourceContent := range sourceClient.List(isRecursive, isIncomplete, DirNone) {
+		var contentCh <-chan *clientContent
+		// Versions are listed by key, not in parallel.
+		if isRecursive && at.IsZero() {
+			contentCh = listParallel(sourceAlias, sourceClient, isIncomplete, listWorkers)
+		} else {
+			contentCh = sourceClient.List(isRecursive, isIncomplete, DirNone)
+		}
+		for sourceContent := range contentCh {
 			if sourceContent.Err != nil {
 				// Listing failed.
 				copyURLsCh <- URLs{Error: sourceContent.Err.Trace(sourceClient.GetURL().String())}
 				continue
 			}
 
+			// Special files are left to the --special-files policy.
+			if kind := specialFileKind(sourceContent.Type); kind != "" {
+				copyURLsCh <- URLs{SourceContent: sourceContent, Error: probe.NewError(SpecialFile{Path: sourceContent.URL.String(), Kind: kind})}
+				continue
+			}
 			if !sourceContent.Type.IsRegular() {
 				// Source is not a regular file. Skip it for copy.
 				continue
 			}
 
+			sourceURL := sourceClientURL
+			if strings.HasPrefix(sourceContent.URL.Path, sourceDirURL.Path) {
+				sourceURL = sourceDirURL
+			}
+
 			// All OK.. We can proceed. Type B: source is a file, target is a folder and exists.
-			copyURLsCh <- makeCopyContentTypeC(sourceAlias, sourceClient.GetURL(), sourceContent, targetAlias, targetURL, encKeyDB)
+			copyURLsCh <- makeCopyContentTypeC(sourceAlias, sourceURL, sourceContent, targetAlias, targetURL, keyEnc, encKeyDB)
 		}
 	}(sourceURL, targetURL, copyURLsCh)
 	return copyURLsCh
 }
 
 // makeCopyContentTypeC - CopyURLs content for copying.
-func makeCopyContentTypeC(sourceAlias string, sourceURL clientURL, sourceContent *clientContent, targetAlias string, targetURL string, encKeyDB map[string][]prefixSSEPair) URLs {
+func makeCopyContentTypeC(sourceAlias string, sourceURL clientURL, sourceContent *clientContent, targetAlias string, targetURL string, keyEnc keyEncoder, encKeyDB map[string][]prefixSSEPair) URLs {
 	newSourceURL := sourceContent.URL
 	pathSeparatorIndex := strings.LastIndex(sourceURL.Path, string(sourceURL.Separator))
 	newSourceSuffix := filepath.ToSlash(newSourceURL.Path)
@@ -193,18 +220,19 @@ func makeCopyContentTypeC(sourceAlias string, sourceURL clientURL, sourceContent
 		sourcePrefix := filepath.ToSlash(sourceURL.Path[:pathSeparatorIndex])
 		newSourceSuffix = strings.TrimPrefix(newSourceSuffix, sourcePrefix)
 	}
+	newSourceSuffix = keyEnc.translate(newSourceSuffix, sourceURL.Type, newClientURL(targetURL).Type)
 	newTargetURL := urlJoinPath(targetURL, newSourceSuffix)
 	return makeCopyContentTypeA(sourceAlias, sourceContent, targetAlias, newTargetURL, encKeyDB)
 }
 
 // MULTI-SOURCE - Type D: copy([](f|d...), d) -> []B
 // prepareCopyURLsTypeE - prepares target and source clientURLs for copying.
-func prepareCopyURLsTypeD(sourceURLs []string, targetURL string, isRecursive bool, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+func prepareCopyURLsTypeD(sourceURLs []string, targetURL string, isRecursive, noTargetDir bool, listWorkers int, at time.Time, keyEnc keyEncoder, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
 	copyURLsCh := make(chan URLs)
 	go func(sourceURLs []string, targetURL string, copyURLsCh chan URLs) {
 		defer close(copyURLsCh)
 		for _, sourceURL := range sourceURLs {
-			for cpURLs := range prepareCopyURLsTypeC(sourceURL, targetURL, isRecursive, encKeyDB) {
+			for cpURLs := range prepareCopyURLsTypeC(sourceURL, targetURL, isRecursive, noTargetDir, listWorkers, at, keyEnc, encKeyDB) {
 				copyURLsCh <- cpURLs
 			}
 		}
@@ -213,24 +241,24 @@ func prepareCopyURLsTypeD(sourceURLs []string, targetURL string, isRecursive boo
 }
 
 // prepareCopyURLs - prepares target and source clientURLs for copying.
-func prepareCopyURLs(sourceURLs []string, targetURL string, isRecursive bool, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+func prepareCopyURLs(sourceURLs []string, targetURL string, isRecursive, noTargetDir bool, listWorkers int, at time.Time, keyEnc keyEncoder, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
 	copyURLsCh := make(chan URLs)
 	go func(sourceURLs []string, targetURL string, copyURLsCh chan URLs, encKeyDB map[string][]prefixSSEPair) {
 		defer close(copyURLsCh)
-		cpType, err := guessCopyURLType(sourceURLs, targetURL, isRecursive, encKeyDB)
+		cpType, err := guessCopyURLType(sourceURLs, targetURL, isRecursive, at, encKeyDB)
 		fatalIf(err.Trace(), "Unable to guess the type of copy operation.")
 
 		switch cpType {
 		case copyURLsTypeA:
-			copyURLsCh <- prepareCopyURLsTypeA(sourceURLs[0], targetURL, encKeyDB)
+			copyURLsCh <- prepareCopyURLsTypeA(sourceURLs[0], targetURL, at, encKeyDB)
 		case copyURLsTypeB:
-			copyURLsCh <- prepareCopyURLsTypeB(sourceURLs[0], targetURL, encKeyDB)
+			copyURLsCh <- prepareCopyURLsTypeB(sourceURLs[0], targetURL, at, keyEnc, encKeyDB)
 		case copyURLsTypeC:
-			for cURLs := range prepareCopyURLsTypeC(sourceURLs[0], targetURL, isRecursive, encKeyDB) {
+			for cURLs := range prepareCopyURLsTypeC(sourceURLs[0], targetURL, isRecursive, noTargetDir, listWorkers, at, keyEnc, encKeyDB) {
 				copyURLsCh <- cURLs
 			}
 		case copyURLsTypeD:
-			for cURLs := range prepareCopyURLsTypeD(sourceURLs, targetURL, isRecursive, encKeyDB) {
+			for cURLs := range prepareCopyURLsTypeD(sourceURLs, targetURL, isRecursive, noTargetDir, listWorkers, at, keyEnc, encKeyDB) {
 				copyURLsCh <- cURLs
 			}
 		default:
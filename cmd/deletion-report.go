@@ -0,0 +1,161 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// deletionEntry - an object removed, along with the version removed on
+// versioned buckets for the object to be restored from it.
+type deletionEntry struct {
+	Key          string    `json:"key"`
+	VersionID    string    `json:"versionId,omitempty"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"lastModified,omitempty"`
+}
+
+// deletionReport - the objects removed by a run, written to a file as
+// they are removed: {"time":..., "fake":..., "objects":[...]}.
+type deletionReport struct {
+	mutex sync.Mutex
+	file  *os.File
+	count int
+}
+
+// newDeletionReport - creates the report at path, it is complete once
+// closed.
+func newDeletionReport(path string, isFake bool) (*deletionReport, *probe.Error) {
+	file, e := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(path)
+	}
+	if _, e = fmt.Fprintf(file, "{\"time\":%q,\"fake\":%t,\"objects\":[", UTCNow().Format(time.RFC3339), isFake); e != nil {
+		file.Close()
+		return nil, probe.NewError(e).Trace(path)
+	}
+	return &deletionReport{file: file}, nil
+}
+
+// add - appends an object removed to the report.
+func (r *deletionReport) add(entry deletionEntry) *probe.Error {
+	data, e := json.Marshal(entry)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	sep := ",\n"
+	if r.count == 0 {
+		sep = "\n"
+	}
+	if _, e = r.file.WriteString(sep + string(data)); e != nil {
+		return probe.NewError(e).Trace(r.file.Name())
+	}
+	r.count++
+	return nil
+}
+
+// close - completes the report.
+func (r *deletionReport) close() *probe.Error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, e := r.file.WriteString("\n]}\n"); e != nil {
+		r.file.Close()
+		return probe.NewError(e).Trace(r.file.Name())
+	}
+	if e := r.file.Close(); e != nil {
+		return probe.NewError(e).Trace(r.file.Name())
+	}
+	return nil
+}
+
+// contentVersionID - returns the version of an object stated, empty if
+// its bucket is not versioned.
+func contentVersionID(content *clientContent) string {
+	if id := content.Metadata["X-Amz-Version-Id"]; id != "null" {
+		return id
+	}
+	return ""
+}
+
+// versionCursor - walks the versions of the objects of a bucket along
+// with a listing of them in the same lexical order, the versions of a
+// key are found without listing or stating it on its own.
+type versionCursor struct {
+	bucket     string
+	versionsCh <-chan objectVersion
+	current    objectVersion
+	ok         bool
+}
+
+// newVersionCursor - returns the cursor of the versions under the path
+// of clnt, one that finds no versions if clnt is not on S3.
+func newVersionCursor(clnt Client) *versionCursor {
+	s3Clnt, isS3 := clnt.(*s3Client)
+	if !isS3 {
+		return &versionCursor{}
+	}
+	bucket, _ := s3Clnt.url2BucketAndObject()
+	if bucket == "" {
+		return &versionCursor{}
+	}
+	v := &versionCursor{bucket: bucket, versionsCh: s3Clnt.listObjectVersions()}
+	v.next()
+	return v
+}
+
+// next - moves to the next version, lists ending with an error end the
+// cursor as it only adds to the report.
+func (v *versionCursor) next() {
+	v.current, v.ok = <-v.versionsCh
+	if v.ok && v.current.Err != nil {
+		v.ok = false
+	}
+}
+
+// lookup - returns the current version of the object at path, of the
+// form /bucket/key, empty if it is unknown. Paths are looked up in
+// lexical order.
+func (v *versionCursor) lookup(path string) string {
+	if v.versionsCh == nil {
+		return ""
+	}
+	key := strings.TrimPrefix(strings.TrimPrefix(path, "/"), v.bucket+"/")
+	for v.ok && v.current.Key < key {
+		v.next()
+	}
+	if !v.ok || v.current.Key != key {
+		return ""
+	}
+	// Versions of a key come newest first.
+	version := v.current
+	for v.ok && v.current.Key == key {
+		v.next()
+	}
+	if version.IsDeleteMarker || version.VersionID == "null" {
+		return ""
+	}
+	return version.VersionID
+}
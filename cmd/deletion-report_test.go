@@ -0,0 +1,103 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVersionCursorLookup(t *testing.T) {
+	versionsCh := make(chan objectVersion, 10)
+	for _, v := range []objectVersion{
+		{Key: "a.txt", VersionID: "v3"},
+		{Key: "a.txt", VersionID: "v2"},
+		{Key: "b.txt", VersionID: "v1", IsDeleteMarker: true},
+		{Key: "c/d.txt", VersionID: "null"},
+		{Key: "e.txt", VersionID: "v4"},
+		{Key: "f.txt", VersionID: "v5"},
+	} {
+		versionsCh <- v
+	}
+	close(versionsCh)
+
+	cursor := &versionCursor{bucket: "bucket", versionsCh: versionsCh}
+	cursor.next()
+	testCases := []struct {
+		path      string
+		versionID string
+	}{
+		{"/bucket/a.txt", "v3"},
+		{"/bucket/b.txt", ""},
+		{"/bucket/c/d.txt", ""},
+		{"/bucket/d.txt", ""},
+		{"/bucket/f.txt", "v5"},
+		{"/bucket/g.txt", ""},
+	}
+	for i, testCase := range testCases {
+		if versionID := cursor.lookup(testCase.path); versionID != testCase.versionID {
+			t.Errorf("Test %d: expected version `%s` of `%s`, got `%s`", i+1, testCase.versionID, testCase.path, versionID)
+		}
+	}
+
+	if versionID := (&versionCursor{}).lookup("/bucket/a.txt"); versionID != "" {
+		t.Errorf("Expected no version without versions, got `%s`", versionID)
+	}
+}
+
+func TestDeletionReport(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-report")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "deleted.json")
+	report, err := newDeletionReport(path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range []deletionEntry{
+		{Key: "s3/bucket/a.txt", VersionID: "v1", Size: 1},
+		{Key: "s3/bucket/b.txt", Size: 2},
+	} {
+		if err = report.add(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = report.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, e := ioutil.ReadFile(path)
+	if e != nil {
+		t.Fatal(e)
+	}
+	var result struct {
+		Fake    bool            `json:"fake"`
+		Objects []deletionEntry `json:"objects"`
+	}
+	if e = json.Unmarshal(data, &result); e != nil {
+		t.Fatalf("Report is not valid JSON: %v\n%s", e, data)
+	}
+	if !result.Fake || len(result.Objects) != 2 || result.Objects[0].VersionID != "v1" || result.Objects[1].Key != "s3/bucket/b.txt" {
+		t.Errorf("Unexpected report %s", data)
+	}
+}
@@ -0,0 +1,220 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Changes of objects between two points in time.
+const (
+	changeCreated  = "created"
+	changeModified = "modified"
+	changeDeleted  = "deleted"
+)
+
+// diffChangeMessage - an object changed between --since and --until.
+type diffChangeMessage struct {
+	Status       string    `json:"status"`
+	Change       string    `json:"change"`
+	Key          string    `json:"key"`
+	URL          string    `json:"url"`
+	VersionID    string    `json:"versionId,omitempty"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+// String colorized change message.
+func (d diffChangeMessage) String() string {
+	switch d.Change {
+	case changeCreated:
+		return console.Colorize("DiffCreated", "+ "+printableKey(d.URL))
+	case changeModified:
+		return console.Colorize("DiffModified", "! "+printableKey(d.URL))
+	default:
+		return console.Colorize("DiffDeleted", "- "+printableKey(d.URL))
+	}
+}
+
+// JSON jsonified change message.
+func (d diffChangeMessage) JSON() string {
+	d.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(d, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// diffExportEntry - an object of the manifest written by --export.
+type diffExportEntry struct {
+	Change       string    `json:"change"`
+	Key          string    `json:"key"`
+	URL          string    `json:"url"`
+	VersionID    string    `json:"versionId,omitempty"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+// diffExportManifest - the objects of a bucket changed between two
+// points in time, written by --export.
+type diffExportManifest struct {
+	Source  string            `json:"source"`
+	Since   time.Time         `json:"since"`
+	Until   time.Time         `json:"until"`
+	Objects []diffExportEntry `json:"objects"`
+}
+
+// parseDiffTime - parses a date or an RFC3339 time given to --since or
+// --until, dates are in UTC.
+func parseDiffTime(value string) (time.Time, *probe.Error) {
+	if t, e := time.Parse("2006-01-02", value); e == nil {
+		return t, nil
+	}
+	t, e := time.Parse(time.RFC3339, value)
+	if e != nil {
+		return time.Time{}, probe.NewError(e).Trace(value)
+	}
+	return t, nil
+}
+
+// versionChange - returns how the object of versions, newest first,
+// changed between since and until along with the version telling it,
+// empty if it did not change.
+func versionChange(versions []objectVersion, since, until time.Time) (string, objectVersion) {
+	// versionAt - index of the version current at t, -1 if none or if
+	// the object was deleted.
+	versionAt := func(t time.Time) int {
+		for i, v := range versions {
+			if v.LastModified.Before(t) {
+				if v.IsDeleteMarker {
+					return -1
+				}
+				return i
+			}
+		}
+		return -1
+	}
+	before, after := versionAt(since), versionAt(until)
+	switch {
+	case before == after:
+		return "", objectVersion{}
+	case before == -1:
+		return changeCreated, versions[after]
+	case after == -1:
+		return changeDeleted, versions[before]
+	}
+	return changeModified, versions[after]
+}
+
+// listVersionChanges - lists the objects of s3Clnt changed between since
+// and until from their versions, their URLs start with bucketURL.
+func listVersionChanges(s3Clnt *s3Client, bucketURL string, since, until time.Time) <-chan diffChangeMessage {
+	changesCh := make(chan diffChangeMessage)
+	go func() {
+		defer close(changesCh)
+		var versions []objectVersion
+		flush := func() {
+			if len(versions) == 0 {
+				return
+			}
+			if change, v := versionChange(versions, since, until); change != "" {
+				changesCh <- diffChangeMessage{
+					Change:       change,
+					Key:          v.Key,
+					URL:          bucketURL + v.Key,
+					VersionID:    v.VersionID,
+					Size:         v.Size,
+					LastModified: v.LastModified,
+				}
+			}
+			versions = nil
+		}
+		for v := range s3Clnt.listObjectVersions() {
+			fatalIf(v.Err, "Unable to list versions of `"+s3Clnt.GetURL().String()+"`.")
+			if len(versions) > 0 && versions[0].Key != v.Key {
+				flush()
+			}
+			versions = append(versions, v)
+		}
+		flush()
+	}()
+	return changesCh
+}
+
+// mainDiffChanges - lists the objects of a bucket created, modified or
+// deleted between --since and --until, optionally exporting them.
+func mainDiffChanges(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 || ctx.String("since") == "" {
+		cli.ShowCommandHelpAndExit(ctx, "diff", 1) // last argument is exit code
+	}
+	urlStr := ctx.Args().Get(0)
+
+	since, err := parseDiffTime(ctx.String("since"))
+	fatalIf(err, "Unable to parse ‘--since’, use a date such as 2019-08-01 or an RFC3339 time.")
+	until := UTCNow()
+	if ctx.String("until") != "" {
+		until, err = parseDiffTime(ctx.String("until"))
+		fatalIf(err, "Unable to parse ‘--until’, use a date such as 2019-08-01 or an RFC3339 time.")
+	}
+	if !until.After(since) {
+		fatalIf(errInvalidArgument().Trace(ctx.String("since"), ctx.String("until")), "‘--until’ must be after ‘--since’.")
+	}
+
+	console.SetColor("DiffCreated", color.New(color.FgGreen))
+	console.SetColor("DiffModified", color.New(color.FgYellow, color.Bold))
+	console.SetColor("DiffDeleted", color.New(color.FgRed))
+
+	// URLs are given with the alias of the bucket, as taken by the other
+	// commands.
+	s3Clnt := newBucketClient(urlStr)
+	bucket, _ := s3Clnt.url2BucketAndObject()
+	alias, _ := url2Alias(urlStr)
+	if alias == "" {
+		alias = strings.TrimSuffix(s3Clnt.GetURL().String(), s3Clnt.GetURL().Path)
+	}
+	bucketURL := alias + "/" + bucket + "/"
+
+	manifest := diffExportManifest{Source: urlStr, Since: since, Until: until, Objects: []diffExportEntry{}}
+	for change := range listVersionChanges(s3Clnt, bucketURL, since, until) {
+		printMsg(change)
+		manifest.Objects = append(manifest.Objects, diffExportEntry{
+			Change:       change.Change,
+			Key:          change.Key,
+			URL:          change.URL,
+			VersionID:    change.VersionID,
+			Size:         change.Size,
+			LastModified: change.LastModified,
+		})
+	}
+
+	exportFile := ctx.String("export")
+	if exportFile == "" {
+		return nil
+	}
+	manifestBytes, e := json.MarshalIndent(manifest, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	fatalIf(probe.NewError(ioutil.WriteFile(exportFile, manifestBytes, 0644)), "Unable to export changes to `"+exportFile+"`.")
+	return nil
+}
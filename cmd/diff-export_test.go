@@ -0,0 +1,57 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVersionChange(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2019, 5, d, 0, 0, 0, 0, time.UTC)
+	}
+	version := func(id string, d int) objectVersion {
+		return objectVersion{Key: "a", VersionID: id, LastModified: day(d)}
+	}
+	deleteMarker := func(id string, d int) objectVersion {
+		return objectVersion{Key: "a", VersionID: id, LastModified: day(d), IsDeleteMarker: true}
+	}
+	since, until := day(10), day(20)
+
+	// Versions are newest first.
+	testCases := []struct {
+		versions  []objectVersion
+		change    string
+		versionID string
+	}{
+		{[]objectVersion{version("v1", 1)}, "", ""},
+		{[]objectVersion{version("v1", 15)}, changeCreated, "v1"},
+		{[]objectVersion{version("v2", 15), version("v1", 1)}, changeModified, "v2"},
+		{[]objectVersion{deleteMarker("d1", 15), version("v1", 1)}, changeDeleted, "v1"},
+		{[]objectVersion{deleteMarker("d1", 16), version("v1", 15)}, "", ""},
+		{[]objectVersion{version("v2", 12), deleteMarker("d1", 5), version("v1", 1)}, changeCreated, "v2"},
+		{[]objectVersion{version("v2", 25), version("v1", 1)}, "", ""},
+		{[]objectVersion{version("v2", 10), version("v1", 1)}, changeModified, "v2"},
+	}
+	for i, testCase := range testCases {
+		change, v := versionChange(testCase.versions, since, until)
+		if change != testCase.change || v.VersionID != testCase.versionID {
+			t.Errorf("Test %d: expected %q of %q, got %q of %q", i+1, testCase.change, testCase.versionID, change, v.VersionID)
+		}
+	}
+}
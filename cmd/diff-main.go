@@ -29,7 +29,32 @@ import (
 
 // diff specific flags.
 var (
-	diffFlags = []cli.Flag{}
+	diffFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "encode-chars",
+			Usage: "compare local file names with reversibly encoded characters, use 'auto' for platform defaults",
+		},
+		cli.StringFlag{
+			Name:  "inventory",
+			Usage: "list the first or second bucket from the manifest of an S3 Inventory report (CSV only)",
+		},
+		cli.BoolFlag{
+			Name:  "metadata",
+			Usage: "also list object(s) of the same size whose content-type, user metadata or tags differ",
+		},
+		cli.StringFlag{
+			Name:  "since",
+			Usage: "list object(s) of a versioned bucket created, modified or deleted since a date or an RFC3339 time",
+		},
+		cli.StringFlag{
+			Name:  "until",
+			Usage: "with --since, list changes made before a date or an RFC3339 time, now by default",
+		},
+		cli.StringFlag{
+			Name:  "export",
+			Usage: "with --since, write the changed object(s) to a JSON manifest",
+		},
+	}
 )
 
 // Compute differences in object name, size, and date between two buckets.
@@ -44,6 +69,7 @@ var diffCmd = cli.Command{
 
 USAGE:
   {{.HelpName}} [FLAGS] FIRST SECOND
+  {{.HelpName}} [FLAGS] --since TIME [--until TIME] TARGET
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -52,10 +78,20 @@ DESCRIPTION:
   Diff only calculates differences in object name, size and time.
   It *DOES NOT* compare objects' contents.
 
+  With --metadata objects of the same size are also compared by their
+  content-type, user metadata and tags.
+
+  With --since a single versioned bucket is compared with itself at an
+  earlier time, from the versions and delete markers of its objects.
+
 LEGEND:
     > - object is only in source.
     < - object is only in destination.
     ! - newer object is in source.
+    ~ - object metadata differs, with --metadata.
+    + - object was created, with --since.
+    ! - object was modified, with --since.
+    - - object was deleted, with --since.
 
 EXAMPLES:
   1. Compare a local folder with a folder on Amazon S3 cloud storage.
@@ -63,6 +99,18 @@ EXAMPLES:
 
   2. Compare two folders on a local filesystem.
      $ {{.HelpName}} ~/Photos /Media/Backup/Photos
+
+  3. Compare a bucket with a local folder on Windows previously mirrored using '--encode-chars'.
+     $ {{.HelpName}} --encode-chars auto s3/mybucket/logs C:\Backup\logs
+
+  4. Compare a bucket with its replica, listing the bucket from its latest inventory report.
+     $ {{.HelpName}} --inventory s3/reports/photos/daily/2019-08-01T00-00Z/manifest.json s3/photos backup/photos
+
+  5. Export the objects of a versioned bucket created, modified or deleted in May 2019 to a manifest.
+     $ {{.HelpName}} --since 2019-05-01 --until 2019-06-01 --export manifest.json s3/photos
+
+  6. Compare a bucket with the bucket it was migrated to, also listing objects whose metadata or tags were dropped.
+     $ {{.HelpName}} --metadata s3/photos minio/photos
 `,
 }
 
@@ -72,6 +120,7 @@ type diffMessage struct {
 	FirstURL      string       `json:"first"`
 	SecondURL     string       `json:"second"`
 	Diff          differType   `json:"diff"`
+	Metadata      []string     `json:"metadata,omitempty"`
 	Error         *probe.Error `json:"error,omitempty"`
 	firstContent  *clientContent
 	secondContent *clientContent
@@ -82,15 +131,17 @@ func (d diffMessage) String() string {
 	msg := ""
 	switch d.Diff {
 	case differInFirst:
-		msg = console.Colorize("DiffOnlyInFirst", "< "+d.FirstURL)
+		msg = console.Colorize("DiffOnlyInFirst", "< "+printableKey(d.FirstURL))
 	case differInSecond:
-		msg = console.Colorize("DiffOnlyInSecond", "> "+d.SecondURL)
+		msg = console.Colorize("DiffOnlyInSecond", "> "+printableKey(d.SecondURL))
 	case differInType:
-		msg = console.Colorize("DiffType", "! "+d.SecondURL)
+		msg = console.Colorize("DiffType", "! "+printableKey(d.SecondURL))
 	case differInSize:
-		msg = console.Colorize("DiffSize", "! "+d.SecondURL)
+		msg = console.Colorize("DiffSize", "! "+printableKey(d.SecondURL))
 	case differInTime:
-		msg = console.Colorize("DiffTime", "! "+d.SecondURL)
+		msg = console.Colorize("DiffTime", "! "+printableKey(d.SecondURL))
+	case differInMetadata:
+		msg = console.Colorize("DiffMetadata", "~ "+printableKey(d.SecondURL)+" ("+strings.Join(d.Metadata, ", ")+")")
 	default:
 		fatalIf(errDummy().Trace(d.FirstURL, d.SecondURL),
 			"Unhandled difference between `"+d.FirstURL+"` and `"+d.SecondURL+"`.")
@@ -146,8 +197,9 @@ func checkDiffSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 	}
 }
 
-// doDiffMain runs the diff.
-func doDiffMain(firstURL, secondURL string) error {
+// doDiffMain runs the diff, objects of the same size are also
+// compared by metadata if withMetadata is set.
+func doDiffMain(firstURL, secondURL string, keyEnc keyEncoder, inventory *inventoryManifest, withMetadata bool, encKeyDB map[string][]prefixSSEPair) error {
 	// Source and targets are always directories
 	sourceSeparator := string(newClientURL(firstURL).Separator)
 	if !strings.HasSuffix(firstURL, sourceSeparator) {
@@ -174,13 +226,36 @@ func doDiffMain(firstURL, secondURL string) error {
 			fmt.Sprintf("Failed to diff '%s' and '%s'", firstURL, secondURL))
 	}
 
-	// Diff first and second urls.
-	for diffMsg := range objectDifference(firstClient, secondClient, firstURL, secondURL) {
+	// Whichever bucket the inventory report is for is listed from it.
+	firstClient, secondClient = withInventory(firstClient, secondClient, inventory)
+
+	// Diff first and second urls, unchanged objects are only needed to
+	// compare their metadata.
+	var diffCh chan diffMessage
+	var metadata *metadataDiffer
+	if withMetadata {
+		diffCh = snapshotDifference(firstClient, secondClient, firstURL, secondURL, keyEnc)
+		metadata = &metadataDiffer{firstAlias: firstAlias, secondAlias: secondAlias, encKeyDB: encKeyDB}
+	} else {
+		diffCh = objectDifference(firstClient, secondClient, firstURL, secondURL, keyEnc)
+	}
+	for diffMsg := range diffCh {
 		if diffMsg.Error != nil {
 			errorIf(diffMsg.Error, "Unable to calculate objects difference.")
 			// Ignore error and proceed to next object.
 			continue
 		}
+		if diffMsg.Diff == differInNone {
+			names, err := metadata.differs(diffMsg.firstContent, diffMsg.secondContent)
+			if err != nil {
+				errorIf(err, "Unable to compare metadata of `"+diffMsg.FirstURL+"` and `"+diffMsg.SecondURL+"`.")
+				continue
+			}
+			if len(names) == 0 {
+				continue
+			}
+			diffMsg.Diff, diffMsg.Metadata = differInMetadata, names
+		}
 		printMsg(diffMsg)
 	}
 
@@ -193,9 +268,17 @@ func mainDiff(ctx *cli.Context) error {
 	encKeyDB, err := getEncKeys(ctx)
 	fatalIf(err, "Unable to parse encryption keys.")
 
+	// Changes of a single bucket over time.
+	if ctx.String("since") != "" || ctx.String("until") != "" || ctx.String("export") != "" {
+		return mainDiffChanges(ctx)
+	}
+
 	// check 'diff' cli arguments.
 	checkDiffSyntax(ctx, encKeyDB)
 
+	keyEnc, err := newKeyEncoder(ctx.String("encode-chars"))
+	fatalIf(err, "Unable to parse characters to encode.")
+
 	// Additional command specific theme customization.
 	console.SetColor("DiffMessage", color.New(color.FgGreen, color.Bold))
 	console.SetColor("DiffOnlyInFirst", color.New(color.FgRed))
@@ -203,10 +286,17 @@ func mainDiff(ctx *cli.Context) error {
 	console.SetColor("DiffType", color.New(color.FgMagenta))
 	console.SetColor("DiffSize", color.New(color.FgYellow, color.Bold))
 	console.SetColor("DiffTime", color.New(color.FgYellow, color.Bold))
+	console.SetColor("DiffMetadata", color.New(color.FgCyan))
+
+	var inventory *inventoryManifest
+	if manifestURL := ctx.String("inventory"); manifestURL != "" {
+		inventory, err = readInventoryManifest(manifestURL)
+		fatalIf(err, "Unable to read inventory manifest `"+manifestURL+"`.")
+	}
 
 	URLs := ctx.Args()
 	firstURL := URLs.Get(0)
 	secondURL := URLs.Get(1)
 
-	return doDiffMain(firstURL, secondURL)
+	return doDiffMain(firstURL, secondURL, keyEnc, inventory, ctx.Bool("metadata"), encKeyDB)
 }
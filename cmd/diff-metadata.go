@@ -0,0 +1,142 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Name reported for objects whose tags differ.
+const tagsMetadataName = "tags"
+
+// metadataDiffer - compares the content-type, user metadata and tags of
+// objects of the first and second alias, for diff --metadata.
+type metadataDiffer struct {
+	firstAlias, secondAlias string
+	encKeyDB                map[string][]prefixSSEPair
+
+	// Set once an endpoint is found to have no object tagging.
+	noTags bool
+}
+
+// differs - returns the names of the metadata of first and second which
+// differ, sorted, nil if they are not both objects.
+func (m *metadataDiffer) differs(first, second *clientContent) ([]string, *probe.Error) {
+	if first.URL.Type != objectStorage || second.URL.Type != objectStorage {
+		return nil, nil
+	}
+	firstClnt, firstMetadata, err := m.stat(m.firstAlias, first)
+	if err != nil {
+		return nil, err.Trace(first.URL.String())
+	}
+	secondClnt, secondMetadata, err := m.stat(m.secondAlias, second)
+	if err != nil {
+		return nil, err.Trace(second.URL.String())
+	}
+	names := compareMetadata(firstMetadata, secondMetadata)
+
+	if !m.noTags {
+		firstTags, secondTags, err := m.tags(firstClnt, secondClnt)
+		if err != nil {
+			return nil, err.Trace(first.URL.String(), second.URL.String())
+		}
+		if !tagsEqual(firstTags, secondTags) {
+			names = append(names, tagsMetadataName)
+		}
+	}
+	return names, nil
+}
+
+// stat - returns the client and metadata of the object content of alias.
+func (m *metadataDiffer) stat(alias string, content *clientContent) (Client, map[string]string, *probe.Error) {
+	clnt, err := newClientFromAlias(alias, content.URL.String())
+	if err != nil {
+		return nil, nil, err
+	}
+	sse := getSSE(filepath.ToSlash(filepath.Join(alias, clnt.GetURL().Path)), m.encKeyDB[alias])
+	st, err := clnt.Stat(false, true, sse)
+	if err != nil {
+		return nil, nil, err
+	}
+	return clnt, st.Metadata, nil
+}
+
+// tags - returns the tags of both objects, tags are no longer compared
+// once an endpoint is found to have no object tagging.
+func (m *metadataDiffer) tags(first, second Client) (firstTags, secondTags map[string]string, err *probe.Error) {
+	firstS3, firstOk := first.(*s3Client)
+	secondS3, secondOk := second.(*s3Client)
+	if !firstOk || !secondOk {
+		m.noTags = true
+		return nil, nil, nil
+	}
+	if firstTags, err = firstS3.GetObjectTags(); err == nil {
+		secondTags, err = secondS3.GetObjectTags()
+	}
+	if err != nil {
+		if _, ok := err.ToGoError().(APINotImplemented); ok {
+			m.noTags = true
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	return firstTags, secondTags, nil
+}
+
+// compareMetadata - returns the names of the content-type and user
+// metadata which differ between first and second, sorted.
+func compareMetadata(first, second map[string]string) []string {
+	var names []string
+	for name := range comparedMetadataNames(first, second) {
+		if first[name] != second[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// comparedMetadataNames - returns the names of the metadata of first or
+// second compared by diff --metadata.
+func comparedMetadataNames(first, second map[string]string) map[string]bool {
+	names := make(map[string]bool)
+	for _, metadata := range []map[string]string{first, second} {
+		for name := range metadata {
+			if name == "Content-Type" || strings.HasPrefix(name, "X-Amz-Meta-") {
+				names[name] = true
+			}
+		}
+	}
+	return names
+}
+
+// tagsEqual - returns true if first and second hold the same tags.
+func tagsEqual(first, second map[string]string) bool {
+	if len(first) != len(second) {
+		return false
+	}
+	for key, value := range first {
+		if other, ok := second[key]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
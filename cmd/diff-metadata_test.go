@@ -0,0 +1,74 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompareMetadata(t *testing.T) {
+	testCases := []struct {
+		first, second map[string]string
+		names         []string
+	}{
+		{
+			map[string]string{"Content-Type": "image/jpeg", "X-Amz-Meta-Camera": "x100"},
+			map[string]string{"Content-Type": "image/jpeg", "X-Amz-Meta-Camera": "x100"},
+			nil,
+		},
+		{
+			map[string]string{"Content-Type": "image/jpeg", "X-Amz-Meta-Camera": "x100"},
+			map[string]string{"Content-Type": "application/octet-stream"},
+			[]string{"Content-Type", "X-Amz-Meta-Camera"},
+		},
+		{
+			map[string]string{"Content-Type": "text/plain"},
+			map[string]string{"Content-Type": "text/plain", "X-Amz-Meta-Owner": "alice"},
+			[]string{"X-Amz-Meta-Owner"},
+		},
+		// Only content-type and user metadata are compared.
+		{
+			map[string]string{"Content-Type": "text/plain", "X-Amz-Storage-Class": "STANDARD"},
+			map[string]string{"Content-Type": "text/plain", "X-Amz-Storage-Class": "GLACIER"},
+			nil,
+		},
+	}
+	for i, testCase := range testCases {
+		if names := compareMetadata(testCase.first, testCase.second); !reflect.DeepEqual(names, testCase.names) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.names, names)
+		}
+	}
+}
+
+func TestTagsEqual(t *testing.T) {
+	testCases := []struct {
+		first, second map[string]string
+		equal         bool
+	}{
+		{nil, map[string]string{}, true},
+		{map[string]string{"team": "web"}, map[string]string{"team": "web"}, true},
+		{map[string]string{"team": "web"}, map[string]string{"team": "db"}, false},
+		{map[string]string{"team": "web"}, map[string]string{"owner": "web"}, false},
+		{map[string]string{"team": "web"}, nil, false},
+	}
+	for i, testCase := range testCases {
+		if equal := tagsEqual(testCase.first, testCase.second); equal != testCase.equal {
+			t.Fatalf("Test %d: expected %t, got %t", i+1, testCase.equal, equal)
+		}
+	}
+}
@@ -29,12 +29,13 @@ import (
 type differType int
 
 const (
-	differInNone   differType = iota // does not differ
-	differInSize                     // differs in size
-	differInTime                     // differs in time
-	differInType                     // differs in type, exfile/directory
-	differInFirst                    // only in source (FIRST)
-	differInSecond                   // only in target (SECOND)
+	differInNone     differType = iota // does not differ
+	differInSize                       // differs in size
+	differInTime                       // differs in time
+	differInType                       // differs in type, exfile/directory
+	differInFirst                      // only in source (FIRST)
+	differInSecond                     // only in target (SECOND)
+	differInMetadata                   // differs in metadata only
 )
 
 func (d differType) String() string {
@@ -51,21 +52,33 @@ func (d differType) String() string {
 		return "only-in-first"
 	case differInSecond:
 		return "only-in-second"
+	case differInMetadata:
+		return "metadata"
 	}
 	return "unknown"
 }
 
-func objectDifference(sourceClnt, targetClnt Client, sourceURL, targetURL string) (diffCh chan diffMessage) {
-	return difference(sourceClnt, targetClnt, sourceURL, targetURL, true, false, DirNone)
+func objectDifference(sourceClnt, targetClnt Client, sourceURL, targetURL string, keyEnc keyEncoder) (diffCh chan diffMessage) {
+	return difference(sourceClnt, targetClnt, sourceURL, targetURL, true, false, DirNone, keyEnc)
+}
+
+// snapshotDifference - like objectDifference but also reports objects
+// which did not change.
+func snapshotDifference(sourceClnt, targetClnt Client, sourceURL, targetURL string, keyEnc keyEncoder) (diffCh chan diffMessage) {
+	return difference(sourceClnt, targetClnt, sourceURL, targetURL, true, true, DirNone, keyEnc)
 }
 
 func dirDifference(sourceClnt, targetClnt Client, sourceURL, targetURL string) (diffCh chan diffMessage) {
-	return difference(sourceClnt, targetClnt, sourceURL, targetURL, false, true, DirFirst)
+	return difference(sourceClnt, targetClnt, sourceURL, targetURL, false, true, DirFirst, keyEncoder{})
 }
 
 // objectDifference function finds the difference between all objects
-// recursively in sorted order from source and target.
-func difference(sourceClnt, targetClnt Client, sourceURL, targetURL string, isRecursive, returnSimilar bool, dirOpt DirOpt) (diffCh chan diffMessage) {
+// recursively in sorted order from source and target. Names listed from
+// the local filesystem are compared by the object keys they encode.
+//
+// Both listings are produced concurrently and merged in a single pass,
+// no object is looked up on its own.
+func difference(sourceClnt, targetClnt Client, sourceURL, targetURL string, isRecursive, returnSimilar bool, dirOpt DirOpt, keyEnc keyEncoder) (diffCh chan diffMessage) {
 	var (
 		srcEOF, tgtEOF       bool
 		srcOk, tgtOk         bool
@@ -78,6 +91,14 @@ func difference(sourceClnt, targetClnt Client, sourceURL, targetURL string, isRe
 	srcCh := sourceClnt.List(isRecursive, isIncomplete, dirOpt)
 	tgtCh := targetClnt.List(isRecursive, isIncomplete, dirOpt)
 
+	// Local names are listed in the order of their encoded form.
+	if keyEnc.isEnabled() && sourceClnt.GetURL().Type == fileSystem {
+		srcCh = keyEnc.sortByKey(srcCh, sourceURL)
+	}
+	if keyEnc.isEnabled() && targetClnt.GetURL().Type == fileSystem {
+		tgtCh = keyEnc.sortByKey(tgtCh, targetURL)
+	}
+
 	diffCh = make(chan diffMessage, 1000)
 
 	go func() {
@@ -128,8 +149,8 @@ func difference(sourceClnt, targetClnt Client, sourceURL, targetURL string, isRe
 				continue
 			}
 
-			srcSuffix = strings.TrimPrefix(srcCtnt.URL.String(), sourceURL)
-			tgtSuffix = strings.TrimPrefix(tgtCtnt.URL.String(), targetURL)
+			srcSuffix = keyEnc.keyOf(strings.TrimPrefix(srcCtnt.URL.String(), sourceURL), srcCtnt.URL.Type)
+			tgtSuffix = keyEnc.keyOf(strings.TrimPrefix(tgtCtnt.URL.String(), targetURL), tgtCtnt.URL.Type)
 
 			current := urlJoinPath(targetURL, srcSuffix)
 			expected := urlJoinPath(targetURL, tgtSuffix)
@@ -176,6 +197,8 @@ func difference(sourceClnt, targetClnt Client, sourceURL, targetURL string, isRe
 						firstContent:  srcCtnt,
 						secondContent: tgtCtnt,
 					}
+					srcCtnt, srcOk = <-srcCh
+					tgtCtnt, tgtOk = <-tgtCh
 					continue
 				}
 				if (srcType.IsRegular() && tgtType.IsRegular()) && srcSize != tgtSize {
@@ -187,8 +210,9 @@ func difference(sourceClnt, targetClnt Client, sourceURL, targetURL string, isRe
 						firstContent:  srcCtnt,
 						secondContent: tgtCtnt,
 					}
-				} else if srcTime.After(tgtTime) {
-					// Regular files differing in timestamp.
+				} else if srcTime.After(tgtTime) && !isSameContent(srcCtnt, tgtCtnt, nil) {
+					// Regular files differing in timestamp, objects of
+					// the same ETag are not newer.
 					diffCh <- diffMessage{
 						FirstURL:      srcCtnt.URL.String(),
 						SecondURL:     tgtCtnt.URL.String(),
@@ -196,9 +220,8 @@ func difference(sourceClnt, targetClnt Client, sourceURL, targetURL string, isRe
 						firstContent:  srcCtnt,
 						secondContent: tgtCtnt,
 					}
-				}
-				// No differ
-				if returnSimilar {
+				} else if returnSimilar {
+					// No differ
 					diffCh <- diffMessage{
 						FirstURL:      srcCtnt.URL.String(),
 						SecondURL:     tgtCtnt.URL.String(),
@@ -46,3 +46,23 @@ func TestExcludeOptions(t *testing.T) {
 		}
 	}
 }
+
+func TestMirrorExcluded(t *testing.T) {
+	testCases := []struct {
+		exclude, include []string
+		suffix           string
+		excluded         bool
+	}{
+		{nil, nil, "photos/a.jpg", false},
+		{[]string{"*.tmp"}, nil, "photos/a.tmp", true},
+		{nil, []string{"*.jpg"}, "photos/a.jpg", false},
+		{nil, []string{"*.jpg"}, "photos/a.png", true},
+		{[]string{"*.DS_Store"}, []string{"photos/*"}, "photos/.DS_Store", true},
+		{[]string{"*.tmp"}, []string{"*.jpg"}, "", false},
+	}
+	for i, testCase := range testCases {
+		if excluded := isMirrorExcluded(testCase.exclude, testCase.include, testCase.suffix); excluded != testCase.excluded {
+			t.Errorf("Test %d: expected excluded %t for `%s`, got %t", i+1, testCase.excluded, testCase.suffix, excluded)
+		}
+	}
+}
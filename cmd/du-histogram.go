@@ -0,0 +1,116 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/mc/pkg/console"
+)
+
+// Objects are counted in the first size bucket they are smaller than.
+var duSizeBuckets = []struct {
+	name  string
+	upper int64
+}{
+	{"0-1KiB", humanize.KiByte},
+	{"1KiB-16KiB", 16 * humanize.KiByte},
+	{"16KiB-256KiB", 256 * humanize.KiByte},
+	{"256KiB-4MiB", 4 * humanize.MiByte},
+	{"4MiB-64MiB", 64 * humanize.MiByte},
+	{"64MiB-1GiB", humanize.GiByte},
+	{">1GiB", math.MaxInt64},
+}
+
+// Objects are counted in the first age bucket they are younger than.
+var duAgeBuckets = []struct {
+	name  string
+	upper time.Duration
+}{
+	{"<1d", 24 * time.Hour},
+	{"1d-7d", 7 * 24 * time.Hour},
+	{"7d-30d", 30 * 24 * time.Hour},
+	{"30d-90d", 90 * 24 * time.Hour},
+	{"90d-1y", 365 * 24 * time.Hour},
+	{">1y", math.MaxInt64},
+}
+
+// histogramBucket - objects of a range of sizes or ages.
+type histogramBucket struct {
+	Name    string `json:"name"`
+	Objects int64  `json:"objects"`
+	Bytes   int64  `json:"bytes"`
+}
+
+// duHistogram - objects counted by size and by age.
+type duHistogram struct {
+	Sizes []histogramBucket `json:"sizes"`
+	Ages  []histogramBucket `json:"ages"`
+
+	// Ages are relative to this time.
+	now time.Time
+}
+
+// newDuHistogram - returns an empty histogram, ages relative to now.
+func newDuHistogram(now time.Time) *duHistogram {
+	h := &duHistogram{now: now}
+	for _, b := range duSizeBuckets {
+		h.Sizes = append(h.Sizes, histogramBucket{Name: b.name})
+	}
+	for _, b := range duAgeBuckets {
+		h.Ages = append(h.Ages, histogramBucket{Name: b.name})
+	}
+	return h
+}
+
+// add - counts an object of size last modified at modTime.
+func (h *duHistogram) add(size int64, modTime time.Time) {
+	for i, b := range duSizeBuckets {
+		if size < b.upper {
+			h.Sizes[i].Objects++
+			h.Sizes[i].Bytes += size
+			break
+		}
+	}
+	age := h.now.Sub(modTime)
+	for i, b := range duAgeBuckets {
+		if age < b.upper {
+			h.Ages[i].Objects++
+			h.Ages[i].Bytes += size
+			break
+		}
+	}
+}
+
+// histogramTable - returns the buckets as a table headed by title, with the share
+// of objects of each bucket out of total.
+func histogramTable(title string, buckets []histogramBucket, total int64) string {
+	lines := []string{console.Colorize("Header", fmt.Sprintf("%-14s %10s %10s %7s", title, "OBJECTS", "SIZE", "SHARE"))}
+	for _, b := range buckets {
+		share := 0.0
+		if total > 0 {
+			share = float64(b.Objects) * 100 / float64(total)
+		}
+		size := strings.Join(strings.Fields(humanize.IBytes(uint64(b.Bytes))), "")
+		lines = append(lines, fmt.Sprintf("%-14s %10d %10s %6.1f%%", b.Name, b.Objects, size, share))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,50 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+func TestDuHistogram(t *testing.T) {
+	now := time.Date(2019, 8, 1, 0, 0, 0, 0, time.UTC)
+	h := newDuHistogram(now)
+	h.add(0, now)
+	h.add(humanize.KiByte-1, now.Add(-time.Hour))
+	h.add(humanize.KiByte, now.Add(-2*24*time.Hour))
+	h.add(5*humanize.MiByte, now.Add(-100*24*time.Hour))
+	h.add(2*humanize.GiByte, now.Add(-400*24*time.Hour))
+
+	sizes := []int64{2, 1, 0, 0, 1, 0, 1}
+	for i, objects := range sizes {
+		if h.Sizes[i].Objects != objects {
+			t.Errorf("Size bucket %s: expected %d objects, got %d", h.Sizes[i].Name, objects, h.Sizes[i].Objects)
+		}
+	}
+	ages := []int64{2, 1, 0, 0, 1, 1}
+	for i, objects := range ages {
+		if h.Ages[i].Objects != objects {
+			t.Errorf("Age bucket %s: expected %d objects, got %d", h.Ages[i].Name, objects, h.Ages[i].Objects)
+		}
+	}
+	if h.Sizes[6].Bytes != 2*humanize.GiByte {
+		t.Errorf("Expected %d bytes over 1GiB, got %d", 2*humanize.GiByte, h.Sizes[6].Bytes)
+	}
+}
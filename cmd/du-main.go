@@ -0,0 +1,158 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// du specific flags.
+var (
+	duFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "histogram",
+			Usage: "count object(s) by ranges of size and of age",
+		},
+	}
+)
+
+// Summarize the disk usage of folders and buckets.
+var duCmd = cli.Command{
+	Name:   "du",
+	Usage:  "summarize disk usage recursively",
+	Action: mainDu,
+	Before: setGlobalsFromContext,
+	Flags:  append(duFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET [TARGET ...]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Summarize the disk usage of a bucket on Amazon S3 cloud storage.
+     $ {{.HelpName}} s3/mybucket
+
+  2. Count the objects of a bucket by ranges of size and of age, to choose part sizes and lifecycle rules.
+     $ {{.HelpName}} --histogram s3/mybucket
+
+  3. Count the files of a local folder by ranges of size and of age, as JSON.
+     $ {{.HelpName}} --histogram --json /var/lib/backups
+`,
+}
+
+// duMessage container for disk usage message structure.
+type duMessage struct {
+	Status    string       `json:"status"`
+	URL       string       `json:"url"`
+	Objects   int64        `json:"objects"`
+	Size      int64        `json:"size"`
+	Histogram *duHistogram `json:"histogram,omitempty"`
+}
+
+// String colorized disk usage message.
+func (d duMessage) String() string {
+	size := strings.Join(strings.Fields(humanize.IBytes(uint64(d.Size))), "")
+	message := console.Colorize("Size", fmt.Sprintf("%-10s", size)) +
+		fmt.Sprintf(" %d object(s)\t", d.Objects) + console.Colorize("Name", d.URL)
+	if d.Histogram != nil {
+		message += "\n\n" + histogramTable("SIZE", d.Histogram.Sizes, d.Objects)
+		message += "\n\n" + histogramTable("AGE", d.Histogram.Ages, d.Objects) + "\n"
+	}
+	return message
+}
+
+// JSON jsonified disk usage message.
+func (d duMessage) JSON() string {
+	d.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(d, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// checkDuSyntax - validate all the passed arguments
+func checkDuSyntax(ctx *cli.Context) {
+	if !ctx.Args().Present() {
+		cli.ShowCommandHelpAndExit(ctx, "du", 1) // last argument is exit code
+	}
+	for _, arg := range ctx.Args() {
+		if strings.TrimSpace(arg) == "" {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...), "Unable to validate empty argument.")
+		}
+	}
+}
+
+// du - sums the sizes of all objects under urlStr, counting them by size
+// and age with histogram.
+func du(urlStr string, histogram bool) (duMessage, *probe.Error) {
+	clnt, err := newClient(urlStr)
+	if err != nil {
+		return duMessage{}, err.Trace(urlStr)
+	}
+	msg := duMessage{URL: urlStr}
+	if histogram {
+		msg.Histogram = newDuHistogram(UTCNow())
+	}
+	for content := range clnt.List(true, false, DirNone) {
+		if content.Err != nil {
+			return duMessage{}, content.Err.Trace(urlStr)
+		}
+		if content.Type.IsDir() {
+			continue
+		}
+		msg.Objects++
+		msg.Size += content.Size
+		if histogram {
+			msg.Histogram.add(content.Size, content.Time)
+		}
+	}
+	return msg, nil
+}
+
+// mainDu - is a handler for mc du command
+func mainDu(ctx *cli.Context) error {
+	checkDuSyntax(ctx)
+
+	// Additional command specific theme customization.
+	console.SetColor("Size", color.New(color.FgYellow))
+	console.SetColor("Name", color.New(color.Bold))
+	console.SetColor("Header", color.New(color.Bold, color.FgCyan))
+
+	var cErr error
+	for _, urlStr := range ctx.Args() {
+		msg, err := du(urlStr, ctx.Bool("histogram"))
+		if err != nil {
+			errorIf(err, "Unable to summarize disk usage of `"+urlStr+"`.")
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		printMsg(msg)
+	}
+	return cErr
+}
@@ -0,0 +1,61 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var encryptClearCmd = cli.Command{
+	Name:            "clear",
+	Usage:           "stop encrypting new objects of a bucket by default",
+	Action:          mainEncryptClear,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Remove the default encryption of bucket 'mybucket', objects already stored stay encrypted.
+     $ {{.HelpName}} myminio/mybucket
+
+`,
+}
+
+// mainEncryptClear is the handle for "mc encrypt clear" command.
+func mainEncryptClear(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "clear", 1) // last argument is exit code
+	}
+	targetURL := ctx.Args().Get(0)
+
+	console.SetColor("Encrypt", color.New(color.FgGreen, color.Bold))
+
+	err := newBucketClient(targetURL).ClearEncryption()
+	fatalIf(err.Trace(targetURL), "Unable to clear default encryption of `"+targetURL+"`.")
+
+	printMsg(encryptMessage{op: "clear", URL: targetURL})
+	return nil
+}
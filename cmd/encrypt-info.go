@@ -0,0 +1,66 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var encryptInfoCmd = cli.Command{
+	Name:            "info",
+	Usage:           "show the default encryption of a bucket",
+	Action:          mainEncryptInfo,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the default encryption of bucket 'mybucket'.
+     $ {{.HelpName}} myminio/mybucket
+
+`,
+}
+
+// mainEncryptInfo is the handle for "mc encrypt info" command.
+func mainEncryptInfo(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "info", 1) // last argument is exit code
+	}
+	targetURL := ctx.Args().Get(0)
+
+	console.SetColor("Encrypt", color.New(color.FgGreen, color.Bold))
+
+	algorithm, keyID, err := newBucketClient(targetURL).GetEncryption()
+	fatalIf(err.Trace(targetURL), "Unable to get default encryption of `"+targetURL+"`.")
+
+	printMsg(encryptMessage{
+		op:        "info",
+		URL:       targetURL,
+		Algorithm: algorithm,
+		KeyID:     keyID,
+	})
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var encryptCmd = cli.Command{
+	Name:            "encrypt",
+	Usage:           "manage default encryption of buckets",
+	HideHelpCommand: true,
+	Action:          mainEncrypt,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	Subcommands: []cli.Command{
+		encryptSetCmd,
+		encryptClearCmd,
+		encryptInfoCmd,
+	},
+}
+
+// mainEncrypt is the handle for "mc encrypt" command.
+func mainEncrypt(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "set", "clear", "info" have their own main.
+}
+
+// encryptMessage container for bucket encryption messages.
+type encryptMessage struct {
+	op        string
+	Status    string `json:"status"`
+	URL       string `json:"url"`
+	Algorithm string `json:"algorithm,omitempty"`
+	KeyID     string `json:"keyId,omitempty"`
+}
+
+// String colorized bucket encryption message.
+func (e encryptMessage) String() string {
+	switch e.op {
+	case "set":
+		return console.Colorize("Encrypt", fmt.Sprintf("Default encryption of `%s` is set to `%s`.", e.URL, encryptionName(e.Algorithm, e.KeyID)))
+	case "clear":
+		return console.Colorize("Encrypt", fmt.Sprintf("Default encryption of `%s` is cleared.", e.URL))
+	case "info":
+		if e.Algorithm == "" {
+			return console.Colorize("Encrypt", fmt.Sprintf("Objects of `%s` are not encrypted by default.", e.URL))
+		}
+		return console.Colorize("Encrypt", fmt.Sprintf("Objects of `%s` are encrypted with `%s` by default.", e.URL, encryptionName(e.Algorithm, e.KeyID)))
+	default:
+		return ""
+	}
+}
+
+// JSON jsonified bucket encryption message.
+func (e encryptMessage) JSON() string {
+	e.Status = "success"
+	encryptMessageBytes, err := json.MarshalIndent(e, "", " ")
+	fatalIf(probe.NewError(err), "Unable to marshal into JSON.")
+
+	return string(encryptMessageBytes)
+}
+
+// encryptionName - returns the name of a default encryption as given to
+// "mc encrypt set".
+func encryptionName(algorithm, keyID string) string {
+	switch algorithm {
+	case sseS3Algorithm:
+		return "sse-s3"
+	case sseKMSAlgorithm:
+		if keyID != "" {
+			return "sse-kms " + keyID
+		}
+		return "sse-kms"
+	}
+	return algorithm
+}
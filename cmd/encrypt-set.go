@@ -0,0 +1,93 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var encryptSetCmd = cli.Command{
+	Name:            "set",
+	Usage:           "encrypt new objects of a bucket on the server",
+	Action:          mainEncryptSet,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} sse-s3 TARGET
+  {{.HelpName}} sse-kms [KEY-ID] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Encrypt new objects of bucket 'mybucket' with keys managed by the server.
+     $ {{.HelpName}} sse-s3 myminio/mybucket
+
+  2. Encrypt new objects of bucket 'mybucket' with the KMS key 'my-minio-key'.
+     $ {{.HelpName}} sse-kms my-minio-key myminio/mybucket
+
+  3. Encrypt new objects of bucket 'mybucket' with the default KMS key of the server.
+     $ {{.HelpName}} sse-kms s3/mybucket
+
+`,
+}
+
+// mainEncryptSet is the handle for "mc encrypt set" command.
+func mainEncryptSet(ctx *cli.Context) error {
+	args := ctx.Args()
+	if len(args) < 2 || len(args) > 3 {
+		cli.ShowCommandHelpAndExit(ctx, "set", 1) // last argument is exit code
+	}
+	targetURL := args.Get(len(args) - 1)
+	keyID := ""
+	if len(args) == 3 {
+		keyID = args.Get(1)
+	}
+
+	var algorithm string
+	switch strings.ToLower(args.First()) {
+	case "sse-s3":
+		if keyID != "" {
+			cli.ShowCommandHelpAndExit(ctx, "set", 1) // last argument is exit code
+		}
+		algorithm = sseS3Algorithm
+	case "sse-kms":
+		algorithm = sseKMSAlgorithm
+	default:
+		fatalIf(errInvalidArgument().Trace(args.First()), "Unknown encryption `"+args.First()+"`, must be sse-s3 or sse-kms.")
+	}
+
+	console.SetColor("Encrypt", color.New(color.FgGreen, color.Bold))
+
+	err := newBucketClient(targetURL).SetEncryption(algorithm, keyID)
+	fatalIf(err.Trace(targetURL), "Unable to set default encryption of `"+targetURL+"`.")
+
+	printMsg(encryptMessage{
+		op:        "set",
+		URL:       targetURL,
+		Algorithm: algorithm,
+		KeyID:     keyID,
+	})
+	return nil
+}
@@ -0,0 +1,166 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+// Machine readable classes of errors, reported in JSON output.
+const (
+	errCodeUnknown             = "Unknown"
+	errCodeInvalidArgument     = "InvalidArgument"
+	errCodeAccessDenied        = "AccessDenied"
+	errCodeInvalidCredentials  = "InvalidCredentials"
+	errCodeNoSuchBucket        = "NoSuchBucket"
+	errCodeNoSuchKey           = "NoSuchKey"
+	errCodePathNotFound        = "PathNotFound"
+	errCodeBucketAlreadyExists = "BucketAlreadyExists"
+	errCodeObjectAlreadyExists = "ObjectAlreadyExists"
+	errCodeQuotaExceeded       = "QuotaExceeded"
+	errCodeSlowDown            = "SlowDown"
+	errCodeNetworkError        = "NetworkError"
+	errCodeTimeout             = "Timeout"
+	errCodeNotImplemented      = "NotImplemented"
+	errCodeServerError         = "ServerError"
+)
+
+// Exit status of fatal errors of each class.
+var errCodeExitStatus = map[string]int{
+	errCodeUnknown:             1,
+	errCodeInvalidArgument:     2,
+	errCodeAccessDenied:        3,
+	errCodeInvalidCredentials:  3,
+	errCodeNoSuchBucket:        4,
+	errCodeNoSuchKey:           4,
+	errCodePathNotFound:        4,
+	errCodeBucketAlreadyExists: 5,
+	errCodeObjectAlreadyExists: 5,
+	errCodeQuotaExceeded:       6,
+	errCodeSlowDown:            7,
+	errCodeNetworkError:        8,
+	errCodeTimeout:             8,
+	errCodeNotImplemented:      9,
+	errCodeServerError:         10,
+}
+
+// Classes of the error codes returned by S3 servers.
+var s3ErrorCodes = map[string]string{
+	"AccessDenied":                    errCodeAccessDenied,
+	"AllAccessDisabled":               errCodeAccessDenied,
+	"AccountProblem":                  errCodeAccessDenied,
+	"InvalidAccessKeyId":              errCodeInvalidCredentials,
+	"SignatureDoesNotMatch":           errCodeInvalidCredentials,
+	"InvalidToken":                    errCodeInvalidCredentials,
+	"ExpiredToken":                    errCodeInvalidCredentials,
+	"NoSuchBucket":                    errCodeNoSuchBucket,
+	"NoSuchKey":                       errCodeNoSuchKey,
+	"NoSuchUpload":                    errCodeNoSuchKey,
+	"BucketAlreadyExists":             errCodeBucketAlreadyExists,
+	"BucketAlreadyOwnedByYou":         errCodeBucketAlreadyExists,
+	"QuotaExceeded":                   errCodeQuotaExceeded,
+	"XMinioAdminBucketQuotaExceeded":  errCodeQuotaExceeded,
+	"TooManyBuckets":                  errCodeQuotaExceeded,
+	"SlowDown":                        errCodeSlowDown,
+	"Throttling":                      errCodeSlowDown,
+	"RequestLimitExceeded":            errCodeSlowDown,
+	"NotImplemented":                  errCodeNotImplemented,
+	"APINotSupported":                 errCodeNotImplemented,
+	"InternalError":                   errCodeServerError,
+	"ServiceUnavailable":              errCodeServerError,
+	"XMinioServerNotInitialized":      errCodeServerError,
+	"InvalidArgument":                 errCodeInvalidArgument,
+	"InvalidBucketName":               errCodeInvalidArgument,
+	"InvalidObjectName":               errCodeInvalidArgument,
+	"KeyTooLongError":                 errCodeInvalidArgument,
+	"EntityTooLarge":                  errCodeInvalidArgument,
+	"XMinioObjectExistsAsDirectory":   errCodeObjectAlreadyExists,
+	"XMinioParentIsObject":            errCodeObjectAlreadyExists,
+	"XMinioStorageFull":               errCodeQuotaExceeded,
+	"XMinioInvalidObjectName":         errCodeInvalidArgument,
+	"XMinioAdminNotificationTimedOut": errCodeTimeout,
+}
+
+// errorCode - classifies err for automation.
+func errorCode(err *probe.Error) string {
+	if err == nil {
+		return ""
+	}
+	e := err.ToGoError()
+	switch e.(type) {
+	case invalidArgumentErr, BucketNameEmpty, BucketInvalid, BucketNameTopLevel, EmptyPath:
+		return errCodeInvalidArgument
+	case BucketDoesNotExist:
+		return errCodeNoSuchBucket
+	case ObjectMissing:
+		return errCodeNoSuchKey
+	case PathNotFound:
+		return errCodePathNotFound
+	case PathInsufficientPermission:
+		return errCodeAccessDenied
+	case BucketExists:
+		return errCodeBucketAlreadyExists
+	case ObjectAlreadyExists, ObjectAlreadyExistsAsDirectory:
+		return errCodeObjectAlreadyExists
+	case APINotImplemented:
+		return errCodeNotImplemented
+	}
+
+	if errResponse := minio.ToErrorResponse(e); errResponse.Code != "" {
+		if code, ok := s3ErrorCodes[errResponse.Code]; ok {
+			return code
+		}
+		switch {
+		case errResponse.StatusCode == http.StatusForbidden:
+			return errCodeAccessDenied
+		case errResponse.StatusCode == http.StatusNotFound:
+			return errCodeNoSuchKey
+		case errResponse.StatusCode >= http.StatusInternalServerError:
+			return errCodeServerError
+		}
+		return errCodeUnknown
+	}
+
+	switch {
+	case os.IsNotExist(e):
+		return errCodePathNotFound
+	case os.IsPermission(e):
+		return errCodeAccessDenied
+	case os.IsExist(e):
+		return errCodeObjectAlreadyExists
+	}
+	if netErr, ok := e.(net.Error); ok {
+		if netErr.Timeout() {
+			return errCodeTimeout
+		}
+		return errCodeNetworkError
+	}
+	return errCodeUnknown
+}
+
+// errorExitStatus - returns the exit status of a fatal err.
+func errorExitStatus(err *probe.Error) int {
+	if status, ok := errCodeExitStatus[errorCode(err)]; ok {
+		return status
+	}
+	return globalErrorExitStatus
+}
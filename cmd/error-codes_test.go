@@ -0,0 +1,47 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+func TestErrorCode(t *testing.T) {
+	testCases := []struct {
+		err  *probe.Error
+		code string
+	}{
+		{nil, ""},
+		{errInvalidArgument(), errCodeInvalidArgument},
+		{probe.NewError(BucketDoesNotExist{Bucket: "photos"}), errCodeNoSuchBucket},
+		{probe.NewError(minio.ErrorResponse{Code: "NoSuchKey", StatusCode: 404}), errCodeNoSuchKey},
+		{probe.NewError(minio.ErrorResponse{Code: "XAmzContentSHA256Mismatch", StatusCode: 403}), errCodeAccessDenied},
+		{probe.NewError(minio.ErrorResponse{Code: "SomethingNew", StatusCode: 503}), errCodeServerError},
+		{probe.NewError(&os.PathError{Op: "open", Path: "/missing", Err: os.ErrNotExist}), errCodePathNotFound},
+		{probe.NewError(errors.New("unexpected")), errCodeUnknown},
+	}
+	for i, testCase := range testCases {
+		if code := errorCode(testCase.err); code != testCase.code {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.code, code)
+		}
+	}
+}
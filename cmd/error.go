@@ -19,6 +19,7 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"unicode"
 
 	"github.com/minio/cli"
@@ -36,6 +37,7 @@ type causeMessage struct {
 // errorMessage container for error messages
 type errorMessage struct {
 	Message   string             `json:"message"`
+	Code      string             `json:"code"`
 	Cause     causeMessage       `json:"cause"`
 	Type      string             `json:"type"`
 	CallTrace []probe.TracePoint `json:"trace,omitempty"`
@@ -51,9 +53,13 @@ func fatalIf(err *probe.Error, msg string, data ...interface{}) {
 }
 
 func fatal(err *probe.Error, msg string, data ...interface{}) {
+	saveTransferStats()
+	stopProfilers()
+
 	if globalJSON {
 		errorMsg := errorMessage{
 			Message: msg,
+			Code:    errorCode(err),
 			Type:    "fatal",
 			Cause: causeMessage{
 				Message: err.ToGoError().Error(),
@@ -75,10 +81,11 @@ func fatal(err *probe.Error, msg string, data ...interface{}) {
 			console.Fatalln(probe.NewError(e))
 		}
 		console.Println(string(json))
-		console.Fatalln()
+		console.FatalExitln(errorExitStatus(err))
 	}
 
-	msg = fmt.Sprintf(msg, data...)
+	// JSON output above is never translated.
+	msg = fmt.Sprintf(translate(msg), data...)
 	errmsg := err.String()
 	if !globalDebug {
 		errmsg = err.ToGoError().Error()
@@ -105,7 +112,7 @@ func fatal(err *probe.Error, msg string, data ...interface{}) {
 		}
 	}
 
-	console.Fatalln(fmt.Sprintf("%s %s", msg, errmsg))
+	console.FatalExitln(errorExitStatus(err), fmt.Sprintf("%s %s", msg, errmsg))
 }
 
 // Exit coder wraps cli new exit error with a
@@ -114,7 +121,19 @@ func fatal(err *probe.Error, msg string, data ...interface{}) {
 // after an action. Which woud allow cli package to
 // exit with the specified `exitStatus`.
 func exitStatus(status int) error {
-	return cli.NewExitError("", status)
+	return cli.NewExitError("", exitStatusOf(status))
+}
+
+// Exit status of the first error reported by errorIf.
+var firstErrorStatus int32
+
+// exitStatusOf - returns status, or in CI mode the exit status of the
+// first error reported if status is the generic one.
+func exitStatusOf(status int) int {
+	if firstStatus := atomic.LoadInt32(&firstErrorStatus); globalCI && status == globalErrorExitStatus && firstStatus != 0 {
+		return int(firstStatus)
+	}
+	return status
 }
 
 // errorIf synonymous with fatalIf but doesn't exit on error != nil
@@ -122,9 +141,11 @@ func errorIf(err *probe.Error, msg string, data ...interface{}) {
 	if err == nil {
 		return
 	}
+	atomic.CompareAndSwapInt32(&firstErrorStatus, 0, int32(errorExitStatus(err)))
 	if globalJSON {
 		errorMsg := errorMessage{
 			Message: fmt.Sprintf(msg, data...),
+			Code:    errorCode(err),
 			Type:    "error",
 			Cause: causeMessage{
 				Message: err.ToGoError().Error(),
@@ -148,7 +169,7 @@ func errorIf(err *probe.Error, msg string, data ...interface{}) {
 		console.Println(string(json))
 		return
 	}
-	msg = fmt.Sprintf(msg, data...)
+	msg = fmt.Sprintf(translate(msg), data...)
 	if !globalDebug {
 		console.Errorln(fmt.Sprintf("%s %s", msg, err.ToGoError()))
 		return
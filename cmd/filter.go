@@ -0,0 +1,78 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// filterReader - output of a command reading an object body on its
+// standard input, the failure of the command is returned at the end of
+// the output.
+type filterReader struct {
+	command string
+	cmd     *exec.Cmd
+	stdout  io.ReadCloser
+
+	once sync.Once
+	err  error
+}
+
+// newFilterReader - starts command transforming r, it stops once r is
+// exhausted or the returned reader is closed.
+func newFilterReader(command string, r io.Reader) (io.ReadCloser, *probe.Error) {
+	cmd := shellCommand(command)
+	cmd.Stdin = r
+	cmd.Stderr = os.Stderr
+	stdout, e := cmd.StdoutPipe()
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	if e = cmd.Start(); e != nil {
+		return nil, probe.NewError(FilterFailed{Command: command, Err: e})
+	}
+	return &filterReader{command: command, cmd: cmd, stdout: stdout}, nil
+}
+
+// Read implements io.Reader.
+func (f *filterReader) Read(b []byte) (int, error) {
+	n, e := f.stdout.Read(b)
+	if e == io.EOF {
+		f.once.Do(func() {
+			if e := f.cmd.Wait(); e != nil {
+				f.err = FilterFailed{Command: f.command, Err: e}
+			}
+		})
+		if f.err != nil {
+			return n, f.err
+		}
+	}
+	return n, e
+}
+
+// Close implements io.Closer, the command is killed if still running.
+func (f *filterReader) Close() error {
+	f.once.Do(func() {
+		f.cmd.Process.Kill()
+		f.cmd.Wait()
+	})
+	return nil
+}
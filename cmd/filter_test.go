@@ -0,0 +1,60 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"io/ioutil"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestFilterReader(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("filters are run by sh")
+	}
+	testCases := []struct {
+		command    string
+		input      string
+		output     string
+		shouldPass bool
+	}{
+		{"tr a-z A-Z", "hello, world", "HELLO, WORLD", true},
+		{"cat", "", "", true},
+		// Filters may stop reading their input early.
+		{"head -c 5", strings.Repeat("x", 1<<20), "xxxxx", true},
+		{"cat; exit 3", "hello", "hello", false},
+	}
+	for i, testCase := range testCases {
+		r, err := newFilterReader(testCase.command, strings.NewReader(testCase.input))
+		if err != nil {
+			t.Fatalf("Test %d: %s", i+1, err.ToGoError())
+		}
+		output, e := ioutil.ReadAll(r)
+		r.Close()
+		if testCase.shouldPass && e != nil {
+			t.Errorf("Test %d: unexpected error: %v", i+1, e)
+		}
+		if !testCase.shouldPass {
+			if _, ok := e.(FilterFailed); !ok {
+				t.Errorf("Test %d: expected FilterFailed, got %v", i+1, e)
+			}
+		}
+		if string(output) != testCase.output {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.output, string(output))
+		}
+	}
+}
@@ -0,0 +1,202 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Size of each of the samples read from objects without a reliable ETag.
+const duplicateSampleSize = 64 * humanize.KiByte
+
+// ETags of single part uploads without SSE-C or SSE-KMS are the MD5
+// sum of the object, multipart ETags carry a '-N' suffix.
+var md5ETagRe = regexp.MustCompile("^[0-9a-fA-F]{32}$")
+
+// duplicateCandidate - a matching object considered by --duplicates.
+type duplicateCandidate struct {
+	key  string
+	url  string
+	size int64
+	etag string
+}
+
+// duplicateMessage container for a set of identical objects.
+type duplicateMessage struct {
+	Status      string   `json:"status"`
+	Size        int64    `json:"size"`
+	Checksum    string   `json:"checksum"`
+	Sampled     bool     `json:"sampled"`
+	Keys        []string `json:"keys"`
+	Reclaimable int64    `json:"reclaimable"`
+}
+
+// String colorized duplicate set message.
+func (d duplicateMessage) String() string {
+	message := console.Colorize("Find", fmt.Sprintf("%d duplicates of %s, %s reclaimable",
+		len(d.Keys), humanize.IBytes(uint64(d.Size)), humanize.IBytes(uint64(d.Reclaimable))))
+	if d.Sampled {
+		message += " (sampled)"
+	}
+	for _, key := range d.Keys {
+		message += "\n   " + key
+	}
+	return message
+}
+
+// JSON jsonified duplicate set message.
+func (d duplicateMessage) JSON() string {
+	d.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(d, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// duplicatesSummaryMessage container for the totals of a duplicates report.
+type duplicatesSummaryMessage struct {
+	Status      string `json:"status"`
+	Sets        int    `json:"sets"`
+	Reclaimable int64  `json:"reclaimable"`
+}
+
+// String colorized duplicates summary message.
+func (d duplicatesSummaryMessage) String() string {
+	return console.Colorize("Find", fmt.Sprintf("Found %d duplicate sets, %s reclaimable.",
+		d.Sets, humanize.IBytes(uint64(d.Reclaimable))))
+}
+
+// JSON jsonified duplicates summary message.
+func (d duplicatesSummaryMessage) JSON() string {
+	d.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(d, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// sampleChecksum - returns the MD5 sum of samples at the start, middle
+// and end of an object, or of its first sample if it cannot be read at
+// random offsets. Small objects are hashed fully.
+func sampleChecksum(alias, urlStr string, size int64) (string, *probe.Error) {
+	clnt, err := newClientFromAlias(alias, urlStr)
+	if err != nil {
+		return "", err.Trace(alias, urlStr)
+	}
+	reader, err := clnt.Get(nil)
+	if err != nil {
+		return "", err.Trace(urlStr)
+	}
+	defer reader.Close()
+
+	hash := md5.New()
+	readerAt, ok := reader.(io.ReaderAt)
+	switch {
+	case size <= 3*duplicateSampleSize:
+		if _, e := io.Copy(hash, reader); e != nil {
+			return "", probe.NewError(e).Trace(urlStr)
+		}
+		return hex.EncodeToString(hash.Sum(nil)), nil
+	case !ok:
+		if _, e := io.CopyN(hash, reader, duplicateSampleSize); e != nil {
+			return "", probe.NewError(e).Trace(urlStr)
+		}
+		return hex.EncodeToString(hash.Sum(nil)), nil
+	}
+	for _, offset := range []int64{0, (size - duplicateSampleSize) / 2, size - duplicateSampleSize} {
+		if _, e := io.Copy(hash, io.NewSectionReader(readerAt, offset, duplicateSampleSize)); e != nil {
+			return "", probe.NewError(e).Trace(urlStr)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// reportDuplicates - groups candidates by size and checksum and prints
+// every set of more than one object. ETags are used as checksums when
+// all objects of the same size have a plain MD5 ETag, a sampled checksum
+// is computed otherwise.
+func reportDuplicates(ctx *findContext, candidates []duplicateCandidate) {
+	bySize := make(map[int64][]duplicateCandidate)
+	for _, candidate := range candidates {
+		bySize[candidate.size] = append(bySize[candidate.size], candidate)
+	}
+
+	var sets []duplicateMessage
+	for size, sameSize := range bySize {
+		if len(sameSize) < 2 {
+			continue
+		}
+		sampled := false
+		for _, candidate := range sameSize {
+			if !md5ETagRe.MatchString(strings.Trim(candidate.etag, "\"")) {
+				sampled = true
+				break
+			}
+		}
+		byChecksum := make(map[string][]string)
+		for _, candidate := range sameSize {
+			checksum := strings.ToLower(strings.Trim(candidate.etag, "\""))
+			if sampled {
+				var err *probe.Error
+				if checksum, err = sampleChecksum(ctx.targetAlias, candidate.url, size); err != nil {
+					errorIf(err.Trace(candidate.key), "Unable to read `"+candidate.key+"`.")
+					continue
+				}
+			}
+			byChecksum[checksum] = append(byChecksum[checksum], candidate.key)
+		}
+		for checksum, keys := range byChecksum {
+			if len(keys) < 2 {
+				continue
+			}
+			sort.Strings(keys)
+			sets = append(sets, duplicateMessage{
+				Size:        size,
+				Checksum:    checksum,
+				Sampled:     sampled,
+				Keys:        keys,
+				Reclaimable: size * int64(len(keys)-1),
+			})
+		}
+	}
+
+	// Largest savings first.
+	sort.Slice(sets, func(i, j int) bool {
+		if sets[i].Reclaimable != sets[j].Reclaimable {
+			return sets[i].Reclaimable > sets[j].Reclaimable
+		}
+		return sets[i].Keys[0] < sets[j].Keys[0]
+	})
+
+	var reclaimable int64
+	for _, set := range sets {
+		reclaimable += set.Reclaimable
+		printMsg(set)
+	}
+	printMsg(duplicatesSummaryMessage{Sets: len(sets), Reclaimable: reclaimable})
+}
@@ -77,6 +77,10 @@ var (
 			Name:  "watch",
 			Usage: "monitor a specified path for newly created object(s)",
 		},
+		cli.BoolFlag{
+			Name:  "duplicates",
+			Usage: "report sets of matching objects with identical size and checksum",
+		},
 	}
 )
 
@@ -119,6 +123,13 @@ FORMAT
 
       {url} --> Substitutes to a shareable URL of the path.
 
+DUPLICATES
+   --duplicates groups matching objects by size and checksum, ETags are used
+   as checksums if all objects of that size have a plain MD5 ETag. Otherwise
+   checksums are computed from samples taken at the start, middle and end of
+   each object, such sets are reported as sampled and may contain objects
+   which only differ outside of the samples.
+
 EXAMPLES:
    01. Find all "foo.jpg" in all buckets under "s3" account.
        $ {{.HelpName}} s3 --name "foo.jpg"
@@ -151,6 +162,9 @@ EXAMPLES:
    10. List all objects up to 3 levels sub-directory deep under "s3/bucket".
        $ {{.HelpName}} s3/bucket --maxdepth 3
 
+   11. Report duplicate objects larger than 1 MB under "s3/bucket" along with the space they waste.
+       $ {{.HelpName}} s3/bucket --larger 1MB --duplicates
+
 `,
 }
 
@@ -169,6 +183,14 @@ func checkFindSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 		}
 	}
 
+	if ctx.Bool("duplicates") {
+		for _, flag := range []string{"watch", "exec", "print"} {
+			if ctx.IsSet(flag) {
+				fatalIf(errInvalidArgument().Trace(args...), "--duplicates cannot be used with --"+flag+".")
+			}
+		}
+	}
+
 	// Extract input URLs and validate.
 	for _, url := range args {
 		_, _, err := url2Stat(url, false, encKeyDB)
@@ -199,6 +221,7 @@ type findContext struct {
 	largerSize    uint64
 	smallerSize   uint64
 	watch         bool
+	duplicates    bool
 
 	// Internal values
 	targetAlias   string
@@ -275,6 +298,7 @@ func mainFind(ctx *cli.Context) error {
 		largerSize:    largerSize,
 		smallerSize:   smallerSize,
 		watch:         ctx.Bool("watch"),
+		duplicates:    ctx.Bool("duplicates"),
 		targetAlias:   targetAlias,
 		targetURL:     args[0],
 		targetFullURL: targetFullURL,
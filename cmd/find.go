@@ -42,7 +42,7 @@ type findMessage struct {
 
 // String calls tells the console what to print and how to print it.
 func (f findMessage) String() string {
-	return console.Colorize("Find", f.contentMessage.Key)
+	return console.Colorize("Find", printableKey(f.contentMessage.Key))
 }
 
 // JSON formats output to be JSON output.
@@ -248,6 +248,7 @@ func doFind(ctx *findContext) error {
 	defer watchFind(ctx)
 
 	var prevKeyName string
+	var candidates []duplicateCandidate
 
 	// iterate over all content which is within the given directory
 	for content := range ctx.clnt.List(true, false, DirNone) {
@@ -288,6 +289,19 @@ func doFind(ctx *findContext) error {
 
 		prevKeyName = fileKeyName
 
+		// Duplicates are reported once all content is listed.
+		if ctx.duplicates {
+			if content.Type.IsRegular() {
+				candidates = append(candidates, duplicateCandidate{
+					key:  fileKeyName,
+					url:  content.URL.String(),
+					size: content.Size,
+					etag: content.ETag,
+				})
+			}
+			continue
+		}
+
 		// proceed to either exec, format the output string.
 		if ctx.execCmd != "" {
 			execFind(stringsReplace(ctx.execCmd, fileContent))
@@ -300,6 +314,10 @@ func doFind(ctx *findContext) error {
 		printMsg(findMessage{fileContent})
 	}
 
+	if ctx.duplicates {
+		reportDuplicates(ctx, candidates)
+	}
+
 	// Success, notice watch will execute in defer only if enabled and this call
 	// will return after watch is canceled.
 	return nil
@@ -34,6 +34,10 @@ var globalFlags = []cli.Flag{
 		Value: mustGetMcConfigDir(),
 		Usage: "path to configuration folder",
 	},
+	cli.StringFlag{
+		Name:  "profile",
+		Usage: "use the aliases and settings of this profile, see 'mc profile'",
+	},
 	cli.BoolFlag{
 		Name:  "quiet, q",
 		Usage: "disable progress bar display",
@@ -54,6 +58,69 @@ var globalFlags = []cli.Flag{
 		Name:  "insecure",
 		Usage: "disable SSL certificate verification",
 	},
+	cli.BoolFlag{
+		Name:  "ascii",
+		Usage: "use plain ASCII characters only, default for non UTF-8 locales",
+	},
+	cli.StringFlag{
+		Name:  "lang",
+		Usage: "language of console messages, defaults to the locale set by LANG",
+	},
+	cli.StringFlag{
+		Name:  "prefer-endpoint",
+		Usage: "send requests to this endpoint of aliases with several endpoints while it is healthy",
+	},
+	cli.IntFlag{
+		Name:  "max-rps",
+		Usage: "limit requests sent to servers to N per second, unlimited by default",
+	},
+	cli.BoolFlag{
+		Name:  "http1",
+		Usage: "disable HTTP/2, use HTTP/1.1 only to talk to servers",
+	},
+	cli.BoolFlag{
+		Name:  "read-only",
+		Usage: "refuse all requests changing buckets, objects and servers",
+	},
+	cli.BoolFlag{
+		Name:  "anonymous",
+		Usage: "send unsigned requests, ignoring the credentials of aliases; URLs of public buckets are accepted without an alias",
+	},
+	cli.StringFlag{
+		Name:  "role-arn",
+		Usage: "assume this role over the credentials of aliases for this command only",
+	},
+	cli.StringFlag{
+		Name:  "external-id",
+		Usage: "external ID required to assume the role set by '--role-arn'",
+	},
+	cli.BoolFlag{
+		Name:  "ci",
+		Usage: "disable progress bars and colors, print progress lines and exit with the error code of the first error, default when output is not a terminal",
+	},
+	cli.StringFlag{
+		Name:  "progress-interval",
+		Usage: "refresh progress and scan bars at this interval, e.g. '1s' for slow terminals and CI logs",
+	},
+	cli.IntFlag{
+		Name:  "progress-width",
+		Usage: "draw progress and scan bars N columns wide instead of following the terminal width",
+	},
+	cli.StringFlag{
+		Name:   "cpuprofile",
+		Usage:  "write a CPU profile to file when exiting",
+		Hidden: true,
+	},
+	cli.StringFlag{
+		Name:   "memprofile",
+		Usage:  "write a heap profile to file when exiting",
+		Hidden: true,
+	},
+	cli.StringFlag{
+		Name:   "trace",
+		Usage:  "write an execution trace to file when exiting",
+		Hidden: true,
+	},
 }
 
 // Flags common across all I/O commands such as cp, mirror, stat, pipe etc.
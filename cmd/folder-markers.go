@@ -0,0 +1,59 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "strings"
+
+// Handling of folder markers by mirror, zero-byte objects named with a
+// trailing separator which consoles create to show empty folders.
+const (
+	// Markers are neither copied nor removed.
+	folderMarkersIgnore = "ignore"
+	// Markers are folders, created on target unless the folder exists
+	// and removed from target only if the folder is missing on source.
+	folderMarkersDirectory = "directory"
+	// Markers are copied and removed like any other object.
+	folderMarkersVerbatim = "verbatim"
+)
+
+// isValidFolderMarkers - validates the mode of --folder-markers.
+func isValidFolderMarkers(mode string) bool {
+	switch mode {
+	case folderMarkersIgnore, folderMarkersDirectory, folderMarkersVerbatim:
+		return true
+	}
+	return false
+}
+
+// isFolderMarker - reports if content is a folder marker.
+func isFolderMarker(content *clientContent) bool {
+	if content == nil || content.Size != 0 || content.Type.IsDir() {
+		return false
+	}
+	return strings.HasSuffix(content.URL.Path, string(content.URL.Separator))
+}
+
+// folderExists - reports if the folder at urlStr exists, as a directory
+// or as objects under its prefix.
+func folderExists(alias, urlStr string) bool {
+	clnt, err := newClientFromAlias(alias, urlStr)
+	if err != nil {
+		return false
+	}
+	_, err = clnt.Stat(false, false, nil)
+	return err == nil
+}
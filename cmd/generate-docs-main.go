@@ -0,0 +1,274 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Formats of the generated documents.
+const (
+	docsFormatMan      = "man"
+	docsFormatMarkdown = "markdown"
+)
+
+var generateDocsFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "format",
+		Value: docsFormatMan,
+		Usage: "format of the documents, 'man' or 'markdown'",
+	},
+}
+
+// Generate man pages and markdown from the help of all commands.
+var generateDocsCmd = cli.Command{
+	Name:   "generate-docs",
+	Usage:  "generate man pages or markdown for all commands",
+	Action: mainGenerateDocs,
+	Before: setGlobalsFromContext,
+	Flags:  append(generateDocsFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] DIR
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Install man pages of all commands, read them with 'man mc-mirror'.
+     $ {{.HelpName}} /usr/local/share/man/man1
+
+  2. Generate markdown documents of all commands in the docs folder.
+     $ {{.HelpName}} --format markdown docs/commands
+`,
+}
+
+// generateDocsMessage container for a generated document.
+type generateDocsMessage struct {
+	Status  string `json:"status"`
+	Command string `json:"command"`
+	File    string `json:"file"`
+}
+
+// String colorized generated document message.
+func (g generateDocsMessage) String() string {
+	return console.Colorize("GenerateDocs", fmt.Sprintf("`%s` -> `%s`", g.Command, g.File))
+}
+
+// JSON jsonified generated document message.
+func (g generateDocsMessage) JSON() string {
+	g.Status = "success"
+	docsJSONBytes, e := json.MarshalIndent(g, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(docsJSONBytes)
+}
+
+// renderHelp - renders the help of cmd as printed by 'helpName -h'.
+// Commands with sub-commands have no help template, their help lists
+// the sub-commands.
+func renderHelp(cmd cli.Command, helpName string) (string, *probe.Error) {
+	if cmd.CustomHelpTemplate == "" {
+		var help bytes.Buffer
+		fmt.Fprintf(&help, "NAME:\n  %s - %s\n\nUSAGE:\n  %s COMMAND [COMMAND FLAGS | -h] [ARGUMENTS...]\n\nCOMMANDS:\n", helpName, cmd.Usage, helpName)
+		for _, subCmd := range cmd.Subcommands {
+			if !subCmd.Hidden {
+				fmt.Fprintf(&help, "  %-10s %s\n", subCmd.Name, subCmd.Usage)
+			}
+		}
+		return help.String(), nil
+	}
+
+	tmpl, e := template.New(helpName).Funcs(template.FuncMap{"join": strings.Join}).Parse(cmd.CustomHelpTemplate)
+	if e != nil {
+		return "", probe.NewError(e).Trace(helpName)
+	}
+	cmd.HelpName = helpName
+	var help bytes.Buffer
+	if e = tmpl.Execute(&help, cmd); e != nil {
+		return "", probe.NewError(e).Trace(helpName)
+	}
+	return help.String(), nil
+}
+
+// helpSection - a section of the help of a command, such as FLAGS.
+type helpSection struct {
+	title string
+	lines []string
+}
+
+var helpSectionTitle = regexp.MustCompile(`^([A-Z][A-Z ]*):$`)
+
+// parseHelp - splits help into its sections, lines are dedented.
+func parseHelp(help string) []helpSection {
+	var sections []helpSection
+	for _, line := range strings.Split(help, "\n") {
+		if match := helpSectionTitle.FindStringSubmatch(line); match != nil {
+			sections = append(sections, helpSection{title: match[1]})
+			continue
+		}
+		if len(sections) == 0 {
+			continue
+		}
+		section := &sections[len(sections)-1]
+		section.lines = append(section.lines, strings.TrimRight(line, " \t"))
+	}
+
+	for i := range sections {
+		lines := sections[i].lines
+		// Drop leading and trailing empty lines.
+		for len(lines) > 0 && lines[0] == "" {
+			lines = lines[1:]
+		}
+		for len(lines) > 0 && lines[len(lines)-1] == "" {
+			lines = lines[:len(lines)-1]
+		}
+		indent := -1
+		for _, line := range lines {
+			if line == "" {
+				continue
+			}
+			if n := len(line) - len(strings.TrimLeft(line, " ")); indent < 0 || n < indent {
+				indent = n
+			}
+		}
+		for j, line := range lines {
+			if len(line) >= indent && indent > 0 {
+				lines[j] = line[indent:]
+			}
+		}
+		sections[i].lines = lines
+	}
+	return sections
+}
+
+// manEscape - escapes text for roff.
+func manEscape(line string) string {
+	line = strings.Replace(line, `\`, `\e`, -1)
+	if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+		line = `\&` + line
+	}
+	return line
+}
+
+// renderMan - renders the help of a command as a man page.
+func renderMan(helpName, help string) string {
+	name := strings.Replace(helpName, " ", "-", -1)
+	var page bytes.Buffer
+	fmt.Fprintf(&page, ".TH %s 1 \"%s\" \"mc %s\" \"MinIO Client\"\n", strings.ToUpper(name), UTCNow().Format("January 2006"), ReleaseTag)
+	for _, section := range parseHelp(help) {
+		fmt.Fprintf(&page, ".SH %s\n", section.title)
+		if section.title == "NAME" && len(section.lines) > 0 {
+			// Man page names are single words.
+			line := strings.Replace(section.lines[0], helpName+" - ", name+` \- `, 1)
+			fmt.Fprintln(&page, manEscape(line))
+			continue
+		}
+		fmt.Fprintln(&page, ".nf")
+		for _, line := range section.lines {
+			fmt.Fprintln(&page, manEscape(line))
+		}
+		fmt.Fprintln(&page, ".fi")
+	}
+	return page.String()
+}
+
+// renderMarkdown - renders the help of a command as markdown.
+func renderMarkdown(helpName, help string) string {
+	var doc bytes.Buffer
+	fmt.Fprintf(&doc, "# %s\n", helpName)
+	for _, section := range parseHelp(help) {
+		if section.title == "NAME" && len(section.lines) > 0 {
+			fmt.Fprintf(&doc, "\n%s\n", strings.TrimPrefix(section.lines[0], helpName+" - "))
+			continue
+		}
+		fmt.Fprintf(&doc, "\n## %s\n\n```\n%s\n```\n", strings.Title(strings.ToLower(section.title)), strings.Join(section.lines, "\n"))
+	}
+	return doc.String()
+}
+
+// generateDocs - writes the documents of cmd and its sub-commands to dir.
+func generateDocs(cmd cli.Command, helpName, format, dir string) *probe.Error {
+	help, err := renderHelp(cmd, helpName)
+	if err != nil {
+		return err.Trace(helpName)
+	}
+
+	name := strings.Replace(helpName, " ", "-", -1)
+	var doc, file string
+	switch format {
+	case docsFormatMarkdown:
+		doc, file = renderMarkdown(helpName, help), filepath.Join(dir, name+".md")
+	default:
+		doc, file = renderMan(helpName, help), filepath.Join(dir, name+".1")
+	}
+	if e := ioutil.WriteFile(file, []byte(doc), 0644); e != nil {
+		return probe.NewError(e).Trace(file)
+	}
+	printMsg(generateDocsMessage{Command: helpName, File: file})
+
+	for _, subCmd := range cmd.Subcommands {
+		if subCmd.Hidden {
+			continue
+		}
+		if err = generateDocs(subCmd, helpName+" "+subCmd.Name, format, dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mainGenerateDocs is the handle for "mc generate-docs" command.
+func mainGenerateDocs(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "generate-docs", 1) // last argument is exit code
+	}
+	format := ctx.String("format")
+	if format != docsFormatMan && format != docsFormatMarkdown {
+		fatalIf(errInvalidArgument().Trace(format), "Unknown format `"+format+"`, must be one of man or markdown.")
+	}
+
+	// Additional command specific theme customization.
+	console.SetColor("GenerateDocs", color.New(color.FgGreen, color.Bold))
+
+	dir := ctx.Args().First()
+	fatalIf(probe.NewError(os.MkdirAll(dir, 0755)), "Unable to create `"+dir+"`.")
+
+	for _, cmd := range ctx.App.Commands {
+		if cmd.Hidden || cmd.Name == "help" {
+			continue
+		}
+		err := generateDocs(cmd, ctx.App.Name+" "+cmd.Name, format, dir)
+		fatalIf(err, "Unable to generate the documents of `"+cmd.Name+"`.")
+	}
+	return nil
+}
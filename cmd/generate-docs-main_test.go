@@ -0,0 +1,44 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseHelp(t *testing.T) {
+	help := `NAME:
+  mc ls - list buckets and objects
+
+USAGE:
+  mc ls [FLAGS] TARGET [TARGET ...]
+
+EXAMPLES:
+   1. List buckets on Amazon S3 cloud storage.
+      $ mc ls s3
+
+`
+	expected := []helpSection{
+		{title: "NAME", lines: []string{"mc ls - list buckets and objects"}},
+		{title: "USAGE", lines: []string{"mc ls [FLAGS] TARGET [TARGET ...]"}},
+		{title: "EXAMPLES", lines: []string{"1. List buckets on Amazon S3 cloud storage.", "   $ mc ls s3"}},
+	}
+	if sections := parseHelp(help); !reflect.DeepEqual(sections, expected) {
+		t.Errorf("expected %v, got %v", expected, sections)
+	}
+}
@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"crypto/x509"
+	"time"
 
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/console"
@@ -43,38 +44,103 @@ const (
 	globalSharedURLsDataDir    = "share"
 	globalSessionConfigVersion = "8"
 
+	// Read cache directory for remote objects.
+	globalCacheDir = "cache"
+
 	// Profile directory for dumping profiler outputs.
 	globalProfileDir = "profile"
 
+	// Configuration folders of profiles other than the default one, and
+	// the file naming the profile in use.
+	globalProfilesDir       = "profiles"
+	globalActiveProfileFile = "active-profile"
+
+	// Cumulative transfers per alias.
+	globalTransferStatsFile = "stats.json"
+
+	// Append-only log of mutating requests.
+	globalAuditLogFile = "audit.log"
+
 	// Global error exit status.
 	globalErrorExitStatus = 1
+
+	// Exit status of a copy or mirror stopped by --max-duration or
+	// --max-bytes, distinct from the exit status of errors.
+	globalRunLimitExitStatus = 11
+
+	// Refresh interval of progress bars, and of progress lines in CI mode.
+	defaultProgressInterval = 125 * time.Millisecond
+	ciProgressInterval      = 10 * time.Second
 )
 
 var (
-	globalQuiet    = false // Quiet flag set via command line
-	globalJSON     = false // Json flag set via command line
-	globalDebug    = false // Debug flag set via command line
-	globalNoColor  = false // No Color flag set via command line
-	globalInsecure = false // Insecure flag set via command line
+	globalQuiet     = false // Quiet flag set via command line
+	globalJSON      = false // Json flag set via command line
+	globalDebug     = false // Debug flag set via command line
+	globalNoColor   = false // No Color flag set via command line
+	globalInsecure  = false // Insecure flag set via command line
+	globalASCII     = false // ASCII flag set via command line or a non UTF-8 locale
+	globalMaxRPS    = 0     // Max requests per second set via command line, 0 for unlimited
+	globalHTTP1     = false // HTTP/1.1 only flag set via command line
+	globalReadOnly  = false // Read-only flag set via command line
+	globalAnonymous = false // Anonymous flag set via command line
+
+	globalPreferEndpoint = "" // Preferred endpoint of aliases with several endpoints set via command line
+
+	globalRoleARN    = "" // Role assumed over the credentials of aliases set via command line
+	globalExternalID = "" // External ID of the assumed role set via command line
+
+	globalProgressInterval = defaultProgressInterval // Refresh interval of progress and scan bars set via command line
+	globalProgressWidth    = 0                       // Fixed width of progress and scan bars set via command line, 0 follows the terminal
+
+	globalCI = false // CI flag set via command line or output not being a terminal
 
 	// WHEN YOU ADD NEXT GLOBAL FLAG, MAKE SURE TO ALSO UPDATE SESSION CODE AND CODE BELOW.
 )
 
 var (
-	// Terminal width
-	globalTermWidth int
-
 	// CA root certificates, a nil value means system certs pool will be used
 	globalRootCAs *x509.CertPool
 )
 
 // Set global states. NOTE: It is deliberately kept monolithic to ensure we dont miss out any flags.
-func setGlobals(quiet, debug, json, noColor, insecure bool) {
+func setGlobals(quiet, debug, json, noColor, insecure, ascii, http1, readOnly, anonymous bool, maxRPS int, preferEndpoint, roleARN, externalID string, progressInterval time.Duration, progressWidth int, ci bool) {
+	globalCI = globalCI || ci
+	// Progress bars and colors only get in the way of logs.
+	if globalCI {
+		quiet = true
+		noColor = true
+		if progressInterval == 0 && globalProgressInterval == defaultProgressInterval {
+			progressInterval = ciProgressInterval
+		}
+	}
+
 	globalQuiet = globalQuiet || quiet
 	globalDebug = globalDebug || debug
 	globalJSON = globalJSON || json
 	globalNoColor = globalNoColor || noColor
 	globalInsecure = globalInsecure || insecure
+	globalASCII = globalASCII || ascii
+	globalHTTP1 = globalHTTP1 || http1
+	globalReadOnly = globalReadOnly || readOnly
+	globalAnonymous = globalAnonymous || anonymous
+	if maxRPS > 0 {
+		globalMaxRPS = maxRPS
+	}
+	if preferEndpoint != "" {
+		globalPreferEndpoint = trimTrailingSeparator(preferEndpoint)
+	}
+	if roleARN != "" {
+		globalRoleARN = roleARN
+		globalExternalID = externalID
+	}
+	if progressInterval > 0 {
+		globalProgressInterval = progressInterval
+	}
+	if progressWidth > 0 {
+		globalProgressWidth = progressWidth
+		setTermWidth(progressWidth)
+	}
 
 	// Enable debug messages if requested.
 	if globalDebug {
@@ -85,6 +151,11 @@ func setGlobals(quiet, debug, json, noColor, insecure bool) {
 	if globalNoColor || globalQuiet {
 		console.SetColorOff()
 	}
+
+	// Restrict output to plain ASCII if requested.
+	if globalASCII {
+		console.SetASCII()
+	}
 }
 
 // Set global states. NOTE: It is deliberately kept monolithic to ensure we dont miss out any flags.
@@ -94,6 +165,32 @@ func setGlobalsFromContext(ctx *cli.Context) error {
 	json := ctx.IsSet("json")
 	noColor := ctx.IsSet("no-color")
 	insecure := ctx.IsSet("insecure")
-	setGlobals(quiet, debug, json, noColor, insecure)
+	ascii := ctx.IsSet("ascii") || !isUTF8Locale()
+	http1 := ctx.IsSet("http1")
+	readOnly := ctx.IsSet("read-only")
+	anonymous := ctx.IsSet("anonymous")
+	maxRPS := ctx.Int("max-rps")
+	preferEndpoint := ctx.String("prefer-endpoint")
+	roleARN := ctx.String("role-arn")
+	externalID := ctx.String("external-id")
+	if externalID != "" && roleARN == "" {
+		fatalIf(errInvalidArgument().Trace(externalID), "‘--external-id’ needs a role set by ‘--role-arn’.")
+	}
+	var progressInterval time.Duration
+	if interval := ctx.String("progress-interval"); interval != "" {
+		var e error
+		if progressInterval, e = time.ParseDuration(interval); e != nil || progressInterval <= 0 {
+			fatalIf(errInvalidArgument().Trace(interval), "Unable to parse ‘--progress-interval’, use a duration such as 1s.")
+		}
+	}
+	progressWidth := ctx.Int("progress-width")
+	if progressWidth < 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("progress-width")), "‘--progress-width’ cannot be negative.")
+	}
+	ci := ctx.IsSet("ci")
+	setGlobals(quiet, debug, json, noColor, insecure, ascii, http1, readOnly, anonymous, maxRPS, preferEndpoint, roleARN, externalID, progressInterval, progressWidth, ci)
+	setLanguage(ctx.String("lang"))
+	err := startProfilers(ctx.String("cpuprofile"), ctx.String("memprofile"), ctx.String("trace"))
+	fatalIf(err, "Unable to start profiling.")
 	return nil
 }
@@ -0,0 +1,338 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+const (
+	// Number of concurrent requests checking targets.
+	headPrefetchWorkers = 16
+
+	// Number of targets checked ahead of their use.
+	headPrefetchWindow = 16 * headPrefetchWorkers
+)
+
+// headResult - stat of a target, nil if it does not exist.
+type headResult struct {
+	content *clientContent
+	attrs   *objectAttributes
+	err     *probe.Error
+	done    chan struct{}
+}
+
+// headPrefetcher - stats targets with its own pool of workers before
+// they are looked at, results are cached until then.
+type headPrefetcher struct {
+	alias   string
+	keys    []prefixSSEPair
+	queueCh chan string
+
+	// Closed to stop the workers.
+	doneCh    chan struct{}
+	closeOnce sync.Once
+
+	// Set once the endpoint is known to have no GetObjectAttributes.
+	noAttributes int32
+
+	mutex sync.Mutex
+	cache map[string]*headResult
+}
+
+// newHeadPrefetcher - starts the workers stating targets of alias, they
+// stop with close.
+func newHeadPrefetcher(alias string, keys []prefixSSEPair) *headPrefetcher {
+	p := &headPrefetcher{
+		alias:   alias,
+		keys:    keys,
+		queueCh: make(chan string, headPrefetchWindow),
+		doneCh:  make(chan struct{}),
+		cache:   make(map[string]*headResult),
+	}
+	for i := 0; i < headPrefetchWorkers; i++ {
+		go func() {
+			for {
+				select {
+				case urlStr := <-p.queueCh:
+					p.mutex.Lock()
+					result := p.cache[urlStr]
+					p.mutex.Unlock()
+					result.content, result.attrs, result.err = p.head(urlStr)
+					close(result.done)
+				case <-p.doneCh:
+					return
+				}
+			}
+		}()
+	}
+	return p
+}
+
+// close - stops the workers, targets still queued are not stated and
+// must not be waited for. It may be called more than once.
+func (p *headPrefetcher) close() {
+	p.closeOnce.Do(func() {
+		close(p.doneCh)
+	})
+}
+
+// head - stats the object at urlStr with a single request, listing is
+// avoided on object storage. GetObjectAttributes is used where the
+// endpoint has it, for the checksums and sizes of parts.
+func (p *headPrefetcher) head(urlStr string) (*clientContent, *objectAttributes, *probe.Error) {
+	clnt, err := newClientFromAlias(p.alias, urlStr)
+	if err != nil {
+		return nil, nil, err.Trace(urlStr)
+	}
+	sse := getSSE(filepath.ToSlash(filepath.Join(p.alias, clnt.GetURL().Path)), p.keys)
+	var content *clientContent
+	var attrs *objectAttributes
+	if s3Clnt, ok := clnt.(*s3Client); ok {
+		if atomic.LoadInt32(&p.noAttributes) == 0 {
+			content, attrs, err = s3Clnt.getObjectAttributes(sse)
+			if err != nil && err.ToGoError() == errAttributesNotSupported {
+				atomic.StoreInt32(&p.noAttributes, 1)
+			}
+		}
+		if atomic.LoadInt32(&p.noAttributes) == 1 {
+			bucket, object := s3Clnt.url2BucketAndObject()
+			opts := minio.StatObjectOptions{}
+			opts.ServerSideEncryption = sse
+			content, err = s3Clnt.getObjectStat(bucket, object, opts)
+		}
+	} else {
+		content, err = clnt.Stat(false, false, sse)
+	}
+	if err != nil {
+		switch err.ToGoError().(type) {
+		case ObjectMissing, PathNotFound:
+			return nil, nil, nil
+		}
+		return nil, nil, err.Trace(urlStr)
+	}
+	return content, attrs, nil
+}
+
+// prefetch - queues urlStr to be stated, blocks while the queue is full
+// unless the prefetcher is closed.
+func (p *headPrefetcher) prefetch(urlStr string) {
+	p.mutex.Lock()
+	if _, ok := p.cache[urlStr]; ok {
+		p.mutex.Unlock()
+		return
+	}
+	p.cache[urlStr] = &headResult{done: make(chan struct{})}
+	p.mutex.Unlock()
+	select {
+	case p.queueCh <- urlStr:
+	case <-p.doneCh:
+	}
+}
+
+// get - waits for the stat of urlStr, nil if it does not exist. Results
+// are removed from the cache once returned.
+func (p *headPrefetcher) get(urlStr string) (*clientContent, *objectAttributes, *probe.Error) {
+	p.mutex.Lock()
+	result, ok := p.cache[urlStr]
+	p.mutex.Unlock()
+	if !ok {
+		return p.head(urlStr)
+	}
+	<-result.done
+	p.mutex.Lock()
+	delete(p.cache, urlStr)
+	p.mutex.Unlock()
+	return result.content, result.attrs, result.err
+}
+
+// headDifference - compares source with target like objectDifference,
+// but the target is not listed: the target of every source object is
+// checked on its own. Objects only on target are not found, newer local
+// files are not copied again if they match the checksum or ETag of
+// their target. Listing and stating stop when the comparison does, on
+// the first error.
+func headDifference(sourceClnt Client, targetAlias, sourceURL, targetURL string, targetType clientURLType, returnSimilar bool, keyEnc keyEncoder, keys []prefixSSEPair) (diffCh chan diffMessage) {
+	diffCh = make(chan diffMessage, 1000)
+	prefetcher := newHeadPrefetcher(targetAlias, keys)
+
+	type pending struct {
+		content   *clientContent
+		targetURL string
+	}
+	// Bounds the number of targets checked ahead.
+	pendingCh := make(chan pending, headPrefetchWindow)
+	go func() {
+		defer close(pendingCh)
+		for content := range sourceClnt.List(true, false, DirNone) {
+			next := pending{content: content}
+			if content.Err == nil {
+				suffix := strings.TrimPrefix(content.URL.String(), sourceURL)
+				next.targetURL = urlJoinPath(targetURL, keyEnc.translate(suffix, content.URL.Type, targetType))
+				prefetcher.prefetch(next.targetURL)
+			}
+			select {
+			case pendingCh <- next:
+			case <-prefetcher.doneCh:
+				return
+			}
+			if content.Err != nil {
+				return
+			}
+		}
+	}()
+
+	go func() {
+		defer close(diffCh)
+		// Stops the listing and the workers on every return.
+		defer prefetcher.close()
+		for p := range pendingCh {
+			srcCtnt := p.content
+			if srcCtnt.Err != nil {
+				diffCh <- diffMessage{Error: srcCtnt.Err.Trace(sourceURL, targetURL)}
+				return
+			}
+			tgtCtnt, attrs, err := prefetcher.get(p.targetURL)
+			if err != nil {
+				diffCh <- diffMessage{Error: err.Trace(sourceURL, targetURL)}
+				return
+			}
+			msg := diffMessage{
+				FirstURL:      srcCtnt.URL.String(),
+				firstContent:  srcCtnt,
+				secondContent: tgtCtnt,
+			}
+			switch {
+			case tgtCtnt == nil:
+				msg.Diff = differInFirst
+			case srcCtnt.Type.IsRegular() != tgtCtnt.Type.IsRegular():
+				msg.Diff = differInType
+			case srcCtnt.Size != tgtCtnt.Size:
+				msg.Diff = differInSize
+			case srcCtnt.Time.After(tgtCtnt.Time) && !isSameContent(srcCtnt, tgtCtnt, attrs):
+				msg.Diff = differInTime
+			case returnSimilar:
+				msg.Diff = differInNone
+			default:
+				continue
+			}
+			if tgtCtnt != nil {
+				msg.SecondURL = tgtCtnt.URL.String()
+			}
+			diffCh <- msg
+		}
+	}()
+	return diffCh
+}
+
+// isSameContent - returns true if source is known to hold the data of
+// target although it is newer. Objects are compared by their ETags,
+// local files by the checksum or ETag of target computed from them.
+func isSameContent(srcCtnt, tgtCtnt *clientContent, attrs *objectAttributes) bool {
+	if srcCtnt.URL.Type == objectStorage {
+		etag := strings.Trim(srcCtnt.ETag, "\"")
+		return etag != "" && etag == strings.Trim(tgtCtnt.ETag, "\"")
+	}
+	if attrs == nil || !srcCtnt.Type.IsRegular() {
+		return false
+	}
+	same, known := compareLocalSum(srcCtnt, tgtCtnt, attrs)
+	return known && same
+}
+
+// compareLocalSum - returns true if the local file holds the data of
+// object, by the checksum or ETag of object computed from it. known is
+// false if they cannot be compared.
+func compareLocalSum(file, object *clientContent, attrs *objectAttributes) (same, known bool) {
+	f, e := os.Open(file.URL.Path)
+	if e != nil {
+		return false, false
+	}
+	defer f.Close()
+
+	var sizes []int64
+	if attrs != nil && attrs.ObjectParts.TotalPartsCount > 0 {
+		if !attrs.hasAllParts() {
+			return false, false
+		}
+		for _, part := range attrs.ObjectParts.Parts {
+			sizes = append(sizes, part.Size)
+		}
+	}
+	if attrs != nil {
+		if checksum := attrs.Checksum.checksum(); checksum.Algorithm != "" {
+			newHash := func() hash.Hash { return newChecksumHash(checksum.Algorithm) }
+			sum, ok := localSum(f, file.Size, sizes, newHash)
+			value := strings.SplitN(checksum.Value, "-", 2)[0]
+			return ok && base64.StdEncoding.EncodeToString(sum) == value, ok
+		}
+	}
+	// ETags of server encrypted objects are not their MD5.
+	if isServerEncrypted(object) {
+		return false, false
+	}
+	etag := strings.Trim(object.ETag, "\"")
+	if len(sizes) > 0 {
+		etag = strings.TrimSuffix(etag, fmt.Sprintf("-%d", len(sizes)))
+	}
+	if !md5ETagRe.MatchString(etag) {
+		return false, false
+	}
+	sum, ok := localSum(f, file.Size, sizes, md5.New)
+	return ok && hex.EncodeToString(sum) == strings.ToLower(etag), ok
+}
+
+// localSum - returns the hash of r of size, or with part sizes the hash
+// of the hashes of its parts, as computed for multipart uploads.
+func localSum(r io.ReaderAt, size int64, sizes []int64, newHash func() hash.Hash) ([]byte, bool) {
+	isMultipart := len(sizes) > 0
+	if !isMultipart {
+		sizes = []int64{size}
+	}
+	var sums []byte
+	var offset int64
+	for _, partSize := range sizes {
+		h := newHash()
+		if n, e := io.Copy(h, io.NewSectionReader(r, offset, partSize)); e != nil || n != partSize {
+			return nil, false
+		}
+		offset += partSize
+		sums = h.Sum(sums)
+	}
+	if offset != size {
+		return nil, false
+	}
+	if !isMultipart {
+		return sums, true
+	}
+	h := newHash()
+	h.Write(sums)
+	return h.Sum(nil), true
+}
@@ -0,0 +1,117 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestIsSameContent(t *testing.T) {
+	file, e := ioutil.TempFile("", "head-prefetch")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.Remove(file.Name())
+	if _, e = file.WriteString("hello, world"); e != nil {
+		t.Fatal(e)
+	}
+	file.Close()
+
+	local := &clientContent{URL: *newClientURL(file.Name()), Size: 12, Type: os.FileMode(0644)}
+	remote := &clientContent{URL: *newClientURL("https://s3.amazonaws.com/bucket/object"), Size: 12, ETag: "e4d7f1b4ed2e42d15898f4b27b019da4"}
+	parts := func(attrs *objectAttributes) *objectAttributes {
+		attrs.ObjectSize = 12
+		attrs.ObjectParts.TotalPartsCount = 3
+		attrs.ObjectParts.Parts = []objectPart{{PartNumber: 1, Size: 5}, {PartNumber: 2, Size: 5}, {PartNumber: 3, Size: 2}}
+		return attrs
+	}
+
+	testCases := []struct {
+		source *clientContent
+		target *clientContent
+		attrs  *objectAttributes
+		same   bool
+	}{
+		// Objects are compared by their ETags.
+		{remote, &clientContent{ETag: "\"e4d7f1b4ed2e42d15898f4b27b019da4\""}, nil, true},
+		{remote, &clientContent{ETag: "0a1b2c3d4e5f60718293a4b5c6d7e8f9"}, nil, false},
+		// Local files need attributes of their target.
+		{local, remote, nil, false},
+		{local, remote, &objectAttributes{ObjectSize: 12}, true},
+		{local, &clientContent{ETag: "0a1b2c3d4e5f60718293a4b5c6d7e8f9"}, &objectAttributes{ObjectSize: 12}, false},
+		// ETags of server encrypted targets are not their MD5.
+		{local, &clientContent{ETag: "e4d7f1b4ed2e42d15898f4b27b019da4", Metadata: map[string]string{"X-Amz-Server-Side-Encryption": "aws:kms"}}, &objectAttributes{ObjectSize: 12}, false},
+		{local, &clientContent{ETag: "909ce955bd5188668b0191809affc873-3"}, parts(&objectAttributes{}), true},
+		{local, &clientContent{ETag: "909ce955bd5188668b0191809affc873-3"}, &objectAttributes{ObjectSize: 12}, false},
+		{local, &clientContent{}, &objectAttributes{ObjectSize: 12, Checksum: attributesChecksum{ChecksumSHA256: "Ccp+TqpuiunH0mEWcSkYSINkTQffuny/vEyKLgg2DVs="}}, true},
+		{local, &clientContent{}, parts(&objectAttributes{Checksum: attributesChecksum{ChecksumSHA256: "7OnWw/8V0MMv7x+dzTgYrNZqGdPW92qZALpDRpf1iwQ=-3"}}), true},
+		{local, &clientContent{}, parts(&objectAttributes{Checksum: attributesChecksum{ChecksumSHA256: "Ccp+TqpuiunH0mEWcSkYSINkTQffuny/vEyKLgg2DVs="}}), false},
+	}
+	for i, testCase := range testCases {
+		if same := isSameContent(testCase.source, testCase.target, testCase.attrs); same != testCase.same {
+			t.Errorf("Test %d: expected %t, got %t", i+1, testCase.same, same)
+		}
+	}
+}
+
+func TestHeadDifferenceStops(t *testing.T) {
+	sourceDir, e := ioutil.TempDir("", "head-prefetch")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(sourceDir)
+	// More objects than are checked ahead, the listing is left blocked
+	// if it is not stopped.
+	for i := 0; i < 2*headPrefetchWindow+headPrefetchWorkers; i++ {
+		if e = ioutil.WriteFile(filepath.Join(sourceDir, fmt.Sprintf("object-%04d", i)), []byte("hello"), 0644); e != nil {
+			t.Fatal(e)
+		}
+	}
+	// Targets under a file cannot be stated.
+	targetFile := filepath.Join(sourceDir, "object-0000")
+
+	// Both sides are local, no alias is configured.
+	defer func(load func() (*configV9, *probe.Error)) { loadMcConfig = load }(loadMcConfig)
+	loadMcConfig = func() (*configV9, *probe.Error) { return newMcConfig(), nil }
+
+	sourceClnt, err := newClient(sourceDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	goroutines := runtime.NumGoroutine()
+	diffCh := headDifference(sourceClnt, "", sourceDir, targetFile, fileSystem, false, keyEncoder{}, nil)
+	msg, ok := <-diffCh
+	if !ok || msg.Error == nil {
+		t.Fatalf("expected an error stating `%s`, got %v", targetFile, msg)
+	}
+	if _, ok = <-diffCh; ok {
+		t.Fatal("expected the comparison to stop on the first error")
+	}
+	for deadline := time.Now().Add(10 * time.Second); runtime.NumGoroutine() > goroutines; {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d goroutines once the comparison stopped, got %d", goroutines, runtime.NumGoroutine())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,60 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/minio/cli"
+)
+
+var holdAddFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "recursive, r",
+		Usage: "hold all objects under the prefix",
+	},
+}
+
+var holdAddCmd = cli.Command{
+	Name:            "add",
+	Usage:           "place objects under legal hold",
+	Action:          mainHoldAdd,
+	Before:          setGlobalsFromContext,
+	Flags:           append(holdAddFlags, globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET [TARGET ...]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Place an object of bucket 'contracts' under legal hold, the bucket has object lock enabled.
+     $ {{.HelpName}} s3/contracts/2019/acme.pdf
+
+  2. Place all objects under prefix 'acme/' of bucket 'contracts' under legal hold, writing a JSON line per object.
+     $ {{.HelpName}} --recursive --json s3/contracts/acme/ > held.json
+
+`,
+}
+
+// mainHoldAdd is the handle for "mc hold add" command.
+func mainHoldAdd(ctx *cli.Context) error {
+	checkHoldSyntax(ctx, "add")
+	return setLegalHolds(ctx, legalHoldOn)
+}
@@ -0,0 +1,198 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/cheggaaa/pb"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var holdCmd = cli.Command{
+	Name:            "hold",
+	Usage:           "add, remove and show legal holds of objects",
+	HideHelpCommand: true,
+	Action:          mainHold,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	Subcommands: []cli.Command{
+		holdAddCmd,
+		holdRemoveCmd,
+		holdStatusCmd,
+	},
+}
+
+// mainHold is the handle for "mc hold" command.
+func mainHold(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "add", "remove", "status" have their own main.
+}
+
+// holdMessage - legal hold of an object.
+type holdMessage struct {
+	Status    string `json:"status"`
+	URL       string `json:"url"`
+	LegalHold string `json:"legalHold"`
+}
+
+// String colorized legal hold message.
+func (h holdMessage) String() string {
+	theme := "HoldOff"
+	if h.LegalHold == legalHoldOn {
+		theme = "HoldOn"
+	}
+	return console.Colorize(theme, fmt.Sprintf("%-3s", h.LegalHold)) + " " + printableKey(h.URL)
+}
+
+// JSON jsonified legal hold message.
+func (h holdMessage) JSON() string {
+	h.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(h, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// holdSummary - count of the objects whose legal hold was set, printed
+// after them.
+type holdSummary struct {
+	Status    string `json:"status"`
+	Type      string `json:"type"`
+	LegalHold string `json:"legalHold"`
+	Objects   int64  `json:"objects"`
+	Failed    int64  `json:"failed"`
+}
+
+// String colorized legal hold summary message.
+func (h holdSummary) String() string {
+	return fmt.Sprintf("Legal hold set to %s on %d object(s), %d failed.", h.LegalHold, h.Objects, h.Failed)
+}
+
+// JSON jsonified legal hold summary message.
+func (h holdSummary) JSON() string {
+	h.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(h, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// checkHoldSyntax - validate all the passed arguments.
+func checkHoldSyntax(ctx *cli.Context, cmdName string) {
+	if !ctx.Args().Present() {
+		cli.ShowCommandHelpAndExit(ctx, cmdName, 1) // last argument is exit code
+	}
+	console.SetColor("HoldOn", color.New(color.FgYellow, color.Bold))
+	console.SetColor("HoldOff", color.New(color.FgGreen))
+}
+
+// holdObjectURLs - returns the aliased URLs of the object at urlStr, or
+// of the objects under it if isRecursive.
+func holdObjectURLs(urlStr string, isRecursive bool) ([]string, *probe.Error) {
+	if !isRecursive {
+		return []string{urlStr}, nil
+	}
+	alias, _, _ := mustExpandAlias(urlStr)
+	clnt, err := newClient(urlStr)
+	if err != nil {
+		return nil, err.Trace(urlStr)
+	}
+	var urls []string
+	for content := range clnt.List(isRecursive, false, DirNone) {
+		if content.Err != nil {
+			return nil, content.Err.Trace(urlStr)
+		}
+		if content.Type.IsDir() {
+			continue
+		}
+		urls = append(urls, filepath.ToSlash(filepath.Join(alias, content.URL.Path)))
+	}
+	return urls, nil
+}
+
+// newHoldClient - returns the client of the object at the aliased URL
+// urlStr, legal holds are only found on S3.
+func newHoldClient(urlStr string) (*s3Client, *probe.Error) {
+	alias, expandedURL, _ := mustExpandAlias(urlStr)
+	clnt, err := newClientFromAlias(alias, expandedURL)
+	if err != nil {
+		return nil, err.Trace(urlStr)
+	}
+	s3Clnt, ok := clnt.(*s3Client)
+	if !ok {
+		return nil, probe.NewError(APINotImplemented{API: "Legal hold", APIType: "filesystem"}).Trace(urlStr)
+	}
+	return s3Clnt, nil
+}
+
+// setLegalHolds - sets the legal hold of the objects at the URLs of ctx
+// to status, showing the objects done on a progress bar unless --json
+// or --quiet print them one by one.
+func setLegalHolds(ctx *cli.Context, status string) error {
+	isRecursive := ctx.Bool("recursive")
+	showProgress := !globalQuiet && !globalJSON
+	summary := holdSummary{Type: "summary", LegalHold: status}
+
+	var rerr error
+	for _, urlStr := range ctx.Args() {
+		urls, err := holdObjectURLs(urlStr, isRecursive)
+		if err != nil {
+			errorIf(err, "Unable to list `"+urlStr+"`.")
+			rerr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+
+		var pg *progressBar
+		showBar := showProgress && len(urls) > 0
+		if showBar {
+			pg = newProgressBar(int64(len(urls)))
+			pg.SetUnits(pb.U_NO)
+			pg.SetCaption(urlStr + ": ")
+		}
+		for _, objectURL := range urls {
+			clnt, err := newHoldClient(objectURL)
+			if err == nil {
+				err = clnt.setLegalHold(status)
+			}
+			if err != nil {
+				errorIf(err.Trace(objectURL), "Unable to set the legal hold of `"+objectURL+"` to "+status+".")
+				summary.Failed++
+				rerr = exitStatus(globalErrorExitStatus)
+			} else {
+				summary.Objects++
+				if !showBar {
+					printMsg(holdMessage{URL: objectURL, LegalHold: status})
+				}
+			}
+			if showBar {
+				pg.Increment()
+			}
+		}
+		if showBar {
+			pg.Finish()
+		}
+	}
+	printMsg(summary)
+	return rerr
+}
@@ -0,0 +1,60 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/minio/cli"
+)
+
+var holdRemoveFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "recursive, r",
+		Usage: "release all objects under the prefix",
+	},
+}
+
+var holdRemoveCmd = cli.Command{
+	Name:            "remove",
+	Usage:           "release objects from legal hold",
+	Action:          mainHoldRemove,
+	Before:          setGlobalsFromContext,
+	Flags:           append(holdRemoveFlags, globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET [TARGET ...]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Release an object of bucket 'contracts' from legal hold.
+     $ {{.HelpName}} s3/contracts/2019/acme.pdf
+
+  2. Release all objects under prefix 'acme/' of bucket 'contracts' from legal hold once the litigation is over.
+     $ {{.HelpName}} --recursive s3/contracts/acme/
+
+`,
+}
+
+// mainHoldRemove is the handle for "mc hold remove" command.
+func mainHoldRemove(ctx *cli.Context) error {
+	checkHoldSyntax(ctx, "remove")
+	return setLegalHolds(ctx, legalHoldOff)
+}
@@ -0,0 +1,86 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/minio/cli"
+)
+
+var holdStatusFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "recursive, r",
+		Usage: "show the legal hold of all objects under the prefix",
+	},
+}
+
+var holdStatusCmd = cli.Command{
+	Name:            "status",
+	Usage:           "show the legal hold of objects",
+	Action:          mainHoldStatus,
+	Before:          setGlobalsFromContext,
+	Flags:           append(holdStatusFlags, globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET [TARGET ...]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the legal hold of an object of bucket 'contracts'.
+     $ {{.HelpName}} s3/contracts/2019/acme.pdf
+
+  2. Show the objects under prefix 'acme/' of bucket 'contracts' which are not held.
+     $ {{.HelpName}} --recursive --json s3/contracts/acme/ | jq 'select(.legalHold == "OFF")'
+
+`,
+}
+
+// mainHoldStatus is the handle for "mc hold status" command.
+func mainHoldStatus(ctx *cli.Context) error {
+	checkHoldSyntax(ctx, "status")
+	isRecursive := ctx.Bool("recursive")
+
+	var rerr error
+	for _, urlStr := range ctx.Args() {
+		urls, err := holdObjectURLs(urlStr, isRecursive)
+		if err != nil {
+			errorIf(err, "Unable to list `"+urlStr+"`.")
+			rerr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		for _, objectURL := range urls {
+			clnt, err := newHoldClient(objectURL)
+			if err != nil {
+				errorIf(err, "Unable to get the legal hold of `"+objectURL+"`.")
+				rerr = exitStatus(globalErrorExitStatus)
+				continue
+			}
+			status, err := clnt.getLegalHold()
+			if err != nil {
+				errorIf(err.Trace(objectURL), "Unable to get the legal hold of `"+objectURL+"`.")
+				rerr = exitStatus(globalErrorExitStatus)
+				continue
+			}
+			printMsg(holdMessage{URL: objectURL, LegalHold: status})
+		}
+	}
+	return rerr
+}
@@ -0,0 +1,167 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Flags running commands around transfers.
+var transferHookFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "pre-exec",
+		Usage: "run command before transfers, objects are skipped if it fails",
+	},
+	cli.StringFlag{
+		Name:  "post-exec",
+		Usage: "run command after transfers",
+	},
+	cli.StringFlag{
+		Name:  "exec-scope",
+		Value: "object",
+		Usage: "run hooks around each 'object' or once around the whole 'job'",
+	},
+}
+
+// transferHooks - commands run around each object or the whole job.
+type transferHooks struct {
+	preExec  string
+	postExec string
+	perJob   bool
+}
+
+// newTransferHooks - returns the hooks for the values of transferHookFlags.
+func newTransferHooks(preExec, postExec, scope string) (transferHooks, *probe.Error) {
+	hooks := transferHooks{preExec: preExec, postExec: postExec}
+	switch scope {
+	case "", "object":
+	case "job":
+		hooks.perJob = true
+	default:
+		return hooks, errInvalidArgument().Trace(scope)
+	}
+	return hooks, nil
+}
+
+// runHook - runs command with env added to the environment of mc, its
+// output goes to stderr to keep the output of mc parseable.
+func runHook(command string, env map[string]string) *probe.Error {
+	cmd := shellCommand(command)
+	cmd.Env = os.Environ()
+	for k, v := range env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if e := cmd.Run(); e != nil {
+		return probe.NewError(HookFailed{Hook: env["MC_HOOK"], Command: command, Err: e})
+	}
+	return nil
+}
+
+// shellCommand - returns command run by the shell of the platform.
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
+
+// setHookStatus - records the result of a transfer for post hooks.
+func setHookStatus(env map[string]string, err *probe.Error) {
+	env["MC_HOOK"] = "post"
+	env["MC_STATUS"] = "success"
+	if err != nil {
+		env["MC_STATUS"] = "error"
+		env["MC_ERROR"] = err.ToGoError().Error()
+	}
+}
+
+// isHookFailed - returns true if err was returned by a failing hook.
+func isHookFailed(err *probe.Error) bool {
+	_, ok := err.ToGoError().(HookFailed)
+	return ok
+}
+
+// aroundObject - runs transfer of urls between the object hooks.
+func (h transferHooks) aroundObject(urls URLs, transfer func() URLs) URLs {
+	if h.perJob || (h.preExec == "" && h.postExec == "") {
+		return transfer()
+	}
+	env := map[string]string{
+		"MC_HOOK":   "pre",
+		"MC_SCOPE":  "object",
+		"MC_SOURCE": filepath.ToSlash(filepath.Join(urls.SourceAlias, urls.SourceContent.URL.Path)),
+		"MC_TARGET": filepath.ToSlash(filepath.Join(urls.TargetAlias, urls.TargetContent.URL.Path)),
+		"MC_SIZE":   strconv.FormatInt(urls.SourceContent.Size, 10),
+	}
+	if h.preExec != "" {
+		if err := runHook(h.preExec, env); err != nil {
+			return urls.WithError(err.Trace(h.preExec))
+		}
+	}
+	urls = transfer()
+	if h.postExec != "" {
+		setHookStatus(env, urls.Error)
+		if err := runHook(h.postExec, env); err != nil && urls.Error == nil {
+			return urls.WithError(err.Trace(h.postExec))
+		}
+	}
+	return urls
+}
+
+// jobEnv - returns the environment of the job hooks.
+func (h transferHooks) jobEnv(sources []string, target string, size int64) map[string]string {
+	return map[string]string{
+		"MC_SCOPE":  "job",
+		"MC_SOURCE": strings.Join(sources, " "),
+		"MC_TARGET": target,
+		"MC_SIZE":   strconv.FormatInt(size, 10),
+	}
+}
+
+// beforeJob - runs the pre hook of a job.
+func (h transferHooks) beforeJob(sources []string, target string, size int64) *probe.Error {
+	if !h.perJob || h.preExec == "" {
+		return nil
+	}
+	env := h.jobEnv(sources, target, size)
+	env["MC_HOOK"] = "pre"
+	return runHook(h.preExec, env)
+}
+
+// afterJob - runs the post hook of a job, failed if any of its
+// transfers failed.
+func (h transferHooks) afterJob(sources []string, target string, size int64, failed bool) *probe.Error {
+	if !h.perJob || h.postExec == "" {
+		return nil
+	}
+	env := h.jobEnv(sources, target, size)
+	env["MC_HOOK"] = "post"
+	env["MC_STATUS"] = "success"
+	if failed {
+		env["MC_STATUS"] = "error"
+	}
+	return runHook(h.postExec, env)
+}
@@ -0,0 +1,191 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Failover policies for aliases with several endpoints.
+const (
+	failoverStandby    = "standby"
+	failoverRoundRobin = "round-robin"
+	failoverLatency    = "latency"
+)
+
+const (
+	// Time an unreachable endpoint is skipped before it is tried again.
+	endpointRetryInterval = 30 * time.Second
+
+	// Timeout of the reachability probe of an endpoint.
+	endpointProbeTimeout = 5 * time.Second
+)
+
+// endpointState - health of the endpoints of all aliases.
+var endpointState = struct {
+	sync.Mutex
+	downUntil map[string]time.Time
+	probed    map[string]bool
+	latency   map[string]time.Duration
+	next      map[string]int
+}{
+	downUntil: make(map[string]time.Time),
+	probed:    make(map[string]bool),
+	latency:   make(map[string]time.Duration),
+	next:      make(map[string]int),
+}
+
+// isValidFailover - validates the failover policy of an alias.
+func isValidFailover(failover string) bool {
+	switch failover {
+	case "", failoverStandby, failoverRoundRobin, failoverLatency:
+		return true
+	}
+	return false
+}
+
+// hostEndpoints - returns all endpoints of an alias, its URL first.
+func hostEndpoints(hostCfg *hostConfigV9) []string {
+	return append([]string{hostCfg.URL}, hostCfg.Endpoints...)
+}
+
+// markEndpointDown - skips endpoint for endpointRetryInterval.
+func markEndpointDown(endpoint string) {
+	endpointState.Lock()
+	defer endpointState.Unlock()
+	endpointState.downUntil[endpoint] = UTCNow().Add(endpointRetryInterval)
+}
+
+// isEndpointUp - reports the health of endpoint, it is probed with a
+// connection attempt when first used.
+func isEndpointUp(endpoint string) bool {
+	endpointState.Lock()
+	probed := endpointState.probed[endpoint]
+	endpointState.probed[endpoint] = true
+	endpointState.Unlock()
+
+	if !probed {
+		latency, ok := probeEndpoint(endpoint)
+		if !ok {
+			markEndpointDown(endpoint)
+		}
+		endpointState.Lock()
+		endpointState.latency[endpoint] = latency
+		endpointState.Unlock()
+	}
+
+	endpointState.Lock()
+	defer endpointState.Unlock()
+	return !UTCNow().Before(endpointState.downUntil[endpoint])
+}
+
+// probeEndpoint - reports if a connection to endpoint can be opened and
+// the time it took.
+func probeEndpoint(endpoint string) (time.Duration, bool) {
+	u := newClientURL(endpoint)
+	host := u.Host
+	if _, _, e := net.SplitHostPort(host); e != nil {
+		if u.Scheme == "http" {
+			host = net.JoinHostPort(host, "80")
+		} else {
+			host = net.JoinHostPort(host, "443")
+		}
+	}
+	start := time.Now()
+	conn, e := net.DialTimeout("tcp", host, endpointProbeTimeout)
+	if e != nil {
+		return 0, false
+	}
+	conn.Close()
+	return time.Since(start), true
+}
+
+// endpointLatency - returns the latency measured by the probe of a
+// healthy endpoint.
+func endpointLatency(endpoint string) time.Duration {
+	endpointState.Lock()
+	defer endpointState.Unlock()
+	return endpointState.latency[endpoint]
+}
+
+// selectEndpoint - returns the endpoint requests for an alias are sent
+// to: the endpoint set by --prefer-endpoint if healthy, otherwise the
+// first healthy one for standby, the next healthy one for round robin
+// and the fastest healthy one for latency. The alias URL is returned if
+// no endpoint is healthy.
+func selectEndpoint(hostCfg *hostConfigV9) string {
+	endpoints := hostEndpoints(hostCfg)
+	for _, endpoint := range endpoints {
+		if endpoint == globalPreferEndpoint && isEndpointUp(endpoint) {
+			return endpoint
+		}
+	}
+	if hostCfg.Failover == failoverLatency {
+		fastest := ""
+		for _, endpoint := range endpoints {
+			if !isEndpointUp(endpoint) {
+				continue
+			}
+			if fastest == "" || endpointLatency(endpoint) < endpointLatency(fastest) {
+				fastest = endpoint
+			}
+		}
+		if fastest != "" {
+			return fastest
+		}
+		return hostCfg.URL
+	}
+	start := 0
+	if hostCfg.Failover == failoverRoundRobin {
+		endpointState.Lock()
+		start = endpointState.next[hostCfg.URL]
+		endpointState.next[hostCfg.URL] = (start + 1) % len(endpoints)
+		endpointState.Unlock()
+	}
+	for i := range endpoints {
+		endpoint := endpoints[(start+i)%len(endpoints)]
+		if isEndpointUp(endpoint) {
+			return endpoint
+		}
+	}
+	return hostCfg.URL
+}
+
+// endpointHealthTransport - marks endpoint down when a connection to it
+// fails or it reports to be unavailable.
+type endpointHealthTransport struct {
+	endpoint  string
+	transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t endpointHealthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, e := t.transport.RoundTrip(req)
+	switch {
+	case e != nil:
+		if req.Context().Err() != context.Canceled {
+			markEndpointDown(t.endpoint)
+		}
+	case resp.StatusCode == http.StatusServiceUnavailable || resp.StatusCode == http.StatusBadGateway:
+		markEndpointDown(t.endpoint)
+	}
+	return resp, e
+}
@@ -0,0 +1,143 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Placeholder for quoted values, such as URLs, in catalog messages.
+const messagePlaceholder = "`{}`"
+
+// Matches quoted values in messages.
+var quotedValueRe = regexp.MustCompile("`[^`]*`")
+
+// messageCatalog - translations of console messages by language, keyed
+// by the English message with quoted values replaced by placeholders.
+// Messages without a translation are printed in English.
+var messageCatalog = map[language.Tag]map[string]string{
+	language.German: {
+		"Unable to marshal into JSON.":                   "JSON-Kodierung fehlgeschlagen.",
+		"Unable to parse encryption keys.":               "Verschlüsselungsschlüssel können nicht gelesen werden.",
+		"Unable to list folder.":                         "Ordner kann nicht aufgelistet werden.",
+		"Unable to validate empty argument.":             "Leeres Argument ist nicht zulässig.",
+		"Unable to guess the type of copy operation.":    "Art des Kopiervorgangs kann nicht bestimmt werden.",
+		"Invalid number of source arguments.":            "Ungültige Anzahl von Quellargumenten.",
+		"Unable to load config.":                         "Konfiguration kann nicht geladen werden.",
+		"Failed to start monitoring.":                    "Überwachung kann nicht gestartet werden.",
+		"Cannot get a configured admin connection.":      "Keine konfigurierte Admin-Verbindung verfügbar.",
+		"Failed to copy `{}`.":                           "`{}` kann nicht kopiert werden.",
+		"Failed to remove `{}`.":                         "`{}` kann nicht entfernt werden.",
+		"Failed to remove `{}` recursively.":             "`{}` kann nicht rekursiv entfernt werden.",
+		"Unable to stat `{}`.":                           "Status von `{}` kann nicht abgefragt werden.",
+		"Unable to stat source `{}`.":                    "Status der Quelle `{}` kann nicht abgefragt werden.",
+		"Unable to initialize target `{}`.":              "Ziel `{}` kann nicht initialisiert werden.",
+		"Target `{}` is not a folder.":                   "Ziel `{}` ist kein Ordner.",
+		"Target `{}` does not contain bucket name.":      "Ziel `{}` enthält keinen Bucket-Namen.",
+		"Unable to remove `{}`.":                         "`{}` kann nicht entfernt werden.",
+		"Unable to make bucket `{}`.":                    "Bucket `{}` kann nicht erstellt werden.",
+		"Unable to read from `{}`.":                      "Aus `{}` kann nicht gelesen werden.",
+		"Mirroring a folder into itself is not allowed.": "Ein Ordner kann nicht in sich selbst gespiegelt werden.",
+	},
+	language.Spanish: {
+		"Unable to marshal into JSON.":                   "No se puede codificar en JSON.",
+		"Unable to parse encryption keys.":               "No se pueden leer las claves de cifrado.",
+		"Unable to list folder.":                         "No se puede listar la carpeta.",
+		"Unable to validate empty argument.":             "No se admite un argumento vacío.",
+		"Unable to guess the type of copy operation.":    "No se puede determinar el tipo de copia.",
+		"Invalid number of source arguments.":            "Número de argumentos de origen no válido.",
+		"Unable to load config.":                         "No se puede cargar la configuración.",
+		"Failed to start monitoring.":                    "No se puede iniciar la supervisión.",
+		"Cannot get a configured admin connection.":      "No hay una conexión de administración configurada.",
+		"Failed to copy `{}`.":                           "No se puede copiar `{}`.",
+		"Failed to remove `{}`.":                         "No se puede eliminar `{}`.",
+		"Failed to remove `{}` recursively.":             "No se puede eliminar `{}` de forma recursiva.",
+		"Unable to stat `{}`.":                           "No se puede obtener el estado de `{}`.",
+		"Unable to stat source `{}`.":                    "No se puede obtener el estado del origen `{}`.",
+		"Unable to initialize target `{}`.":              "No se puede inicializar el destino `{}`.",
+		"Target `{}` is not a folder.":                   "El destino `{}` no es una carpeta.",
+		"Target `{}` does not contain bucket name.":      "El destino `{}` no contiene el nombre del bucket.",
+		"Unable to remove `{}`.":                         "No se puede eliminar `{}`.",
+		"Unable to make bucket `{}`.":                    "No se puede crear el bucket `{}`.",
+		"Unable to read from `{}`.":                      "No se puede leer de `{}`.",
+		"Mirroring a folder into itself is not allowed.": "No se permite replicar una carpeta en sí misma.",
+	},
+}
+
+// Translations used for console messages, nil for English.
+var globalMessages map[string]string
+
+// setLanguage - selects the language of console messages, from the
+// --lang flag if set, the locale set in the environment otherwise.
+func setLanguage(lang string) {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if lang != "" {
+			break
+		}
+		lang = os.Getenv(env)
+	}
+	globalMessages = nil
+	tag, ok := parseLanguage(lang)
+	if !ok {
+		return
+	}
+	supported := []language.Tag{language.English}
+	for t := range messageCatalog {
+		supported = append(supported, t)
+	}
+	if _, index, confidence := language.NewMatcher(supported).Match(tag); confidence >= language.High {
+		globalMessages = messageCatalog[supported[index]]
+	}
+}
+
+// parseLanguage - parses a language tag or a POSIX locale name such as
+// 'de_DE.UTF-8'.
+func parseLanguage(lang string) (language.Tag, bool) {
+	if i := strings.IndexAny(lang, ".@"); i >= 0 {
+		lang = lang[:i]
+	}
+	lang = strings.Replace(lang, "_", "-", -1)
+	if lang == "" || lang == "C" || lang == "POSIX" {
+		return language.Und, false
+	}
+	tag, e := language.Parse(lang)
+	if e != nil {
+		return language.Und, false
+	}
+	return tag, true
+}
+
+// translate - returns msg in the selected language, quoted values in msg
+// are kept as is.
+func translate(msg string) string {
+	if globalMessages == nil {
+		return msg
+	}
+	values := quotedValueRe.FindAllString(msg, -1)
+	translated, ok := globalMessages[quotedValueRe.ReplaceAllLiteralString(msg, messagePlaceholder)]
+	if !ok {
+		return msg
+	}
+	for _, value := range values {
+		translated = strings.Replace(translated, messagePlaceholder, value, 1)
+	}
+	return translated
+}
@@ -0,0 +1,44 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestTranslate(t *testing.T) {
+	defer setLanguage("en")
+
+	testCases := []struct {
+		lang     string
+		msg      string
+		expected string
+	}{
+		{"en", "Failed to copy `s3/a`.", "Failed to copy `s3/a`."},
+		{"de_DE.UTF-8", "Failed to copy `s3/a`.", "`s3/a` kann nicht kopiert werden."},
+		{"es", "Target `s3/b` is not a folder.", "El destino `s3/b` no es una carpeta."},
+		{"de", "Message without translation.", "Message without translation."},
+		{"fr", "Failed to copy `s3/a`.", "Failed to copy `s3/a`."},
+		{"C", "Failed to copy `s3/a`.", "Failed to copy `s3/a`."},
+	}
+	for i, testCase := range testCases {
+		setLanguage(testCase.lang)
+		if msg := translate(testCase.msg); msg != testCase.expected {
+			t.Errorf("Test %d: expected `%s`, got `%s`", i+1, testCase.expected, msg)
+		}
+	}
+}
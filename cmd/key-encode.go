@@ -0,0 +1,190 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// escapeChar is the prefix used for encoded characters, it is always
+// encoded itself when translation is enabled to keep it reversible.
+const escapeChar = '%'
+
+// GOOS specific list of characters not allowed in file names.
+var illegalFileChars = map[string]string{
+	"windows": `:*?"<>|`,
+	"default": "",
+}
+
+// keyEncoder translates object key characters which are not allowed
+// in local file names into '%XX' sequences and back.
+type keyEncoder struct {
+	chars string
+}
+
+// newKeyEncoder returns an encoder for the given set of characters,
+// 'auto' selects the characters illegal on the current platform.
+func newKeyEncoder(chars string) (keyEncoder, *probe.Error) {
+	if chars == "auto" {
+		var ok bool
+		if chars, ok = illegalFileChars[runtime.GOOS]; !ok {
+			chars = illegalFileChars["default"]
+		}
+	}
+	for _, r := range chars {
+		if r >= 0x80 || r < 0x20 {
+			return keyEncoder{}, probe.NewError(fmt.Errorf("Character %q cannot be encoded, only printable ASCII characters are supported", r))
+		}
+		if r == '/' || r == '\\' || r == escapeChar {
+			return keyEncoder{}, probe.NewError(errors.New("Path separators and `%` cannot be part of the encoded characters"))
+		}
+	}
+	return keyEncoder{chars: chars}, nil
+}
+
+// isEnabled returns true if any translation needs to be done.
+func (k keyEncoder) isEnabled() bool {
+	return k.chars != ""
+}
+
+// isEncoded returns true if 'c' is encoded by this encoder.
+func (k keyEncoder) isEncoded(c byte) bool {
+	return c == escapeChar || strings.IndexByte(k.chars, c) >= 0
+}
+
+// encode converts an object key into a name safe for the local filesystem.
+func (k keyEncoder) encode(key string) string {
+	if !k.isEnabled() {
+		return key
+	}
+	var b strings.Builder
+	for i := 0; i < len(key); i++ {
+		if k.isEncoded(key[i]) {
+			fmt.Fprintf(&b, "%c%02X", escapeChar, key[i])
+			continue
+		}
+		b.WriteByte(key[i])
+	}
+	return b.String()
+}
+
+// decode converts a local file name back into its original object key,
+// sequences which do not stand for an encoded character are left as is.
+func (k keyEncoder) decode(name string) string {
+	if !k.isEnabled() || strings.IndexByte(name, escapeChar) < 0 {
+		return name
+	}
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] == escapeChar && i+2 < len(name) {
+			if v, e := strconv.ParseUint(name[i+1:i+3], 16, 8); e == nil && k.isEncoded(byte(v)) {
+				b.WriteByte(byte(v))
+				i += 2
+				continue
+			}
+		}
+		b.WriteByte(name[i])
+	}
+	return b.String()
+}
+
+// translate converts a key suffix copied from a source of type 'srcType'
+// to a target of type 'tgtType', encoding only when writing to the local
+// filesystem and decoding only when reading from it.
+func (k keyEncoder) translate(suffix string, srcType, tgtType clientURLType) string {
+	switch {
+	case srcType == tgtType:
+		return suffix
+	case tgtType == fileSystem:
+		return k.encode(suffix)
+	case srcType == fileSystem:
+		return k.decode(suffix)
+	}
+	return suffix
+}
+
+// keyOf returns the object key represented by a listed suffix, names
+// listed from the local filesystem are decoded.
+func (k keyEncoder) keyOf(suffix string, urlType clientURLType) string {
+	if urlType == fileSystem {
+		return k.decode(suffix)
+	}
+	return suffix
+}
+
+// sortByKey returns the contents of contentCh listed under urlPrefix in
+// the order of the object keys they represent. Decoding changes the order
+// of local names, so the whole listing is read before it is sent.
+func (k keyEncoder) sortByKey(contentCh <-chan *clientContent, urlPrefix string) <-chan *clientContent {
+	sortedCh := make(chan *clientContent, listBufferSize)
+	go func() {
+		defer close(sortedCh)
+		type keyedContent struct {
+			key     string
+			content *clientContent
+		}
+		var contents []keyedContent
+		for content := range contentCh {
+			if content.Err != nil {
+				sortedCh <- content
+				return
+			}
+			key := k.keyOf(strings.TrimPrefix(content.URL.String(), urlPrefix), content.URL.Type)
+			contents = append(contents, keyedContent{key, content})
+		}
+		sort.SliceStable(contents, func(i, j int) bool {
+			return contents[i].key < contents[j].key
+		})
+		for _, c := range contents {
+			sortedCh <- c.content
+		}
+	}()
+	return sortedCh
+}
+
+// printableKey escapes control characters in an object key before it is
+// printed, so that keys with newlines or terminal escape sequences can
+// neither break the output nor drive the terminal.
+func printableKey(key string) string {
+	if strings.IndexFunc(key, unicode.IsControl) < 0 {
+		return key
+	}
+	var b strings.Builder
+	for _, r := range key {
+		switch {
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case unicode.IsControl(r):
+			fmt.Fprintf(&b, `\x%02x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
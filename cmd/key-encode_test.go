@@ -0,0 +1,98 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestKeyEncoder(t *testing.T) {
+	testCases := []struct {
+		chars   string
+		key     string
+		encoded string
+	}{
+		{"", "a:b", "a:b"},
+		{":", "a:b", "a%3Ab"},
+		{":", "100%", "100%25"},
+		{`:*?"<>|`, "logs/2019-01-01T10:00:00|x?.log", "logs/2019-01-01T10%3A00%3A00%7Cx%3F.log"},
+		{":", "a%3Ab", "a%253Ab"},
+	}
+	for i, testCase := range testCases {
+		keyEnc, err := newKeyEncoder(testCase.chars)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %s", i+1, err)
+		}
+		if encoded := keyEnc.encode(testCase.key); encoded != testCase.encoded {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.encoded, encoded)
+		}
+		if decoded := keyEnc.decode(testCase.encoded); decoded != testCase.key {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.key, decoded)
+		}
+	}
+}
+
+func TestKeyEncoderInvalid(t *testing.T) {
+	for i, chars := range []string{"/", `\`, "%", "\t", "é"} {
+		if _, err := newKeyEncoder(chars); err == nil {
+			t.Fatalf("Test %d: expected error for %q", i+1, chars)
+		}
+	}
+}
+
+func TestKeyEncoderSortByKey(t *testing.T) {
+	keyEnc, err := newKeyEncoder(`:*?"<>|`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Local names in listing order, before decoding.
+	names := []string{"a#", "a%22b", "a%3Ab", "a9", "a;"}
+	contentCh := make(chan *clientContent, len(names))
+	for _, name := range names {
+		contentCh <- &clientContent{URL: *newClientURL("/data/" + name)}
+	}
+	close(contentCh)
+
+	var keys []string
+	for content := range keyEnc.sortByKey(contentCh, "/data/") {
+		keys = append(keys, keyEnc.keyOf(strings.TrimPrefix(content.URL.String(), "/data/"), content.URL.Type))
+	}
+	expected := []string{`a"b`, "a#", "a9", "a:b", "a;"}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("Expected %v, got %v", expected, keys)
+	}
+}
+
+func TestPrintableKey(t *testing.T) {
+	testCases := []struct {
+		key       string
+		printable string
+	}{
+		{"photos/2019/a.jpg", "photos/2019/a.jpg"},
+		{"line\nbreak", `line\nbreak`},
+		{"a\r\tb", `a\r\tb`},
+		{"\x1b[31mred", `\x1b[31mred`},
+		{"日本語", "日本語"},
+	}
+	for i, testCase := range testCases {
+		if printable := printableKey(testCase.key); printable != testCase.printable {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.printable, printable)
+		}
+	}
+}
@@ -33,7 +33,27 @@ var (
 		},
 		cli.BoolFlag{
 			Name:  "incomplete, I",
-			Usage: "list incomplete uploads",
+			Usage: "list incomplete uploads with their upload ids and the number of parts uploaded so far",
+		},
+		cli.StringFlag{
+			Name:  "sort",
+			Usage: "sort listing by 'name', 'size' or 'time', entries are printed once listing completes",
+		},
+		cli.BoolFlag{
+			Name:  "reverse",
+			Usage: "reverse the order of the listing",
+		},
+		cli.StringFlag{
+			Name:  "prefix",
+			Usage: "list only entries whose name starts with the given prefix",
+		},
+		cli.StringFlag{
+			Name:  "match",
+			Usage: "list only entries whose name matches the wildcard pattern",
+		},
+		cli.StringFlag{
+			Name:  "at",
+			Usage: "list a versioned bucket as it was at a date or an RFC3339 time, e.g. '2024-03-01T00:00:00Z'",
 		},
 	}
 )
@@ -73,6 +93,15 @@ EXAMPLES:
    6. List incomplete (previously failed) uploads of objects on Amazon S3.
       $ {{.HelpName}} --incomplete s3/mybucket
 
+   7. List contents of mybucket on Amazon S3 cloud storage, largest objects first.
+      $ {{.HelpName}} --sort size --reverse s3/mybucket/
+
+   8. List all JPEG images recursively under 'photos/2019' in mybucket, most recent first.
+      $ {{.HelpName}} --recursive --prefix photos/2019 --match "*.jpg" --sort time --reverse s3/mybucket/
+
+   9. List versioned bucket mybucket recursively as it was on March 1st 2024 at midnight UTC.
+      $ {{.HelpName}} --recursive --at 2024-03-01T00:00:00Z s3/mybucket/
+
 `,
 }
 
@@ -87,6 +116,20 @@ func checkListSyntax(ctx *cli.Context) {
 			fatalIf(errInvalidArgument().Trace(args...), "Unable to validate empty argument.")
 		}
 	}
+	switch ctx.String("sort") {
+	case "", lsSortName, lsSortSize, lsSortTime:
+	default:
+		fatalIf(errInvalidArgument().Trace(ctx.String("sort")), "Unable to validate sort option, must be one of `name`, `size` or `time`.")
+	}
+	if ctx.String("at") != "" {
+		_, err := parsePointInTime(ctx.String("at"))
+		fatalIf(err, "Unable to parse --at, use a date or an RFC3339 time.")
+		if ctx.Bool("incomplete") {
+			fatalIf(errInvalidArgument().Trace(ctx.String("at")), "Incomplete uploads have no versions, --at cannot be used with --incomplete.")
+		}
+		// Objects removed since are found by the listing itself.
+		return
+	}
 	// extract URLs.
 	URLs := ctx.Args()
 	isIncomplete := ctx.Bool("incomplete")
@@ -111,6 +154,8 @@ func mainList(ctx *cli.Context) error {
 	console.SetColor("Dir", color.New(color.FgCyan, color.Bold))
 	console.SetColor("Size", color.New(color.FgYellow))
 	console.SetColor("Time", color.New(color.FgGreen))
+	console.SetColor("Parts", color.New(color.FgMagenta))
+	console.SetColor("UploadID", color.New(color.FgWhite))
 
 	// check 'ls' cli arguments.
 	checkListSyntax(ctx)
@@ -118,6 +163,15 @@ func mainList(ctx *cli.Context) error {
 	// Set command flags from context.
 	isRecursive := ctx.Bool("recursive")
 	isIncomplete := ctx.Bool("incomplete")
+	opts := lsOptions{
+		sortBy:  ctx.String("sort"),
+		reverse: ctx.Bool("reverse"),
+		prefix:  ctx.String("prefix"),
+		pattern: ctx.String("match"),
+	}
+
+	at, err := parsePointInTime(ctx.String("at"))
+	fatalIf(err, "Unable to parse --at, use a date or an RFC3339 time.")
 
 	args := ctx.Args()
 	// mimic operating system tool behavior.
@@ -127,7 +181,7 @@ func mainList(ctx *cli.Context) error {
 
 	var cErr error
 	for _, targetURL := range args {
-		clnt, err := newClient(targetURL)
+		clnt, err := newPointInTimeClient(targetURL, at)
 		fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
 
 		if !strings.HasSuffix(targetURL, string(clnt.GetURL().Separator)) {
@@ -135,12 +189,12 @@ func mainList(ctx *cli.Context) error {
 			st, err = clnt.Stat(isIncomplete, false, nil)
 			if err == nil && st.Type.IsDir() {
 				targetURL = targetURL + string(clnt.GetURL().Separator)
-				clnt, err = newClient(targetURL)
+				clnt, err = newPointInTimeClient(targetURL, at)
 				fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
 			}
 		}
 
-		if e := doList(clnt, isRecursive, isIncomplete); e != nil {
+		if e := doList(clnt, isRecursive, isIncomplete, opts); e != nil {
 			cErr = e
 		}
 	}
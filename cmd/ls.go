@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
@@ -34,6 +35,55 @@ const (
 	printDate = "2006-01-02 15:04:05 MST"
 )
 
+// Supported sort orders for ls.
+const (
+	lsSortName = "name"
+	lsSortSize = "size"
+	lsSortTime = "time"
+)
+
+// lsOptions - filtering and sorting options for listing.
+type lsOptions struct {
+	sortBy  string
+	reverse bool
+	prefix  string
+	pattern string
+}
+
+// isBuffered returns true if the listing has to be collected
+// before printing, which is needed to sort or reverse it.
+func (o lsOptions) isBuffered() bool {
+	return o.sortBy != "" || o.reverse
+}
+
+// matches returns true if the key passes the prefix and pattern filters.
+func (o lsOptions) matches(key string) bool {
+	if o.prefix != "" && !strings.HasPrefix(key, o.prefix) {
+		return false
+	}
+	if o.pattern != "" && !pathMatch(o.pattern, key) {
+		return false
+	}
+	return true
+}
+
+// sortContents - sort parsed contents in place as requested by the options.
+func sortContents(contents []contentMessage, opts lsOptions) {
+	switch opts.sortBy {
+	case lsSortSize:
+		sort.SliceStable(contents, func(i, j int) bool { return contents[i].Size < contents[j].Size })
+	case lsSortTime:
+		sort.SliceStable(contents, func(i, j int) bool { return contents[i].Time.Before(contents[j].Time) })
+	case lsSortName:
+		sort.SliceStable(contents, func(i, j int) bool { return contents[i].Key < contents[j].Key })
+	}
+	if opts.reverse {
+		for i, j := 0, len(contents)-1; i < j; i, j = i+1, j-1 {
+			contents[i], contents[j] = contents[j], contents[i]
+		}
+	}
+}
+
 // contentMessage container for content message structure.
 type contentMessage struct {
 	Status   string    `json:"status"`
@@ -42,19 +92,33 @@ type contentMessage struct {
 	Size     int64     `json:"size"`
 	Key      string    `json:"key"`
 	ETag     string    `json:"etag"`
+	UploadID string    `json:"uploadId,omitempty"`
+	Parts    int       `json:"parts,omitempty"`
 }
 
 // String colorized string message.
 func (c contentMessage) String() string {
-	message := console.Colorize("Time", fmt.Sprintf("[%s] ", c.Time.Format(printDate)))
-	message = message + console.Colorize("Size", fmt.Sprintf("%7s ", strings.Join(strings.Fields(humanize.IBytes(uint64(c.Size))), "")))
-	message = func() string {
-		if c.Filetype == "folder" {
-			return message + console.Colorize("Dir", c.Key)
-		}
-		return message + console.Colorize("File", c.Key)
-	}()
-	return message
+	keyTheme := "File"
+	if c.Filetype == "folder" {
+		keyTheme = "Dir"
+	}
+	// Keys are never cut, sizes are right aligned.
+	size := strings.Join(strings.Fields(humanize.IBytes(uint64(c.Size))), "")
+	if c.UploadID != "" {
+		return console.NewTableRenderer(" ",
+			console.Column{Theme: "Time"},
+			console.Column{Theme: "Size"},
+			console.Column{Theme: "Parts"},
+			console.Column{Theme: keyTheme},
+			console.Column{Theme: "UploadID"},
+		).Row("["+c.Time.Format(printDate)+"]", console.Pad(size, 7, true),
+			console.Pad(fmt.Sprintf("%d part(s)", c.Parts), 12, true), printableKey(c.Key), c.UploadID)
+	}
+	return console.NewTableRenderer(" ",
+		console.Column{Theme: "Time"},
+		console.Column{Theme: "Size"},
+		console.Column{Theme: keyTheme},
+	).Row("["+c.Time.Format(printDate)+"]", console.Pad(size, 7, true), printableKey(c.Key))
 }
 
 // JSON jsonified content message.
@@ -80,6 +144,7 @@ func parseContent(c *clientContent) contentMessage {
 	}()
 
 	content.Size = c.Size
+	content.UploadID = c.UploadID
 	md5sum := strings.TrimPrefix(c.ETag, "\"")
 	md5sum = strings.TrimSuffix(md5sum, "\"")
 	content.ETag = md5sum
@@ -105,13 +170,14 @@ func getKey(c *clientContent) string {
 }
 
 // doList - list all entities inside a folder.
-func doList(clnt Client, isRecursive, isIncomplete bool) error {
+func doList(clnt Client, isRecursive, isIncomplete bool, opts lsOptions) error {
 	prefixPath := clnt.GetURL().Path
 	separator := string(clnt.GetURL().Separator)
 	if !strings.HasSuffix(prefixPath, separator) {
 		prefixPath = prefixPath[:strings.LastIndex(prefixPath, separator)+1]
 	}
 	var cErr error
+	var contents []contentMessage
 	for content := range clnt.List(isRecursive, isIncomplete, DirNone) {
 		if content.Err != nil {
 			switch content.Err.ToGoError().(type) {
@@ -136,6 +202,15 @@ func doList(clnt Client, isRecursive, isIncomplete bool) error {
 			cErr = exitStatus(globalErrorExitStatus) // Set the exit status.
 			continue
 		}
+		// Parts of incomplete uploads are listed on their own.
+		parts := 0
+		if s3Clnt, ok := clnt.(*s3Client); ok && content.UploadID != "" {
+			var err *probe.Error
+			if parts, err = s3Clnt.countUploadedParts(content); err != nil {
+				errorIf(err, "Unable to list the parts uploaded of `%s`.", content.URL.Path)
+			}
+		}
+
 		// Convert any os specific delimiters to "/".
 		contentURL := filepath.ToSlash(content.URL.Path)
 		prefixPath = filepath.ToSlash(prefixPath)
@@ -145,9 +220,21 @@ func doList(clnt Client, isRecursive, isIncomplete bool) error {
 		// Trim prefix path from the content path.
 		contentURL = strings.TrimPrefix(contentURL, prefixPath)
 		content.URL.Path = contentURL
+		if !opts.matches(contentURL) {
+			continue
+		}
 		parsedContent := parseContent(content)
+		parsedContent.Parts = parts
+		if opts.isBuffered() {
+			contents = append(contents, parsedContent)
+			continue
+		}
 		// Print colorized or jsonized content info.
 		printMsg(parsedContent)
 	}
+	sortContents(contents, opts)
+	for _, parsedContent := range contents {
+		printMsg(parsedContent)
+	}
 	return cErr
 }
@@ -15,3 +15,60 @@
  */
 
 package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSortContents(t *testing.T) {
+	now := time.Now()
+	contents := func() []contentMessage {
+		return []contentMessage{
+			{Key: "b", Size: 10, Time: now},
+			{Key: "c", Size: 1, Time: now.Add(-time.Hour)},
+			{Key: "a", Size: 5, Time: now.Add(time.Hour)},
+		}
+	}
+	testCases := []struct {
+		opts lsOptions
+		keys string
+	}{
+		{lsOptions{sortBy: lsSortName}, "abc"},
+		{lsOptions{sortBy: lsSortSize}, "cab"},
+		{lsOptions{sortBy: lsSortTime}, "cba"},
+		{lsOptions{sortBy: lsSortSize, reverse: true}, "bac"},
+		{lsOptions{reverse: true}, "acb"},
+	}
+	for i, testCase := range testCases {
+		c := contents()
+		sortContents(c, testCase.opts)
+		var keys string
+		for _, content := range c {
+			keys += content.Key
+		}
+		if keys != testCase.keys {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.keys, keys)
+		}
+	}
+}
+
+func TestListOptionsMatches(t *testing.T) {
+	testCases := []struct {
+		opts  lsOptions
+		key   string
+		match bool
+	}{
+		{lsOptions{}, "photos/a.jpg", true},
+		{lsOptions{prefix: "photos/"}, "photos/a.jpg", true},
+		{lsOptions{prefix: "videos/"}, "photos/a.jpg", false},
+		{lsOptions{pattern: "*.jpg"}, "photos/a.jpg", true},
+		{lsOptions{pattern: "*.png"}, "photos/a.jpg", false},
+		{lsOptions{prefix: "photos/", pattern: "*.png"}, "photos/a.jpg", false},
+	}
+	for i, testCase := range testCases {
+		if testCase.opts.matches(testCase.key) != testCase.match {
+			t.Fatalf("Test %d: expected %t for %s", i+1, testCase.match, testCase.key)
+		}
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"github.com/cheggaaa/pb"
+	isatty "github.com/mattn/go-isatty"
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/console"
 	"github.com/minio/mc/pkg/probe"
@@ -92,15 +93,31 @@ func Main(args []string) {
 	if w, e := pb.GetTerminalWidth(); e != nil {
 		globalQuiet = true
 	} else {
-		globalTermWidth = w
+		setTermWidth(w)
+		watchTermWidth()
+	}
+
+	// Enable ANSI escape sequences on Windows consoles, fall back to
+	// plain output without colors and progress bar where unsupported.
+	if !console.EnableVirtualTerminal() {
+		globalQuiet = true
+		globalNoColor = true
+	}
+
+	// CI logs are not terminals, behave as with --ci there.
+	if !isatty.IsTerminal(os.Stdout.Fd()) {
+		globalCI = true
 	}
 
 	// Set the mc app name.
 	appName := filepath.Base(args[0])
 
 	// Run the app - exit on error.
-	if err := registerApp(appName).Run(args); err != nil {
-		os.Exit(1)
+	err := registerApp(appName).Run(expandShortcut(args))
+	saveTransferStats()
+	stopProfilers()
+	if err != nil {
+		os.Exit(exitStatusOf(globalErrorExitStatus))
 	}
 }
 
@@ -226,8 +243,9 @@ func registerBefore(ctx *cli.Context) error {
 	// Check if mc was compiled using a supported version of Golang.
 	checkGoVersion()
 
-	// Set the config directory.
-	setMcConfigDir(ctx.GlobalString("config-dir"))
+	// Set the config directory, of the profile in use if any.
+	err := setMcProfile(ctx.GlobalString("config-dir"), ctx.GlobalString("profile"))
+	fatalIf(err.Trace(), "Unable to select profile.")
 
 	// Migrate any old version of config / state files to newer format.
 	migrate()
@@ -291,21 +309,37 @@ var appCmds = []cli.Command{
 	headCmd,
 	pipeCmd,
 	shareCmd,
+	signCmd,
 	cpCmd,
 	mirrorCmd,
+	backupCmd,
 	findCmd,
 	sqlCmd,
 	statCmd,
+	duCmd,
+	sumCmd,
 	diffCmd,
+	scrubCmd,
 	rmCmd,
+	trashCmd,
+	transitionCmd,
+	holdCmd,
+	cleanupUploadsCmd,
 	eventCmd,
 	watchCmd,
 	policyCmd,
+	encryptCmd,
+	bucketCmd,
 	adminCmd,
 	sessionCmd,
+	cacheCmd,
+	statsCmd,
+	auditCmd,
 	configCmd,
+	profileCmd,
 	updateCmd,
 	versionCmd,
+	generateDocsCmd,
 }
 
 func registerApp(name string) *cli.App {
@@ -17,6 +17,8 @@
 package cmd
 
 import (
+	"strings"
+
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/mc/pkg/colorjson"
@@ -131,6 +133,14 @@ func mainMakeBucket(ctx *cli.Context) error {
 				errorIf(err.Trace(targetURL), "Unable to make bucket, please use `mc mb %s/<your-bucket-name>`.", targetURL)
 			case BucketNameTopLevel:
 				errorIf(err.Trace(targetURL), "Unable to make prefix, please use `mc mb %s/`.", targetURL)
+			case BucketInvalid:
+				bucketErr := err.ToGoError().(BucketInvalid)
+				if suggestion := suggestBucketName(bucketErr.Bucket); suggestion != "" {
+					errorIf(err.Trace(targetURL), "Unable to make bucket `%s`, try `%s` instead.", targetURL,
+						strings.Replace(targetURL, "/"+bucketErr.Bucket, "/"+suggestion, 1))
+				} else {
+					errorIf(err.Trace(targetURL), "Unable to make bucket `"+targetURL+"`.")
+				}
 			default:
 				errorIf(err.Trace(targetURL), "Unable to make bucket `"+targetURL+"`.")
 			}
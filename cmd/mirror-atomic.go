@@ -0,0 +1,234 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+const (
+	// Folder next to target holding the objects of an atomic mirror
+	// until they are published, under the name of target.
+	mirrorStagingDir = ".mc-atomic"
+
+	// Age past which objects staged by runs which did not finish are
+	// removed.
+	mirrorStagingExpiry = 24 * time.Hour
+)
+
+// stagedObject - an object uploaded under the staging prefix, with the
+// URL it is published at.
+type stagedObject struct {
+	stagingURL string
+	targetURL  string
+	size       int64
+}
+
+// mirrorAtomic - objects of an atomic mirror are uploaded under a
+// temporary prefix next to target, they are copied into target and
+// removals are done only once all of them succeeded.
+type mirrorAtomic struct {
+	alias     string
+	targetURL string
+	// staging prefixes of all runs mirroring to target, and of this one.
+	stagingDirURL string
+	stagingURL    string
+
+	mutex    sync.Mutex
+	staged   []stagedObject
+	removals []URLs
+
+	// Set when the mirror is stopped by a signal.
+	interrupted bool
+}
+
+// mirrorStagingDirURL - returns the URL of the folder next to the
+// expanded URL targetURL the objects mirrored to it are staged under,
+// false if target is not a folder of a bucket.
+func mirrorStagingDirURL(targetURL string) (string, bool) {
+	url := newClientURL(targetURL)
+	separator := string(url.Separator)
+	targetPath := strings.TrimSuffix(url.Path, separator)
+	i := strings.LastIndex(targetPath, separator)
+	if i < 0 || targetPath[i+1:] == "" || (url.Type == objectStorage && i == 0) {
+		return "", false
+	}
+	url.Path = targetPath[:i+1]
+	return urlJoinPath(url.String(), mirrorStagingDir+separator+targetPath[i+1:]) + separator, true
+}
+
+// newMirrorAtomic - stages the objects mirrored to the aliased URL
+// targetURL under a new prefix next to it, readers of target never see
+// them.
+func newMirrorAtomic(targetURL string) (*mirrorAtomic, *probe.Error) {
+	alias, expandedURL, _ := mustExpandAlias(targetURL)
+	stagingDirURL, ok := mirrorStagingDirURL(expandedURL)
+	if !ok {
+		return nil, errInvalidArgument().Trace(targetURL)
+	}
+	return &mirrorAtomic{
+		alias:         alias,
+		targetURL:     expandedURL,
+		stagingDirURL: stagingDirURL,
+		stagingURL:    urlJoinPath(stagingDirURL, newRandomID(8)) + string(newClientURL(expandedURL).Separator),
+	}, nil
+}
+
+// stage - returns sURLs uploading to the staging prefix instead of its
+// target. Objects are staged in the default storage class, archived
+// ones could not be copied when published.
+func (a *mirrorAtomic) stage(sURLs URLs) URLs {
+	suffix := strings.TrimPrefix(sURLs.TargetContent.URL.String(), a.targetURL)
+	target := *sURLs.TargetContent
+	target.URL = *newClientURL(urlJoinPath(a.stagingURL, suffix))
+	if _, ok := target.Metadata["X-Amz-Storage-Class"]; ok {
+		target.Metadata = make(map[string]string, len(sURLs.TargetContent.Metadata))
+		for k, v := range sURLs.TargetContent.Metadata {
+			if k != "X-Amz-Storage-Class" {
+				target.Metadata[k] = v
+			}
+		}
+	}
+	sURLs.TargetContent = &target
+	return sURLs
+}
+
+// add - records the object of sURLs uploaded to staged, published at
+// targetURL.
+func (a *mirrorAtomic) add(staged URLs, targetURL string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.staged = append(a.staged, stagedObject{
+		stagingURL: staged.TargetContent.URL.String(),
+		targetURL:  targetURL,
+		size:       staged.SourceContent.Size,
+	})
+}
+
+// remove - defers the removal of the target of sURLs until publishing.
+func (a *mirrorAtomic) remove(sURLs URLs) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.removals = append(a.removals, sURLs)
+}
+
+// removeStale - removes the objects staged for target by earlier runs
+// which did not finish, once they are older than mirrorStagingExpiry.
+func (a *mirrorAtomic) removeStale() *probe.Error {
+	clnt, err := newClientFromAlias(a.alias, a.stagingDirURL)
+	if err != nil {
+		return err.Trace(a.stagingDirURL)
+	}
+	var stale []*clientContent
+	for content := range clnt.List(true, false, DirNone) {
+		if content.Err != nil {
+			// Nothing was staged yet.
+			if _, ok := content.Err.ToGoError().(PathNotFound); ok {
+				return nil
+			}
+			return content.Err.Trace(a.stagingDirURL)
+		}
+		if content.Type.IsRegular() && UTCNow().Sub(content.Time) > mirrorStagingExpiry {
+			stale = append(stale, content)
+		}
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+	contentCh := make(chan *clientContent, len(stale))
+	for _, content := range stale {
+		contentCh <- content
+	}
+	close(contentCh)
+	for err = range clnt.Remove(false, false, contentCh) {
+		if err != nil {
+			return err.Trace(a.stagingDirURL)
+		}
+	}
+	return nil
+}
+
+// removeStaged - removes the staged objects of this run.
+func (a *mirrorAtomic) removeStaged() *probe.Error {
+	if len(a.staged) == 0 {
+		return nil
+	}
+	clnt, err := newClientFromAlias(a.alias, a.stagingURL)
+	if err != nil {
+		return err.Trace(a.stagingURL)
+	}
+	contentCh := make(chan *clientContent, len(a.staged))
+	for _, o := range a.staged {
+		contentCh <- &clientContent{URL: *newClientURL(o.stagingURL)}
+	}
+	close(contentCh)
+	for err = range clnt.Remove(false, false, contentCh) {
+		if err != nil {
+			return err.Trace(a.stagingURL)
+		}
+	}
+	return nil
+}
+
+// publish - copies the staged objects into target on the server side,
+// then does the removals. Staged objects are removed in any case.
+func (mj *mirrorJob) publish(ctx context.Context) (errDuringPublish bool) {
+	a := mj.atomic
+	for _, o := range a.staged {
+		urls := URLs{
+			SourceAlias:   a.alias,
+			SourceContent: &clientContent{URL: *newClientURL(o.stagingURL), Size: o.size},
+			TargetAlias:   a.alias,
+			TargetContent: &clientContent{URL: *newClientURL(o.targetURL)},
+		}
+		// Objects are published in the storage class asked for target.
+		if mj.storageClass != "" {
+			urls.TargetContent.Metadata = map[string]string{"X-Amz-Storage-Class": mj.storageClass}
+		}
+		if err := uploadSourceToTargetURL(ctx, urls, nil, uploadOptions{}, mj.encKeyDB).Error; err != nil {
+			errorIf(err.Trace(o.stagingURL, o.targetURL), "Failed to publish `"+o.targetURL+"`.")
+			errDuringPublish = true
+			break
+		}
+	}
+	if !errDuringPublish {
+		for _, sURLs := range a.removals {
+			if err := mj.doRemove(sURLs).Error; err != nil {
+				errorIf(err.Trace(sURLs.TargetContent.URL.String()),
+					"Failed to remove `"+sURLs.TargetContent.URL.String()+"`.")
+				errDuringPublish = true
+				continue
+			}
+			targetPath := filepath.ToSlash(filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path))
+			printMsg(rmMessage{Key: targetPath, Size: sURLs.TargetContent.Size})
+		}
+	}
+	errorIf(a.removeStaged(), "Unable to remove the staged objects under `"+a.stagingURL+"`.")
+	return errDuringPublish
+}
+
+// discard - removes the staged objects of a mirror which failed, target
+// is left as it was.
+func (mj *mirrorJob) discard() {
+	errorIf(mj.atomic.removeStaged(), "Unable to remove the staged objects under `"+mj.atomic.stagingURL+"`.")
+}
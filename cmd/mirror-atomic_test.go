@@ -0,0 +1,74 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMirrorAtomicStage(t *testing.T) {
+	a := &mirrorAtomic{
+		alias:      "s3",
+		targetURL:  "https://s3.amazonaws.com/mybucket/backup",
+		stagingURL: "https://s3.amazonaws.com/mybucket/.mc-atomic/backup/abcdefgh/",
+	}
+	metadata := map[string]string{"X-Amz-Storage-Class": "STANDARD_IA", "X-Amz-Acl": "private"}
+	sURLs := URLs{
+		SourceContent: &clientContent{URL: *newClientURL("/var/lib/backup/db/dump.sql")},
+		TargetContent: &clientContent{URL: *newClientURL("https://s3.amazonaws.com/mybucket/backup/db/dump.sql"), Metadata: metadata},
+	}
+
+	staged := a.stage(sURLs)
+	if url := staged.TargetContent.URL.String(); url != "https://s3.amazonaws.com/mybucket/.mc-atomic/backup/abcdefgh/db/dump.sql" {
+		t.Fatalf("Expected the object staged under the staging prefix, got %s", url)
+	}
+	// Staged objects are copied when published, they are kept out of archive classes.
+	if expected := map[string]string{"X-Amz-Acl": "private"}; !reflect.DeepEqual(staged.TargetContent.Metadata, expected) {
+		t.Fatalf("Expected staged metadata %v, got %v", expected, staged.TargetContent.Metadata)
+	}
+	if sURLs.TargetContent.Metadata["X-Amz-Storage-Class"] != "STANDARD_IA" {
+		t.Fatalf("Expected the storage class of the target to be kept, got %v", sURLs.TargetContent.Metadata)
+	}
+}
+
+func TestMirrorStagingDirURL(t *testing.T) {
+	testCases := []struct {
+		targetURL     string
+		stagingDirURL string
+		ok            bool
+	}{
+		// Staged next to target, out of its listings.
+		{"https://s3.amazonaws.com/mybucket/backup", "https://s3.amazonaws.com/mybucket/.mc-atomic/backup/", true},
+		{"https://s3.amazonaws.com/mybucket/backup/", "https://s3.amazonaws.com/mybucket/.mc-atomic/backup/", true},
+		{"https://s3.amazonaws.com/mybucket/site/reports", "https://s3.amazonaws.com/mybucket/site/.mc-atomic/reports/", true},
+		{"/mnt/backup/reports", "/mnt/backup/.mc-atomic/reports/", true},
+		// Nothing is next to the root of a bucket or of the filesystem.
+		{"https://s3.amazonaws.com/mybucket", "", false},
+		{"https://s3.amazonaws.com/mybucket/", "", false},
+		{"/", "", false},
+	}
+	for i, testCase := range testCases {
+		stagingDirURL, ok := mirrorStagingDirURL(testCase.targetURL)
+		if ok != testCase.ok {
+			t.Fatalf("Test %d: expected %t, got %t", i+1, testCase.ok, ok)
+		}
+		if stagingDirURL != testCase.stagingDirURL {
+			t.Fatalf("Test %d: expected staging folder %s, got %s", i+1, testCase.stagingDirURL, stagingDirURL)
+		}
+	}
+}
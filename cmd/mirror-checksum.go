@@ -0,0 +1,155 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/md5"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// checksumComparer - tells apart sources and targets of the same size
+// holding different data, for --compare-checksum. Objects are stated
+// for the checksums and part sizes needed to compare them with files.
+// Comparisons run on a worker per CPU, so that local files are hashed
+// while other objects are listed and transferred.
+type checksumComparer struct {
+	source, target *headPrefetcher
+
+	jobsCh chan func()
+	wg     sync.WaitGroup
+}
+
+// newChecksumComparer - returns the comparer of objects of sourceAlias
+// with those of targetAlias, it is stopped with close.
+func newChecksumComparer(sourceAlias, targetAlias string, encKeyDB map[string][]prefixSSEPair) *checksumComparer {
+	workers := runtime.NumCPU()
+	c := &checksumComparer{
+		source: newHeadPrefetcher(sourceAlias, encKeyDB[sourceAlias]),
+		target: newHeadPrefetcher(targetAlias, encKeyDB[targetAlias]),
+		jobsCh: make(chan func(), workers),
+	}
+	for i := 0; i < workers; i++ {
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			for job := range c.jobsCh {
+				job()
+			}
+		}()
+	}
+	return c
+}
+
+// compare - compares source with target on one of the workers, done is
+// called there with whether they differ. It blocks while all workers
+// are busy and the queue is full.
+func (c *checksumComparer) compare(source, target *clientContent, done func(differs bool)) {
+	c.jobsCh <- func() {
+		done(c.differs(source, target))
+	}
+}
+
+// close - waits for the comparisons queued, then stops the comparer.
+func (c *checksumComparer) close() {
+	close(c.jobsCh)
+	c.wg.Wait()
+	c.source.close()
+	c.target.close()
+}
+
+// differs - returns true if source and target are found to hold
+// different data. Those whose checksums cannot be compared, such as
+// objects uploaded in parts of unknown sizes, are not reported.
+func (c *checksumComparer) differs(source, target *clientContent) bool {
+	if !source.Type.IsRegular() || !target.Type.IsRegular() {
+		return false
+	}
+	switch {
+	case source.URL.Type == objectStorage && target.URL.Type == objectStorage:
+		return etagsDiffer(source.ETag, target.ETag) &&
+			!c.isEncrypted(source, c.source) && !c.isEncrypted(target, c.target)
+	case source.URL.Type == objectStorage:
+		return c.fileDiffers(target, source, c.source)
+	case target.URL.Type == objectStorage:
+		return c.fileDiffers(source, target, c.target)
+	}
+	return filesDiffer(source.URL.Path, target.URL.Path)
+}
+
+// fileDiffers - returns true if the local file does not hold the data
+// of object, stated with p.
+func (c *checksumComparer) fileDiffers(file, object *clientContent, p *headPrefetcher) bool {
+	content, attrs, err := p.head(object.URL.String())
+	if err != nil || content == nil {
+		return false
+	}
+	same, known := compareLocalSum(file, content, attrs)
+	return known && !same
+}
+
+// isEncrypted - returns true if object, stated with p, is encrypted on
+// the server side or cannot be stated, its ETag is then not known to be
+// its MD5. Listings do not tell.
+func (c *checksumComparer) isEncrypted(object *clientContent, p *headPrefetcher) bool {
+	if isServerEncrypted(object) {
+		return true
+	}
+	content, _, err := p.head(object.URL.String())
+	return err != nil || content == nil || isServerEncrypted(content)
+}
+
+// etagsDiffer - returns true if the ETags of two objects uploaded with
+// a single request differ, ETags of multipart uploads also depend on
+// the size of their parts.
+func etagsDiffer(first, second string) bool {
+	first, second = strings.Trim(first, "\""), strings.Trim(second, "\"")
+	if !md5ETagRe.MatchString(first) || !md5ETagRe.MatchString(second) {
+		return false
+	}
+	return !strings.EqualFold(first, second)
+}
+
+// filesDiffer - returns true if the MD5 of two local files differ.
+func filesDiffer(first, second string) bool {
+	firstSum, ok := fileMD5(first)
+	if !ok {
+		return false
+	}
+	secondSum, ok := fileMD5(second)
+	if !ok {
+		return false
+	}
+	return !bytes.Equal(firstSum, secondSum)
+}
+
+// fileMD5 - returns the MD5 of the file at path.
+func fileMD5(path string) ([]byte, bool) {
+	file, e := os.Open(path)
+	if e != nil {
+		return nil, false
+	}
+	defer file.Close()
+	st, e := file.Stat()
+	if e != nil {
+		return nil, false
+	}
+	return localSum(file, st.Size(), nil, md5.New)
+}
@@ -0,0 +1,115 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestETagsDiffer(t *testing.T) {
+	testCases := []struct {
+		first, second string
+		differs       bool
+	}{
+		{"5d41402abc4b2a76b9719d911017c592", "5d41402abc4b2a76b9719d911017c592", false},
+		{"\"5d41402abc4b2a76b9719d911017c592\"", "5D41402ABC4B2A76B9719D911017C592", false},
+		{"5d41402abc4b2a76b9719d911017c592", "7d793037a0760186574b0282f2f435e7", true},
+		// Multipart ETags depend on the size of parts.
+		{"5d41402abc4b2a76b9719d911017c592-2", "7d793037a0760186574b0282f2f435e7", false},
+		{"", "7d793037a0760186574b0282f2f435e7", false},
+	}
+	for i, testCase := range testCases {
+		if differs := etagsDiffer(testCase.first, testCase.second); differs != testCase.differs {
+			t.Errorf("Test %d: expected %t, got %t", i+1, testCase.differs, differs)
+		}
+	}
+}
+
+func TestFilesDiffer(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-checksum-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{"a": "hello", "b": "hello", "c": "world"}
+	for name, data := range files {
+		if e = ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); e != nil {
+			t.Fatal(e)
+		}
+	}
+	if filesDiffer(filepath.Join(dir, "a"), filepath.Join(dir, "b")) {
+		t.Fatal("Files of the same content are not expected to differ")
+	}
+	if !filesDiffer(filepath.Join(dir, "a"), filepath.Join(dir, "c")) {
+		t.Fatal("Files of different content are expected to differ")
+	}
+	if filesDiffer(filepath.Join(dir, "a"), filepath.Join(dir, "missing")) {
+		t.Fatal("Files which cannot be read are not expected to differ")
+	}
+}
+
+func TestChecksumComparerCompare(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-checksum-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	content := func(path string) *clientContent {
+		return &clientContent{URL: *newClientURL(path), Type: os.FileMode(0644)}
+	}
+	c := newChecksumComparer("", "", nil)
+	pairs := 2*runtime.NumCPU() + 3
+	var mutex sync.Mutex
+	differing := map[int]bool{}
+	for i := 0; i < pairs; i++ {
+		source, target := filepath.Join(dir, fmt.Sprintf("%d.src", i)), filepath.Join(dir, fmt.Sprintf("%d.tgt", i))
+		if e = ioutil.WriteFile(source, []byte("hello"), 0644); e != nil {
+			t.Fatal(e)
+		}
+		data := "hello"
+		if i%2 == 1 {
+			data = "world"
+		}
+		if e = ioutil.WriteFile(target, []byte(data), 0644); e != nil {
+			t.Fatal(e)
+		}
+		i := i
+		c.compare(content(source), content(target), func(differs bool) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			differing[i] = differs
+		})
+	}
+	// All comparisons queued are done once closed.
+	c.close()
+	if len(differing) != pairs {
+		t.Fatalf("Expected %d comparisons, got %d", pairs, len(differing))
+	}
+	for i := 0; i < pairs; i++ {
+		if differing[i] != (i%2 == 1) {
+			t.Errorf("Test %d: expected %t, got %t", i+1, i%2 == 1, differing[i])
+		}
+	}
+}
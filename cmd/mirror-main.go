@@ -19,6 +19,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
@@ -26,6 +27,7 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
@@ -46,10 +48,18 @@ var (
 			Name:  "overwrite",
 			Usage: "overwrite object(s) on target",
 		},
+		cli.StringFlag{
+			Name:  "overwrite-mode",
+			Usage: "overwrite object(s) on target by mode: 'never', 'always', 'if-newer' or 'if-different' like --overwrite",
+		},
 		cli.BoolFlag{
 			Name:  "fake",
 			Usage: "perform a fake mirror operation",
 		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "only print the object(s) to copy, and to remove with --remove, without transferring anything",
+		},
 		cli.BoolFlag{
 			Name:  "watch, w",
 			Usage: "watch and synchronize changes",
@@ -58,6 +68,7 @@ var (
 			Name:  "remove",
 			Usage: "remove extraneous object(s) on target",
 		},
+		iKnowWhatIAmDoingFlag,
 		cli.StringFlag{
 			Name:  "region",
 			Usage: "specify region when creating new bucket(s) on target",
@@ -71,6 +82,14 @@ var (
 			Name:  "exclude",
 			Usage: "exclude object(s) that match specified object name pattern",
 		},
+		cli.StringSliceFlag{
+			Name:  "include",
+			Usage: "only mirror object(s) that match specified object name pattern, exclude patterns win",
+		},
+		cli.StringFlag{
+			Name:  "priority-from",
+			Usage: "mirror the keys listed in this file, one per line, before all other object(s)",
+		},
 		cli.StringFlag{
 			Name:  "older-than",
 			Usage: "filter object(s) older than L days, M hours and N minutes",
@@ -79,14 +98,147 @@ var (
 			Name:  "newer-than",
 			Usage: "filter object(s) newer than L days, M hours and N minutes",
 		},
+		cli.StringFlag{
+			Name:  "at",
+			Usage: "mirror a versioned bucket as it was at a date or an RFC3339 time, e.g. '2024-03-01T00:00:00Z'",
+		},
 		cli.StringFlag{
 			Name:  "storage-class, sc",
-			Usage: "specify storage class for new object(s) on target",
+			Usage: "specify storage class for new object(s) on target, such as STANDARD_IA or REDUCED_REDUNDANCY",
+		},
+		cli.StringFlag{
+			Name:  "acl",
+			Usage: "apply a canned ACL to new object(s) on target, e.g. 'bucket-owner-full-control'",
 		},
 		cli.StringFlag{
 			Name:  "encrypt",
 			Usage: "encrypt/decrypt objects (using server-side encryption with server managed keys)",
 		},
+		cli.StringFlag{
+			Name:  "compress",
+			Usage: "compress uploads on the fly and set their Content-Encoding, only 'gzip' is supported",
+		},
+		cli.BoolFlag{
+			Name:  "no-decompress",
+			Usage: "do not decompress encoded objects written to the local filesystem",
+		},
+		cli.StringFlag{
+			Name:  "filter",
+			Usage: "pipe the body of each object through a command, its output is transferred instead",
+		},
+		cli.StringFlag{
+			Name:  "checksum",
+			Usage: "send a 'crc32', 'crc32c', 'sha1' or 'sha256' checksum with uploads smaller than 64MiB for the server to verify",
+		},
+		cli.StringFlag{
+			Name:  "limit-upload",
+			Usage: "limit the bandwidth of uploads to object storage, e.g. '10MiB/s', or by target alias, e.g. 'dr=5MiB/s,100MiB/s'",
+		},
+		cli.StringFlag{
+			Name:  "limit-download",
+			Usage: "limit the bandwidth of downloads from object storage, e.g. '10MiB/s'",
+		},
+		cli.StringFlag{
+			Name:  "limit-schedule",
+			Usage: "limit the bandwidth of transfers from or to object storage by local time of day, e.g. '08:00-18:00=5MiB,18:00-08:00=0', 0 is unlimited",
+		},
+		cli.StringFlag{
+			Name:  "max-duration",
+			Usage: "stop starting new transfers after this long, e.g. '4h', the next run continues from there",
+		},
+		cli.StringFlag{
+			Name:  "max-bytes",
+			Usage: "stop before transferring more than this many bytes, e.g. '500GiB', the next run continues from there",
+		},
+		cli.BoolFlag{
+			Name:  "snapshot",
+			Usage: "write each run under a new dated prefix, copying unchanged object(s) from the previous one on target",
+		},
+		cli.IntFlag{
+			Name:  "list-workers",
+			Value: listPrefixWorkers,
+			Usage: "number of folders listed in parallel on object storage",
+		},
+		cli.IntFlag{
+			Name:  "transfer-workers, parallel",
+			Usage: "number of objects mirrored in parallel, up to 1024, scaled with the transfer speed or set with 'mc config parallel' by default",
+		},
+		cli.StringFlag{
+			Name:  "encode-chars",
+			Usage: "reversibly encode characters not allowed in local file names, use 'auto' for platform defaults",
+		},
+		cli.StringFlag{
+			Name:  "inventory",
+			Usage: "list the source or target bucket from the manifest of an S3 Inventory report (CSV only)",
+		},
+		cli.StringFlag{
+			Name:  "folder-markers",
+			Value: folderMarkersVerbatim,
+			Usage: "handle zero-byte folder marker objects: 'ignore', 'directory' or 'verbatim'",
+		},
+		cli.BoolFlag{
+			Name:  "compare-checksum",
+			Usage: "also replace object(s) on target of the same size as their source but a different MD5, ETag or checksum",
+		},
+		cli.IntFlag{
+			Name:  "retry",
+			Usage: "try each object failing with a network, throttling or server error again up to N times, waiting longer each time",
+		},
+		cli.BoolFlag{
+			Name:  "strict",
+			Usage: "exit with an error if any source is skipped, such as vanished or unreadable files and special files",
+		},
+		cli.BoolFlag{
+			Name:  "preflight",
+			Usage: "scan local sources for unreadable files and folders first, and stop before mirroring if any are found",
+		},
+		cli.BoolFlag{
+			Name:  "preserve",
+			Usage: "store the modification time and mode of local files with their object(s), and restore them on local files",
+		},
+		cli.BoolFlag{
+			Name:  "no-list-target",
+			Usage: "check objects on target one by one instead of listing it, comparing checksums where the target has them",
+		},
+		cli.BoolFlag{
+			Name:  "merge",
+			Usage: "mirror several sources into one target, the last argument is the target",
+		},
+		cli.StringFlag{
+			Name:  "on-collision",
+			Value: collisionError,
+			Usage: "with --merge, object(s) found in several sources: mirror the 'first' one, the 'newest' one or 'error'",
+		},
+		cli.BoolFlag{
+			Name:  "atomic",
+			Usage: "upload object(s) under a temporary prefix next to target, copying them into target only once all of them succeeded",
+		},
+		cli.StringFlag{
+			Name:  "watch-interval",
+			Value: defaultWatchInterval.String(),
+			Usage: "with --watch, list object storage sources for changes at this interval instead of listening for notifications, done anyway where the server sends none",
+		},
+		cli.StringFlag{
+			Name:  "queue-dir",
+			Usage: "with --watch, keep detected changes in this directory until they are mirrored, retrying failures and resuming after restarts",
+		},
+		cli.BoolFlag{
+			Name:  "manifest",
+			Usage: "write a manifest of the object(s) of target with their checksums once the mirror succeeded",
+		},
+		cli.BoolFlag{
+			Name:  "verify-manifest",
+			Usage: "validate the object(s) of TARGET against its last manifest instead of mirroring",
+		},
+		cli.StringFlag{
+			Name:  "special-files",
+			Value: specialFilesSkip,
+			Usage: "fifos, sockets and devices found in a local source: 'skip' them or report an 'error'",
+		},
+		cli.StringFlag{
+			Name:  "report",
+			Usage: "with --remove, write the object(s) removed on target with their version ids to a JSON file",
+		},
 	}
 )
 
@@ -96,12 +248,14 @@ var mirrorCmd = cli.Command{
 	Usage:  "synchronize object(s) to a remote site",
 	Action: mainMirror,
 	Before: setGlobalsFromContext,
-	Flags:  append(append(mirrorFlags, ioFlags...), globalFlags...),
+	Flags:  append(append(append(append(mirrorFlags, removalConfirmFlags...), transferHookFlags...), ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
   {{.HelpName}} [FLAGS] SOURCE TARGET
+  {{.HelpName}} [FLAGS] --merge SOURCE1 SOURCE2 [SOURCE...] TARGET
+  {{.HelpName}} [FLAGS] --verify-manifest TARGET
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -110,6 +264,13 @@ ENVIRONMENT VARIABLES:
    MC_ENCRYPT:      list of comma delimited prefixes
    MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
 
+HOOKS:
+   Commands given to --pre-exec and --post-exec are run by the shell with
+   MC_HOOK ("pre" or "post"), MC_SCOPE ("object" or "job"), MC_SOURCE,
+   MC_TARGET and MC_SIZE set. Post hooks also get MC_STATUS ("success" or
+   "error") and, for objects, MC_ERROR. Objects whose pre hook fails are
+   skipped, a job whose pre hook fails does not start.
+
 EXAMPLES:
    1. Mirror a bucket recursively from MinIO cloud storage to a bucket on Amazon S3 cloud storage.
       $ {{.HelpName}} play/photos/2014 s3/backup-photos
@@ -123,7 +284,8 @@ EXAMPLES:
    4. Mirror a bucket from aliased Amazon S3 cloud storage to a folder on Windows.
       $ {{.HelpName}} s3\documents\2014\ C:\backup\2014
 
-   5. Mirror a bucket from aliased Amazon S3 cloud storage to a local folder use '--overwrite' to overwrite destination.
+   5. Mirror a bucket from aliased Amazon S3 cloud storage to a local folder use '--overwrite' to overwrite
+      destination files differing in size or older than their source.
       $ {{.HelpName}} --overwrite s3/miniocloud miniocloud-backup
 
    6. Mirror a bucket from MinIO cloud storage to a bucket on Amazon S3 cloud storage and remove any extraneous
@@ -177,11 +339,74 @@ type mirrorJob struct {
 	sourceURL string
 	targetURL string
 
-	isFake, isRemove, isOverwrite, isWatch bool
-	olderThan, newerThan                   string
-	storageClass                           string
+	isFake, isRemove, isWatch bool
+	overwrite                 string
+	olderThan, newerThan      string
+	storageClass              string
+	acl                       string
+
+	// fake mirror printing each object, see --dry-run.
+	isDryRun bool
+
+	// previous snapshot to copy unchanged objects from.
+	snapshotURL string
+
+	// inventory report listing the source or target bucket.
+	inventory *inventoryManifest
+
+	// point in time the source is read as of, zero for its latest state.
+	at time.Time
+
+	// sources merged into target, nil with a single source.
+	merge *mirrorMerge
+
+	// keys mirrored before all others, nil unless listed.
+	priority *mirrorPriority
+
+	// staging of the objects until they are published, nil unless atomic.
+	atomic *mirrorAtomic
+
+	// removals held back until confirmed, nil unless one may be asked.
+	removals *mirrorRemovals
+
+	// events of the watched source kept on disk, nil unless queued.
+	queue *watchQueue
+
+	// transfers by top-level prefix of target.
+	summary *prefixStats
+
+	// special files found on source.
+	specials *specialFiles
+
+	// object storage sources are listed at every interval when polling,
+	// or once they are found to send no notifications.
+	watchInterval time.Duration
+	isPolling     bool
+
+	// object(s) removed on target, nil unless reported.
+	report *deletionReport
+
+	// stops the mirror from starting new transfers.
+	limits *runLimits
+
+	// objects of the same size are compared by checksum.
+	compareChecksum bool
+
+	// folders of object storage listed in parallel, 0 for the default.
+	listWorkers int
+
+	// failed transfers are tried again up to this many times.
+	retries int
+
+	// sources skipped fail the mirror.
+	strict bool
 
 	excludeOptions []string
+	includeOptions []string
+	folderMarkers  string
+	noListTarget   bool
+	keyEnc         keyEncoder
+	uploadOpts     uploadOptions
 	encKeyDB       map[string][]prefixSSEPair
 }
 
@@ -197,7 +422,7 @@ type mirrorMessage struct {
 
 // String colorized mirror message
 func (m mirrorMessage) String() string {
-	return console.Colorize("Mirror", fmt.Sprintf("`%s` -> `%s`", m.Source, m.Target))
+	return console.Colorize("Mirror", fmt.Sprintf("`%s` -> `%s`", printableKey(m.Source), printableKey(m.Target)))
 }
 
 // JSON jsonified mirror message
@@ -211,34 +436,61 @@ func (m mirrorMessage) JSON() string {
 
 // doRemove - removes files on target.
 func (mj *mirrorJob) doRemove(sURLs URLs) URLs {
+	// Construct proper path with alias.
+	targetWithAlias := filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path)
+	entry := deletionEntry{
+		Key:          targetWithAlias,
+		Size:         sURLs.TargetContent.Size,
+		LastModified: sURLs.TargetContent.Time,
+	}
 	if mj.isFake {
-		return sURLs.WithError(nil)
+		return sURLs.WithError(mj.reportRemoval(entry))
 	}
 
-	// Construct proper path with alias.
-	targetWithAlias := filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path)
 	clnt, pErr := newClient(targetWithAlias)
 	if pErr != nil {
 		return sURLs.WithError(pErr)
 	}
+	if mj.report != nil {
+		// Listings of target have no version ids.
+		if content, err := clnt.Stat(false, false, nil); err == nil {
+			entry.VersionID = contentVersionID(content)
+		}
+	}
 
 	contentCh := make(chan *clientContent, 1)
 	contentCh <- &clientContent{URL: *newClientURL(sURLs.TargetContent.URL.Path)}
 	close(contentCh)
 	isRemoveBucket := false
 	errorCh := clnt.Remove(false, isRemoveBucket, contentCh)
+	isRemoved := true
 	for pErr := range errorCh {
 		if pErr != nil {
 			switch pErr.ToGoError().(type) {
 			case PathInsufficientPermission:
-				// Ignore Permission error.
+				// Ignore Permission error, the object is not reported.
+				isRemoved = false
 				continue
 			}
 			return sURLs.WithError(pErr)
 		}
 	}
+	if !isRemoved {
+		return sURLs.WithError(nil)
+	}
+
+	return sURLs.WithError(mj.reportRemoval(entry))
+}
 
-	return sURLs.WithError(nil)
+// reportRemoval - adds an object removed on target to the report, if any.
+func (mj *mirrorJob) reportRemoval(entry deletionEntry) *probe.Error {
+	if mj.report == nil {
+		return nil
+	}
+	if err := mj.report.add(entry); err != nil {
+		return err.Trace(entry.Key)
+	}
+	return nil
 }
 
 // doMirror - Mirror an object to multiple destination. URLs status contains a copy of sURLs and error if any.
@@ -251,9 +503,19 @@ func (mj *mirrorJob) doMirror(ctx context.Context, cancelMirror context.CancelFu
 	//s For a fake mirror make sure we update respective progress bars
 	// and accounting readers under relevant conditions.
 	if mj.isFake {
+		if mj.isDryRun {
+			mj.status.PrintMsg(mirrorMessage{
+				Source:     filepath.ToSlash(filepath.Join(sURLs.SourceAlias, sURLs.SourceContent.URL.Path)),
+				Target:     filepath.ToSlash(filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path)),
+				Size:       sURLs.SourceContent.Size,
+				TotalCount: sURLs.TotalCount,
+				TotalSize:  sURLs.TotalSize,
+			})
+		}
 		mj.status.Add(sURLs.SourceContent.Size)
 		return sURLs.WithError(nil)
 	}
+	sURLs.started = UTCNow()
 
 	sourceAlias := sURLs.SourceAlias
 	sourceURL := sURLs.SourceContent.URL
@@ -270,6 +532,13 @@ func (mj *mirrorJob) doMirror(ctx context.Context, cancelMirror context.CancelFu
 		sURLs.TargetContent.Metadata["X-Amz-Storage-Class"] = mj.storageClass
 	}
 
+	if mj.acl != "" {
+		if sURLs.TargetContent.Metadata == nil {
+			sURLs.TargetContent.Metadata = make(map[string]string)
+		}
+		sURLs.TargetContent.Metadata["X-Amz-Acl"] = mj.acl
+	}
+
 	sourcePath := filepath.ToSlash(filepath.Join(sourceAlias, sourceURL.Path))
 	targetPath := filepath.ToSlash(filepath.Join(targetAlias, targetURL.Path))
 	mj.status.PrintMsg(mirrorMessage{
@@ -279,7 +548,25 @@ func (mj *mirrorJob) doMirror(ctx context.Context, cancelMirror context.CancelFu
 		TotalCount: sURLs.TotalCount,
 		TotalSize:  sURLs.TotalSize,
 	})
-	return uploadSourceToTargetURL(ctx, sURLs, mj.status, mj.encKeyDB)
+	if mj.atomic != nil {
+		staged := mj.atomic.stage(sURLs)
+		urls := mj.uploadOpts.hooks.aroundObject(staged, func() URLs {
+			return mj.withRetries(ctx, staged, func(progress io.Reader) URLs {
+				return uploadSourceToTargetURL(ctx, staged, progress, mj.uploadOpts, mj.encKeyDB)
+			})
+		})
+		if urls.Error == nil {
+			mj.atomic.add(staged, targetURL.String())
+		}
+		// Reported with the URL it is published at.
+		urls.TargetContent = sURLs.TargetContent
+		return urls
+	}
+	return mj.uploadOpts.hooks.aroundObject(sURLs, func() URLs {
+		return mj.withRetries(ctx, sURLs, func(progress io.Reader) URLs {
+			return uploadSourceToTargetURL(ctx, sURLs, progress, mj.uploadOpts, mj.encKeyDB)
+		})
+	})
 }
 
 // Update progress status
@@ -289,6 +576,16 @@ func (mj *mirrorJob) monitorMirrorStatus() (errDuringMirror bool) {
 	defer mj.status.Finish()
 
 	for sURLs := range mj.statusCh {
+		// Special files left out by policy are only counted.
+		if mj.specials.skip(sURLs.Error) {
+			continue
+		}
+		if ps, ok := mj.status.(*ProgressStatus); ok {
+			ps.AddObject()
+		}
+		if !mj.isFake && (sURLs.Error == nil || !isErrIgnored(sURLs.Error)) {
+			mj.summary.record(sURLs)
+		}
 		if sURLs.Error != nil {
 			switch {
 			case sURLs.SourceContent != nil:
@@ -296,6 +593,11 @@ func (mj *mirrorJob) monitorMirrorStatus() (errDuringMirror bool) {
 					errorIf(sURLs.Error.Trace(sURLs.SourceContent.URL.String()),
 						fmt.Sprintf("Failed to copy `%s`.", sURLs.SourceContent.URL.String()))
 					errDuringMirror = true
+				} else if mj.strict {
+					// Sources gone or unreadable are only skipped silently without --strict.
+					errorIf(sURLs.Error.Trace(sURLs.SourceContent.URL.String()),
+						fmt.Sprintf("Skipped `%s`.", sURLs.SourceContent.URL.String()))
+					errDuringMirror = true
 				}
 			case sURLs.TargetContent != nil:
 				// When sURLs.SourceContent is nil, we know that we have an error related to removing
@@ -357,14 +659,28 @@ func (mj *mirrorJob) watchMirror(ctx context.Context, cancelMirror context.Cance
 			// build target path, it is the relative of the eventPath with the sourceUrl
 			// joined to the targetURL.
 			sourceSuffix := strings.TrimPrefix(eventPath, sourceURLFull)
-			//Skip the object, if it matches the Exclude options provided
-			if matchExcludeOptions(mj.excludeOptions, sourceSuffix) {
+			//Skip the object, if it is excluded or not included
+			if isMirrorExcluded(mj.excludeOptions, mj.includeOptions, sourceSuffix) {
 				continue
 			}
 
-			targetPath := urlJoinPath(mj.targetURL, sourceSuffix)
+			_, expandedTargetURL, _ := mustExpandAlias(mj.targetURL)
+			targetType := newClientURL(expandedTargetURL).Type
+			targetPath := urlJoinPath(mj.targetURL, mj.keyEnc.translate(sourceSuffix, sourceURL.Type, targetType))
 
 			// newClient needs the unexpanded  path, newCLientURL needs the expanded path
+			// Queued events are mirrored by the workers of the queue.
+			if mj.queue != nil && (event.Type == EventCreate || event.Type == EventRemove) {
+				ev := queuedEvent{Type: queuedCreate, Source: aliasedPath, Target: targetPath, Time: UTCNow()}
+				if event.Type == EventRemove {
+					ev.Type = queuedRemove
+				}
+				if err := mj.queue.enqueue(ev); err != nil {
+					mj.statusCh <- URLs{Error: err.Trace(aliasedPath)}
+				}
+				continue
+			}
+
 			targetAlias, expandedTargetPath, _ := mustExpandAlias(targetPath)
 			targetURL := newClientURL(expandedTargetPath)
 			sourcePath := filepath.ToSlash(filepath.Join(sourceAlias, sourceURL.Path))
@@ -401,16 +717,19 @@ func (mj *mirrorJob) watchMirror(ctx context.Context, cancelMirror context.Cance
 						return
 					}
 					shouldQueue := false
-					if !mj.isOverwrite {
-						_, err = targetClient.Stat(false, false, tgtSSE)
-						if err == nil {
+					if mj.overwrite != overwriteAlways {
+						targetContent, err := targetClient.Stat(false, false, tgtSSE)
+						if err == nil && !shouldOverwrite(mj.overwrite, sourceContent, targetContent) {
 							continue
-						} // doesn't exist
+						} // doesn't exist or is replaced
 						shouldQueue = true
 					}
-					if shouldQueue || mj.isOverwrite {
+					if shouldQueue || mj.overwrite == overwriteAlways {
 						mirrorURL.TotalCount = mj.TotalObjects
 						mirrorURL.TotalSize = mj.TotalBytes
+						if !mj.limits.allow(sourceContent.Size) {
+							return
+						}
 						// adjust total, because we want to show progress of the item still queued to be copied.
 						mj.status.SetTotal(mj.status.Total() + sourceContent.Size).Update()
 						mj.statusCh <- mj.doMirror(ctx, cancelMirror, mirrorURL)
@@ -418,23 +737,28 @@ func (mj *mirrorJob) watchMirror(ctx context.Context, cancelMirror context.Cance
 					continue
 				}
 				shouldQueue := false
-				if !mj.isOverwrite {
+				if mj.overwrite != overwriteAlways {
 					targetClient, err := newClient(targetPath)
 					if err != nil {
 						// cannot create targetclient
 						mj.statusCh <- mirrorURL.WithError(err)
 						return
 					}
-					_, err = targetClient.Stat(false, false, tgtSSE)
-					if err == nil {
+					targetContent, err := targetClient.Stat(false, false, tgtSSE)
+					// The source was just written, it is newer than target.
+					sourceContent := &clientContent{Size: event.Size, Time: UTCNow()}
+					if err == nil && !shouldOverwrite(mj.overwrite, sourceContent, targetContent) {
 						continue
-					} // doesn't exist
+					} // doesn't exist or is replaced
 					shouldQueue = true
 				}
-				if shouldQueue || mj.isOverwrite {
+				if shouldQueue || mj.overwrite == overwriteAlways {
 					mirrorURL.SourceContent.Size = event.Size
 					mirrorURL.TotalCount = mj.TotalObjects
 					mirrorURL.TotalSize = mj.TotalBytes
+					if !mj.limits.allow(event.Size) {
+						return
+					}
 					// adjust total, because we want to show progress of the itemj stiil queued to be copied.
 					mj.status.SetTotal(mj.status.Total() + event.Size).Update()
 					mj.statusCh <- mj.doMirror(ctx, cancelMirror, mirrorURL)
@@ -464,12 +788,21 @@ func (mj *mirrorJob) watchMirror(ctx context.Context, cancelMirror context.Cance
 			return
 		case <-mj.trapCh:
 			return
+		case <-mj.limits.done():
+			return
 		}
 	}
 }
 
 func (mj *mirrorJob) watchURL(sourceClient Client) *probe.Error {
-	return mj.watcher.Join(sourceClient, true)
+	if sourceClient.GetURL().Type != objectStorage {
+		return mj.watcher.Join(sourceClient, true)
+	}
+	if mj.isPolling {
+		mj.watcher.JoinPolling(sourceClient, mj.watchInterval)
+		return nil
+	}
+	return mj.watcher.JoinFallback(sourceClient, mj.watchInterval)
 }
 
 // Fetch urls that need to be mirrored
@@ -482,21 +815,59 @@ func (mj *mirrorJob) startMirror(ctx context.Context, cancelMirror context.Cance
 		mj.parallel.wait()
 	}
 
-	URLsCh := prepareMirrorURLs(mj.sourceURL, mj.targetURL, mj.isFake, mj.isOverwrite, mj.isRemove, mj.excludeOptions, mj.encKeyDB)
+	// Objects written by the mirror itself are found by listing target.
+	_, expandedTarget, _ := mustExpandAlias(mj.targetURL)
+	isOwnObject := func(content *clientContent) bool {
+		key := strings.TrimPrefix(filepath.ToSlash(strings.TrimPrefix(content.URL.String(), expandedTarget)), "/")
+		return isManifestKey(key)
+	}
+
+	var URLsCh <-chan URLs
+	if mj.merge != nil {
+		URLsCh = mj.merge.prepareURLs(mj)
+	} else if mj.snapshotURL != "" {
+		URLsCh = prepareSnapshotURLs(mj.sourceURL, mj.snapshotURL, mj.targetURL, mj.excludeOptions, mj.includeOptions, mj.keyEnc)
+	} else {
+		URLsCh = prepareMirrorURLs(mj.sourceURL, mj.targetURL, mj.mirrorOptions())
+		if mj.priority != nil {
+			URLsCh = mj.priority.prepareURLs(mj, URLsCh)
+		}
+	}
 
 	for {
 		select {
 		case sURLs, ok := <-URLsCh:
 			if !ok {
+				mj.releaseRemovals()
 				stopParallel()
 				return
 			}
+			// Special files are reported without stopping the mirror.
+			if isSpecialFileErr(sURLs.Error) {
+				mj.statusCh <- sURLs
+				continue
+			}
 			if sURLs.Error != nil {
 				stopParallel()
 				mj.statusCh <- sURLs
 				return
 			}
 
+			if sURLs.SourceContent == nil && sURLs.TargetContent != nil && isOwnObject(sURLs.TargetContent) {
+				continue
+			}
+
+			// No new transfers are started once a limit is reached,
+			// those in flight are finished.
+			var size int64
+			if sURLs.SourceContent != nil {
+				size = sURLs.SourceContent.Size
+			}
+			if !mj.limits.allow(size) {
+				stopParallel()
+				return
+			}
+
 			if sURLs.SourceContent != nil {
 				if mj.olderThan != "" && isOlder(sURLs.SourceContent.Time, mj.olderThan) {
 					continue
@@ -512,6 +883,9 @@ func (mj *mirrorJob) startMirror(ctx context.Context, cancelMirror context.Cance
 			mj.TotalBytes = totalBytes
 			mj.TotalObjects = totalObjects
 			mj.status.SetTotal(totalBytes)
+			if ps, ok := mj.status.(*ProgressStatus); ok {
+				ps.SetTotalObjects(totalObjects)
+			}
 
 			// Save total count.
 			sURLs.TotalCount = mj.TotalObjects
@@ -522,14 +896,17 @@ func (mj *mirrorJob) startMirror(ctx context.Context, cancelMirror context.Cance
 				mj.queueCh <- func() URLs {
 					return mj.doMirror(ctx, cancelMirror, sURLs)
 				}
+			} else if sURLs.TargetContent != nil && mj.isRemove && mj.removals != nil {
+				mj.removals.hold(sURLs)
 			} else if sURLs.TargetContent != nil && mj.isRemove {
-				mj.queueCh <- func() URLs {
-					return mj.doRemove(sURLs)
-				}
+				mj.queueRemoval(sURLs)
 			}
 		case <-mj.trapCh:
 			stopParallel()
 			cancelMirror()
+			if mj.atomic != nil {
+				mj.atomic.interrupted = true
+			}
 			return
 		}
 	}
@@ -545,10 +922,25 @@ func (mj *mirrorJob) mirror(ctx context.Context, cancelMirror context.CancelFunc
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
+			if mj.queue != nil {
+				// Stops the workers of the queue along.
+				defer cancelMirror()
+			}
 			mj.watchMirror(ctx, cancelMirror)
 		}()
 	}
 
+	// Starts the workers mirroring queued events.
+	if mj.queue != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mj.queue.run(ctx, func(ev queuedEvent) *probe.Error {
+				return mj.doQueued(ctx, cancelMirror, ev)
+			})
+		}()
+	}
+
 	// Start mirroring.
 	wg.Add(1)
 	go func() {
@@ -565,7 +957,7 @@ func (mj *mirrorJob) mirror(ctx context.Context, cancelMirror context.CancelFunc
 	return mj.monitorMirrorStatus()
 }
 
-func newMirrorJob(srcURL, dstURL string, isFake, isRemove, isOverwrite, isWatch bool, excludeOptions []string, olderThan, newerThan string, storageClass string, encKeyDB map[string][]prefixSSEPair) *mirrorJob {
+func newMirrorJob(srcURL, dstURL string, isFake, isRemove bool, overwrite string, isWatch bool, excludeOptions, includeOptions []string, folderMarkers string, noListTarget bool, olderThan, newerThan string, storageClass, acl, snapshotURL string, inventory *inventoryManifest, transferWorkers int, keyEnc keyEncoder, uploadOpts uploadOptions, encKeyDB map[string][]prefixSSEPair) *mirrorJob {
 	mj := mirrorJob{
 		trapCh: signalTrap(os.Interrupt, syscall.SIGTERM, syscall.SIGKILL),
 		m:      new(sync.Mutex),
@@ -575,18 +967,29 @@ func newMirrorJob(srcURL, dstURL string, isFake, isRemove, isOverwrite, isWatch
 
 		isFake:         isFake,
 		isRemove:       isRemove,
-		isOverwrite:    isOverwrite,
+		overwrite:      overwrite,
 		isWatch:        isWatch,
 		excludeOptions: excludeOptions,
+		includeOptions: includeOptions,
+		folderMarkers:  folderMarkers,
+		noListTarget:   noListTarget,
 		olderThan:      olderThan,
 		newerThan:      newerThan,
-		storageClass:   storageClass,
+		storageClass:   strings.ToUpper(storageClass),
+		acl:            acl,
+		snapshotURL:    snapshotURL,
+		inventory:      inventory,
+		keyEnc:         keyEnc,
+		uploadOpts:     uploadOpts,
 		encKeyDB:       encKeyDB,
 		statusCh:       make(chan URLs),
 		watcher:        NewWatcher(UTCNow()),
+		summary:        newPrefixStats(dstURL),
+		specials:       newSpecialFiles(specialFilesSkip),
+		limits:         newRunLimits(0, 0),
 	}
 
-	mj.parallel, mj.queueCh = newParallelManager(mj.statusCh)
+	mj.parallel, mj.queueCh = newParallelManager(mj.statusCh, transferWorkers)
 
 	// we'll define the status to use here,
 	// do we want the quiet status? or the progressbar
@@ -601,6 +1004,44 @@ func newMirrorJob(srcURL, dstURL string, isFake, isRemove, isOverwrite, isWatch
 	return &mj
 }
 
+// mirrorOptions - options the source and target of mj are compared with.
+func (mj *mirrorJob) mirrorOptions() mirrorOptions {
+	return mirrorOptions{
+		isFake:          mj.isFake,
+		isRemove:        mj.isRemove,
+		overwrite:       mj.overwrite,
+		excludeOptions:  mj.excludeOptions,
+		includeOptions:  mj.includeOptions,
+		folderMarkers:   mj.folderMarkers,
+		noListTarget:    mj.noListTarget,
+		compareChecksum: mj.compareChecksum,
+		listWorkers:     mj.listWorkers,
+		preserve:        mj.uploadOpts.preserve,
+		keyEnc:          mj.keyEnc,
+		inventory:       mj.inventory,
+		at:              mj.at,
+		encKeyDB:        mj.encKeyDB,
+	}
+}
+
+// previewRemoval - counts the objects the mirror removes from target.
+func (mj *mirrorJob) previewRemoval() (removalPreview, *probe.Error) {
+	var preview removalPreview
+	URLsCh := prepareMirrorURLs(mj.sourceURL, mj.targetURL, mj.mirrorOptions())
+	for sURLs := range URLsCh {
+		if isSpecialFileErr(sURLs.Error) {
+			continue
+		}
+		if sURLs.Error != nil {
+			return preview, sURLs.Error.Trace(mj.targetURL)
+		}
+		if sURLs.SourceContent == nil && sURLs.TargetContent != nil {
+			preview.add(sURLs.TargetContent)
+		}
+	}
+	return preview, nil
+}
+
 // copyBucketPolicies - copy policies from source to dest
 func copyBucketPolicies(srcClt, dstClt Client, isOverwrite bool) *probe.Error {
 	rules, err := srcClt.GetAccessRules()
@@ -625,27 +1066,105 @@ func copyBucketPolicies(srcClt, dstClt Client, isOverwrite bool) *probe.Error {
 	return nil
 }
 
-// runMirror - mirrors all buckets to another S3 server
-func runMirror(srcURL, dstURL string, ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) bool {
-	// This is kept for backward compatibility, `--force` means
-	// --overwrite.
-	isOverwrite := ctx.Bool("force")
-	if !isOverwrite {
-		isOverwrite = ctx.Bool("overwrite")
+// runMirror - mirrors all buckets to another S3 server, returns the exit
+// status of the mirror.
+func runMirror(srcURLs []string, dstURL string, ctx *cli.Context, report *deletionReport, encKeyDB map[string][]prefixSSEPair) error {
+	srcURL := srcURLs[0]
+	overwrite := mirrorOverwrite(ctx)
+	isOverwrite := overwrite != "" && overwrite != overwriteNever
+
+	keyEnc, err := newKeyEncoder(ctx.String("encode-chars"))
+	fatalIf(err, "Unable to parse characters to encode.")
+
+	// Snapshots are written under a new dated prefix of the target.
+	var snapshotURL string
+	if ctx.Bool("snapshot") {
+		snapshotURL, err = latestSnapshot(dstURL)
+		fatalIf(err, "Unable to find the previous snapshot in `"+dstURL+"`.")
+		dstURL = urlJoinPath(dstURL, snapshotName(UTCNow()))
+	}
+
+	var inventory *inventoryManifest
+	if manifestURL := ctx.String("inventory"); manifestURL != "" {
+		inventory, err = readInventoryManifest(manifestURL)
+		fatalIf(err, "Unable to read inventory manifest `"+manifestURL+"`.")
 	}
 
+	hooks, err := newTransferHooks(ctx.String("pre-exec"), ctx.String("post-exec"), ctx.String("exec-scope"))
+	fatalIf(err, "Unable to parse ‘--exec-scope’.")
+
 	// Create a new mirror job and execute it
 	mj := newMirrorJob(srcURL, dstURL,
-		ctx.Bool("fake"),
+		ctx.Bool("fake") || ctx.Bool("dry-run"),
 		ctx.Bool("remove"),
-		isOverwrite,
+		overwrite,
 		ctx.Bool("watch"),
 		ctx.StringSlice("exclude"),
+		ctx.StringSlice("include"),
+		ctx.String("folder-markers"),
+		ctx.Bool("no-list-target"),
 		ctx.String("older-than"),
 		ctx.String("newer-than"),
 		ctx.String("storage-class"),
+		ctx.String("acl"),
+		snapshotURL,
+		inventory,
+		mirrorWorkers(ctx),
+		keyEnc,
+		uploadOptions{
+			compress:      ctx.String("compress"),
+			noDecompress:  ctx.Bool("no-decompress"),
+			filter:        ctx.String("filter"),
+			checksum:      ctx.String("checksum"),
+			hooks:         hooks,
+			uploadLimits:  newUploadLimiters(ctx.String("limit-upload")),
+			downloadLimit: newBandwidthLimiter(ctx.String("limit-download")),
+			scheduleLimit: newScheduledBandwidthLimiter(ctx.String("limit-schedule")),
+			preserve:      ctx.Bool("preserve"),
+		},
 		encKeyDB)
 
+	mj.specials = newSpecialFiles(ctx.String("special-files"))
+	mj.compareChecksum = ctx.Bool("compare-checksum")
+	mj.listWorkers = ctx.Int("list-workers")
+	mj.at, _ = parsePointInTime(ctx.String("at"))
+	mj.retries = ctx.Int("retry")
+	mj.strict = ctx.Bool("strict")
+
+	// Dry runs print each object instead of a progress bar.
+	if mj.isDryRun = ctx.Bool("dry-run"); mj.isDryRun && !globalJSON {
+		mj.status = NewQuietStatus(mj.parallel)
+	}
+
+	mj.watchInterval, _ = time.ParseDuration(ctx.String("watch-interval"))
+	mj.isPolling = ctx.IsSet("watch-interval")
+
+	mj.report = report
+
+	// Objects found in more than one source are left out by all sources
+	// but the one told by the collision policy.
+	var unresolved int
+	if ctx.Bool("merge") {
+		mj.merge, unresolved, err = newMirrorMerge(srcURLs, dstURL, ctx.String("on-collision"), mj.excludeOptions, mj.includeOptions, keyEnc)
+		fatalIf(err, "Unable to list the sources merged into `"+dstURL+"`.")
+	}
+
+	// Unreadable local sources are found before anything is mirrored.
+	if ctx.Bool("preflight") {
+		checkPreflight(srcURLs)
+	}
+
+	// Ask before removing many objects from target. Removals are
+	// counted during the run, watched targets are compared beforehand as
+	// the run does not end. Listing errors are reported by the mirror.
+	if mj.isRemove && snapshotURL == "" && !mj.isFake && !skipConfirmation(ctx) {
+		if !mj.isWatch {
+			mj.removals = &mirrorRemovals{threshold: int64(ctx.Int("confirm-threshold"))}
+		} else if preview, err := mj.previewRemoval(); err == nil {
+			confirmRemoval(ctx, dstURL, preview, true)
+		}
+	}
+
 	srcClt, err := newClient(srcURL)
 	fatalIf(err, "Unable to initialize `"+srcURL+"`.")
 
@@ -659,6 +1178,35 @@ func runMirror(srcURL, dstURL string, ctx *cli.Context, encKeyDB map[string][]pr
 	mirrorAllBuckets := (srcClt.GetURL().Type == objectStorage && srcClt.GetURL().Path == "/") ||
 		(dstClt.GetURL().Type == objectStorage && dstClt.GetURL().Path == "/")
 
+	if mirrorAllBuckets && ctx.Bool("snapshot") {
+		fatalIf(errInvalidArgument().Trace(srcURL, dstURL), "Snapshots of all buckets are not supported, please specify a bucket.")
+	}
+
+	if mirrorAllBuckets && mj.merge != nil {
+		fatalIf(errInvalidArgument().Trace(srcURL, dstURL), "Merging all buckets is not supported, please specify a bucket.")
+	}
+
+	if mirrorAllBuckets && ctx.Bool("atomic") {
+		fatalIf(errInvalidArgument().Trace(srcURL, dstURL), "Atomic mirroring of all buckets is not supported, please specify a bucket.")
+	}
+
+	if priorityFile := ctx.String("priority-from"); priorityFile != "" {
+		mj.priority, err = newMirrorPriority(priorityFile)
+		fatalIf(err, "Unable to read priority keys from `"+priorityFile+"`.")
+	}
+
+	if queueDir := ctx.String("queue-dir"); queueDir != "" {
+		mj.queue, err = newWatchQueue(queueDir)
+		fatalIf(err, "Unable to open queue `"+queueDir+"`.")
+	}
+
+	// Nothing is written to target itself until all objects succeeded.
+	if ctx.Bool("atomic") && !mj.isFake {
+		mj.atomic, err = newMirrorAtomic(dstURL)
+		fatalIf(err, "`--atomic` cannot mirror to the root of a bucket or of the filesystem, objects are staged next to target.")
+		errorIf(mj.atomic.removeStale(), "Unable to remove the objects left staged under `"+mj.atomic.stagingDirURL+"`.")
+	}
+
 	if mirrorAllBuckets {
 		// Synchronize buckets using dirDifference function
 		for d := range dirDifference(srcClt, dstClt, srcURL, dstURL) {
@@ -712,8 +1260,61 @@ func runMirror(srcURL, dstURL string, ctx *cli.Context, encKeyDB map[string][]pr
 	ctxt, cancelMirror := context.WithCancel(context.Background())
 	defer cancelMirror()
 
+	maxDuration, _ := parseMaxDuration(ctx.String("max-duration"))
+	maxBytes, _ := parseMaxBytes(ctx.String("max-bytes"))
+	mj.limits = newRunLimits(maxDuration, maxBytes)
+
+	fatalIf(hooks.beforeJob(srcURLs, dstURL, 0), "Unable to start mirroring, the ‘--pre-exec’ hook failed.")
+
 	// Start mirroring job
-	return mj.mirror(ctxt, cancelMirror)
+	errorDetected := mj.mirror(ctxt, cancelMirror) || unresolved > 0
+	if msg := mj.specials.message(); mj.strict && msg.Skipped > 0 {
+		errorIf(errDummy().Trace(dstURL), fmt.Sprintf("%d special file(s) skipped, failing as ‘--strict’ is set.", msg.Skipped))
+		errorDetected = true
+	}
+	if mj.atomic != nil {
+		if errorDetected || mj.atomic.interrupted {
+			mj.discard()
+		} else {
+			errorDetected = mj.publish(ctxt)
+		}
+	}
+	// Removals past the threshold wait until everything else is mirrored
+	// and published.
+	if mj.removals.needsConfirmation() && !errorDetected {
+		confirmRemoval(ctx, dstURL, mj.removals.preview, true)
+		errorDetected = mj.removePending()
+	}
+	// The breakdown only tells something about several prefixes.
+	if msg := mj.summary.message(); len(msg.Prefixes) > 1 {
+		printMsg(msg)
+	}
+	if msg := mj.specials.message(); msg.Skipped > 0 {
+		printMsg(msg)
+	}
+
+	if ctx.Bool("manifest") && !errorDetected && !mj.isFake {
+		manifest, err := writeMirrorManifest(ctxt, dstURL, encKeyDB)
+		if err != nil {
+			errorIf(err, "Unable to write the manifest of `"+dstURL+"`.")
+			errorDetected = true
+		} else if !globalJSON {
+			console.Infoln(fmt.Sprintf("Manifest of generation %d written under `%s`, %d object(s).", manifest.Generation, dstURL, len(manifest.Objects)))
+		}
+	}
+	if err := hooks.afterJob(srcURLs, dstURL, mj.status.Get(), errorDetected); err != nil {
+		errorIf(err, "The ‘--post-exec’ hook failed.")
+		errorDetected = true
+	}
+	if errorDetected {
+		return exitStatus(globalErrorExitStatus)
+	}
+	// Objects left are found again by the next run.
+	if mj.limits.reached() {
+		printMsg(mj.limits.message(""))
+		return exitStatus(globalRunLimitExitStatus)
+	}
+	return nil
 }
 
 // Main entry point for mirror command.
@@ -722,20 +1323,41 @@ func mainMirror(ctx *cli.Context) error {
 	encKeyDB, err := getEncKeys(ctx)
 	fatalIf(err, "Unable to parse encryption keys.")
 
+	if ctx.Bool("verify-manifest") {
+		if len(ctx.Args()) != 1 {
+			cli.ShowCommandHelpAndExit(ctx, "mirror", 1) // last argument is exit code
+		}
+		console.SetColor("Mirror", color.New(color.FgGreen, color.Bold))
+		console.SetColor("ManifestProblem", color.New(color.FgRed, color.Bold))
+		if verifyMirrorManifest(ctx.Args().Get(0), encKeyDB) {
+			return exitStatus(globalErrorExitStatus)
+		}
+		return nil
+	}
+
 	// check 'mirror' cli arguments.
 	checkMirrorSyntax(ctx, encKeyDB)
 
 	// Additional command specific theme customization.
 	console.SetColor("Mirror", color.New(color.FgGreen, color.Bold))
+	console.SetColor("SpecialFiles", color.New(color.FgYellow))
+	console.SetColor("RunLimit", color.New(color.FgYellow))
 
 	args := ctx.Args()
 
-	srcURL := args[0]
-	tgtURL := args[1]
-
-	if errorDetected := runMirror(srcURL, tgtURL, ctx, encKeyDB); errorDetected {
-		return exitStatus(globalErrorExitStatus)
+	// With --merge all arguments but the last one are sources.
+	srcURLs := args[:len(args)-1]
+	tgtURL := args[len(args)-1]
+
+	// Objects removed from target are reported, once per command.
+	var report *deletionReport
+	if reportPath := ctx.String("report"); reportPath != "" {
+		report, err = newDeletionReport(reportPath, ctx.Bool("fake") || ctx.Bool("dry-run"))
+		fatalIf(err, "Unable to create the deletion report.")
+		defer func() {
+			fatalIf(report.close(), "Unable to write the deletion report.")
+		}()
 	}
 
-	return nil
+	return runMirror(srcURLs, tgtURL, ctx, report, encKeyDB)
 }
@@ -0,0 +1,237 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Name of the manifest object written under target.
+const mirrorManifestName = ".mc-manifest.json"
+
+// manifestObject - an object of target recorded in its manifest.
+type manifestObject struct {
+	Key      string `json:"key"`
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum"`
+}
+
+// mirrorManifest - the objects of target once a mirror succeeded. The
+// generation grows with every manifest written under target.
+type mirrorManifest struct {
+	Version    string           `json:"version"`
+	Generation int64            `json:"generation"`
+	Time       time.Time        `json:"time"`
+	Objects    []manifestObject `json:"objects"`
+}
+
+// isManifestKey - returns true if key is written by the mirror itself
+// rather than mirrored, it is not recorded in manifests.
+func isManifestKey(key string) bool {
+	return key == mirrorManifestName
+}
+
+// contentChecksum - returns the ETag of an object, or the MD5 of a local
+// file as its checksum.
+func contentChecksum(content *clientContent) (string, *probe.Error) {
+	if content.URL.Type == objectStorage {
+		return strings.Trim(content.ETag, "\""), nil
+	}
+	file, e := os.Open(content.URL.Path)
+	if e != nil {
+		return "", probe.NewError(e).Trace(content.URL.Path)
+	}
+	defer file.Close()
+	sum, ok := localSum(file, content.Size, nil, md5.New)
+	if !ok {
+		return "", probe.NewError(fmt.Errorf("Unable to read `%s`", content.URL.Path))
+	}
+	return hex.EncodeToString(sum), nil
+}
+
+// listManifestObjects - lists the objects of the aliased URL targetURL,
+// sorted by key.
+func listManifestObjects(targetURL string) ([]manifestObject, *probe.Error) {
+	clnt, err := newClient(targetURL)
+	if err != nil {
+		return nil, err.Trace(targetURL)
+	}
+	prefix := clnt.GetURL().String()
+	var objects []manifestObject
+	for content := range clnt.List(true, false, DirNone) {
+		if content.Err != nil {
+			return nil, content.Err.Trace(targetURL)
+		}
+		if !content.Type.IsRegular() {
+			continue
+		}
+		key := strings.TrimPrefix(filepath.ToSlash(strings.TrimPrefix(content.URL.String(), prefix)), "/")
+		if isManifestKey(key) {
+			continue
+		}
+		checksum, err := contentChecksum(content)
+		if err != nil {
+			return nil, err.Trace(targetURL)
+		}
+		objects = append(objects, manifestObject{Key: key, Size: content.Size, Checksum: checksum})
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// readMirrorManifest - reads the manifest under the aliased URL
+// targetURL, nil if none was written yet.
+func readMirrorManifest(targetURL string, encKeyDB map[string][]prefixSSEPair) (*mirrorManifest, *probe.Error) {
+	manifestURL := urlJoinPath(targetURL, mirrorManifestName)
+	reader, err := getSourceStreamFromURL(manifestURL, encKeyDB)
+	if err != nil {
+		switch err.ToGoError().(type) {
+		case ObjectMissing, PathNotFound:
+			return nil, nil
+		}
+		return nil, err.Trace(manifestURL)
+	}
+	defer reader.Close()
+	data, e := ioutil.ReadAll(reader)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(manifestURL)
+	}
+	manifest := &mirrorManifest{}
+	if e = json.Unmarshal(data, manifest); e != nil {
+		return nil, probe.NewError(e).Trace(manifestURL)
+	}
+	return manifest, nil
+}
+
+// writeMirrorManifest - writes the manifest of the objects now under
+// the aliased URL targetURL.
+func writeMirrorManifest(ctx context.Context, targetURL string, encKeyDB map[string][]prefixSSEPair) (*mirrorManifest, *probe.Error) {
+	previous, err := readMirrorManifest(targetURL, encKeyDB)
+	if err != nil {
+		return nil, err.Trace(targetURL)
+	}
+	objects, err := listManifestObjects(targetURL)
+	if err != nil {
+		return nil, err.Trace(targetURL)
+	}
+	manifest := &mirrorManifest{Version: "1", Generation: 1, Time: UTCNow(), Objects: objects}
+	if previous != nil {
+		manifest.Generation = previous.Generation + 1
+	}
+	data, e := json.MarshalIndent(manifest, "", " ")
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+
+	manifestURL := urlJoinPath(targetURL, mirrorManifestName)
+	alias, expandedURL, _ := mustExpandAlias(manifestURL)
+	sse := getSSE(manifestURL, encKeyDB[alias])
+	metadata := map[string]string{"Content-Type": "application/json"}
+	if _, err = putTargetStream(ctx, alias, expandedURL, bytes.NewReader(data), int64(len(data)), metadata, nil, sse); err != nil {
+		return nil, err.Trace(manifestURL)
+	}
+	return manifest, nil
+}
+
+// Problems of target found against its manifest.
+const (
+	manifestMissing = "missing"
+	manifestChanged = "changed"
+	manifestExtra   = "extra"
+)
+
+// manifestMessage - an object of target not matching its manifest.
+type manifestMessage struct {
+	Status  string `json:"status"`
+	Key     string `json:"key"`
+	Problem string `json:"problem"`
+}
+
+// String colorized manifest message.
+func (m manifestMessage) String() string {
+	return console.Colorize("ManifestProblem", fmt.Sprintf("%-8s", m.Problem)) + console.Colorize("Mirror", m.Key)
+}
+
+// JSON jsonified manifest message.
+func (m manifestMessage) JSON() string {
+	m.Status = "error"
+	data, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(data)
+}
+
+// compareManifest - returns the objects differing between a manifest
+// and the objects of its target, both sorted by key.
+func compareManifest(recorded, current []manifestObject) (msgs []manifestMessage) {
+	i, j := 0, 0
+	for i < len(recorded) || j < len(current) {
+		switch {
+		case j == len(current) || (i < len(recorded) && recorded[i].Key < current[j].Key):
+			msgs = append(msgs, manifestMessage{Key: recorded[i].Key, Problem: manifestMissing})
+			i++
+		case i == len(recorded) || current[j].Key < recorded[i].Key:
+			msgs = append(msgs, manifestMessage{Key: current[j].Key, Problem: manifestExtra})
+			j++
+		default:
+			if recorded[i].Size != current[j].Size || recorded[i].Checksum != current[j].Checksum {
+				msgs = append(msgs, manifestMessage{Key: recorded[i].Key, Problem: manifestChanged})
+			}
+			i++
+			j++
+		}
+	}
+	return msgs
+}
+
+// verifyMirrorManifest - validates the aliased URL targetURL against its
+// last manifest, returns true if it does not match.
+func verifyMirrorManifest(targetURL string, encKeyDB map[string][]prefixSSEPair) bool {
+	manifest, err := readMirrorManifest(targetURL, encKeyDB)
+	fatalIf(err, "Unable to read the manifest of `"+targetURL+"`.")
+	if manifest == nil {
+		fatalIf(errDummy().Trace(targetURL), "No manifest found under `"+targetURL+"`.")
+	}
+	objects, err := listManifestObjects(targetURL)
+	fatalIf(err, "Unable to list `"+targetURL+"`.")
+
+	msgs := compareManifest(manifest.Objects, objects)
+	for _, msg := range msgs {
+		printMsg(msg)
+	}
+	if len(msgs) > 0 {
+		errorIf(errDummy().Trace(targetURL), fmt.Sprintf("`%s` does not match its manifest of generation %d, %d object(s) differ.", targetURL, manifest.Generation, len(msgs)))
+		return true
+	}
+	if !globalJSON {
+		console.Infoln(fmt.Sprintf("`%s` matches its manifest of generation %d, %d object(s).", targetURL, manifest.Generation, len(objects)))
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompareManifest(t *testing.T) {
+	object := func(key string, size int64, checksum string) manifestObject {
+		return manifestObject{Key: key, Size: size, Checksum: checksum}
+	}
+	recorded := []manifestObject{object("a", 1, "x"), object("b", 2, "y"), object("c", 3, "z")}
+	testCases := []struct {
+		current  []manifestObject
+		expected []manifestMessage
+	}{
+		{recorded, nil},
+		{nil, []manifestMessage{{Key: "a", Problem: manifestMissing}, {Key: "b", Problem: manifestMissing}, {Key: "c", Problem: manifestMissing}}},
+		{[]manifestObject{object("a", 1, "x"), object("c", 3, "z")}, []manifestMessage{{Key: "b", Problem: manifestMissing}}},
+		{[]manifestObject{object("a", 1, "x"), object("b", 2, "w"), object("c", 4, "z")}, []manifestMessage{{Key: "b", Problem: manifestChanged}, {Key: "c", Problem: manifestChanged}}},
+		{append([]manifestObject{object("0", 1, "x")}, append(recorded, object("d", 1, "x"))...), []manifestMessage{{Key: "0", Problem: manifestExtra}, {Key: "d", Problem: manifestExtra}}},
+	}
+	for i, testCase := range testCases {
+		msgs := compareManifest(recorded, testCase.current)
+		if !reflect.DeepEqual(msgs, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, msgs)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Policies for objects found in more than one source of a merged mirror.
+const (
+	collisionFirst  = "first"
+	collisionNewest = "newest"
+	collisionError  = "error"
+)
+
+// isValidCollisionPolicy - returns true if policy is a known collision policy.
+func isValidCollisionPolicy(policy string) bool {
+	switch policy {
+	case collisionFirst, collisionNewest, collisionError:
+		return true
+	}
+	return false
+}
+
+// mirrorMerge - sources mirrored into one target along with the objects
+// of each source left out, as another source has the same target.
+type mirrorMerge struct {
+	sourceURLs []string
+	skip       []map[string]bool
+}
+
+// mergeSource - the listing of a source, positioned on its current object.
+type mergeSource struct {
+	url     string
+	listCh  <-chan *clientContent
+	content *clientContent
+	suffix  string
+	key     string
+}
+
+// next - moves to the next object of the source not excluded, content
+// is nil at the end of the listing.
+func (s *mergeSource) next(targetType clientURLType, excludeOptions, includeOptions []string, keyEnc keyEncoder) *probe.Error {
+	for content := range s.listCh {
+		if content.Err != nil {
+			return content.Err.Trace(s.url)
+		}
+		suffix := strings.TrimPrefix(content.URL.String(), s.url)
+		if isMirrorExcluded(excludeOptions, includeOptions, suffix) {
+			continue
+		}
+		s.content = content
+		s.suffix = suffix
+		s.key = keyEnc.translate(suffix, content.URL.Type, targetType)
+		return nil
+	}
+	s.content = nil
+	return nil
+}
+
+// mergeSourceURL - returns the alias and the expanded URL of a source
+// as listed by the mirror, with a trailing separator.
+func mergeSourceURL(sourceURL string) (string, string) {
+	separator := string(newClientURL(sourceURL).Separator)
+	if !strings.HasSuffix(sourceURL, separator) {
+		sourceURL = sourceURL + separator
+	}
+	alias, expandedURL, _ := mustExpandAlias(sourceURL)
+	return alias, expandedURL
+}
+
+// newMirrorMerge - lists all sources side by side to find objects with
+// the same target, one of them is kept as told by policy. With the
+// 'error' policy none of them is mirrored, they are reported and
+// counted in unresolved.
+func newMirrorMerge(sourceURLs []string, targetURL, policy string, excludeOptions, includeOptions []string, keyEnc keyEncoder) (m *mirrorMerge, unresolved int, err *probe.Error) {
+	targetType := newClientURL(targetURL).Type
+	m = &mirrorMerge{sourceURLs: sourceURLs}
+	sources := make([]*mergeSource, len(sourceURLs))
+	for i, sourceURL := range sourceURLs {
+		alias, expandedURL := mergeSourceURL(sourceURL)
+		clnt, err := newClientFromAlias(alias, expandedURL)
+		if err != nil {
+			return nil, 0, err.Trace(sourceURL)
+		}
+		sources[i] = &mergeSource{url: expandedURL, listCh: clnt.List(true, false, DirNone)}
+		if err = sources[i].next(targetType, excludeOptions, includeOptions, keyEnc); err != nil {
+			return nil, 0, err
+		}
+		m.skip = append(m.skip, make(map[string]bool))
+	}
+
+	for {
+		// Sources are listed in lexical order, the smallest key is the
+		// next one to look at on all of them.
+		var holders []*mergeSource
+		var holderIndexes []int
+		for i, s := range sources {
+			if s.content == nil {
+				continue
+			}
+			if len(holders) > 0 && s.key > holders[0].key {
+				continue
+			}
+			if len(holders) > 0 && s.key < holders[0].key {
+				holders, holderIndexes = nil, nil
+			}
+			holders = append(holders, s)
+			holderIndexes = append(holderIndexes, i)
+		}
+		if len(holders) == 0 {
+			return m, unresolved, nil
+		}
+
+		if len(holders) > 1 {
+			winner := 0
+			switch policy {
+			case collisionNewest:
+				for i, s := range holders {
+					if s.content.Time.After(holders[winner].content.Time) {
+						winner = i
+					}
+				}
+			case collisionError:
+				winner = -1
+				var urls []string
+				for _, s := range holders {
+					urls = append(urls, s.content.URL.String())
+				}
+				errorIf(errDummy().Trace(urls...), "`"+holders[0].key+"` is found in more than one source, use ‘--on-collision first’ or ‘--on-collision newest’ to mirror one of them.")
+				unresolved++
+			}
+			for i, s := range holders {
+				if i != winner {
+					m.skip[holderIndexes[i]][s.suffix] = true
+				}
+			}
+		}
+
+		for _, s := range holders {
+			if err = s.next(targetType, excludeOptions, includeOptions, keyEnc); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+}
+
+// prepareURLs - mirrors all sources at once, objects of a source left out
+// by the merge are not sent. Objects only found on target are left
+// there, removal is not supported with more than one source.
+func (m *mirrorMerge) prepareURLs(mj *mirrorJob) <-chan URLs {
+	URLsCh := make(chan URLs)
+	// Removals and inventory reports are not supported with --merge.
+	opts := mj.mirrorOptions()
+	opts.isRemove = false
+	opts.inventory = nil
+
+	var wg sync.WaitGroup
+	for i, sourceURL := range m.sourceURLs {
+		wg.Add(1)
+		go func(skip map[string]bool, sourceURL string) {
+			defer wg.Done()
+			_, expandedURL := mergeSourceURL(sourceURL)
+			for sURLs := range prepareMirrorURLs(sourceURL, mj.targetURL, opts) {
+				if sURLs.Error == nil && sURLs.SourceContent == nil {
+					continue
+				}
+				if sURLs.SourceContent != nil && skip[strings.TrimPrefix(sURLs.SourceContent.URL.String(), expandedURL)] {
+					continue
+				}
+				URLsCh <- sURLs
+			}
+		}(m.skip[i], sourceURL)
+	}
+	go func() {
+		wg.Wait()
+		close(URLsCh)
+	}()
+	return URLsCh
+}
@@ -0,0 +1,152 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// mirrorPriority - keys of source listed with --priority-from, they are
+// mirrored before all other objects.
+type mirrorPriority struct {
+	keys []string
+	// keys mirrored or found unchanged, left out of the listing.
+	handled map[string]bool
+}
+
+// readPriorityKeys - reads one key relative to source per line of r,
+// empty lines and lines starting with '#' are ignored.
+func readPriorityKeys(r io.Reader) ([]string, *probe.Error) {
+	var keys []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		key := strings.TrimSpace(scanner.Text())
+		if key == "" || strings.HasPrefix(key, "#") {
+			continue
+		}
+		keys = append(keys, strings.TrimPrefix(key, "/"))
+	}
+	if e := scanner.Err(); e != nil {
+		return nil, probe.NewError(e)
+	}
+	return keys, nil
+}
+
+// newMirrorPriority - reads the keys to mirror first from the file at path.
+func newMirrorPriority(path string) (*mirrorPriority, *probe.Error) {
+	file, e := os.Open(path)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(path)
+	}
+	defer file.Close()
+	keys, err := readPriorityKeys(file)
+	if err != nil {
+		return nil, err.Trace(path)
+	}
+	return &mirrorPriority{keys: keys, handled: make(map[string]bool)}, nil
+}
+
+// prepareURLs - sends the objects of the priority keys to mirror, then
+// those of URLsCh but the priority keys already handled. Priority keys
+// whose target differs in a way only the listing decides on, or which
+// are missing on source, are left to the listing.
+func (p *mirrorPriority) prepareURLs(mj *mirrorJob, URLsCh <-chan URLs) <-chan URLs {
+	priorityCh := make(chan URLs)
+	sourceAlias, expandedSource := mergeSourceURL(mj.sourceURL)
+	go func() {
+		defer close(priorityCh)
+		for _, key := range p.keys {
+			if isMirrorExcluded(mj.excludeOptions, mj.includeOptions, key) {
+				continue
+			}
+			sURLs, ok := p.stat(mj, sourceAlias, expandedSource, key)
+			if !ok {
+				continue
+			}
+			p.handled[key] = true
+			if sURLs.SourceContent != nil {
+				priorityCh <- sURLs
+			}
+		}
+		for sURLs := range URLsCh {
+			if sURLs.SourceContent != nil {
+				key := strings.TrimPrefix(filepath.ToSlash(strings.TrimPrefix(sURLs.SourceContent.URL.String(), expandedSource)), "/")
+				if p.handled[key] {
+					continue
+				}
+			}
+			priorityCh <- sURLs
+		}
+	}()
+	return priorityCh
+}
+
+// stat - returns the URLs mirroring key, without source content if its
+// target is unchanged. ok is false if the listing decides on key.
+func (p *mirrorPriority) stat(mj *mirrorJob, sourceAlias, expandedSource, key string) (sURLs URLs, ok bool) {
+	sourcePath := urlJoinPath(expandedSource, key)
+	sourceClnt, err := newClientFromAlias(sourceAlias, sourcePath)
+	if err != nil {
+		return sURLs, false
+	}
+	sourceSSE := getSSE(filepath.ToSlash(filepath.Join(sourceAlias, sourceClnt.GetURL().Path)), mj.encKeyDB[sourceAlias])
+	sourceContent, err := sourceClnt.Stat(false, false, sourceSSE)
+	if err != nil || !sourceContent.Type.IsRegular() {
+		return sURLs, false
+	}
+
+	targetAlias, expandedTarget, _ := mustExpandAlias(mj.targetURL)
+	targetPath := urlJoinPath(expandedTarget, mj.keyEnc.translate(key, sourceClnt.GetURL().Type, newClientURL(expandedTarget).Type))
+	targetClnt, err := newClientFromAlias(targetAlias, targetPath)
+	if err != nil {
+		return sURLs, false
+	}
+	targetSSE := getSSE(filepath.ToSlash(filepath.Join(targetAlias, targetClnt.GetURL().Path)), mj.encKeyDB[targetAlias])
+	targetContent, err := targetClnt.Stat(false, false, targetSSE)
+	if err == nil {
+		switch {
+		case mj.overwrite == overwriteAlways:
+		case sourceContent.Size == targetContent.Size && !sourceContent.Time.After(targetContent.Time):
+			// Unchanged, unless checksums are compared.
+			if mj.compareChecksum {
+				return sURLs, false
+			}
+			return URLs{}, true
+		case mj.overwrite != "" && shouldOverwrite(mj.overwrite, sourceContent, targetContent):
+		default:
+			return sURLs, false
+		}
+	} else {
+		switch err.ToGoError().(type) {
+		case ObjectMissing, PathNotFound:
+		default:
+			return sURLs, false
+		}
+	}
+	return URLs{
+		SourceAlias:   sourceAlias,
+		SourceContent: sourceContent,
+		TargetAlias:   targetAlias,
+		TargetContent: &clientContent{URL: *newClientURL(targetPath)},
+	}, true
+}
@@ -0,0 +1,35 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadPriorityKeys(t *testing.T) {
+	input := "# customer databases\ndb/customers.dump\n\n  /db/orders.dump  \nreports/2019/q4.pdf\n"
+	keys, err := readPriorityKeys(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"db/customers.dump", "db/orders.dump", "reports/2019/q4.pdf"}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("Expected %v, got %v", expected, keys)
+	}
+}
@@ -0,0 +1,364 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+const (
+	// Number of workers uploading queued events.
+	watchQueueWorkers = 4
+
+	// Interval the queue directory is scanned at for events to retry.
+	watchQueueScanInterval = time.Second
+
+	// Delay before retrying a failed event, doubled on each failure.
+	watchQueueMinRetryDelay = time.Second
+	watchQueueMaxRetryDelay = 5 * time.Minute
+)
+
+// Types of queued events.
+const (
+	queuedCreate = "create"
+	queuedRemove = "remove"
+)
+
+// queuedEvent - a change of the watched source, kept on disk until it
+// is mirrored.
+type queuedEvent struct {
+	Type   string    `json:"type"`
+	Source string    `json:"source"`
+	Target string    `json:"target"`
+	Time   time.Time `json:"time"`
+}
+
+// queueRetry - failures of a queued event and when it is tried again.
+type queueRetry struct {
+	failures int
+	next     time.Time
+}
+
+// watchQueue - events of a watched mirror queued in a directory, one
+// file each named by the time it was queued. Events are removed once
+// mirrored, they are retried until then and across runs.
+type watchQueue struct {
+	dir      string
+	notifyCh chan struct{}
+
+	mutex    sync.Mutex
+	inFlight map[string]bool
+	running  map[string]bool
+	retries  map[string]queueRetry
+}
+
+// newWatchQueue - opens the queue in dir, created if missing. Events
+// left by a previous run are mirrored first.
+func newWatchQueue(dir string) (*watchQueue, *probe.Error) {
+	if e := os.MkdirAll(dir, 0700); e != nil {
+		return nil, probe.NewError(e).Trace(dir)
+	}
+	return &watchQueue{
+		dir:      dir,
+		notifyCh: make(chan struct{}, 1),
+		inFlight: make(map[string]bool),
+		running:  make(map[string]bool),
+		retries:  make(map[string]queueRetry),
+	}, nil
+}
+
+// enqueue - writes ev to the queue, it is on disk once this returns.
+func (q *watchQueue) enqueue(ev queuedEvent) *probe.Error {
+	data, e := json.Marshal(ev)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	name := fmt.Sprintf("%020d-%s.json", ev.Time.UnixNano(), newRandomID(4))
+
+	// Events are written aside then renamed, partly written events are
+	// never read.
+	tmpFile := filepath.Join(q.dir, "."+name)
+	f, e := os.OpenFile(tmpFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if e != nil {
+		return probe.NewError(e).Trace(tmpFile)
+	}
+	if _, e = f.Write(data); e == nil {
+		e = f.Sync()
+	}
+	if ce := f.Close(); e == nil {
+		e = ce
+	}
+	if e == nil {
+		e = os.Rename(tmpFile, filepath.Join(q.dir, name))
+	}
+	if e != nil {
+		os.Remove(tmpFile)
+		return probe.NewError(e).Trace(tmpFile)
+	}
+
+	select {
+	case q.notifyCh <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// pending - returns the names of queued events, oldest first.
+func (q *watchQueue) pending() ([]string, *probe.Error) {
+	entries, e := ioutil.ReadDir(q.dir)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(q.dir)
+	}
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.Mode().IsRegular() && !strings.HasPrefix(name, ".") && strings.HasSuffix(name, ".json") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// read - reads the queued event name.
+func (q *watchQueue) read(name string) (queuedEvent, *probe.Error) {
+	var ev queuedEvent
+	data, e := ioutil.ReadFile(filepath.Join(q.dir, name))
+	if e != nil {
+		return ev, probe.NewError(e).Trace(name)
+	}
+	if e = json.Unmarshal(data, &ev); e != nil {
+		return ev, probe.NewError(e).Trace(name)
+	}
+	return ev, nil
+}
+
+// failed - delays the next try of the queued event name.
+func (q *watchQueue) failed(name string) time.Duration {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	retry := q.retries[name]
+	delay := watchQueueMinRetryDelay << uint(retry.failures)
+	if delay > watchQueueMaxRetryDelay || delay <= 0 {
+		delay = watchQueueMaxRetryDelay
+	}
+	retry.failures++
+	retry.next = UTCNow().Add(delay)
+	q.retries[name] = retry
+	return delay
+}
+
+// done - removes the queued event name once mirrored.
+func (q *watchQueue) done(name string) *probe.Error {
+	q.mutex.Lock()
+	delete(q.retries, name)
+	q.mutex.Unlock()
+	if e := os.Remove(filepath.Join(q.dir, name)); e != nil && !os.IsNotExist(e) {
+		return probe.NewError(e).Trace(name)
+	}
+	return nil
+}
+
+// next - returns the oldest queued event ready to be tried, events of
+// a target being mirrored wait for it to keep their order.
+func (q *watchQueue) next(names []string, events map[string]queuedEvent) (string, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	now := UTCNow()
+	waiting := make(map[string]bool)
+	for _, name := range names {
+		// Events already handed out or unreadable are not tried.
+		ev, ok := events[name]
+		if !ok || q.running[name] {
+			continue
+		}
+		if q.inFlight[ev.Target] || waiting[ev.Target] {
+			waiting[ev.Target] = true
+			continue
+		}
+		if retry, ok := q.retries[name]; ok && now.Before(retry.next) {
+			waiting[ev.Target] = true
+			continue
+		}
+		q.inFlight[ev.Target] = true
+		q.running[name] = true
+		return name, true
+	}
+	return "", false
+}
+
+// isRunning - returns true if the queued event name is being mirrored.
+func (q *watchQueue) isRunning(name string) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.running[name]
+}
+
+// release - marks the queued event name and its target as no longer
+// being mirrored.
+func (q *watchQueue) release(name string, ev queuedEvent) {
+	q.mutex.Lock()
+	delete(q.inFlight, ev.Target)
+	delete(q.running, name)
+	q.mutex.Unlock()
+	select {
+	case q.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// run - mirrors queued events with handle until ctx is done, failed
+// events are kept and tried again later.
+func (q *watchQueue) run(ctx context.Context, handle func(queuedEvent) *probe.Error) {
+	type job struct {
+		name string
+		ev   queuedEvent
+	}
+	jobCh := make(chan job)
+	var wg sync.WaitGroup
+	for i := 0; i < watchQueueWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobCh {
+				if err := handle(j.ev); err != nil {
+					delay := q.failed(j.name)
+					errorIf(err.Trace(j.ev.Source, j.ev.Target),
+						fmt.Sprintf("Failed to mirror `%s`, retrying in %s.", j.ev.Source, delay))
+				} else {
+					errorIf(q.done(j.name), "Unable to remove `"+j.name+"` from queue `"+q.dir+"`.")
+				}
+				q.release(j.name, j.ev)
+			}
+		}()
+	}
+	defer wg.Wait()
+	defer close(jobCh)
+
+	events := make(map[string]queuedEvent)
+	ticker := time.NewTicker(watchQueueScanInterval)
+	defer ticker.Stop()
+	for {
+		names, err := q.pending()
+		if err != nil {
+			errorIf(err, "Unable to read queue `"+q.dir+"`.")
+		}
+		for _, name := range names {
+			if _, ok := events[name]; ok || q.isRunning(name) {
+				continue
+			}
+			ev, err := q.read(name)
+			if err != nil {
+				errorIf(err, "Unable to read `"+name+"` from queue `"+q.dir+"`, skipping.")
+				continue
+			}
+			events[name] = ev
+		}
+		for {
+			name, ok := q.next(names, events)
+			if !ok {
+				break
+			}
+			ev := events[name]
+			delete(events, name)
+			select {
+			case jobCh <- job{name: name, ev: ev}:
+			case <-ctx.Done():
+				return
+			}
+		}
+		select {
+		case <-q.notifyCh:
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// doQueued - mirrors a queued event of the watched source, changes are
+// checked again as the event may be older than the source.
+func (mj *mirrorJob) doQueued(ctx context.Context, cancelMirror context.CancelFunc, ev queuedEvent) *probe.Error {
+	sourceAlias, expandedSourcePath, _ := mustExpandAlias(ev.Source)
+	targetAlias, expandedTargetPath, _ := mustExpandAlias(ev.Target)
+	mirrorURL := URLs{
+		SourceAlias:   sourceAlias,
+		SourceContent: &clientContent{URL: *newClientURL(expandedSourcePath)},
+		TargetAlias:   targetAlias,
+		TargetContent: &clientContent{URL: *newClientURL(expandedTargetPath)},
+		encKeyDB:      mj.encKeyDB,
+	}
+	mirrorURL.TotalCount = mj.TotalObjects
+	mirrorURL.TotalSize = mj.TotalBytes
+
+	if ev.Type == queuedRemove {
+		if !mj.isRemove {
+			return nil
+		}
+		mirrorURL.SourceContent = nil
+		urls := mj.doRemove(mirrorURL)
+		if urls.Error != nil {
+			return urls.Error
+		}
+		mj.statusCh <- urls
+		return nil
+	}
+
+	sourceClient, err := newClient(ev.Source)
+	if err != nil {
+		return err.Trace(ev.Source)
+	}
+	sourcePath := filepath.ToSlash(filepath.Join(sourceAlias, mirrorURL.SourceContent.URL.Path))
+	sourceContent, err := sourceClient.Stat(false, false, getSSE(sourcePath, mj.encKeyDB[sourceAlias]))
+	if err != nil {
+		switch err.ToGoError().(type) {
+		case PathNotFound, ObjectMissing:
+			// Removed since, its removal is queued as well.
+			return nil
+		}
+		return err.Trace(ev.Source)
+	}
+	if mj.overwrite != overwriteAlways {
+		targetClient, err := newClient(ev.Target)
+		if err != nil {
+			return err.Trace(ev.Target)
+		}
+		targetContent, err := targetClient.Stat(false, false, getSSE(ev.Target, mj.encKeyDB[targetAlias]))
+		if err == nil && !shouldOverwrite(mj.overwrite, sourceContent, targetContent) {
+			return nil
+		}
+	}
+	mirrorURL.SourceContent = sourceContent
+	mj.status.SetTotal(mj.status.Total() + sourceContent.Size).Update()
+	urls := mj.doMirror(ctx, cancelMirror, mirrorURL)
+	if urls.Error != nil {
+		return urls.Error
+	}
+	mj.statusCh <- urls
+	return nil
+}
@@ -0,0 +1,136 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestWatchQueueNext(t *testing.T) {
+	q := &watchQueue{
+		inFlight: make(map[string]bool),
+		running:  make(map[string]bool),
+		retries:  make(map[string]queueRetry),
+	}
+	names := []string{"1.json", "2.json", "3.json", "4.json"}
+	events := map[string]queuedEvent{
+		"2.json": {Type: queuedCreate, Source: "/data/a", Target: "s3/bucket/a"},
+		"3.json": {Type: queuedCreate, Source: "/data/a", Target: "s3/bucket/a"},
+		"4.json": {Type: queuedCreate, Source: "/data/b", Target: "s3/bucket/b"},
+	}
+
+	// 1.json is not read, 2.json is the oldest event.
+	if name, ok := q.next(names, events); !ok || name != "2.json" {
+		t.Fatalf("Expected 2.json, got %s", name)
+	}
+	delete(events, "2.json")
+	// 3.json waits for 2.json, both are for the same target.
+	if name, ok := q.next(names, events); !ok || name != "4.json" {
+		t.Fatalf("Expected 4.json, got %s", name)
+	}
+	// 2.json and 4.json are running, even if still listed.
+	events["4.json"] = queuedEvent{Type: queuedCreate, Source: "/data/b", Target: "s3/bucket/b"}
+	if name, ok := q.next(names, events); ok {
+		t.Fatalf("Expected no event, got %s", name)
+	}
+}
+
+func TestWatchQueueRun(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-queue-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	q, err := newWatchQueue(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := UTCNow()
+	queued := []queuedEvent{
+		{Type: queuedCreate, Source: "/data/a", Target: "s3/bucket/a", Time: now},
+		{Type: queuedCreate, Source: "/data/b", Target: "s3/bucket/b", Time: now.Add(time.Millisecond)},
+		{Type: queuedRemove, Source: "/data/a", Target: "s3/bucket/a", Time: now.Add(2 * time.Millisecond)},
+	}
+	for _, ev := range queued {
+		if err = q.enqueue(ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var mutex sync.Mutex
+	var handled []queuedEvent
+	failed := false
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		q.run(ctx, func(ev queuedEvent) *probe.Error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			// The first try of b fails, it is tried again later.
+			if ev.Target == "s3/bucket/b" && !failed {
+				failed = true
+				return probe.NewError(errors.New("unavailable"))
+			}
+			handled = append(handled, ev)
+			return nil
+		})
+	}()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		names, err := q.pending()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(names) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected all events mirrored, %d left", len(names))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	var a, b []queuedEvent
+	for _, ev := range handled {
+		if ev.Target == "s3/bucket/a" {
+			a = append(a, ev)
+		} else {
+			b = append(b, ev)
+		}
+	}
+	// Events of a target are mirrored once each in order.
+	if expected := []queuedEvent{queued[0], queued[2]}; !reflect.DeepEqual(a, expected) {
+		t.Fatalf("Expected %v, got %v", expected, a)
+	}
+	if len(b) != 1 || b[0] != queued[1] {
+		t.Fatalf("Expected %v, got %v", queued[1:2], b)
+	}
+}
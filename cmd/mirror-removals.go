@@ -0,0 +1,107 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "path/filepath"
+
+// mirrorRemovals - removals of a mirror held back while they are
+// counted during the run, rather than by comparing source and target
+// beforehand. They are done once the listing ends within the
+// confirmation threshold, past it once confirmed after the transfers.
+type mirrorRemovals struct {
+	threshold int64
+	held      []URLs
+	preview   removalPreview
+	// Set once the listing ended past the threshold.
+	isPending bool
+}
+
+// hold - holds the removal of sURLs back until the listing ends.
+func (r *mirrorRemovals) hold(sURLs URLs) {
+	r.preview.add(sURLs.TargetContent)
+	r.held = append(r.held, sURLs)
+}
+
+// isExceeded - returns true if more removals than the threshold are
+// held back.
+func (r *mirrorRemovals) isExceeded() bool {
+	return r != nil && r.preview.count > r.threshold
+}
+
+// needsConfirmation - returns true if the listing ended with removals
+// waiting for a confirmation.
+func (r *mirrorRemovals) needsConfirmation() bool {
+	return r != nil && r.isPending
+}
+
+// queueRemoval - removes the target of sURLs, on publishing if atomic.
+func (mj *mirrorJob) queueRemoval(sURLs URLs) {
+	if mj.atomic != nil {
+		mj.atomic.remove(sURLs)
+		return
+	}
+	mj.queueCh <- func() URLs {
+		return mj.doRemove(sURLs)
+	}
+}
+
+// releaseRemovals - queues the removals held back once the listing
+// ended, those past the threshold are left pending.
+func (mj *mirrorJob) releaseRemovals() {
+	if mj.removals == nil {
+		return
+	}
+	if mj.removals.isExceeded() {
+		mj.removals.isPending = true
+		return
+	}
+	for _, sURLs := range mj.removals.held {
+		mj.queueRemoval(sURLs)
+	}
+	mj.removals.held = nil
+}
+
+// removePending - does the removals confirmed once the transfers ended,
+// with a worker per CPU. Returns true if any of them failed.
+func (mj *mirrorJob) removePending() (errDuringRemoval bool) {
+	resultCh := make(chan URLs)
+	parallel, queueCh := newParallelManager(resultCh, 0)
+	go func() {
+		for _, sURLs := range mj.removals.held {
+			sURLs := sURLs
+			queueCh <- func() URLs {
+				return mj.doRemove(sURLs)
+			}
+		}
+		close(queueCh)
+		parallel.wait()
+		close(resultCh)
+	}()
+	for sURLs := range resultCh {
+		if err := sURLs.Error; err != nil {
+			errorIf(err.Trace(sURLs.TargetContent.URL.String()),
+				"Failed to remove `"+sURLs.TargetContent.URL.String()+"`.")
+			errDuringRemoval = true
+			continue
+		}
+		targetPath := filepath.ToSlash(filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path))
+		printMsg(rmMessage{Key: targetPath, Size: sURLs.TargetContent.Size})
+	}
+	mj.removals.held = nil
+	mj.removals.isPending = false
+	return errDuringRemoval
+}
@@ -0,0 +1,131 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestMirrorRemovalsHold(t *testing.T) {
+	removal := URLs{TargetContent: &clientContent{Size: 10}}
+
+	testCases := []struct {
+		threshold int64
+		removals  int
+		exceeded  bool
+	}{
+		{1000, 0, false},
+		{3, 3, false},
+		// All are held and counted past the threshold.
+		{3, 4, true},
+		{0, 1, true},
+	}
+	for i, testCase := range testCases {
+		r := &mirrorRemovals{threshold: testCase.threshold}
+		for j := 0; j < testCase.removals; j++ {
+			r.hold(removal)
+		}
+		if len(r.held) != testCase.removals {
+			t.Fatalf("Test %d: expected %d removal(s) held, got %d", i+1, testCase.removals, len(r.held))
+		}
+		if r.preview.count != int64(testCase.removals) || r.preview.size != int64(testCase.removals)*10 {
+			t.Fatalf("Test %d: expected %d removal(s) counted, got %d of %d bytes", i+1, testCase.removals, r.preview.count, r.preview.size)
+		}
+		if r.isExceeded() != testCase.exceeded {
+			t.Fatalf("Test %d: expected exceeded to be %t, got %t", i+1, testCase.exceeded, r.isExceeded())
+		}
+	}
+	var r *mirrorRemovals
+	if r.isExceeded() || r.needsConfirmation() {
+		t.Fatalf("Expected no removals held back without a threshold")
+	}
+}
+
+func TestMirrorRemovePendingReport(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-mirror-remove")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	// Target is local, no alias is configured.
+	defer func(load func() (*configV9, *probe.Error)) { loadMcConfig = load }(loadMcConfig)
+	loadMcConfig = func() (*configV9, *probe.Error) { return newMcConfig(), nil }
+
+	report, err := newDeletionReport(filepath.Join(dir, "deleted.json"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := filepath.Join(dir, "target")
+	if e = os.Mkdir(target, 0700); e != nil {
+		t.Fatal(e)
+	}
+	mj := &mirrorJob{report: report, removals: &mirrorRemovals{threshold: 1}}
+	for _, name := range []string{"a.txt", "b.txt", "missing.txt"} {
+		path := filepath.Join(target, name)
+		if name != "missing.txt" {
+			if e = ioutil.WriteFile(path, []byte(name), 0600); e != nil {
+				t.Fatal(e)
+			}
+		}
+		mj.removals.hold(URLs{TargetContent: &clientContent{URL: *newClientURL(path), Size: int64(len(name))}})
+	}
+
+	// Past the threshold nothing is removed until confirmed.
+	mj.releaseRemovals()
+	if !mj.removals.needsConfirmation() || len(mj.removals.held) != 3 {
+		t.Fatalf("Expected 3 removals pending a confirmation, got %d", len(mj.removals.held))
+	}
+	if _, e = os.Stat(filepath.Join(target, "a.txt")); e != nil {
+		t.Fatalf("Expected `a.txt` to be kept until confirmed: %v", e)
+	}
+
+	// The object missing fails to be removed and is not reported.
+	if !mj.removePending() {
+		t.Fatalf("Expected the removal of `missing.txt` to fail")
+	}
+	if err = report.close(); err != nil {
+		t.Fatal(err)
+	}
+	data, e := ioutil.ReadFile(filepath.Join(dir, "deleted.json"))
+	if e != nil {
+		t.Fatal(e)
+	}
+	var result struct {
+		Objects []deletionEntry `json:"objects"`
+	}
+	if e = json.Unmarshal(data, &result); e != nil {
+		t.Fatalf("Report is not valid JSON: %v\n%s", e, data)
+	}
+	if len(result.Objects) != 2 {
+		t.Fatalf("Expected 2 objects reported, got %s", data)
+	}
+	for _, entry := range result.Objects {
+		if filepath.Base(entry.Key) == "missing.txt" {
+			t.Fatalf("Expected `missing.txt` not to be reported, got %s", data)
+		}
+		if _, e = os.Stat(entry.Key); !os.IsNotExist(e) {
+			t.Fatalf("Expected `%s` to be removed", entry.Key)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+const (
+	// Delay before the first retry of a failed transfer, doubled on each
+	// retry up to mirrorRetryMaxDelay.
+	mirrorRetryMinDelay = time.Second
+	mirrorRetryMaxDelay = time.Minute
+)
+
+// isRetryable - returns true if err may not happen again, such as
+// network errors, timeouts, throttling and server errors.
+func isRetryable(err *probe.Error) bool {
+	if err == nil {
+		return false
+	}
+	if err.ToGoError() == io.ErrUnexpectedEOF {
+		return true
+	}
+	switch errorCode(err) {
+	case errCodeSlowDown, errCodeNetworkError, errCodeTimeout, errCodeServerError:
+		return true
+	}
+	return false
+}
+
+// retryDelay - returns the delay before retry number attempt, starting
+// at 0, with a random jitter of up to half of it.
+func retryDelay(attempt int) time.Duration {
+	delay := mirrorRetryMinDelay << uint(attempt)
+	if delay > mirrorRetryMaxDelay || delay <= 0 {
+		delay = mirrorRetryMaxDelay
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// retryCounter - a progress hook counting the bytes read through it, to
+// take them back from progress when the transfer is tried again.
+type retryCounter struct {
+	hook  io.Reader
+	bytes int64
+}
+
+// Read implements io.Reader.
+func (r *retryCounter) Read(p []byte) (int, error) {
+	atomic.AddInt64(&r.bytes, int64(len(p)))
+	return r.hook.Read(p)
+}
+
+// withRetries - runs transfer up to retries more times while it fails
+// with a retryable error, waiting for retryDelay in between. Bytes of
+// failed tries are taken back from the progress of mj.
+func (mj *mirrorJob) withRetries(ctx context.Context, sURLs URLs, transfer func(progress io.Reader) URLs) URLs {
+	for attempt := 0; ; attempt++ {
+		counter := &retryCounter{hook: mj.status}
+		urls := transfer(counter)
+		if urls.Error == nil || attempt >= mj.retries || !isRetryable(urls.Error) {
+			return urls
+		}
+		mj.status.Add(-atomic.LoadInt64(&counter.bytes))
+
+		delay := retryDelay(attempt)
+		errorIf(urls.Error.Trace(sURLs.SourceContent.URL.String()),
+			fmt.Sprintf("Failed to copy `%s`, retrying in %s (%d/%d).", sURLs.SourceContent.URL.String(), delay, attempt+1, mj.retries))
+		select {
+		case <-ctx.Done():
+			return urls
+		case <-time.After(delay):
+		}
+	}
+}
@@ -0,0 +1,66 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+func TestIsRetryable(t *testing.T) {
+	testCases := []struct {
+		err       *probe.Error
+		retryable bool
+	}{
+		{nil, false},
+		{probe.NewError(io.ErrUnexpectedEOF), true},
+		{probe.NewError(minio.ErrorResponse{Code: "SlowDown", StatusCode: http.StatusServiceUnavailable}), true},
+		{probe.NewError(minio.ErrorResponse{Code: "InternalError", StatusCode: http.StatusInternalServerError}), true},
+		{probe.NewError(minio.ErrorResponse{Code: "AccessDenied", StatusCode: http.StatusForbidden}), false},
+		{probe.NewError(ObjectMissing{}), false},
+		{probe.NewError(errors.New("unknown")), false},
+	}
+	for i, testCase := range testCases {
+		if retryable := isRetryable(testCase.err); retryable != testCase.retryable {
+			t.Fatalf("Test %d: expected %t, got %t", i+1, testCase.retryable, retryable)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	testCases := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{0, mirrorRetryMinDelay / 2, mirrorRetryMinDelay},
+		{1, mirrorRetryMinDelay, 2 * mirrorRetryMinDelay},
+		{3, 4 * mirrorRetryMinDelay, 8 * mirrorRetryMinDelay},
+		{20, mirrorRetryMaxDelay / 2, mirrorRetryMaxDelay},
+		{100, mirrorRetryMaxDelay / 2, mirrorRetryMaxDelay},
+	}
+	for i, testCase := range testCases {
+		if delay := retryDelay(testCase.attempt); delay < testCase.min || delay > testCase.max {
+			t.Fatalf("Test %d: expected a delay between %s and %s, got %s", i+1, testCase.min, testCase.max, delay)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Layout of the prefix each snapshot is written under.
+const snapshotTimeFormat = "2006-01-02T15:04:05Z"
+
+// snapshotName - returns the prefix of a snapshot taken at t.
+func snapshotName(t time.Time) string {
+	return t.UTC().Format(snapshotTimeFormat)
+}
+
+// latestSnapshot - returns the URL of the most recent snapshot found
+// under targetURL, an empty string if there is none yet.
+func latestSnapshot(targetURL string) (string, *probe.Error) {
+	clnt, err := newClient(targetURL)
+	if err != nil {
+		return "", err.Trace(targetURL)
+	}
+
+	var latest time.Time
+	var latestName string
+	isRecursive := false
+	isIncomplete := false
+	for content := range clnt.List(isRecursive, isIncomplete, DirNone) {
+		if content.Err != nil {
+			switch content.Err.ToGoError().(type) {
+			case PathNotFound, BucketDoesNotExist:
+				// No snapshot taken yet.
+				return "", nil
+			}
+			return "", content.Err.Trace(targetURL)
+		}
+		if !content.Type.IsDir() {
+			continue
+		}
+		name := path.Base(strings.TrimSuffix(content.URL.Path, string(content.URL.Separator)))
+		t, e := time.Parse(snapshotTimeFormat, name)
+		if e != nil {
+			// Not a snapshot, ignore.
+			continue
+		}
+		if t.After(latest) {
+			latest = t
+			latestName = name
+		}
+	}
+	if latestName == "" {
+		return "", nil
+	}
+	return urlJoinPath(targetURL, latestName), nil
+}
+
+// deltaSourceSnapshot - compares the source with the previous snapshot,
+// new and modified objects are copied from the source while unchanged
+// objects are copied from the previous snapshot, which is a server side
+// copy when both snapshots live on the same server.
+func deltaSourceSnapshot(sourceURL, snapshotURL, targetURL string, excludeOptions, includeOptions []string, keyEnc keyEncoder, URLsCh chan<- URLs) {
+	// source and snapshots are always directories
+	sourceSeparator := string(newClientURL(sourceURL).Separator)
+	if !strings.HasSuffix(sourceURL, sourceSeparator) {
+		sourceURL = sourceURL + sourceSeparator
+	}
+	targetSeparator := string(newClientURL(targetURL).Separator)
+	if !strings.HasSuffix(snapshotURL, targetSeparator) {
+		snapshotURL = snapshotURL + targetSeparator
+	}
+	if !strings.HasSuffix(targetURL, targetSeparator) {
+		targetURL = targetURL + targetSeparator
+	}
+
+	// Extract alias and expanded URL
+	sourceAlias, sourceURL, _ := mustExpandAlias(sourceURL)
+	snapshotAlias, snapshotURL, _ := mustExpandAlias(snapshotURL)
+	targetAlias, targetURL, _ := mustExpandAlias(targetURL)
+
+	defer close(URLsCh)
+
+	sourceClnt, err := newClientFromAlias(sourceAlias, sourceURL)
+	if err != nil {
+		URLsCh <- URLs{Error: err.Trace(sourceAlias, sourceURL)}
+		return
+	}
+
+	snapshotClnt, err := newClientFromAlias(snapshotAlias, snapshotURL)
+	if err != nil {
+		URLsCh <- URLs{Error: err.Trace(snapshotAlias, snapshotURL)}
+		return
+	}
+
+	for diffMsg := range snapshotDifference(sourceClnt, snapshotClnt, sourceURL, snapshotURL, keyEnc) {
+		if diffMsg.Error != nil {
+			// Send all errors through the channel
+			URLsCh <- URLs{Error: diffMsg.Error}
+			return
+		}
+
+		srcSuffix := strings.TrimPrefix(diffMsg.FirstURL, sourceURL)
+		//Skip the source object if it is excluded or not included
+		if isMirrorExcluded(excludeOptions, includeOptions, srcSuffix) {
+			continue
+		}
+
+		switch diffMsg.Diff {
+		case differInNone:
+			// Unchanged, reference the object of the previous snapshot.
+			snapshotSuffix := strings.TrimPrefix(diffMsg.SecondURL, snapshotURL)
+			URLsCh <- URLs{
+				SourceAlias:   snapshotAlias,
+				SourceContent: diffMsg.secondContent,
+				TargetAlias:   targetAlias,
+				TargetContent: &clientContent{URL: *newClientURL(urlJoinPath(targetURL, snapshotSuffix))},
+			}
+		case differInType:
+			URLsCh <- URLs{Error: errInvalidTarget(diffMsg.SecondURL)}
+		case differInSize, differInTime, differInFirst:
+			// New or modified since the previous snapshot.
+			targetPath := urlJoinPath(targetURL, keyEnc.translate(srcSuffix, sourceClnt.GetURL().Type, snapshotClnt.GetURL().Type))
+			URLsCh <- URLs{
+				SourceAlias:   sourceAlias,
+				SourceContent: diffMsg.firstContent,
+				TargetAlias:   targetAlias,
+				TargetContent: &clientContent{URL: *newClientURL(targetPath)},
+			}
+		case differInSecond:
+			// Removed from source, left out of the new snapshot.
+		default:
+			URLsCh <- URLs{
+				Error: errUnrecognizedDiffType(diffMsg.Diff).Trace(diffMsg.FirstURL, diffMsg.SecondURL),
+			}
+		}
+	}
+}
+
+// Prepares urls for a snapshot based on the previous snapshot.
+func prepareSnapshotURLs(sourceURL, snapshotURL, targetURL string, excludeOptions, includeOptions []string, keyEnc keyEncoder) <-chan URLs {
+	URLsCh := make(chan URLs)
+	go deltaSourceSnapshot(sourceURL, snapshotURL, targetURL, excludeOptions, includeOptions, keyEnc, URLsCh)
+	return URLsCh
+}
@@ -18,9 +18,12 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio/pkg/wildcard"
 )
 
@@ -31,14 +34,14 @@ import (
 
 // checkMirrorSyntax(URLs []string)
 func checkMirrorSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
-	if len(ctx.Args()) != 2 {
+	URLs := ctx.Args()
+	if len(URLs) < 2 || (len(URLs) > 2 && !ctx.Bool("merge")) {
 		cli.ShowCommandHelpAndExit(ctx, "mirror", 1) // last argument is exit code.
 	}
 
-	// extract URLs.
-	URLs := ctx.Args()
-	srcURL := URLs[0]
-	tgtURL := URLs[1]
+	// extract URLs, with --merge all but the last one are sources.
+	srcURLs := URLs[:len(URLs)-1]
+	tgtURL := URLs[len(URLs)-1]
 
 	if ctx.Bool("force") && ctx.Bool("remove") {
 		errorIf(errInvalidArgument().Trace(URLs...), "`--force` is deprecated please use `--overwrite` instead with `--remove` for the same functionality.")
@@ -46,6 +49,107 @@ func checkMirrorSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 		errorIf(errInvalidArgument().Trace(URLs...), "`--force` is deprecated please use `--overwrite` instead for the same functionality.")
 	}
 
+	if folderMarkers := ctx.String("folder-markers"); !isValidFolderMarkers(folderMarkers) {
+		fatalIf(errInvalidArgument().Trace(folderMarkers), "Unknown folder markers mode `"+folderMarkers+"`, must be one of ignore, directory or verbatim.")
+	}
+
+	if overwrite := ctx.String("overwrite-mode"); overwrite != "" && !isValidOverwrite(overwrite) {
+		fatalIf(errInvalidArgument().Trace(overwrite), "Unknown overwrite mode `"+overwrite+"`, must be one of never, always, if-newer or if-different.")
+	}
+
+	if acl := ctx.String("acl"); acl != "" && !isValidCannedACL(acl) {
+		fatalIf(errInvalidArgument().Trace(acl), "Unknown canned ACL `"+acl+"`, must be one of "+strings.Join(cannedACLs, ", ")+".")
+	}
+
+	fatalIf(checkContentEncoding(ctx.String("compress")), "Unable to validate compression.")
+	fatalIf(checkChecksumAlgorithm(ctx.String("checksum")), "Unable to validate checksum algorithm.")
+	fatalIf(checkUploadLimits(ctx.String("limit-upload")), "Unable to parse ‘--limit-upload’.")
+	fatalIf(checkBandwidthLimit(ctx.String("limit-download")), "Unable to parse ‘--limit-download’.")
+	fatalIf(checkBandwidthSchedule(ctx.String("limit-schedule")), "Unable to parse ‘--limit-schedule’.")
+	_, err := parseMaxDuration(ctx.String("max-duration"))
+	fatalIf(err, "Unable to parse ‘--max-duration’.")
+	_, err = parseMaxBytes(ctx.String("max-bytes"))
+	fatalIf(err, "Unable to parse ‘--max-bytes’.")
+	fatalIf(checkAgeFilters(ctx.String("older-than"), ctx.String("newer-than")), "Unable to validate ‘--older-than’ and ‘--newer-than’.")
+
+	if n := ctx.Int("retry"); n < 0 {
+		fatalIf(errInvalidArgument().Trace(strconv.Itoa(n)), "`--retry` cannot be negative.")
+	}
+
+	checkWorkersSyntax(ctx)
+
+	if ctx.Bool("snapshot") && (ctx.Bool("watch") || ctx.Bool("remove")) {
+		fatalIf(errInvalidArgument().Trace(URLs...), "`--snapshot` cannot be used with `--watch` or `--remove`.")
+	}
+
+	if ctx.IsSet("watch-interval") {
+		if !ctx.Bool("watch") {
+			fatalIf(errInvalidArgument().Trace(URLs...), "`--watch-interval` sets how often sources are listed for changes, it needs `--watch`.")
+		}
+		interval, e := time.ParseDuration(ctx.String("watch-interval"))
+		fatalIf(probe.NewError(e), "Unable to parse `--watch-interval`.")
+		if interval <= 0 {
+			fatalIf(errInvalidArgument().Trace(ctx.String("watch-interval")), "`--watch-interval` should be positive.")
+		}
+	}
+
+	if ctx.String("queue-dir") != "" && !ctx.Bool("watch") {
+		fatalIf(errInvalidArgument().Trace(URLs...), "`--queue-dir` queues the changes watched, it needs `--watch`.")
+	}
+
+	if ctx.Bool("manifest") && ctx.Bool("watch") {
+		fatalIf(errInvalidArgument().Trace(URLs...), "`--manifest` is written once the mirror ends, it cannot be used with `--watch`.")
+	}
+
+	if ctx.Bool("atomic") && (ctx.Bool("watch") || ctx.Bool("snapshot")) {
+		fatalIf(errInvalidArgument().Trace(URLs...), "`--atomic` cannot be used with `--watch` or `--snapshot`.")
+	}
+
+	if ctx.String("priority-from") != "" && (ctx.Bool("merge") || ctx.Bool("snapshot")) {
+		fatalIf(errInvalidArgument().Trace(URLs...), "`--priority-from` cannot be used with `--merge` or `--snapshot`.")
+	}
+
+	if ctx.Bool("dry-run") && ctx.Bool("watch") {
+		fatalIf(errInvalidArgument().Trace(URLs...), "`--dry-run` prints the object(s) to mirror once, it cannot be used with `--watch`.")
+	}
+
+	if ctx.Bool("no-list-target") && ctx.Bool("remove") {
+		fatalIf(errInvalidArgument().Trace(URLs...), "`--remove` needs to list the target, it cannot be used with `--no-list-target`.")
+	}
+
+	if ctx.Bool("remove") {
+		checkProtected(ctx, "--remove", tgtURL)
+	} else if ctx.Bool("force") {
+		checkProtected(ctx, "--force", tgtURL)
+	}
+
+	if ctx.String("report") != "" && !ctx.Bool("remove") {
+		fatalIf(errInvalidArgument().Trace(URLs...), "`--report` lists the object(s) removed, it needs `--remove`.")
+	}
+
+	if ctx.Bool("merge") {
+		if len(srcURLs) < 2 {
+			fatalIf(errInvalidArgument().Trace(URLs...), "`--merge` needs at least two sources.")
+		}
+		if ctx.Bool("watch") || ctx.Bool("remove") || ctx.Bool("snapshot") || ctx.String("inventory") != "" {
+			fatalIf(errInvalidArgument().Trace(URLs...), "`--merge` cannot be used with `--watch`, `--remove`, `--snapshot` or `--inventory`.")
+		}
+	}
+
+	at, err := parsePointInTime(ctx.String("at"))
+	fatalIf(err.Trace(ctx.String("at")), "Unable to parse ‘--at’.")
+	if !at.IsZero() && (ctx.Bool("watch") || ctx.String("inventory") != "" || ctx.Bool("compare-checksum")) {
+		fatalIf(errInvalidArgument().Trace(URLs...), "`--at` cannot be used with `--watch`, `--inventory` or `--compare-checksum`.")
+	}
+
+	if policy := ctx.String("special-files"); !isValidSpecialFilesPolicy(policy) {
+		fatalIf(errInvalidArgument().Trace(policy), "Unknown special files policy `"+policy+"`, must be one of skip or error.")
+	}
+
+	if policy := ctx.String("on-collision"); !isValidCollisionPolicy(policy) {
+		fatalIf(errInvalidArgument().Trace(policy), "Unknown collision policy `"+policy+"`, must be one of first, newest or error.")
+	}
+
 	tgtClientURL := newClientURL(tgtURL)
 	if tgtClientURL.Host != "" {
 		if tgtClientURL.Path == string(tgtClientURL.Separator) {
@@ -56,21 +160,23 @@ func checkMirrorSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 
 	/****** Generic rules *******/
 	if !ctx.Bool("watch") {
-		c, srcContent, err := url2Stat(srcURL, false, encKeyDB)
-		// incomplete uploads are not necessary for copy operation, no need to verify for them.
-		isIncomplete := false
-		if err != nil && !isURLPrefixExists(srcURL, isIncomplete) {
-			errorIf(err.Trace(srcURL), "Unable to stat source `"+srcURL+"`.")
-		}
-
-		if err == nil {
-			if !srcContent.Type.IsDir() {
-				fatalIf(errInvalidArgument().Trace(srcContent.URL.String(), srcContent.Type.String()), fmt.Sprintf("Source `%s` is not a folder. Only folders are supported by mirror command.", srcURL))
+		for _, srcURL := range srcURLs {
+			c, srcContent, err := url2StatAt(srcURL, at, false, encKeyDB)
+			// incomplete uploads are not necessary for copy operation, no need to verify for them.
+			isIncomplete := false
+			if err != nil && (!at.IsZero() || !isURLPrefixExists(srcURL, isIncomplete)) {
+				errorIf(err.Trace(srcURL), "Unable to stat source `"+srcURL+"`.")
 			}
 
-			// Disallow mirroring a directory to itself
-			if isURLContains(srcURL, tgtURL, string(c.GetURL().Separator)) {
-				fatalIf(errInvalidArgument().Trace(), "Mirroring a folder into itself is not allowed.")
+			if err == nil {
+				if !srcContent.Type.IsDir() {
+					fatalIf(errInvalidArgument().Trace(srcContent.URL.String(), srcContent.Type.String()), fmt.Sprintf("Source `%s` is not a folder. Only folders are supported by mirror command.", srcURL))
+				}
+
+				// Disallow mirroring a directory to itself
+				if isURLContains(srcURL, tgtURL, string(c.GetURL().Separator)) {
+					fatalIf(errInvalidArgument().Trace(), "Mirroring a folder into itself is not allowed.")
+				}
 			}
 		}
 	}
@@ -86,7 +192,45 @@ func matchExcludeOptions(excludeOptions []string, srcSuffix string) bool {
 	return false
 }
 
-func deltaSourceTarget(sourceURL, targetURL string, isFake, isOverwrite, isRemove bool, excludeOptions []string, URLsCh chan<- URLs, encKeyDB map[string][]prefixSSEPair) {
+// isMirrorExcluded - reports if the object at suffix is left out of the
+// mirror: it matches an exclude pattern, or include patterns are given
+// and it matches none of them. Objects left out are never copied nor
+// removed.
+func isMirrorExcluded(excludeOptions, includeOptions []string, suffix string) bool {
+	// Objects only on one side have no suffix on the other.
+	if suffix == "" {
+		return false
+	}
+	if matchExcludeOptions(excludeOptions, suffix) {
+		return true
+	}
+	return len(includeOptions) > 0 && !matchExcludeOptions(includeOptions, suffix)
+}
+
+// mirrorOptions - options of prepareMirrorURLs.
+type mirrorOptions struct {
+	isFake, isRemove bool
+	overwrite        string
+	excludeOptions   []string
+	includeOptions   []string
+	folderMarkers    string
+	// targets are checked object by object instead of being listed.
+	noListTarget bool
+	// objects of the same size are compared by checksum.
+	compareChecksum bool
+	// folders of object storage listed in parallel, 0 for the default.
+	listWorkers int
+	// local files restored with --preserve are not copied again.
+	preserve bool
+	keyEnc   keyEncoder
+	// inventory report listing the source or target bucket, if any.
+	inventory *inventoryManifest
+	// point in time the source is read as of, zero for its latest state.
+	at       time.Time
+	encKeyDB map[string][]prefixSSEPair
+}
+
+func deltaSourceTarget(sourceURL, targetURL string, opts mirrorOptions, URLsCh chan<- URLs) {
 	// source and targets are always directories
 	sourceSeparator := string(newClientURL(sourceURL).Separator)
 	if !strings.HasSuffix(sourceURL, sourceSeparator) {
@@ -104,6 +248,9 @@ func deltaSourceTarget(sourceURL, targetURL string, isFake, isOverwrite, isRemov
 	defer close(URLsCh)
 
 	sourceClnt, err := newClientFromAlias(sourceAlias, sourceURL)
+	if err == nil {
+		sourceClnt, err = withPointInTime(sourceClnt, opts.at)
+	}
 	if err != nil {
 		URLsCh <- URLs{Error: err.Trace(sourceAlias, sourceURL)}
 		return
@@ -115,8 +262,71 @@ func deltaSourceTarget(sourceURL, targetURL string, isFake, isOverwrite, isRemov
 		return
 	}
 
-	// List both source and target, compare and return values through channel.
-	for diffMsg := range objectDifference(sourceClnt, targetClnt, sourceURL, targetURL) {
+	// Whichever side the inventory report is for is listed from it.
+	sourceClnt, targetClnt = withInventory(sourceClnt, targetClnt, opts.inventory)
+	setListWorkers(sourceClnt, opts.listWorkers)
+	setListWorkers(targetClnt, opts.listWorkers)
+
+	// Objects of the same size are told apart by their checksums, the
+	// comparisons left are waited for before URLsCh is closed.
+	var checksums *checksumComparer
+	if opts.compareChecksum {
+		checksums = newChecksumComparer(sourceAlias, targetAlias, opts.encKeyDB)
+		defer checksums.close()
+	}
+
+	// List both source and target, compare and return values through
+	// channel. Unchanged objects are only needed to replace them all,
+	// or to compare their checksums. Targets not listed are checked
+	// object by object instead.
+	returnSimilar := opts.overwrite == overwriteAlways || opts.compareChecksum
+	var diffCh chan diffMessage
+	if opts.noListTarget {
+		diffCh = headDifference(sourceClnt, targetAlias, sourceURL, targetURL, targetClnt.GetURL().Type, returnSimilar, opts.keyEnc, opts.encKeyDB[targetAlias])
+	} else if returnSimilar {
+		diffCh = snapshotDifference(sourceClnt, targetClnt, sourceURL, targetURL, opts.keyEnc)
+	} else {
+		diffCh = objectDifference(sourceClnt, targetClnt, sourceURL, targetURL, opts.keyEnc)
+	}
+
+	// Sends the source differing from its target to be copied if it
+	// is replaced, checksumDiffers is set for objects of the same size
+	// whose checksums differ.
+	sendChanged := func(diffMsg diffMessage, checksumDiffers bool) {
+		// Local files restored with --preserve are older than their
+		// objects, they are not copied again.
+		if diffMsg.Diff == differInTime && opts.preserve && isPreservedFile(sourceAlias, diffMsg.firstContent, diffMsg.secondContent, opts.encKeyDB[sourceAlias]) {
+			return
+		}
+		// Objects compressed on upload, or decompressed on download,
+		// differ in size from their copies.
+		if diffMsg.Diff == differInSize && isDecompressedCopy(sourceAlias, targetAlias, diffMsg.firstContent, diffMsg.secondContent, opts.encKeyDB) {
+			return
+		}
+		if opts.overwrite == "" && !opts.isFake {
+			// Size, time or checksum differs but --overwrite not set.
+			URLsCh <- URLs{Error: errOverWriteNotAllowed(diffMsg.SecondURL)}
+			return
+		}
+		if opts.overwrite != "" && !shouldOverwrite(opts.overwrite, diffMsg.firstContent, diffMsg.secondContent) &&
+			!(checksumDiffers && opts.overwrite == overwriteIfDifferent) {
+			return
+		}
+
+		sourceSuffix := strings.TrimPrefix(diffMsg.FirstURL, sourceURL)
+		// Either available only in source or size differs and force is set
+		targetPath := urlJoinPath(targetURL, opts.keyEnc.translate(sourceSuffix, sourceClnt.GetURL().Type, targetClnt.GetURL().Type))
+		sourceContent := diffMsg.firstContent
+		targetContent := &clientContent{URL: *newClientURL(targetPath)}
+		URLsCh <- URLs{
+			SourceAlias:   sourceAlias,
+			SourceContent: sourceContent,
+			TargetAlias:   targetAlias,
+			TargetContent: targetContent,
+		}
+	}
+
+	for diffMsg := range diffCh {
 		if diffMsg.Error != nil {
 			// Send all errors through the channel
 			URLsCh <- URLs{Error: diffMsg.Error}
@@ -124,44 +334,71 @@ func deltaSourceTarget(sourceURL, targetURL string, isFake, isOverwrite, isRemov
 		}
 
 		srcSuffix := strings.TrimPrefix(diffMsg.FirstURL, sourceURL)
-		//Skip the source object if it matches the Exclude options provided
-		if matchExcludeOptions(excludeOptions, srcSuffix) {
+		//Skip the source object if it is excluded or not included
+		if isMirrorExcluded(opts.excludeOptions, opts.includeOptions, srcSuffix) {
 			continue
 		}
 
 		tgtSuffix := strings.TrimPrefix(diffMsg.SecondURL, targetURL)
-		//Skip the target object if it matches the Exclude options provided
-		if matchExcludeOptions(excludeOptions, tgtSuffix) {
+		//Skip the target object if it is excluded or not included
+		if isMirrorExcluded(opts.excludeOptions, opts.includeOptions, tgtSuffix) {
 			continue
 		}
 
-		switch diffMsg.Diff {
-		case differInNone:
-			// No difference, continue.
-		case differInType:
-			URLsCh <- URLs{Error: errInvalidTarget(diffMsg.SecondURL)}
-		case differInSize, differInTime:
-			if !isOverwrite && !isFake {
-				// Size or time differs but --overwrite not set.
-				URLsCh <- URLs{Error: errOverWriteNotAllowed(diffMsg.SecondURL)}
+		// Special files are never mirrored, targets of the same name are kept.
+		if diffMsg.firstContent != nil {
+			if kind := specialFileKind(diffMsg.firstContent.Type); kind != "" {
+				URLsCh <- URLs{SourceContent: diffMsg.firstContent, Error: probe.NewError(SpecialFile{Path: diffMsg.FirstURL, Kind: kind})}
 				continue
 			}
+		}
 
-			sourceSuffix := strings.TrimPrefix(diffMsg.FirstURL, sourceURL)
-			// Either available only in source or size differs and force is set
-			targetPath := urlJoinPath(targetURL, sourceSuffix)
-			sourceContent := diffMsg.firstContent
-			targetContent := &clientContent{URL: *newClientURL(targetPath)}
-			URLsCh <- URLs{
-				SourceAlias:   sourceAlias,
-				SourceContent: sourceContent,
-				TargetAlias:   targetAlias,
-				TargetContent: targetContent,
+		if opts.folderMarkers != folderMarkersVerbatim && (isFolderMarker(diffMsg.firstContent) || isFolderMarker(diffMsg.secondContent)) {
+			if opts.folderMarkers == folderMarkersIgnore {
+				continue
+			}
+			switch diffMsg.Diff {
+			case differInFirst:
+				// Create the folder only if missing on target.
+				targetPath := urlJoinPath(targetURL, opts.keyEnc.translate(srcSuffix, sourceClnt.GetURL().Type, targetClnt.GetURL().Type))
+				if folderExists(targetAlias, targetPath) {
+					continue
+				}
+			case differInSecond:
+				// Remove the marker only if the folder is missing on source.
+				sourcePath := urlJoinPath(sourceURL, opts.keyEnc.translate(tgtSuffix, targetClnt.GetURL().Type, sourceClnt.GetURL().Type))
+				if folderExists(sourceAlias, sourcePath) {
+					continue
+				}
+			default:
+				// The folder exists on both sides.
+				continue
+			}
+		}
+
+		switch diffMsg.Diff {
+		case differInNone:
+			// No difference, only copied again with --overwrite always
+			// or if checksums differ, compared by the workers of
+			// checksums while listing goes on.
+			if opts.overwrite == overwriteAlways {
+				sendChanged(diffMsg, false)
+			} else if checksums != nil {
+				diffMsg := diffMsg
+				checksums.compare(diffMsg.firstContent, diffMsg.secondContent, func(differs bool) {
+					if differs {
+						sendChanged(diffMsg, true)
+					}
+				})
 			}
+		case differInSize, differInTime:
+			sendChanged(diffMsg, false)
+		case differInType:
+			URLsCh <- URLs{Error: errInvalidTarget(diffMsg.SecondURL)}
 		case differInFirst:
 			// Only in first, always copy.
 			sourceSuffix := strings.TrimPrefix(diffMsg.FirstURL, sourceURL)
-			targetPath := urlJoinPath(targetURL, sourceSuffix)
+			targetPath := urlJoinPath(targetURL, opts.keyEnc.translate(sourceSuffix, sourceClnt.GetURL().Type, targetClnt.GetURL().Type))
 			sourceContent := diffMsg.firstContent
 			targetContent := &clientContent{URL: *newClientURL(targetPath)}
 			URLsCh <- URLs{
@@ -171,7 +408,7 @@ func deltaSourceTarget(sourceURL, targetURL string, isFake, isOverwrite, isRemov
 				TargetContent: targetContent,
 			}
 		case differInSecond:
-			if !isRemove && !isFake {
+			if !opts.isRemove && !opts.isFake {
 				continue
 			}
 			URLsCh <- URLs{
@@ -187,8 +424,8 @@ func deltaSourceTarget(sourceURL, targetURL string, isFake, isOverwrite, isRemov
 }
 
 // Prepares urls that need to be copied or removed based on requested options.
-func prepareMirrorURLs(sourceURL string, targetURL string, isFake, isOverwrite, isRemove bool, excludeOptions []string, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+func prepareMirrorURLs(sourceURL string, targetURL string, opts mirrorOptions) <-chan URLs {
 	URLsCh := make(chan URLs)
-	go deltaSourceTarget(sourceURL, targetURL, isFake, isOverwrite, isRemove, excludeOptions, URLsCh, encKeyDB)
+	go deltaSourceTarget(sourceURL, targetURL, opts, URLsCh)
 	return URLsCh
 }
@@ -0,0 +1,62 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "github.com/minio/cli"
+
+// Modes of --overwrite-mode, deciding if existing objects on target are
+// replaced.
+const (
+	overwriteNever       = "never"
+	overwriteAlways      = "always"
+	overwriteIfNewer     = "if-newer"
+	overwriteIfDifferent = "if-different"
+)
+
+// isValidOverwrite - validates the mode of --overwrite-mode.
+func isValidOverwrite(mode string) bool {
+	switch mode {
+	case overwriteNever, overwriteAlways, overwriteIfNewer, overwriteIfDifferent:
+		return true
+	}
+	return false
+}
+
+// shouldOverwrite - reports if the existing target is replaced by source
+// for mode. Targets are newer than their sources once copied, so only
+// sources modified after target are told apart by time.
+func shouldOverwrite(mode string, source, target *clientContent) bool {
+	switch mode {
+	case overwriteAlways:
+		return true
+	case overwriteIfNewer:
+		return source.Time.After(target.Time)
+	case overwriteIfDifferent:
+		return source.Size != target.Size || source.Time.After(target.Time)
+	}
+	return false
+}
+
+// mirrorOverwrite - returns the mode of --overwrite-mode of mirror,
+// --overwrite and the deprecated --force replace differing objects.
+func mirrorOverwrite(ctx *cli.Context) string {
+	mode := ctx.String("overwrite-mode")
+	if mode == "" && (ctx.Bool("overwrite") || ctx.Bool("force")) {
+		mode = overwriteIfDifferent
+	}
+	return mode
+}
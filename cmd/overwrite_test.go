@@ -0,0 +1,51 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShouldOverwrite(t *testing.T) {
+	now := UTCNow()
+	older := &clientContent{Size: 10, Time: now.Add(-time.Hour)}
+	newer := &clientContent{Size: 10, Time: now}
+	larger := &clientContent{Size: 20, Time: now.Add(-time.Hour)}
+
+	testCases := []struct {
+		mode           string
+		source, target *clientContent
+		overwrite      bool
+	}{
+		{overwriteNever, newer, older, false},
+		{overwriteAlways, older, newer, true},
+		{overwriteAlways, newer, newer, true},
+		{overwriteIfNewer, newer, older, true},
+		{overwriteIfNewer, older, newer, false},
+		{overwriteIfNewer, larger, newer, false},
+		{overwriteIfDifferent, larger, newer, true},
+		{overwriteIfDifferent, newer, older, true},
+		{overwriteIfDifferent, older, newer, false},
+		{"", newer, older, false},
+	}
+	for i, testCase := range testCases {
+		if overwrite := shouldOverwrite(testCase.mode, testCase.source, testCase.target); overwrite != testCase.overwrite {
+			t.Errorf("Test %d: expected %t, got %t", i+1, testCase.overwrite, overwrite)
+		}
+	}
+}
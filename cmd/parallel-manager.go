@@ -17,16 +17,24 @@
 package cmd
 
 import (
+	"fmt"
 	"runtime"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/minio/cli"
 )
 
 const (
 	// Maximum number of parallel workers
 	maxParallelWorkers = 128
 
+	// Maximum number of workers set with --transfer-workers, raised for
+	// links of high latency.
+	maxTransferWorkers = 1024
+
 	// Monitor tick to decide to add new workers
 	monitorPeriod = 4 * time.Second
 
@@ -42,6 +50,9 @@ type ParallelManager struct {
 	// Current threads number
 	workersNum uint32
 
+	// Maximum threads number
+	maxWorkers uint32
+
 	// Calculate sent bytes.
 	sentBytes int64
 
@@ -55,7 +66,7 @@ type ParallelManager struct {
 
 // addWorker creates a new worker to process tasks
 func (p *ParallelManager) addWorker() {
-	if atomic.LoadUint32(&p.workersNum) >= maxParallelWorkers {
+	if atomic.LoadUint32(&p.workersNum) >= p.maxWorkers {
 		// Number of maximum workers is reached, no need to
 		// to create a new one.
 		return
@@ -137,16 +148,27 @@ func (p *ParallelManager) wait() {
 	close(p.stopMonitorCh)
 }
 
-// newParallelManager starts new workers waiting for executing tasks
-func newParallelManager(resultCh chan URLs) (*ParallelManager, chan func() URLs) {
+// newParallelManager starts new workers waiting for executing tasks,
+// a fixed number of workers is started if workers is set, otherwise
+// workers are added as long as the transfer speed improves.
+func newParallelManager(resultCh chan URLs, workers int) (*ParallelManager, chan func() URLs) {
 	p := &ParallelManager{
 		wg:            &sync.WaitGroup{},
 		workersNum:    0,
+		maxWorkers:    maxParallelWorkers,
 		stopMonitorCh: make(chan struct{}),
 		queueCh:       make(chan func() URLs),
 		resultCh:      resultCh,
 	}
 
+	if workers > 0 {
+		p.maxWorkers = uint32(workers)
+		for i := 0; i < workers; i++ {
+			p.addWorker()
+		}
+		return p, p.queueCh
+	}
+
 	// Start with runtime.NumCPU().
 	for i := 0; i < runtime.NumCPU(); i++ {
 		p.addWorker()
@@ -157,3 +179,28 @@ func newParallelManager(resultCh chan URLs) (*ParallelManager, chan func() URLs)
 
 	return p, p.queueCh
 }
+
+// checkWorkersSyntax - validates --list-workers and --transfer-workers.
+func checkWorkersSyntax(ctx *cli.Context) {
+	if n := ctx.Int("list-workers"); n < 0 {
+		fatalIf(errInvalidArgument().Trace(strconv.Itoa(n)), "`--list-workers` cannot be negative.")
+	}
+	if n := ctx.Int("transfer-workers"); n < 0 || n > maxTransferWorkers {
+		fatalIf(errInvalidArgument().Trace(strconv.Itoa(n)),
+			fmt.Sprintf("`--transfer-workers` must be between 0 and %d.", maxTransferWorkers))
+	}
+}
+
+// mirrorWorkers - returns the number of objects mirrored in parallel,
+// that of the configuration unless set with --parallel, 0 to scale it
+// with the transfer speed.
+func mirrorWorkers(ctx *cli.Context) int {
+	if ctx.IsSet("transfer-workers") || ctx.IsSet("parallel") {
+		return ctx.Int("transfer-workers")
+	}
+	conf, err := loadMcConfig()
+	if err != nil {
+		return 0
+	}
+	return conf.Parallel
+}
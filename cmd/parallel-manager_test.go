@@ -0,0 +1,49 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestParallelManagerWorkers(t *testing.T) {
+	testCases := []struct {
+		workers int
+	}{
+		{1},
+		{maxParallelWorkers},
+		{maxTransferWorkers},
+	}
+	for i, testCase := range testCases {
+		resultCh := make(chan URLs)
+		p, queueCh := newParallelManager(resultCh, testCase.workers)
+		if n := atomic.LoadUint32(&p.workersNum); n != uint32(testCase.workers) {
+			t.Fatalf("Test %d: expected %d workers, got %d", i+1, testCase.workers, n)
+		}
+		go func() {
+			for range resultCh {
+			}
+		}()
+		for j := 0; j < 2*testCase.workers; j++ {
+			queueCh <- func() URLs { return URLs{} }
+		}
+		close(queueCh)
+		p.wait()
+		close(resultCh)
+	}
+}
@@ -0,0 +1,242 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+// Interval new data of followed files is uploaded at by default.
+const defaultFollowInterval = time.Minute
+
+// User metadata of segments, the followed file they were read from and
+// its offset right after them.
+const (
+	mcFollowFileMetaKey   = "X-Amz-Meta-Mc-Follow-File"
+	mcFollowOffsetMetaKey = "X-Amz-Meta-Mc-Follow-Offset"
+)
+
+// pipeFollowMessage container for an uploaded segment of a followed file.
+type pipeFollowMessage struct {
+	Status string `json:"status"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Offset int64  `json:"offset"`
+	Size   int64  `json:"size"`
+}
+
+// String colorized pipe follow message.
+func (p pipeFollowMessage) String() string {
+	return console.Colorize("Pipe", fmt.Sprintf("`%s` -> `%s` (%s)", p.Source, p.Target, humanize.IBytes(uint64(p.Size))))
+}
+
+// JSON jsonified pipe follow message.
+func (p pipeFollowMessage) JSON() string {
+	p.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(p, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// followSegmentName - returns the object name of segment seq of file.
+func followSegmentName(file string, seq int) string {
+	return fmt.Sprintf("%s.%08d", filepath.Base(file), seq)
+}
+
+// parseFollowSegment - returns the sequence number of the segment
+// called name, false if it is not a segment of file.
+func parseFollowSegment(file, name string) (int, bool) {
+	suffix := strings.TrimPrefix(name, filepath.Base(file)+".")
+	if suffix == name || len(suffix) != 8 {
+		return 0, false
+	}
+	seq, e := strconv.Atoi(suffix)
+	if e != nil || seq < 0 {
+		return 0, false
+	}
+	return seq, true
+}
+
+// followFile - tails file, uploading the data appended since the last
+// upload every interval as the next segment under targetURL. Segments
+// found under targetURL are continued from. Returns once trapCh fires,
+// after uploading what is left.
+func followFile(file, targetURL string, interval time.Duration, sse encrypt.ServerSide, trapCh <-chan bool) *probe.Error {
+	alias, targetURLFull, _, err := expandAlias(targetURL)
+	if err != nil {
+		return err.Trace(targetURL)
+	}
+	f, e := os.Open(file)
+	if e != nil {
+		return probe.NewError(e).Trace(file)
+	}
+	defer func() { f.Close() }()
+
+	st, e := f.Stat()
+	if e != nil {
+		return probe.NewError(e).Trace(file)
+	}
+	seq, offset, err := lastFollowSegment(alias, targetURLFull, file, st, sse)
+	if err != nil {
+		return err.Trace(targetURL)
+	}
+
+	metadata := map[string]string{"Content-Type": guessURLContentType(file)}
+	// upload - returns true once all data of the file read so far is
+	// uploaded.
+	upload := func() bool {
+		st, e := f.Stat()
+		if e != nil {
+			errorIf(probe.NewError(e).Trace(file), "Unable to stat `"+file+"`.")
+			return false
+		}
+		if st.Size() < offset {
+			// Truncated since the last upload, start over.
+			offset = 0
+		}
+		size := st.Size() - offset
+		if size <= 0 {
+			return true
+		}
+		segment := followSegmentName(file, seq)
+		segmentURL := urlJoinPath(targetURLFull, segment)
+		// Copy metadata, it is consumed by the upload.
+		md := make(map[string]string, len(metadata))
+		for k, v := range metadata {
+			md[k] = v
+		}
+		md[mcFollowFileMetaKey] = fileID(st)
+		md[mcFollowOffsetMetaKey] = strconv.FormatInt(offset+size, 10)
+		if _, err := putTargetStream(context.Background(), alias, segmentURL, io.NewSectionReader(f, offset, size), size, md, nil, sse); err != nil {
+			// Retried with more data at the next interval.
+			errorIf(err.Trace(file, segmentURL), "Unable to upload new data of `"+file+"`.")
+			return false
+		}
+		printMsg(pipeFollowMessage{
+			Source: file,
+			Target: urlJoinPath(targetURL, segment),
+			Offset: offset,
+			Size:   size,
+		})
+		offset += size
+		seq++
+		return true
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		upload()
+
+		// Files rotated by renaming are followed by name, once the
+		// data left in the old file is uploaded. The old file is kept
+		// until then, the upload is retried at the next interval.
+		if st, e := os.Stat(file); e == nil {
+			if openSt, e := f.Stat(); e == nil && !os.SameFile(st, openSt) && upload() {
+				if newFile, e := os.Open(file); e == nil {
+					f.Close()
+					f, offset = newFile, 0
+				}
+			}
+		}
+
+		select {
+		case <-trapCh:
+			upload()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// lastFollowSegment - returns the sequence number following the last
+// segment of file found under targetURL, along with the offset of st,
+// the followed file, to continue from. The last segment records the
+// file it was read from and the offset after it, files rotated since
+// are read from their start.
+func lastFollowSegment(alias, targetURL, file string, st os.FileInfo, sse encrypt.ServerSide) (seq int, offset int64, err *probe.Error) {
+	clnt, err := newClientFromAlias(alias, targetURL)
+	if err != nil {
+		return 0, 0, err.Trace(targetURL)
+	}
+	// Size of all segments, the offset of segments without metadata.
+	var total int64
+	for content := range clnt.List(false, false, DirNone) {
+		if content.Err != nil {
+			switch content.Err.ToGoError().(type) {
+			case PathNotFound, BucketDoesNotExist:
+				// Nothing uploaded yet.
+				return 0, 0, nil
+			}
+			return 0, 0, content.Err.Trace(targetURL)
+		}
+		n, ok := parseFollowSegment(file, path.Base(filepath.ToSlash(content.URL.Path)))
+		if !ok {
+			continue
+		}
+		total += content.Size
+		if n >= seq {
+			seq = n + 1
+		}
+	}
+	if seq == 0 {
+		return 0, 0, nil
+	}
+
+	segmentURL := urlJoinPath(targetURL, followSegmentName(file, seq-1))
+	segmentClnt, err := newClientFromAlias(alias, segmentURL)
+	if err != nil {
+		return 0, 0, err.Trace(segmentURL)
+	}
+	segment, err := segmentClnt.Stat(false, true, sse)
+	if err != nil {
+		return 0, 0, err.Trace(segmentURL)
+	}
+	return seq, followOffset(segment.Metadata, st, total), nil
+}
+
+// followOffset - returns the offset of st to continue from after the
+// segment with metadata, total if the segment has none.
+func followOffset(metadata map[string]string, st os.FileInfo, total int64) int64 {
+	offset, e := strconv.ParseInt(metadata[mcFollowOffsetMetaKey], 10, 64)
+	if e != nil {
+		return total
+	}
+	if id := metadata[mcFollowFileMetaKey]; id != "" && id != fileID(st) {
+		// Rotated since, the new file is read from its start.
+		return 0
+	}
+	if offset > st.Size() {
+		// Truncated since.
+		return 0
+	}
+	return offset
+}
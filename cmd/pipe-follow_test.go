@@ -0,0 +1,103 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+)
+
+func TestParseFollowSegment(t *testing.T) {
+	testCases := []struct {
+		name string
+		seq  int
+		ok   bool
+	}{
+		{followSegmentName("/var/log/app.log", 0), 0, true},
+		{followSegmentName("/var/log/app.log", 42), 42, true},
+		{"app.log.1", 0, false},
+		{"app.log.0000000a", 0, false},
+		{"web.log.00000001", 0, false},
+		{"app.log", 0, false},
+	}
+	for i, testCase := range testCases {
+		seq, ok := parseFollowSegment("/var/log/app.log", testCase.name)
+		if ok != testCase.ok || seq != testCase.seq {
+			t.Fatalf("Test %d: expected (%d, %t), got (%d, %t)", i+1, testCase.seq, testCase.ok, seq, ok)
+		}
+	}
+}
+
+func TestFollowOffset(t *testing.T) {
+	// Files are kept until the end, their ids would be reused.
+	var files []string
+	defer func() {
+		for _, file := range files {
+			os.Remove(file)
+		}
+	}()
+	newFile := func(size int) os.FileInfo {
+		f, e := ioutil.TempFile("", "mc-follow-")
+		if e != nil {
+			t.Fatal(e)
+		}
+		files = append(files, f.Name())
+		defer f.Close()
+		if _, e = f.Write(make([]byte, size)); e != nil {
+			t.Fatal(e)
+		}
+		st, e := f.Stat()
+		if e != nil {
+			t.Fatal(e)
+		}
+		return st
+	}
+	followed := newFile(100)
+	rotated := newFile(100)
+
+	segment := func(st os.FileInfo, offset int64) map[string]string {
+		return map[string]string{
+			mcFollowFileMetaKey:   fileID(st),
+			mcFollowOffsetMetaKey: strconv.FormatInt(offset, 10),
+		}
+	}
+	// Files are only told apart by name where their id is not known.
+	rotatedOffset := int64(0)
+	if fileID(rotated) == "" {
+		rotatedOffset = 60
+	}
+	testCases := []struct {
+		metadata map[string]string
+		offset   int64
+	}{
+		// Continued after the segment.
+		{segment(followed, 60), 60},
+		{segment(followed, 100), 100},
+		// Truncated since.
+		{segment(followed, 150), 0},
+		// Rotated since.
+		{segment(rotated, 60), rotatedOffset},
+		// Segments without metadata.
+		{map[string]string{}, 250},
+	}
+	for i, testCase := range testCases {
+		if offset := followOffset(testCase.metadata, followed, 250); offset != testCase.offset {
+			t.Fatalf("Test %d: expected %d, got %d", i+1, testCase.offset, offset)
+		}
+	}
+}
@@ -19,8 +19,11 @@ package cmd
 import (
 	"os"
 	"syscall"
+	"time"
 
+	"github.com/fatih/color"
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
 	"github.com/minio/mc/pkg/probe"
 )
 
@@ -30,6 +33,15 @@ var (
 			Name:  "encrypt",
 			Usage: "encrypt objects (using server-side encryption with server managed keys)",
 		},
+		cli.StringFlag{
+			Name:  "follow",
+			Usage: "tail a growing file, uploading new data as numbered objects under TARGET",
+		},
+		cli.StringFlag{
+			Name:  "interval",
+			Value: defaultFollowInterval.String(),
+			Usage: "upload new data of the file followed at this interval",
+		},
 	}
 )
 
@@ -65,6 +77,9 @@ EXAMPLES:
 
    4. Stream MySQL database dump to Amazon S3 directly.
       $ mysqldump -u root -p ******* accountsdb | {{.HelpName}} s3/sql-backups/backups/accountsdb-oct-9-2015.sql
+
+   5. Ship a growing log file to Amazon S3, uploading new lines every 5 minutes as 'app.log.00000000', 'app.log.00000001', ...
+      $ {{.HelpName}} --follow /var/log/app.log --interval 5m s3/logs/web1/
 `,
 }
 
@@ -96,6 +111,16 @@ func checkPipeSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) > 1 {
 		cli.ShowCommandHelpAndExit(ctx, "pipe", 1) // last argument is exit code.
 	}
+	if ctx.String("follow") != "" {
+		if len(ctx.Args()) != 1 {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...), "A target is required with ‘--follow’.")
+		}
+		interval, e := time.ParseDuration(ctx.String("interval"))
+		fatalIf(probe.NewError(e), "Unable to parse ‘--interval’.")
+		if interval <= 0 {
+			fatalIf(errInvalidArgument().Trace(ctx.String("interval")), "‘--interval’ should be positive.")
+		}
+	}
 }
 
 // mainPipe is the main entry point for pipe command.
@@ -107,6 +132,17 @@ func mainPipe(ctx *cli.Context) error {
 	// validate pipe input arguments.
 	checkPipeSyntax(ctx)
 
+	if file := ctx.String("follow"); file != "" {
+		console.SetColor("Pipe", color.New(color.FgGreen, color.Bold))
+		targetURL := ctx.Args().Get(0)
+		alias, _ := url2Alias(targetURL)
+		interval, _ := time.ParseDuration(ctx.String("interval"))
+		trapCh := signalTrap(os.Interrupt, syscall.SIGTERM)
+		err = followFile(file, targetURL, interval, getSSE(targetURL, encKeyDB[alias]), trapCh)
+		fatalIf(err.Trace(file, targetURL), "Unable to follow `"+file+"`.")
+		return nil
+	}
+
 	if len(ctx.Args()) == 0 {
 		err = pipe("", nil)
 		fatalIf(err.Trace("stdout"), "Unable to write to one or more targets.")
@@ -0,0 +1,155 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// unreadableSource - a local file or folder which cannot be read.
+type unreadableSource struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+	Size int64  `json:"size,omitempty"`
+}
+
+// preflightMessage - local files and folders found unreadable by
+// --preflight, before anything is copied.
+type preflightMessage struct {
+	Status     string             `json:"status"`
+	Files      int64              `json:"files"`
+	Size       int64              `json:"size"`
+	Unreadable []unreadableSource `json:"unreadable"`
+}
+
+// unreadable - returns the number and size of unreadable files, and the
+// number of unreadable folders, their content is unknown.
+func (p preflightMessage) unreadable() (files, size, folders int64) {
+	for _, source := range p.Unreadable {
+		if source.Type == "folder" {
+			folders++
+			continue
+		}
+		files++
+		size += source.Size
+	}
+	return files, size, folders
+}
+
+// String colorized preflight message.
+func (p preflightMessage) String() string {
+	if len(p.Unreadable) == 0 {
+		return fmt.Sprintf("All %d file(s) readable, %s.", p.Files, humanize.IBytes(uint64(p.Size)))
+	}
+	var lines []string
+	for _, source := range p.Unreadable {
+		if source.Type == "folder" {
+			lines = append(lines, console.Colorize("Unreadable", fmt.Sprintf("Unreadable folder `%s`.", source.Path)))
+		} else {
+			lines = append(lines, console.Colorize("Unreadable", fmt.Sprintf("Unreadable file `%s` (%s).", source.Path, humanize.IBytes(uint64(source.Size)))))
+		}
+	}
+	files, size, folders := p.unreadable()
+	share := 0.0
+	if p.Size > 0 {
+		share = float64(size) * 100 / float64(p.Size)
+	}
+	lines = append(lines, fmt.Sprintf("%d of %d file(s) unreadable, %s or %.1f%% of %s, and %d folder(s) of unknown content.",
+		files, p.Files, humanize.IBytes(uint64(size)), share, humanize.IBytes(uint64(p.Size)), folders))
+	return strings.Join(lines, "\n")
+}
+
+// JSON jsonified preflight message.
+func (p preflightMessage) JSON() string {
+	p.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(p, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// preflightScan - walks the local folders and files of sources and
+// returns those which cannot be read. Object storage sources are not
+// scanned, their access is only known by reading them.
+func preflightScan(sources []string) preflightMessage {
+	msg := preflightMessage{Unreadable: []unreadableSource{}}
+	for _, source := range sources {
+		if _, _, hostCfg, _ := expandAlias(source); hostCfg != nil {
+			continue
+		}
+		filepath.Walk(source, func(path string, info os.FileInfo, e error) error {
+			if e != nil {
+				// Other errors, such as missing sources, are reported by the copy itself.
+				if os.IsPermission(e) {
+					unreadable := unreadableSource{Path: path, Type: "folder"}
+					if info != nil && !info.IsDir() {
+						unreadable.Type, unreadable.Size = "file", info.Size()
+					}
+					msg.Unreadable = append(msg.Unreadable, unreadable)
+				}
+				if info != nil && info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if isIgnoredFile(info.Name()) {
+				return nil
+			}
+			// Links are copied as the files they point to.
+			if info.Mode()&os.ModeSymlink == os.ModeSymlink {
+				if info, e = os.Stat(path); e != nil {
+					return nil
+				}
+			}
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+			msg.Files++
+			msg.Size += info.Size()
+			f, e := os.Open(path)
+			if e != nil {
+				if os.IsPermission(e) {
+					msg.Unreadable = append(msg.Unreadable, unreadableSource{Path: path, Type: "file", Size: info.Size()})
+				}
+				return nil
+			}
+			f.Close()
+			return nil
+		})
+	}
+	return msg
+}
+
+// checkPreflight - reports the unreadable local files and folders of
+// sources and stops before anything is copied if any are found.
+func checkPreflight(sources []string) {
+	console.SetColor("Unreadable", color.New(color.FgYellow))
+	msg := preflightScan(sources)
+	printMsg(msg)
+	if len(msg.Unreadable) > 0 {
+		fatalIf(errDummy().Trace(sources...), "Unreadable sources found, fix their permissions or exclude them before copying.")
+	}
+}
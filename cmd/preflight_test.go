@@ -0,0 +1,88 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestPreflightScan(t *testing.T) {
+	// Permissions are not enforced for root, nor as such on Windows.
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("permissions are not enforced")
+	}
+	dir, e := ioutil.TempDir("", "mc-preflight-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, file := range []struct {
+		name string
+		size int
+		mode os.FileMode
+	}{
+		{"readable", 10, 0644},
+		{"unreadable", 30, 0200},
+		{filepath.Join("private", "secret"), 5, 0644},
+	} {
+		path := filepath.Join(dir, file.name)
+		if e = os.MkdirAll(filepath.Dir(path), 0755); e != nil {
+			t.Fatal(e)
+		}
+		if e = ioutil.WriteFile(path, make([]byte, file.size), file.mode); e != nil {
+			t.Fatal(e)
+		}
+	}
+	private := filepath.Join(dir, "private")
+	if e = os.Chmod(private, 0); e != nil {
+		t.Fatal(e)
+	}
+	defer os.Chmod(private, 0755)
+
+	msg := preflightScan([]string{dir, filepath.Join(dir, "missing")})
+	unreadable := []unreadableSource{
+		{Path: private, Type: "folder"},
+		{Path: filepath.Join(dir, "unreadable"), Type: "file", Size: 30},
+	}
+	if !reflect.DeepEqual(msg.Unreadable, unreadable) {
+		t.Fatalf("Expected %v, got %v", unreadable, msg.Unreadable)
+	}
+	if msg.Files != 2 || msg.Size != 40 {
+		t.Fatalf("Expected 2 files of 40 bytes, got %d files of %d bytes", msg.Files, msg.Size)
+	}
+}
+
+func TestPreflightUnreadable(t *testing.T) {
+	msg := preflightMessage{
+		Files: 4,
+		Size:  1000,
+		Unreadable: []unreadableSource{
+			{Path: "/data/a", Type: "file", Size: 100},
+			{Path: "/data/b", Type: "folder"},
+			{Path: "/data/c", Type: "file", Size: 150},
+		},
+	}
+	if files, size, folders := msg.unreadable(); files != 2 || size != 250 || folders != 1 {
+		t.Fatalf("Expected 2 files of 250 bytes and 1 folder, got %d files of %d bytes and %d folders", files, size, folders)
+	}
+}
@@ -0,0 +1,144 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// mcAttrsMetaKey - user metadata holding the attributes of the file an
+// object was uploaded from with --preserve.
+const mcAttrsMetaKey = "X-Amz-Meta-Mc-Attrs"
+
+// fileAttrs - attributes of a file kept by --preserve, zero if not known.
+type fileAttrs struct {
+	mode  os.FileMode
+	mtime time.Time
+}
+
+// String - returns attributes as stored in mcAttrsMetaKey, such as
+// 'mode:0644/mtime:2019-10-01T10:00:00.123456789Z'.
+func (a fileAttrs) String() string {
+	var fields []string
+	if a.mode != 0 {
+		fields = append(fields, fmt.Sprintf("mode:%#o", a.mode.Perm()))
+	}
+	if !a.mtime.IsZero() {
+		fields = append(fields, "mtime:"+a.mtime.UTC().Format(time.RFC3339Nano))
+	}
+	return strings.Join(fields, "/")
+}
+
+// parseFileAttrs - returns the attributes stored in mcAttrsMetaKey,
+// unknown fields are ignored.
+func parseFileAttrs(s string) (fileAttrs, *probe.Error) {
+	var attrs fileAttrs
+	for _, field := range strings.Split(s, "/") {
+		parts := strings.SplitN(field, ":", 2)
+		if len(parts) != 2 {
+			return fileAttrs{}, probe.NewError(fmt.Errorf("`%s` is not of the form NAME:VALUE", field))
+		}
+		switch parts[0] {
+		case "mode":
+			mode, e := strconv.ParseUint(parts[1], 8, 32)
+			if e != nil {
+				return fileAttrs{}, probe.NewError(e).Trace(s)
+			}
+			attrs.mode = os.FileMode(mode).Perm()
+		case "mtime":
+			mtime, e := time.Parse(time.RFC3339Nano, parts[1])
+			if e != nil {
+				return fileAttrs{}, probe.NewError(e).Trace(s)
+			}
+			attrs.mtime = mtime
+		}
+	}
+	return attrs, nil
+}
+
+// statFileAttrs - returns the attributes of the local file at path.
+func statFileAttrs(path string) (fileAttrs, *probe.Error) {
+	st, e := os.Stat(path)
+	if e != nil {
+		return fileAttrs{}, probe.NewError(e).Trace(path)
+	}
+	return fileAttrs{mode: st.Mode().Perm(), mtime: st.ModTime()}, nil
+}
+
+// sourceFileAttrs - returns the attributes of the local file source, or
+// those its object was uploaded with, its last modified time otherwise.
+func sourceFileAttrs(source *clientContent, metadata map[string]string) (fileAttrs, *probe.Error) {
+	if source.URL.Type == fileSystem {
+		return statFileAttrs(source.URL.Path)
+	}
+	if value, ok := metadata[mcAttrsMetaKey]; ok {
+		if attrs, err := parseFileAttrs(value); err == nil {
+			return attrs, nil
+		}
+	}
+	return fileAttrs{mtime: source.Time}, nil
+}
+
+// apply - sets the mode and modification time of the local file at path.
+func (a fileAttrs) apply(path string) *probe.Error {
+	if a.mode != 0 {
+		if e := os.Chmod(path, a.mode); e != nil {
+			return probe.NewError(e).Trace(path)
+		}
+	}
+	if !a.mtime.IsZero() {
+		if e := os.Chtimes(path, a.mtime, a.mtime); e != nil {
+			return probe.NewError(e).Trace(path)
+		}
+	}
+	return nil
+}
+
+// isPreservedFile - returns true if the local file target was restored
+// with --preserve from the object source, and not modified since: its
+// modification time is not older than that source was uploaded with.
+func isPreservedFile(sourceAlias string, source, target *clientContent, keys []prefixSSEPair) bool {
+	if source.URL.Type != objectStorage || target.URL.Type != fileSystem {
+		return false
+	}
+	clnt, err := newClientFromAlias(sourceAlias, source.URL.String())
+	if err != nil {
+		return false
+	}
+	sse := getSSE(filepath.ToSlash(filepath.Join(sourceAlias, clnt.GetURL().Path)), keys)
+	st, err := clnt.Stat(false, true, sse)
+	if err != nil {
+		return false
+	}
+	value, ok := st.Metadata[mcAttrsMetaKey]
+	if !ok {
+		return false
+	}
+	attrs, err := parseFileAttrs(value)
+	if err != nil || attrs.mtime.IsZero() {
+		return false
+	}
+	// Some filesystems keep modification times to the second.
+	return !attrs.mtime.Truncate(time.Second).After(target.Time)
+}
@@ -0,0 +1,118 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestParseFileAttrs(t *testing.T) {
+	mtime := time.Date(2019, 10, 1, 10, 0, 0, 123456789, time.UTC)
+	testCases := []struct {
+		attrs   string
+		mode    os.FileMode
+		mtime   time.Time
+		success bool
+	}{
+		{"mode:0644/mtime:2019-10-01T10:00:00.123456789Z", 0644, mtime, true},
+		{"mtime:2019-10-01T10:00:00.123456789Z", 0, mtime, true},
+		{"mode:0755", 0755, time.Time{}, true},
+		{"mode:0644/uid:1000", 0644, time.Time{}, true},
+		{"mode:rw-r--r--", 0, time.Time{}, false},
+		{"mtime:yesterday", 0, time.Time{}, false},
+		{"0644", 0, time.Time{}, false},
+	}
+	for i, testCase := range testCases {
+		attrs, err := parseFileAttrs(testCase.attrs)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %t, got error %v", i+1, testCase.success, err)
+		}
+		if attrs.mode != testCase.mode || !attrs.mtime.Equal(testCase.mtime) {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, fileAttrs{testCase.mode, testCase.mtime}, attrs)
+		}
+	}
+
+	attrs := fileAttrs{mode: 0640, mtime: mtime}
+	parsed, err := parseFileAttrs(attrs.String())
+	if err != nil || parsed.mode != attrs.mode || !parsed.mtime.Equal(attrs.mtime) {
+		t.Fatalf("Expected %s, got %s, %v", attrs, parsed, err)
+	}
+}
+
+func TestApplyFileAttrs(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-preserve-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	source, target := filepath.Join(dir, "source"), filepath.Join(dir, "target")
+	for _, path := range []string{source, target} {
+		if e = ioutil.WriteFile(path, []byte("hello"), 0644); e != nil {
+			t.Fatal(e)
+		}
+	}
+	mtime := time.Date(2019, 10, 1, 10, 0, 0, 0, time.UTC)
+	if err := (fileAttrs{mode: 0600, mtime: mtime}).apply(source); err != nil {
+		t.Fatal(err)
+	}
+
+	attrs, err := sourceFileAttrs(&clientContent{URL: *newClientURL(source)}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = attrs.apply(target); err != nil {
+		t.Fatal(err)
+	}
+	st, e := os.Stat(target)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if !st.ModTime().Equal(mtime) {
+		t.Fatalf("Expected modification time %s, got %s", mtime, st.ModTime())
+	}
+	// Windows only keeps the read-only bit.
+	if runtime.GOOS != "windows" && st.Mode().Perm() != 0600 {
+		t.Fatalf("Expected mode %s, got %s", os.FileMode(0600), st.Mode().Perm())
+	}
+}
+
+func TestSourceFileAttrs(t *testing.T) {
+	lastModified := time.Date(2019, 10, 2, 10, 0, 0, 0, time.UTC)
+	object := &clientContent{URL: *newClientURL("https://s3.amazonaws.com/mybucket/object"), Time: lastModified}
+
+	attrs, err := sourceFileAttrs(object, map[string]string{mcAttrsMetaKey: "mode:0600/mtime:2019-10-01T10:00:00Z"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attrs.mode != 0600 || !attrs.mtime.Equal(time.Date(2019, 10, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Expected the attributes the object was uploaded with, got %s", attrs)
+	}
+
+	attrs, err = sourceFileAttrs(object, map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attrs.mode != 0 || !attrs.mtime.Equal(lastModified) {
+		t.Fatalf("Expected the last modified time of the object, got %s", attrs)
+	}
+}
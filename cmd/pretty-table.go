@@ -17,8 +17,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/minio/mc/pkg/console"
 )
 
@@ -43,42 +41,34 @@ func newPrettyTable(separator string, cols ...Field) PrettyTable {
 }
 
 // buildRow - creates a string which represents a line table given
-// some fields contents.
+// some fields contents. Contents are measured in terminal columns, cut
+// with '...' when longer than maxLen.
 func (t PrettyTable) buildRow(contents ...string) (line string) {
-	dots := "..."
-
-	// totalColumns is the minimum of the number of fields config
-	// and the number of contents elements.
-	totalColumns := len(contents)
-	if len(t.cols) < totalColumns {
-		totalColumns = len(t.cols)
+	columns := make([]console.Column, len(t.cols))
+	for i, col := range t.cols {
+		columns[i] = console.Column{Theme: col.colorTheme, Width: col.maxLen}
 	}
+	return console.NewTableRenderer(t.separator, columns...).Row(contents...)
+}
 
-	// Format fields and construct message
-	for i := 0; i < totalColumns; i++ {
-		// Default field format without pretty effect
-		fieldContent := ""
-		fieldFormat := "%s"
-		if t.cols[i].maxLen >= 0 {
-			// Override field format
-			fieldFormat = fmt.Sprintf("%%-%d.%ds", t.cols[i].maxLen, t.cols[i].maxLen)
-			// Cut field string and add '...' if length is greater than maxLen
-			if len(contents[i]) > t.cols[i].maxLen {
-				fieldContent = contents[i][:t.cols[i].maxLen-len(dots)] + dots
-			} else {
-				fieldContent = contents[i]
-			}
-		} else {
-			fieldContent = contents[i]
-		}
-
-		// Add separator if this is not the last column
-		if i < totalColumns-1 {
-			fieldFormat += t.separator
+// fitWidth - limits the last field without max length to what is left
+// of width terminal columns by the other fields, width <= 0 keeps it.
+func (t PrettyTable) fitWidth(width int) PrettyTable {
+	last := len(t.cols) - 1
+	if width <= 0 || last < 0 || t.cols[last].maxLen >= 0 {
+		return t
+	}
+	left := width - last*console.StringWidth(t.separator)
+	for _, col := range t.cols[:last] {
+		if col.maxLen < 0 {
+			return t
 		}
-
-		// Add the field to the resulted message
-		line += console.Colorize(t.cols[i].colorTheme, fmt.Sprintf(fieldFormat, fieldContent))
+		left -= col.maxLen
+	}
+	if left > len("...") {
+		cols := append([]Field{}, t.cols...)
+		cols[last].maxLen = left
+		t.cols = cols
 	}
-	return
+	return t
 }
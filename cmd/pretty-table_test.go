@@ -39,6 +39,8 @@ func TestPrettyTable(t *testing.T) {
 		{" | ", []Field{{"", -1}, {"", -1}, {"", -1}}, []string{"column1", "column2", "column3"}, "column1 | column2 | column3"},
 		// Test 6: multiple fields
 		{" | ", []Field{{"", 5}, {"", -1}}, []string{"144550032", "my long content that should not be cut"}, "14... | my long content that should not be cut"},
+		// Test 7: wide runes take two columns
+		{" | ", []Field{{"", 6}, {"", -1}}, []string{"日本語の名前", "x"}, "日...  | x"},
 	}
 
 	for idx, testCase := range testCases {
@@ -49,3 +51,14 @@ func TestPrettyTable(t *testing.T) {
 		}
 	}
 }
+
+// TestPrettyTableFitWidth - testing the last field is cut to the width
+func TestPrettyTableFitWidth(t *testing.T) {
+	tb := newPrettyTable("  ", Field{"", 4}, Field{"", -1})
+	if row := tb.fitWidth(14).buildRow("time", "my-long-resource"); row != "time  my-lo..." {
+		t.Fatalf("expected = `time  my-lo...`, found = `%s`", row)
+	}
+	if row := tb.fitWidth(0).buildRow("time", "my-long-resource"); row != "time  my-long-resource" {
+		t.Fatalf("expected = `time  my-long-resource`, found = `%s`", row)
+	}
+}
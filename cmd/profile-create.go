@@ -0,0 +1,80 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var profileCreateCmd = cli.Command{
+	Name:            "create",
+	Usage:           "create a profile with its own aliases and settings",
+	Action:          mainProfileCreate,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} PROFILE
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Create a profile for the staging servers and add an alias to it.
+     $ {{.HelpName}} staging
+     $ mc --profile staging config host add myminio https://staging.example.com:9000 ACCESSKEY SECRETKEY
+
+`,
+}
+
+// mainProfileCreate is the handle for "mc profile create" command.
+func mainProfileCreate(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "create", 1) // last argument is exit code
+	}
+	profile := ctx.Args().Get(0)
+	if !isValidProfile(profile) {
+		fatalIf(errInvalidArgument().Trace(profile), "Invalid profile name `"+profile+"`.")
+	}
+	if isProfileExists(mcProfileRootDir, profile) {
+		fatalIf(errInvalidArgument().Trace(profile), "Profile `"+profile+"` already exists.")
+	}
+
+	console.SetColor("Profile", color.New(color.FgGreen, color.Bold))
+
+	profileDir := getProfileDir(mcProfileRootDir, profile)
+	e := os.MkdirAll(profileDir, 0700)
+	fatalIf(probe.NewError(e).Trace(profileDir), "Unable to create profile `"+profile+"`.")
+
+	// The configuration of the new profile starts from the defaults,
+	// without any alias of the others.
+	configDir := mustGetMcConfigDir()
+	setMcConfigDir(profileDir)
+	err := saveMcConfig(newMcConfig())
+	setMcConfigDir(configDir)
+	fatalIf(err.Trace(profileDir), "Unable to create profile `"+profile+"`.")
+
+	printMsg(profileMessage{op: "create", Profile: profile, Path: profileDir})
+	return nil
+}
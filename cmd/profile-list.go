@@ -0,0 +1,69 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var profileListCmd = cli.Command{
+	Name:            "list",
+	ShortName:       "ls",
+	Usage:           "list profiles, marking the one in use",
+	Action:          mainProfileList,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}}
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. List all profiles.
+     $ {{.HelpName}}
+
+`,
+}
+
+// mainProfileList is the handle for "mc profile list" command.
+func mainProfileList(ctx *cli.Context) error {
+	if len(ctx.Args()) != 0 {
+		cli.ShowCommandHelpAndExit(ctx, "list", 1) // last argument is exit code
+	}
+
+	console.SetColor("Profile", color.New(color.Bold))
+	console.SetColor("ActiveProfile", color.New(color.FgGreen, color.Bold))
+
+	profiles, err := listProfiles(mcProfileRootDir)
+	fatalIf(err.Trace(mcProfileRootDir), "Unable to list profiles.")
+
+	for _, profile := range profiles {
+		printMsg(profileMessage{
+			op:      "list",
+			Profile: profile,
+			Path:    getProfileDir(mcProfileRootDir, profile),
+			Active:  profile == mcProfile,
+		})
+	}
+	return nil
+}
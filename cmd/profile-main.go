@@ -0,0 +1,200 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Profile of the configuration folder itself.
+const defaultProfile = "default"
+
+var profileCmd = cli.Command{
+	Name:            "profile",
+	Usage:           "manage separate sets of aliases and settings",
+	HideHelpCommand: true,
+	Action:          mainProfile,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	Subcommands: []cli.Command{
+		profileCreateCmd,
+		profileUseCmd,
+		profileListCmd,
+	},
+}
+
+// mainProfile is the handle for "mc profile" command.
+func mainProfile(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "create", "use", "list" have their own main.
+}
+
+// profileMessage container for profile messages.
+type profileMessage struct {
+	op      string
+	Status  string `json:"status"`
+	Profile string `json:"profile"`
+	Path    string `json:"path"`
+	Active  bool   `json:"active"`
+}
+
+// String colorized profile message.
+func (p profileMessage) String() string {
+	switch p.op {
+	case "create":
+		return console.Colorize("Profile", "Profile `"+p.Profile+"` created in `"+p.Path+"`.")
+	case "use":
+		return console.Colorize("Profile", "Using profile `"+p.Profile+"`.")
+	case "list":
+		if p.Active {
+			return console.Colorize("ActiveProfile", "* "+p.Profile)
+		}
+		return console.Colorize("Profile", "  "+p.Profile)
+	default:
+		return ""
+	}
+}
+
+// JSON jsonified profile message.
+func (p profileMessage) JSON() string {
+	p.Status = "success"
+	profileMessageBytes, e := json.MarshalIndent(p, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(profileMessageBytes)
+}
+
+// Configuration folder holding the profiles, and the profile in use.
+var (
+	mcProfileRootDir string
+	mcProfile        = defaultProfile
+)
+
+// profileRgx - names a profile can have.
+var profileRgx = regexp.MustCompile("^[a-zA-Z][a-zA-Z0-9-_]*$")
+
+// isValidProfile - checks if name can be a profile.
+func isValidProfile(name string) bool {
+	return profileRgx.MatchString(name)
+}
+
+// getProfileDir - returns the configuration folder of profile, the
+// default profile is rootDir itself.
+func getProfileDir(rootDir, profile string) string {
+	if profile == defaultProfile {
+		return rootDir
+	}
+	return filepath.Join(rootDir, globalProfilesDir, profile)
+}
+
+// isProfileExists - checks if profile was created in rootDir.
+func isProfileExists(rootDir, profile string) bool {
+	if profile == defaultProfile {
+		return true
+	}
+	st, e := os.Stat(getProfileDir(rootDir, profile))
+	return e == nil && st.IsDir()
+}
+
+// getActiveProfile - returns the profile set by "mc profile use" in
+// rootDir.
+func getActiveProfile(rootDir string) (string, *probe.Error) {
+	data, e := ioutil.ReadFile(filepath.Join(rootDir, globalActiveProfileFile))
+	if os.IsNotExist(e) {
+		return defaultProfile, nil
+	}
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	profile := strings.TrimSpace(string(data))
+	if profile == "" {
+		return defaultProfile, nil
+	}
+	return profile, nil
+}
+
+// setActiveProfile - sets the profile used by later commands of rootDir.
+func setActiveProfile(rootDir, profile string) *probe.Error {
+	activeFile := filepath.Join(rootDir, globalActiveProfileFile)
+	if profile == defaultProfile {
+		if e := os.Remove(activeFile); e != nil && !os.IsNotExist(e) {
+			return probe.NewError(e).Trace(activeFile)
+		}
+		return nil
+	}
+	if e := ioutil.WriteFile(activeFile, []byte(profile+"\n"), 0600); e != nil {
+		return probe.NewError(e).Trace(activeFile)
+	}
+	return nil
+}
+
+// listProfiles - returns the profiles of rootDir, sorted by name.
+func listProfiles(rootDir string) ([]string, *probe.Error) {
+	profiles := []string{defaultProfile}
+	entries, e := ioutil.ReadDir(filepath.Join(rootDir, globalProfilesDir))
+	if e != nil && !os.IsNotExist(e) {
+		return nil, probe.NewError(e)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && isValidProfile(entry.Name()) && entry.Name() != defaultProfile {
+			profiles = append(profiles, entry.Name())
+		}
+	}
+	sort.Strings(profiles[1:])
+	return profiles, nil
+}
+
+// resolveProfile - returns the profile to use with the configuration
+// folder rootDir: profile if given, else MC_PROFILE or the active one.
+func resolveProfile(rootDir, profile string) (string, *probe.Error) {
+	if profile == "" {
+		profile = os.Getenv("MC_PROFILE")
+	}
+	if profile == "" {
+		var err *probe.Error
+		if profile, err = getActiveProfile(rootDir); err != nil {
+			return "", err.Trace(rootDir)
+		}
+	}
+	if !isProfileExists(rootDir, profile) {
+		return "", errProfileNotFound(profile)
+	}
+	return profile, nil
+}
+
+// setMcProfile - uses the configuration folder of the profile resolved
+// in rootDir for this command.
+func setMcProfile(rootDir, profile string) *probe.Error {
+	profile, err := resolveProfile(rootDir, profile)
+	if err != nil {
+		return err.Trace(rootDir)
+	}
+	mcProfileRootDir = rootDir
+	mcProfile = profile
+	setMcConfigDir(getProfileDir(rootDir, profile))
+	return nil
+}
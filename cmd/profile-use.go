@@ -0,0 +1,68 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var profileUseCmd = cli.Command{
+	Name:            "use",
+	Usage:           "switch the profile used by later commands",
+	Action:          mainProfileUse,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} PROFILE
+
+  The flag '--profile' and MC_PROFILE take precedence over the profile in use.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Use the aliases and settings of profile 'staging' from now on.
+     $ {{.HelpName}} staging
+
+  2. Go back to the aliases and settings of the configuration folder itself.
+     $ {{.HelpName}} default
+
+`,
+}
+
+// mainProfileUse is the handle for "mc profile use" command.
+func mainProfileUse(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "use", 1) // last argument is exit code
+	}
+	profile := ctx.Args().Get(0)
+	if !isValidProfile(profile) || !isProfileExists(mcProfileRootDir, profile) {
+		fatalIf(errProfileNotFound(profile), "Unable to use profile `"+profile+"`.")
+	}
+
+	console.SetColor("Profile", color.New(color.FgGreen, color.Bold))
+
+	fatalIf(setActiveProfile(mcProfileRootDir, profile), "Unable to use profile `"+profile+"`.")
+
+	printMsg(profileMessage{op: "use", Profile: profile, Path: getProfileDir(mcProfileRootDir, profile), Active: true})
+	return nil
+}
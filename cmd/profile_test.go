@@ -0,0 +1,75 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestResolveProfile(t *testing.T) {
+	rootDir, e := ioutil.TempDir("", "mc-profile")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(rootDir)
+	for _, profile := range []string{"staging", "prod"} {
+		if e = os.MkdirAll(filepath.Join(rootDir, globalProfilesDir, profile), 0700); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	profiles, err := listProfiles(rootDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"default", "prod", "staging"}; !reflect.DeepEqual(profiles, expected) {
+		t.Fatalf("Expected %v, got %v", expected, profiles)
+	}
+
+	os.Unsetenv("MC_PROFILE")
+	testCases := []struct {
+		active   string
+		flag     string
+		expected string
+		success  bool
+	}{
+		{"", "", "default", true},
+		{"staging", "", "staging", true},
+		{"staging", "prod", "prod", true},
+		{"staging", "default", "default", true},
+		{"", "dev", "", false},
+	}
+	for i, testCase := range testCases {
+		active := testCase.active
+		if active == "" {
+			active = defaultProfile
+		}
+		if err = setActiveProfile(rootDir, active); err != nil {
+			t.Fatal(err)
+		}
+		profile, err := resolveProfile(rootDir, testCase.flag)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %t, got %v", i+1, testCase.success, err)
+		}
+		if profile != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, profile)
+		}
+	}
+}
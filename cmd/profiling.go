@@ -0,0 +1,107 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"os"
+	"runtime"
+	"runtime/pprof"
+	rtrace "runtime/trace"
+	"sync"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Profiles requested by --cpuprofile, --memprofile and --trace,
+// written when mc exits.
+var globalProfilers = struct {
+	sync.Mutex
+	started bool
+	stops   []func()
+}{}
+
+// createProfileFile - creates a file a profile is written to.
+func createProfileFile(name string) (*os.File, *probe.Error) {
+	f, e := os.Create(name)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(name)
+	}
+	return f, nil
+}
+
+// startProfilers - starts the requested profiles once, empty names are
+// not profiled. Global flags are parsed again for every sub command,
+// only the first request is honored.
+func startProfilers(cpuProfile, memProfile, traceFile string) *probe.Error {
+	globalProfilers.Lock()
+	defer globalProfilers.Unlock()
+	if globalProfilers.started || cpuProfile == "" && memProfile == "" && traceFile == "" {
+		return nil
+	}
+	globalProfilers.started = true
+
+	if cpuProfile != "" {
+		f, err := createProfileFile(cpuProfile)
+		if err != nil {
+			return err
+		}
+		if e := pprof.StartCPUProfile(f); e != nil {
+			f.Close()
+			return probe.NewError(e).Trace(cpuProfile)
+		}
+		globalProfilers.stops = append(globalProfilers.stops, func() {
+			pprof.StopCPUProfile()
+			f.Close()
+		})
+	}
+	if traceFile != "" {
+		f, err := createProfileFile(traceFile)
+		if err != nil {
+			return err
+		}
+		if e := rtrace.Start(f); e != nil {
+			f.Close()
+			return probe.NewError(e).Trace(traceFile)
+		}
+		globalProfilers.stops = append(globalProfilers.stops, func() {
+			rtrace.Stop()
+			f.Close()
+		})
+	}
+	if memProfile != "" {
+		f, err := createProfileFile(memProfile)
+		if err != nil {
+			return err
+		}
+		// Heap profiles show the allocations up to the time they are written.
+		globalProfilers.stops = append(globalProfilers.stops, func() {
+			runtime.GC()
+			pprof.WriteHeapProfile(f)
+			f.Close()
+		})
+	}
+	return nil
+}
+
+// stopProfilers - writes the started profiles, called before mc exits.
+func stopProfilers() {
+	globalProfilers.Lock()
+	defer globalProfilers.Unlock()
+	for _, stop := range globalProfilers.stops {
+		stop()
+	}
+	globalProfilers.stops = nil
+}
@@ -17,19 +17,41 @@
 package cmd
 
 import (
+	"fmt"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cheggaaa/pb"
 	"github.com/fatih/color"
+	"github.com/mattn/go-runewidth"
 
 	"github.com/minio/mc/pkg/console"
 )
 
+const (
+	// Transfer rate is sampled at this interval to compute the ETA.
+	etaSampleInterval = time.Second
+
+	// Weight of the latest rate sample in the moving average, lower
+	// values give a steadier ETA which reacts slower to changes.
+	etaSmoothing = 0.2
+)
+
 // progress extender.
 type progressBar struct {
 	*pb.ProgressBar
+
+	mu sync.Mutex
+
+	// moving average of the transfer rate in bytes per second.
+	rate       float64
+	lastSample time.Time
+	lastBytes  int64
+
+	objects      int64
+	totalObjects int64
 }
 
 // newProgressBar - instantiate a progress bar.
@@ -45,8 +67,13 @@ func newProgressBar(total int64) *progressBar {
 	// Set new human friendly print units.
 	bar.SetUnits(pb.U_BYTES)
 
-	// Refresh rate for progress bar is set to 125 milliseconds.
-	bar.SetRefreshRate(time.Millisecond * 125)
+	// Refresh rate for progress bar, 125 milliseconds by default.
+	bar.SetRefreshRate(globalProgressInterval)
+
+	// Width follows the terminal unless fixed.
+	if globalProgressWidth > 0 {
+		bar.SetWidth(globalProgressWidth)
+	}
 
 	// Do not print a newline by default handled, it is handled manually.
 	bar.NotPrint = true
@@ -54,17 +81,23 @@ func newProgressBar(total int64) *progressBar {
 	// Show current speed is true.
 	bar.ShowSpeed = true
 
+	// Time left is computed from a moving average of the transfer
+	// rate instead, and shown in the postfix.
+	bar.ShowTimeLeft = false
+
 	// Custom callback with colorized bar.
 	bar.Callback = func(s string) {
 		console.Print(console.Colorize("Bar", "\r"+s))
 	}
 
 	// Use different unicodes for Linux, OS X and Windows.
-	switch runtime.GOOS {
-	case "linux":
+	switch {
+	case globalASCII:
+		bar.Format("[=> ]")
+	case runtime.GOOS == "linux":
 		// Need to add '\x00' as delimiter for unicode characters.
 		bar.Format("┃\x00▓\x00█\x00░\x00┃")
-	case "darwin":
+	case runtime.GOOS == "darwin":
 		// Need to add '\x00' as delimiter for unicode characters.
 		bar.Format(" \x00▓\x00 \x00░\x00 ")
 	default:
@@ -106,7 +139,69 @@ func (p *progressBar) Read(buf []byte) (n int, err error) {
 		}
 	}()
 
-	return p.ProgressBar.Read(buf)
+	n, err = p.ProgressBar.Read(buf)
+	p.sample()
+	return n, err
+}
+
+// sample - updates the moving average of the transfer rate once per
+// sample interval and refreshes the postfix.
+func (p *progressBar) sample() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if p.lastSample.IsZero() {
+		p.lastSample = now
+		p.lastBytes = p.ProgressBar.Get()
+		return
+	}
+	elapsed := now.Sub(p.lastSample)
+	if elapsed < etaSampleInterval {
+		return
+	}
+	current := p.ProgressBar.Get()
+	rate := float64(current-p.lastBytes) / elapsed.Seconds()
+	if p.rate == 0 {
+		p.rate = rate
+	} else {
+		p.rate = etaSmoothing*rate + (1-etaSmoothing)*p.rate
+	}
+	p.lastSample = now
+	p.lastBytes = current
+	p.updatePostfix()
+}
+
+// SetTotalObjects - sets the number of objects to be transferred.
+func (p *progressBar) SetTotalObjects(total int64) *progressBar {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.totalObjects = total
+	p.updatePostfix()
+	return p
+}
+
+// AddObject - accounts for an object transfer which is done.
+func (p *progressBar) AddObject() *progressBar {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.objects++
+	p.updatePostfix()
+	return p
+}
+
+// updatePostfix - shows objects done and the time left after the bar,
+// must be called with the mutex held.
+func (p *progressBar) updatePostfix() {
+	var postfix string
+	if p.totalObjects > 0 {
+		postfix += fmt.Sprintf(" objects: %d/%d", p.objects, p.totalObjects)
+	}
+	if remaining := p.ProgressBar.Total - p.ProgressBar.Get(); p.rate > 0 && remaining > 0 {
+		eta := time.Duration(float64(remaining)/p.rate) * time.Second
+		postfix += " ETA " + eta.Round(time.Second).String()
+	}
+	p.ProgressBar.Postfix(postfix)
 }
 
 func (p *progressBar) SetTotal(total int64) *progressBar {
@@ -119,8 +214,10 @@ func cursorAnimate() <-chan string {
 	cursorCh := make(chan string)
 	var cursors string
 
-	switch runtime.GOOS {
-	case "linux":
+	switch {
+	case globalASCII:
+		cursors = "|/-\\"
+	case runtime.GOOS == "linux":
 		// cursors = "➩➪➫➬➭➮➯➱"
 		// cursors = "▁▃▄▅▆▇█▇▆▅▄▃"
 		cursors = "◐◓◑◒"
@@ -128,7 +225,7 @@ func cursorAnimate() <-chan string {
 		// cursors = "◴◷◶◵"
 		// cursors = "◰◳◲◱"
 		//cursors = "⣾⣽⣻⢿⡿⣟⣯⣷"
-	case "darwin":
+	case runtime.GOOS == "darwin":
 		cursors = "◐◓◑◒"
 	default:
 		cursors = "|/-\\"
@@ -143,17 +240,21 @@ func cursorAnimate() <-chan string {
 	return cursorCh
 }
 
-// fixateBarCaption - fancify bar caption based on the terminal width.
+// fixateBarCaption - fancify bar caption based on the terminal width,
+// widths are measured in terminal columns so that wide characters do
+// not make the bar wrap.
 func fixateBarCaption(caption string, width int) string {
-	switch {
-	case len(caption) > width:
-		// Trim caption to fit within the screen
-		trimSize := len(caption) - width + 3
-		if trimSize < len(caption) {
-			caption = "..." + caption[trimSize:]
+	switch captionWidth := runewidth.StringWidth(caption); {
+	case captionWidth > width:
+		// Trim caption from the left to fit within the screen, the
+		// end of a URL is the more useful part.
+		runes := []rune(caption)
+		for len(runes) > 0 && runewidth.StringWidth(string(runes))+3 > width {
+			runes = runes[1:]
 		}
-	case len(caption) < width:
-		caption += strings.Repeat(" ", width-len(caption))
+		caption = "..." + string(runes)
+	case captionWidth < width:
+		caption += strings.Repeat(" ", width-captionWidth)
 	}
 	return caption
 }
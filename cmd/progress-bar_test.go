@@ -0,0 +1,40 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestFixateBarCaption(t *testing.T) {
+	testCases := []struct {
+		caption  string
+		width    int
+		expected string
+	}{
+		{"abc", 5, "abc  "},
+		{"abcde", 5, "abcde"},
+		{"play/bucket/object", 10, ".../object"},
+		{"s3/本語本語", 9, "...語本語"},
+		{"s3/本語", 8, "s3/本語 "},
+	}
+	for i, testCase := range testCases {
+		if caption := fixateBarCaption(testCase.caption, testCase.width); caption != testCase.expected {
+			t.Errorf("Test %d: expected `%s`, got `%s`", i+1, testCase.expected, caption)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/minio/pkg/wildcard"
+)
+
+// Runs destructive flags on protected prefixes.
+var iKnowWhatIAmDoingFlag = cli.BoolFlag{
+	Name:  "i-know-what-i-am-doing",
+	Usage: "allow destructive flags on prefixes protected by 'mc config protect'",
+}
+
+// protectedPattern - returns the pattern protecting urlStr, empty if
+// none. A pattern protects the paths it matches, the paths under them
+// and their parents, which recursive removals reach it through.
+func protectedPattern(urlStr string, patterns []string) string {
+	urlStr = strings.TrimSuffix(filepath.ToSlash(filepath.Clean(urlStr)), "/")
+	for _, pattern := range patterns {
+		pattern = strings.TrimSuffix(pattern, "/")
+		if wildcard.Match(pattern, urlStr) || wildcard.Match(pattern+"/*", urlStr) {
+			return pattern
+		}
+		literal := pattern
+		if i := strings.IndexAny(pattern, "*?"); i >= 0 {
+			literal = pattern[:i]
+		}
+		if strings.HasPrefix(literal, urlStr+"/") {
+			return pattern
+		}
+	}
+	return ""
+}
+
+// checkProtected - refuses flag on any of urls under a protected prefix,
+// unless told otherwise by --i-know-what-i-am-doing.
+func checkProtected(ctx *cli.Context, flag string, urls ...string) {
+	if ctx.Bool("i-know-what-i-am-doing") {
+		return
+	}
+	conf, err := loadMcConfig()
+	if err != nil || len(conf.Protected) == 0 {
+		return
+	}
+	for _, urlStr := range urls {
+		if pattern := protectedPattern(urlStr, conf.Protected); pattern != "" {
+			fatalIf(errInvalidArgument().Trace(urlStr), "`"+urlStr+"` is protected by `"+pattern+"`, `"+flag+
+				"` is refused on it. Retry with `--i-know-what-i-am-doing` if you are really sure.")
+		}
+	}
+}
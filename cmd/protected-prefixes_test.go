@@ -0,0 +1,46 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestProtectedPattern(t *testing.T) {
+	patterns := []string{"prod/*", "s3/backup-*", "play/mybucket/logs/"}
+	testCases := []struct {
+		url     string
+		pattern string
+	}{
+		{"prod", "prod/*"},
+		{"prod/", "prod/*"},
+		{"prod/bucket", "prod/*"},
+		{"prod/bucket/dir/object", "prod/*"},
+		{"production/bucket", ""},
+		{"s3", "s3/backup-*"},
+		{"s3/backup-2019/db.gz", "s3/backup-*"},
+		{"s3/photos", ""},
+		{"play/mybucket", "play/mybucket/logs"},
+		{"play/mybucket/logs", "play/mybucket/logs"},
+		{"play/mybucket/logs/2019/app.log", "play/mybucket/logs"},
+		{"play/mybucket/photos", ""},
+		{"play/mybucket/logsbackup", ""},
+	}
+	for i, testCase := range testCases {
+		if pattern := protectedPattern(testCase.url, patterns); pattern != testCase.pattern {
+			t.Errorf("Test %d: expected `%s` protected by `%s`, got `%s`", i+1, testCase.url, testCase.pattern, pattern)
+		}
+	}
+}
@@ -38,6 +38,7 @@ var (
 			Name:  "dangerous",
 			Usage: "allow site-wide removal of objects",
 		},
+		iKnowWhatIAmDoingFlag,
 	}
 )
 
@@ -110,6 +111,9 @@ func checkRbSyntax(ctx *cli.Context) {
 				"This operation results in **site-wide** removal of buckets. If you are really sure, retry this command with ‘--force’ and ‘--dangerous’ flags.")
 		}
 	}
+	if isForce {
+		checkProtected(ctx, "--force", ctx.Args()...)
+	}
 }
 
 // deletes a bucket and all its contents
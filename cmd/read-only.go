@@ -0,0 +1,57 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"net/http"
+	"sync"
+)
+
+// Read-only setting of the configuration, read once.
+var (
+	readOnlyConfig     bool
+	readOnlyConfigOnce sync.Once
+)
+
+// isReadOnly - reports if requests changing servers are refused, as
+// set by --read-only or the configuration of the profile in use.
+func isReadOnly() bool {
+	if globalReadOnly {
+		return true
+	}
+	readOnlyConfigOnce.Do(func() {
+		conf, err := loadMcConfig()
+		readOnlyConfig = err == nil && conf.ReadOnly
+	})
+	return readOnlyConfig
+}
+
+// readOnlyTransport - refuses mutating requests before they are sent,
+// requests only reading from servers go through.
+type readOnlyTransport struct {
+	transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isMutatingRequest(req) {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, ReadOnlyMode{Method: req.Method}
+	}
+	return t.transport.RoundTrip(req)
+}
@@ -0,0 +1,64 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+var (
+	requestLimiterOnce sync.Once
+	requestLimiter     *rate.Limiter
+)
+
+// getRequestLimiter - returns the limiter shared by all servers, nil
+// if requests are not limited.
+func getRequestLimiter() *rate.Limiter {
+	requestLimiterOnce.Do(func() {
+		if globalMaxRPS > 0 {
+			requestLimiter = rate.NewLimiter(rate.Limit(globalMaxRPS), 1)
+		}
+	})
+	return requestLimiter
+}
+
+// requestLimitTransport - paces requests sent through transport to the
+// rate allowed by limiter, retries included.
+type requestLimitTransport struct {
+	limiter   *rate.Limiter
+	transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t requestLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if e := t.limiter.Wait(req.Context()); e != nil {
+		return nil, e
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// withRequestLimit - wraps transport to honor --max-rps.
+func withRequestLimit(transport http.RoundTripper) http.RoundTripper {
+	limiter := getRequestLimiter()
+	if limiter == nil {
+		return transport
+	}
+	return requestLimitTransport{limiter: limiter, transport: transport}
+}
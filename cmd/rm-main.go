@@ -64,6 +64,23 @@ var (
 			Name:  "newer-than",
 			Usage: "remove objects newer than L days, M hours and N minutes",
 		},
+		cli.BoolFlag{
+			Name:  "versions",
+			Usage: "remove object versions and delete markers of a versioned bucket, along with the current version",
+		},
+		cli.BoolFlag{
+			Name:  "noncurrent",
+			Usage: "with --versions, remove only noncurrent versions and the delete markers they leave alone",
+		},
+		cli.StringFlag{
+			Name:  "trash",
+			Usage: "move objects to a trash folder or bucket prefix instead of removing them",
+		},
+		iKnowWhatIAmDoingFlag,
+		cli.StringFlag{
+			Name:  "report",
+			Usage: "write the objects removed with their version ids to a JSON file, with --fake the objects that would be removed",
+		},
 	}
 )
 
@@ -73,7 +90,7 @@ var rmCmd = cli.Command{
 	Usage:  "remove objects",
 	Action: mainRm,
 	Before: setGlobalsFromContext,
-	Flags:  append(append(rmFlags, ioFlags...), globalFlags...),
+	Flags:  append(append(append(rmFlags, removalConfirmFlags...), ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -113,18 +130,35 @@ EXAMPLES:
 
    9. Remove an encrypted object from Amazon S3 cloud storage.
       $ {{.HelpName}} --encrypt-key "s3/sql-backups/=32byteslongsecretkeymustbegiven1" s3/sql-backups/1999/old-backup.tgz
+
+  10. Remove all objects recursively from bucket 'jazz-songs' without asking for a confirmation.
+      $ {{.HelpName}} --recursive --force --yes s3/jazz-songs/
+
+  11. Remove all objects recursively from bucket 'jazz-songs', keeping them in its trash prefix to restore them later.
+      $ {{.HelpName}} --recursive --force --trash s3/jazz-songs/.trash/ s3/jazz-songs/
+
+  12. Remove all objects recursively from versioned bucket 'jazz-songs', writing the versions removed to 'deleted.json'.
+      $ {{.HelpName}} --recursive --force --report deleted.json s3/jazz-songs/
+
+  13. Remove the versions of objects under 'louis' prefix of versioned bucket 'jazz-songs' that are noncurrent
+      for more than 90 days, along with the delete markers they leave alone.
+      $ {{.HelpName}} --versions --noncurrent --older-than 90d s3/jazz-songs/louis/
 `,
 }
 
 // Structured message depending on the type of console.
 type rmMessage struct {
-	Key  string `json:"key"`
-	Size int64  `json:"size"`
+	Key       string `json:"key"`
+	VersionID string `json:"versionId,omitempty"`
+	Size      int64  `json:"size"`
 }
 
 // Colorized message for console printing.
 func (r rmMessage) String() string {
-	return console.Colorize("Remove", fmt.Sprintf("Removing `%s`.", r.Key))
+	if r.VersionID != "" {
+		return console.Colorize("Remove", fmt.Sprintf("Removing `%s` (version `%s`).", printableKey(r.Key), r.VersionID))
+	}
+	return console.Colorize("Remove", fmt.Sprintf("Removing `%s`.", printableKey(r.Key)))
 }
 
 // JSON'ified message for scripting.
@@ -141,6 +175,7 @@ func checkRmSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 	isRecursive := ctx.Bool("recursive")
 	isStdin := ctx.Bool("stdin")
 	isDangerous := ctx.Bool("dangerous")
+	isVersions := ctx.Bool("versions")
 	isNamespaceRemoval := false
 
 	for _, url := range ctx.Args() {
@@ -172,9 +207,42 @@ func checkRmSyntax(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 		fatalIf(errDummy().Trace(),
 			"This operation results in site-wide removal of objects. If you are really sure, retry this command with ‘--dangerous’ and ‘--force’ flags.")
 	}
+	if ctx.Bool("noncurrent") && !isVersions {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...),
+			"--noncurrent is only valid with --versions.")
+	}
+	if isVersions {
+		if ctx.Bool("incomplete") || ctx.String("trash") != "" {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...),
+				"Versions cannot be removed along with incomplete uploads or moved to trash.")
+		}
+		if isNamespaceRemoval {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...),
+				"Versions are only removed from a bucket, retry with a bucket or a prefix of a bucket.")
+		}
+		// Current versions are removed for good, noncurrent ones are hidden already.
+		if !ctx.Bool("noncurrent") && !isForce {
+			fatalIf(errDummy().Trace(),
+				"Removal of all versions requires --force flag. This operation is *IRREVERSIBLE*. Please review carefully before performing this *DANGEROUS* operation.")
+		}
+		checkProtected(ctx, "--versions", ctx.Args()...)
+	}
+	if ctx.String("trash") != "" && ctx.Bool("incomplete") {
+		fatalIf(errInvalidArgument().Trace(ctx.String("trash")),
+			"Incomplete uploads cannot be moved to trash.")
+	}
+	if ctx.String("report") != "" && ctx.Bool("incomplete") {
+		fatalIf(errInvalidArgument().Trace(ctx.String("report")),
+			"Incomplete uploads are not reported.")
+	}
+	if isRecursive {
+		checkProtected(ctx, "--recursive", ctx.Args()...)
+	} else if isForce {
+		checkProtected(ctx, "--force", ctx.Args()...)
+	}
 }
 
-func removeSingle(url string, isIncomplete bool, isFake, isForce bool, olderThan, newerThan string, encKeyDB map[string][]prefixSSEPair) error {
+func removeSingle(url string, isIncomplete bool, isFake, isForce bool, olderThan, newerThan, trashURL string, report *deletionReport, encKeyDB map[string][]prefixSSEPair) error {
 	isRecursive := false
 	contents, pErr := statURL(url, isIncomplete, isRecursive, encKeyDB)
 	if pErr != nil {
@@ -214,6 +282,15 @@ func removeSingle(url string, isIncomplete bool, isFake, isForce bool, olderThan
 			return exitStatus(globalErrorExitStatus) // End of journey.
 		}
 
+		if trashURL != "" {
+			trashContent := *content
+			trashContent.URL = *newClientURL(targetURL)
+			if pErr = moveToTrash(trashURL, targetAlias, &trashContent, encKeyDB); pErr != nil {
+				errorIf(pErr.Trace(url, trashURL), "Failed to move `"+url+"` to trash.")
+				return exitStatus(globalErrorExitStatus)
+			}
+		}
+
 		contentCh := make(chan *clientContent, 1)
 		contentCh <- &clientContent{URL: *newClientURL(targetURL)}
 		close(contentCh)
@@ -224,17 +301,28 @@ func removeSingle(url string, isIncomplete bool, isFake, isForce bool, olderThan
 				errorIf(pErr.Trace(url), "Failed to remove `"+url+"`.")
 				switch pErr.ToGoError().(type) {
 				case PathInsufficientPermission:
-					// Ignore Permission error.
-					continue
+					// Ignore Permission error, the object is not reported.
+					return nil
 				}
 				return exitStatus(globalErrorExitStatus)
 			}
 		}
 	}
+	if report != nil {
+		if pErr = report.add(deletionEntry{
+			Key:          url,
+			VersionID:    contentVersionID(content),
+			Size:         content.Size,
+			LastModified: content.Time,
+		}); pErr != nil {
+			errorIf(pErr.Trace(url), "Unable to write the deletion report.")
+			return exitStatus(globalErrorExitStatus)
+		}
+	}
 	return nil
 }
 
-func removeRecursive(url string, isIncomplete bool, isFake bool, olderThan, newerThan string, encKeyDB map[string][]prefixSSEPair) error {
+func removeRecursive(url string, isIncomplete bool, isFake bool, olderThan, newerThan, trashURL string, report *deletionReport, encKeyDB map[string][]prefixSSEPair) error {
 	targetAlias, targetURL, _ := mustExpandAlias(url)
 	clnt, pErr := newClientFromAlias(targetAlias, targetURL)
 	if pErr != nil {
@@ -246,6 +334,59 @@ func removeRecursive(url string, isIncomplete bool, isFake bool, olderThan, newe
 
 	errorCh := clnt.Remove(isIncomplete, isRemoveBucket, contentCh)
 
+	// Versions removed are found along with the listing.
+	var versions *versionCursor
+	if report != nil {
+		versions = newVersionCursor(clnt)
+	}
+
+	// Objects are reported once the removals are done, leaving out
+	// those which failed.
+	var removed []deletionEntry
+	failed := map[string]bool{}
+	isFailureUnknown := false
+	addFailure := func(pErr *probe.Error) {
+		if pErr == nil {
+			return
+		}
+		if path := removalFailurePath(pErr); path != "" {
+			failed[targetAlias+path] = true
+		} else {
+			isFailureUnknown = true
+		}
+	}
+	// finish - waits for the removals to be done and reports them.
+	finish := func(rerr error) error {
+		close(contentCh)
+		for pErr := range errorCh {
+			errorIf(pErr.Trace(url), "Failed to remove `"+url+"` recursively.")
+			addFailure(pErr)
+			switch pErr.ToGoError().(type) {
+			case PathInsufficientPermission:
+				// Ignore Permission error.
+				continue
+			}
+			rerr = exitStatus(globalErrorExitStatus)
+		}
+		if report == nil {
+			return rerr
+		}
+		if isFailureUnknown {
+			errorIf(errDummy().Trace(url), "Unable to tell the objects removed from `"+url+"`, they are not reported.")
+			return exitStatus(globalErrorExitStatus)
+		}
+		for _, entry := range removed {
+			if failed[entry.Key] {
+				continue
+			}
+			if pErr := report.add(entry); pErr != nil {
+				errorIf(pErr.Trace(entry.Key), "Unable to write the deletion report.")
+				return exitStatus(globalErrorExitStatus)
+			}
+		}
+		return rerr
+	}
+
 	isRecursive := true
 	for content := range clnt.List(isRecursive, isIncomplete, DirLast) {
 		if content.Err != nil {
@@ -255,11 +396,15 @@ func removeRecursive(url string, isIncomplete bool, isFake bool, olderThan, newe
 				// Ignore Permission error.
 				continue
 			}
-			close(contentCh)
-			return exitStatus(globalErrorExitStatus)
+			return finish(exitStatus(globalErrorExitStatus))
 		}
 		urlString := content.URL.Path
 
+		// Never remove the trash objects are moved to.
+		if trashURL != "" && isInTrash(trashURL, targetAlias, urlString) {
+			continue
+		}
+
 		if !content.Time.IsZero() {
 			// Skip objects older than --older-than parameter if specified
 			if olderThan != "" && isOlder(content.Time, olderThan) {
@@ -277,6 +422,13 @@ func removeRecursive(url string, isIncomplete bool, isFake bool, olderThan, newe
 			Size: content.Size,
 		})
 
+		if !isFake && trashURL != "" && !content.Type.IsDir() {
+			if pErr := moveToTrash(trashURL, targetAlias, content, encKeyDB); pErr != nil {
+				errorIf(pErr.Trace(urlString, trashURL), "Failed to move `"+urlString+"` to trash.")
+				return finish(exitStatus(globalErrorExitStatus))
+			}
+		}
+
 		if !isFake {
 			sent := false
 			for !sent {
@@ -285,30 +437,61 @@ func removeRecursive(url string, isIncomplete bool, isFake bool, olderThan, newe
 					sent = true
 				case pErr := <-errorCh:
 					errorIf(pErr.Trace(urlString), "Failed to remove `"+urlString+"`.")
+					addFailure(pErr)
 					switch pErr.ToGoError().(type) {
 					case PathInsufficientPermission:
 						// Ignore Permission error.
 						continue
 					}
-					close(contentCh)
-					return exitStatus(globalErrorExitStatus)
+					return finish(exitStatus(globalErrorExitStatus))
 				}
 			}
 		}
-	}
 
-	close(contentCh)
-	for pErr := range errorCh {
-		errorIf(pErr.Trace(url), "Failed to remove `"+url+"` recursively.")
-		switch pErr.ToGoError().(type) {
-		case PathInsufficientPermission:
-			// Ignore Permission error.
-			continue
+		if report != nil && !content.Type.IsDir() {
+			removed = append(removed, deletionEntry{
+				Key:          targetAlias + urlString,
+				VersionID:    versions.lookup(urlString),
+				Size:         content.Size,
+				LastModified: content.Time,
+			})
 		}
-		return exitStatus(globalErrorExitStatus)
 	}
 
-	return nil
+	return finish(nil)
+}
+
+// removalFailurePath - returns the path of the object a removal failed
+// on, empty if the error does not tell.
+func removalFailurePath(pErr *probe.Error) string {
+	switch e := pErr.ToGoError().(type) {
+	case ObjectRemoveFailed:
+		return e.Path
+	case PathInsufficientPermission:
+		return e.Path
+	case *os.PathError:
+		return e.Path
+	}
+	return ""
+}
+
+// confirmRecursiveRemoval - asks for a confirmation before removing
+// more objects than the threshold under url.
+func confirmRecursiveRemoval(ctx *cli.Context, url string, isIncomplete bool, olderThan, newerThan string, canPrompt bool) {
+	if skipConfirmation(ctx) {
+		return
+	}
+	targetAlias, targetURL, _ := mustExpandAlias(url)
+	clnt, err := newClientFromAlias(targetAlias, targetURL)
+	if err != nil {
+		// Reported by the removal.
+		return
+	}
+	preview, err := previewRecursiveRemoval(clnt, isIncomplete, olderThan, newerThan)
+	if err != nil {
+		return
+	}
+	confirmRemoval(ctx, url, preview, canPrompt)
 }
 
 // main for rm command.
@@ -328,6 +511,18 @@ func mainRm(ctx *cli.Context) error {
 	olderThan := ctx.String("older-than")
 	newerThan := ctx.String("newer-than")
 	isForce := ctx.Bool("force")
+	trashURL := ctx.String("trash")
+	isVersions := ctx.Bool("versions")
+	isNoncurrent := ctx.Bool("noncurrent")
+
+	var report *deletionReport
+	if reportPath := ctx.String("report"); reportPath != "" {
+		report, err = newDeletionReport(reportPath, isFake)
+		fatalIf(err, "Unable to create the deletion report.")
+		defer func() {
+			fatalIf(report.close(), "Unable to write the deletion report.")
+		}()
+	}
 
 	// Set color.
 	console.SetColor("Remove", color.New(color.FgGreen, color.Bold))
@@ -336,10 +531,13 @@ func mainRm(ctx *cli.Context) error {
 	var e error
 	// Support multiple targets.
 	for _, url := range ctx.Args() {
-		if isRecursive {
-			e = removeRecursive(url, isIncomplete, isFake, olderThan, newerThan, encKeyDB)
+		if isVersions {
+			e = removeVersions(url, isFake, isNoncurrent, olderThan, newerThan, report)
+		} else if isRecursive {
+			confirmRecursiveRemoval(ctx, url, isIncomplete, olderThan, newerThan, !isStdin)
+			e = removeRecursive(url, isIncomplete, isFake, olderThan, newerThan, trashURL, report, encKeyDB)
 		} else {
-			e = removeSingle(url, isIncomplete, isFake, isForce, olderThan, newerThan, encKeyDB)
+			e = removeSingle(url, isIncomplete, isFake, isForce, olderThan, newerThan, trashURL, report, encKeyDB)
 		}
 
 		if rerr == nil {
@@ -354,10 +552,18 @@ func mainRm(ctx *cli.Context) error {
 	scanner := bufio.NewScanner(os.Stdin)
 	for scanner.Scan() {
 		url := scanner.Text()
-		if isRecursive {
-			e = removeRecursive(url, isIncomplete, isFake, olderThan, newerThan, encKeyDB)
+		if isVersions {
+			checkProtected(ctx, "--versions", url)
+			e = removeVersions(url, isFake, isNoncurrent, olderThan, newerThan, report)
+		} else if isRecursive {
+			checkProtected(ctx, "--recursive", url)
+			confirmRecursiveRemoval(ctx, url, isIncomplete, olderThan, newerThan, false)
+			e = removeRecursive(url, isIncomplete, isFake, olderThan, newerThan, trashURL, report, encKeyDB)
 		} else {
-			e = removeSingle(url, isIncomplete, isFake, isForce, olderThan, newerThan, encKeyDB)
+			if isForce {
+				checkProtected(ctx, "--force", url)
+			}
+			e = removeSingle(url, isIncomplete, isFake, isForce, olderThan, newerThan, trashURL, report, encKeyDB)
 		}
 
 		if rerr == nil {
@@ -0,0 +1,155 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// isVersionAgeSkipped - returns true if a version dated t is left alone
+// by --older-than and --newer-than.
+func isVersionAgeSkipped(t time.Time, olderThan, newerThan string) bool {
+	if olderThan != "" && isOlder(t, olderThan) {
+		return true
+	}
+	return newerThan != "" && isNewer(t, newerThan)
+}
+
+// selectRemovedVersions - returns the versions of a key, newest first,
+// removed by rm --versions. All of them are removed, or with noncurrent
+// only those which are not the current version, aged from the time the
+// next version replaced them. A delete marker left as the only version
+// of its key is removed along with them.
+func selectRemovedVersions(versions []objectVersion, isNoncurrent bool, olderThan, newerThan string) []objectVersion {
+	var removed []objectVersion
+	if !isNoncurrent {
+		for _, version := range versions {
+			if !isVersionAgeSkipped(version.LastModified, olderThan, newerThan) {
+				removed = append(removed, version)
+			}
+		}
+		return removed
+	}
+	if len(versions) == 0 {
+		return nil
+	}
+	for i := 1; i < len(versions); i++ {
+		if !isVersionAgeSkipped(versions[i-1].LastModified, olderThan, newerThan) {
+			removed = append(removed, versions[i])
+		}
+	}
+	latest := versions[0]
+	if latest.IsDeleteMarker && len(removed) == len(versions)-1 &&
+		!isVersionAgeSkipped(latest.LastModified, olderThan, newerThan) {
+		removed = append([]objectVersion{latest}, removed...)
+	}
+	return removed
+}
+
+// removeVersions - removes the versions of the objects under url on a
+// versioned bucket, in bulk.
+func removeVersions(url string, isFake, isNoncurrent bool, olderThan, newerThan string, report *deletionReport) error {
+	targetAlias, targetURL, _ := mustExpandAlias(url)
+	clnt, pErr := newClientFromAlias(targetAlias, targetURL)
+	if pErr != nil {
+		errorIf(pErr.Trace(url), "Failed to remove versions of `"+url+"`.")
+		return exitStatus(globalErrorExitStatus)
+	}
+	s3Clnt, ok := clnt.(*s3Client)
+	if !ok {
+		errorIf(probe.NewError(APINotImplemented{API: "ListObjectVersions", APIType: "filesystem"}).Trace(url),
+			"Failed to remove versions of `"+url+"`.")
+		return exitStatus(globalErrorExitStatus)
+	}
+	bucket, _ := s3Clnt.url2BucketAndObject()
+	objectURL := func(key string) string {
+		return targetAlias + "/" + bucket + "/" + key
+	}
+
+	var rerr error
+	var pending []objectVersion
+	flush := func() {
+		if len(pending) == 0 {
+			return
+		}
+		failed := map[removedVersion]bool{}
+		if !isFake {
+			failedVersions, pErr := s3Clnt.removeObjectVersions(pending)
+			if pErr != nil {
+				errorIf(pErr.Trace(url), "Failed to remove versions of `"+url+"`.")
+				pending = nil
+				rerr = exitStatus(globalErrorExitStatus)
+				return
+			}
+			for _, version := range failedVersions {
+				errorIf(version.Err.Trace(objectURL(version.Key), version.VersionID),
+					"Failed to remove version `"+version.VersionID+"` of `"+objectURL(version.Key)+"`.")
+				failed[removedVersion{Key: version.Key, VersionID: version.VersionID}] = true
+				rerr = exitStatus(globalErrorExitStatus)
+			}
+		}
+		for _, version := range pending {
+			if report == nil || failed[removedVersion{Key: version.Key, VersionID: version.VersionID}] {
+				continue
+			}
+			if pErr := report.add(deletionEntry{
+				Key:          objectURL(version.Key),
+				VersionID:    version.VersionID,
+				Size:         version.Size,
+				LastModified: version.LastModified,
+			}); pErr != nil {
+				errorIf(pErr.Trace(url), "Unable to write the deletion report.")
+				rerr = exitStatus(globalErrorExitStatus)
+				break
+			}
+		}
+		pending = nil
+	}
+	remove := func(versions []objectVersion) {
+		for _, version := range selectRemovedVersions(versions, isNoncurrent, olderThan, newerThan) {
+			printMsg(rmMessage{
+				Key:       objectURL(version.Key),
+				VersionID: version.VersionID,
+				Size:      version.Size,
+			})
+			pending = append(pending, version)
+			if len(pending) == maxRemovedVersions {
+				flush()
+			}
+		}
+	}
+
+	// Versions of a key come together, newest first.
+	var versions []objectVersion
+	for version := range s3Clnt.listObjectVersions() {
+		if version.Err != nil {
+			errorIf(version.Err.Trace(url), "Failed to list versions of `"+url+"`.")
+			flush()
+			return exitStatus(globalErrorExitStatus)
+		}
+		if len(versions) > 0 && versions[0].Key != version.Key {
+			remove(versions)
+			versions = nil
+		}
+		versions = append(versions, version)
+	}
+	remove(versions)
+	flush()
+	return rerr
+}
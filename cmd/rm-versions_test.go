@@ -0,0 +1,70 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSelectRemovedVersions(t *testing.T) {
+	now := UTCNow()
+	daysAgo := func(days int) time.Time {
+		return now.Add(-time.Duration(days) * 24 * time.Hour)
+	}
+	version := func(id string, days int) objectVersion {
+		return objectVersion{Key: "a.txt", VersionID: id, LastModified: daysAgo(days)}
+	}
+	marker := func(id string, days int) objectVersion {
+		return objectVersion{Key: "a.txt", VersionID: id, LastModified: daysAgo(days), IsDeleteMarker: true}
+	}
+
+	testCases := []struct {
+		versions     []objectVersion
+		isNoncurrent bool
+		olderThan    string
+		removed      []string
+	}{
+		// All versions.
+		{[]objectVersion{version("v3", 1), version("v2", 100), version("v1", 200)}, false, "", []string{"v3", "v2", "v1"}},
+		// All versions older than 90 days.
+		{[]objectVersion{version("v3", 1), version("v2", 100), version("v1", 200)}, false, "90d", []string{"v2", "v1"}},
+		// Noncurrent versions, the current one is kept.
+		{[]objectVersion{version("v3", 1), version("v2", 100), version("v1", 200)}, true, "", []string{"v2", "v1"}},
+		// Noncurrent versions are aged from the version replacing them.
+		{[]objectVersion{version("v3", 1), version("v2", 100), version("v1", 200)}, true, "90d", []string{"v1"}},
+		{[]objectVersion{version("v3", 95), version("v2", 100), version("v1", 200)}, true, "90d", []string{"v2", "v1"}},
+		// A delete marker left alone is removed with the versions it hides.
+		{[]objectVersion{marker("m1", 120), version("v2", 150), version("v1", 200)}, true, "90d", []string{"m1", "v2", "v1"}},
+		{[]objectVersion{marker("m1", 120)}, true, "90d", []string{"m1"}},
+		// A recent delete marker is kept, as is one hiding versions kept.
+		{[]objectVersion{marker("m1", 10)}, true, "90d", nil},
+		{[]objectVersion{marker("m1", 10), version("v1", 200)}, true, "90d", nil},
+		{[]objectVersion{marker("m2", 100), version("v2", 110), marker("m1", 120)}, true, "90d", []string{"m2", "v2", "m1"}},
+		{nil, true, "90d", nil},
+	}
+	for i, testCase := range testCases {
+		var removed []string
+		for _, v := range selectRemovedVersions(testCase.versions, testCase.isNoncurrent, testCase.olderThan, "") {
+			removed = append(removed, v.VersionID)
+		}
+		if strings.Join(removed, ",") != strings.Join(testCase.removed, ",") {
+			t.Fatalf("Test %d: expected versions %v to be removed, got %v", i+1, testCase.removed, removed)
+		}
+	}
+}
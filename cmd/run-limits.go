@@ -0,0 +1,171 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// parseMaxDuration - returns the duration of a limit such as '4h', 0 if
+// limit is empty.
+func parseMaxDuration(limit string) (time.Duration, *probe.Error) {
+	if limit == "" {
+		return 0, nil
+	}
+	duration, e := time.ParseDuration(limit)
+	if e != nil {
+		return 0, probe.NewError(e).Trace(limit)
+	}
+	if duration <= 0 {
+		return 0, errInvalidArgument().Trace(limit)
+	}
+	return duration, nil
+}
+
+// parseMaxBytes - returns the bytes of a limit such as '500GiB', 0 if
+// limit is empty.
+func parseMaxBytes(limit string) (int64, *probe.Error) {
+	if limit == "" {
+		return 0, nil
+	}
+	bytes, e := humanize.ParseBytes(limit)
+	if e != nil {
+		return 0, probe.NewError(e).Trace(limit)
+	}
+	if bytes == 0 || bytes > math.MaxInt64 {
+		return 0, errInvalidArgument().Trace(limit)
+	}
+	return int64(bytes), nil
+}
+
+// runLimits - stops a copy or mirror from starting new transfers once it
+// ran for longer than --max-duration, or once the next one would take
+// it over --max-bytes, transfers in flight are finished.
+type runLimits struct {
+	maxDuration time.Duration
+	deadline    time.Time
+	maxBytes    int64
+
+	mutex  sync.Mutex
+	bytes  int64
+	reason string
+	// closed once a limit is reached.
+	doneCh chan struct{}
+}
+
+// newRunLimits - returns the limits of a run starting now, a run with
+// no limits is never stopped.
+func newRunLimits(maxDuration time.Duration, maxBytes int64) *runLimits {
+	l := &runLimits{maxDuration: maxDuration, maxBytes: maxBytes, doneCh: make(chan struct{})}
+	if maxDuration > 0 {
+		l.deadline = UTCNow().Add(maxDuration)
+		time.AfterFunc(maxDuration, func() {
+			l.stop(fmt.Sprintf("Reached --max-duration of %s.", maxDuration))
+		})
+	}
+	return l
+}
+
+// stop - marks the run as stopped for reason, once.
+func (l *runLimits) stop(reason string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.stopLocked(reason)
+}
+
+func (l *runLimits) stopLocked(reason string) {
+	if l.reason != "" {
+		return
+	}
+	l.reason = reason
+	close(l.doneCh)
+}
+
+// allow - returns true if a new transfer of size bytes may start, its
+// bytes are then counted against --max-bytes.
+func (l *runLimits) allow(size int64) bool {
+	if l.maxDuration > 0 && !UTCNow().Before(l.deadline) {
+		l.stop(fmt.Sprintf("Reached --max-duration of %s.", l.maxDuration))
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.reason != "" {
+		return false
+	}
+	if l.maxBytes > 0 && l.bytes+size > l.maxBytes {
+		l.stopLocked(fmt.Sprintf("Reached --max-bytes of %s after %s.", humanize.IBytes(uint64(l.maxBytes)), humanize.IBytes(uint64(l.bytes))))
+		return false
+	}
+	l.bytes += size
+	return true
+}
+
+// reached - returns true once a limit stopped the run.
+func (l *runLimits) reached() bool {
+	select {
+	case <-l.doneCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// done - returns a channel closed once a limit stopped the run.
+func (l *runLimits) done() <-chan struct{} {
+	return l.doneCh
+}
+
+// message - returns the report of the limit which stopped the run.
+func (l *runLimits) message(sessionID string) runLimitMessage {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return runLimitMessage{Status: "success", Reason: l.reason, SessionID: sessionID}
+}
+
+// runLimitMessage - a copy or mirror stopped by a limit before the end.
+type runLimitMessage struct {
+	Status    string `json:"status"`
+	Reason    string `json:"reason"`
+	SessionID string `json:"sessionId,omitempty"`
+}
+
+// String colorized run limit message.
+func (r runLimitMessage) String() string {
+	msg := r.Reason + " Transfers in flight were finished, no new ones were started."
+	if r.SessionID != "" {
+		msg += " To resume session `mc session resume " + r.SessionID + "`"
+	} else {
+		msg += " Run the mirror again to continue."
+	}
+	return console.Colorize("RunLimit", msg)
+}
+
+// JSON jsonified run limit message.
+func (r runLimitMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
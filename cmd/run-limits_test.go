@@ -0,0 +1,105 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseMaxDuration(t *testing.T) {
+	testCases := []struct {
+		limit    string
+		duration time.Duration
+		success  bool
+	}{
+		{"", 0, true},
+		{"4h", 4 * time.Hour, true},
+		{"90m", 90 * time.Minute, true},
+		{"0s", 0, false},
+		{"-1h", 0, false},
+		{"4 hours", 0, false},
+	}
+	for i, testCase := range testCases {
+		duration, err := parseMaxDuration(testCase.limit)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %t, got error %v", i+1, testCase.success, err)
+		}
+		if duration != testCase.duration {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.duration, duration)
+		}
+	}
+}
+
+func TestRunLimitsMaxDuration(t *testing.T) {
+	limits := newRunLimits(0, 0)
+	if !limits.allow(1<<40) || limits.reached() {
+		t.Fatal("Runs without limits are not expected to stop")
+	}
+
+	limits = newRunLimits(time.Millisecond, 0)
+	select {
+	case <-limits.done():
+	case <-time.After(time.Second):
+		t.Fatal("Expected the run to stop once --max-duration passed")
+	}
+	if limits.allow(0) {
+		t.Fatal("No new transfers are expected once --max-duration passed")
+	}
+	if msg := limits.message("abc"); msg.Reason == "" || msg.SessionID != "abc" {
+		t.Fatalf("Unexpected message %+v", msg)
+	}
+}
+
+func TestParseMaxBytes(t *testing.T) {
+	testCases := []struct {
+		limit   string
+		bytes   int64
+		success bool
+	}{
+		{"", 0, true},
+		{"500GiB", 500 << 30, true},
+		{"1KB", 1000, true},
+		{"0", 0, false},
+		{"lots", 0, false},
+	}
+	for i, testCase := range testCases {
+		bytes, err := parseMaxBytes(testCase.limit)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %t, got error %v", i+1, testCase.success, err)
+		}
+		if bytes != testCase.bytes {
+			t.Fatalf("Test %d: expected %d, got %d", i+1, testCase.bytes, bytes)
+		}
+	}
+}
+
+func TestRunLimitsMaxBytes(t *testing.T) {
+	limits := newRunLimits(0, 100)
+	if !limits.allow(60) || !limits.allow(40) {
+		t.Fatal("Transfers within --max-bytes are expected to start")
+	}
+	if limits.reached() {
+		t.Fatal("The run is not expected to stop before a transfer goes over --max-bytes")
+	}
+	if limits.allow(1) || !limits.reached() {
+		t.Fatal("Transfers over --max-bytes are not expected to start")
+	}
+	if limits.allow(0) {
+		t.Fatal("No new transfers are expected once the run stopped")
+	}
+}
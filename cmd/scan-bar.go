@@ -19,6 +19,7 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/minio/mc/pkg/console"
@@ -44,12 +45,19 @@ type scanBarFunc func(string)
 // scanBarFactory returns a progress bar function to report URL scanning.
 func scanBarFactory() scanBarFunc {
 	fileCount := 0
+	var lastPrint time.Time
 
 	// Cursor animate channel.
 	cursorCh := cursorAnimate()
 	return func(source string) {
+		// Objects are counted but only printed once per refresh interval.
+		if time.Since(lastPrint) < globalProgressInterval {
+			fileCount++
+			return
+		}
+		lastPrint = time.Now()
 		scanPrefix := fmt.Sprintf("[%s] %s ", humanize.Comma(int64(fileCount)), <-cursorCh)
-		source = fixateScanBar(source, globalTermWidth-len([]rune(scanPrefix)))
+		source = fixateScanBar(source, getTermWidth()-len([]rune(scanPrefix)))
 		barText := scanPrefix + source
 		console.PrintC("\r" + barText + "\r")
 		fileCount++
@@ -0,0 +1,316 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/rand"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+// scrub specific flags.
+var (
+	scrubFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "sample",
+			Value: "100%",
+			Usage: "read back only this percentage of objects, picked at random",
+		},
+		cli.BoolFlag{
+			Name:  "partial",
+			Usage: "read only ranges at the start, middle and end of large objects, their checksums are not verified",
+		},
+	}
+)
+
+// Read back objects and verify their checksums.
+var scrubCmd = cli.Command{
+	Name:   "scrub",
+	Usage:  "read back objects to find corrupt or unreadable ones",
+	Action: mainScrub,
+	Before: setGlobalsFromContext,
+	Flags:  append(append(scrubFlags, ioFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET [TARGET ...]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+   MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+
+DESCRIPTION:
+   Objects are read in full and their MD5 sum is compared with their ETag.
+   Objects uploaded in several parts or encrypted by the server do not have
+   an MD5 ETag, they are read in full but reported as unverified.
+
+EXAMPLES:
+   1. Verify all objects of bucket 'archive'.
+      $ {{.HelpName}} s3/archive
+
+   2. Verify a random sample of 5% of the objects under prefix '2019/' of bucket 'archive'.
+      $ {{.HelpName}} --sample 5% s3/archive/2019/
+
+   3. Find objects which cannot be read, reading only a few ranges of each object.
+      $ {{.HelpName}} --partial s3/archive
+
+   4. Verify objects encrypted with a customer provided key.
+      $ {{.HelpName}} --encrypt-key "s3/archive/=32byteslongsecretkeymustbegiven1" s3/archive
+`,
+}
+
+// scrubMessage container for an object which failed verification.
+type scrubMessage struct {
+	Status string `json:"status"`
+	URL    string `json:"url"`
+	Size   int64  `json:"size"`
+	ETag   string `json:"etag,omitempty"`
+	MD5    string `json:"md5,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// String colorized scrub message.
+func (s scrubMessage) String() string {
+	if s.Status == "corrupt" {
+		return console.Colorize("ScrubCorrupt", fmt.Sprintf("Corrupt: `%s`, ETag %s does not match MD5 %s.", s.URL, s.ETag, s.MD5))
+	}
+	return console.Colorize("ScrubCorrupt", fmt.Sprintf("Unreadable: `%s`, %s", s.URL, s.Error))
+}
+
+// JSON jsonified scrub message.
+func (s scrubMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// scrubSummaryMessage container for the totals of a scrub.
+type scrubSummaryMessage struct {
+	Status     string `json:"status"`
+	Scanned    int64  `json:"scanned"`
+	Verified   int64  `json:"verified"`
+	Unverified int64  `json:"unverified"`
+	Corrupt    int64  `json:"corrupt"`
+	Unreadable int64  `json:"unreadable"`
+	BytesRead  int64  `json:"bytesRead"`
+}
+
+// String colorized scrub summary message.
+func (s scrubSummaryMessage) String() string {
+	message := fmt.Sprintf("Scrubbed %d objects, %s read: %d verified, %d unverified, %d corrupt, %d unreadable.",
+		s.Scanned, humanize.IBytes(uint64(s.BytesRead)), s.Verified, s.Unverified, s.Corrupt, s.Unreadable)
+	if s.Corrupt > 0 || s.Unreadable > 0 {
+		return console.Colorize("ScrubCorrupt", message)
+	}
+	return console.Colorize("Scrub", message)
+}
+
+// JSON jsonified scrub summary message.
+func (s scrubSummaryMessage) JSON() string {
+	s.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// parseScrubSample - parses a percentage such as "5%" or "0.5", the
+// percent sign is optional.
+func parseScrubSample(sample string) (float64, *probe.Error) {
+	percent, e := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(sample), "%"), 64)
+	if e != nil {
+		return 0, probe.NewError(e)
+	}
+	if percent <= 0 || percent > 100 {
+		return 0, errInvalidArgument().Trace(sample)
+	}
+	return percent, nil
+}
+
+// isServerEncrypted - returns true if content is encrypted by the
+// server, its ETag is then not the MD5 sum of the object.
+func isServerEncrypted(content *clientContent) bool {
+	for _, headers := range []map[string]string{content.Metadata, content.EncryptionHeaders} {
+		for k := range headers {
+			if strings.HasPrefix(strings.ToLower(k), "x-amz-server-side-encryption") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// readBack - reads the object of clnt into w, returning the number of
+// bytes read. With partial, only ranges at the start, middle and end
+// of large objects are read and sampled is true.
+func readBack(clnt Client, size int64, partial bool, sse encrypt.ServerSide, w io.Writer) (n int64, sampled bool, err *probe.Error) {
+	reader, err := clnt.Get(sse)
+	if err != nil {
+		return 0, false, err.Trace(clnt.GetURL().String())
+	}
+	defer reader.Close()
+
+	if readerAt, ok := reader.(io.ReaderAt); ok && partial && size > 3*duplicateSampleSize {
+		for _, offset := range []int64{0, (size - duplicateSampleSize) / 2, size - duplicateSampleSize} {
+			m, e := io.Copy(w, io.NewSectionReader(readerAt, offset, duplicateSampleSize))
+			n += m
+			if e != nil {
+				return n, true, probe.NewError(e)
+			}
+		}
+		return n, true, nil
+	}
+	n, e := io.Copy(w, reader)
+	if e != nil {
+		return n, false, probe.NewError(e)
+	}
+	if n != size {
+		return n, false, probe.NewError(UnexpectedEOF{TotalSize: size, TotalWritten: n})
+	}
+	return n, false, nil
+}
+
+// scrubObject - reads back content, updating summary. ETags of single
+// part uploads are compared with the MD5 sum of the data read.
+func scrubObject(alias string, content *clientContent, partial bool, encKeyDB map[string][]prefixSSEPair, summary *scrubSummaryMessage) {
+	urlStr := content.URL.String()
+	msg := scrubMessage{
+		URL:  urlStr,
+		Size: content.Size,
+		ETag: strings.Trim(content.ETag, "\""),
+	}
+	summary.Scanned++
+
+	clnt, err := newClientFromAlias(alias, urlStr)
+	if err != nil {
+		summary.Unreadable++
+		msg.Status, msg.Error = "unreadable", err.ToGoError().Error()
+		printMsg(msg)
+		return
+	}
+	sse := getSSE(filepath.ToSlash(filepath.Join(alias, clnt.GetURL().Path)), encKeyDB[alias])
+	hash := md5.New()
+
+	n, sampled, err := readBack(clnt, content.Size, partial, sse, hash)
+	summary.BytesRead += n
+	if err != nil {
+		summary.Unreadable++
+		msg.Status, msg.Error = "unreadable", err.ToGoError().Error()
+		printMsg(msg)
+		return
+	}
+
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if sampled || sse != nil || !md5ETagRe.MatchString(msg.ETag) {
+		summary.Unverified++
+		return
+	}
+	if !strings.EqualFold(sum, msg.ETag) {
+		// ETags of objects encrypted with SSE-S3 or SSE-KMS
+		// are not their MD5 sum either, check before reporting.
+		if s3Clnt, ok := clnt.(*s3Client); ok {
+			bucket, object := s3Clnt.url2BucketAndObject()
+			if st, err := s3Clnt.getObjectStat(bucket, object, minio.StatObjectOptions{}); err == nil && isServerEncrypted(st) {
+				summary.Unverified++
+				return
+			}
+		}
+		summary.Corrupt++
+		msg.Status, msg.MD5 = "corrupt", sum
+		printMsg(msg)
+		return
+	}
+	summary.Verified++
+}
+
+// checkScrubSyntax - validate all the passed arguments
+func checkScrubSyntax(ctx *cli.Context) {
+	if !ctx.Args().Present() {
+		cli.ShowCommandHelpAndExit(ctx, "scrub", 1) // last argument is exit code
+	}
+	for _, url := range ctx.Args() {
+		if strings.TrimSpace(url) == "" {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...), "Unable to validate empty argument.")
+		}
+	}
+	_, err := parseScrubSample(ctx.String("sample"))
+	fatalIf(err, "Unable to parse ‘--sample’, expected a percentage between 0 and 100%.")
+}
+
+// mainScrub - is a handler for mc scrub command
+func mainScrub(ctx *cli.Context) error {
+	// check 'scrub' cli arguments.
+	checkScrubSyntax(ctx)
+
+	// Parse encryption keys per command.
+	encKeyDB, err := getEncKeys(ctx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	console.SetColor("Scrub", color.New(color.FgGreen, color.Bold))
+	console.SetColor("ScrubCorrupt", color.New(color.FgRed, color.Bold))
+
+	percent, _ := parseScrubSample(ctx.String("sample"))
+	partial := ctx.Bool("partial")
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	var summary scrubSummaryMessage
+	for _, url := range ctx.Args() {
+		alias, _, _ := mustExpandAlias(url)
+		clnt, err := newClient(url)
+		fatalIf(err.Trace(url), "Unable to initialize target `"+url+"`.")
+
+		for content := range clnt.List(true, false, DirNone) {
+			if content.Err != nil {
+				errorIf(content.Err.Trace(url), "Unable to list `"+url+"`.")
+				summary.Unreadable++
+				continue
+			}
+			if !content.Type.IsRegular() {
+				continue
+			}
+			if percent < 100 && random.Float64()*100 >= percent {
+				continue
+			}
+			scrubObject(alias, content, partial, encKeyDB, &summary)
+		}
+	}
+	printMsg(summary)
+
+	if summary.Corrupt > 0 || summary.Unreadable > 0 {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
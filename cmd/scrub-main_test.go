@@ -0,0 +1,39 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import "testing"
+
+func TestParseScrubSample(t *testing.T) {
+	testCases := []struct {
+		sample  string
+		percent float64
+		ok      bool
+	}{
+		{"100%", 100, true},
+		{"5%", 5, true},
+		{"0.5", 0.5, true},
+		{"0%", 0, false},
+		{"101%", 0, false},
+		{"five", 0, false},
+	}
+	for i, testCase := range testCases {
+		percent, err := parseScrubSample(testCase.sample)
+		if (err == nil) != testCase.ok || percent != testCase.percent {
+			t.Fatalf("Test %d: expected (%v, %t), got (%v, %v)", i+1, testCase.percent, testCase.ok, percent, err)
+		}
+	}
+}
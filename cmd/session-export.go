@@ -0,0 +1,176 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Version of the records written by session export, see
+// docs/session-file-format.md.
+const sessionExportVersion = "1"
+
+var sessionExportFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "format",
+		Value: "json",
+		Usage: "output format, json or csv",
+	},
+}
+
+var sessionExport = cli.Command{
+	Name:            "export",
+	Usage:           "export the objects planned and copied by a session",
+	Action:          mainSessionExport,
+	Before:          setGlobalsFromContext,
+	Flags:           append(sessionExportFlags, globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] SESSION-ID
+
+SESSION-ID:
+  SESSION - Session is your previously saved SESSION-ID
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Export a session as one JSON object per line.
+     $ {{.HelpName}} ygVIpSJs
+
+  2. Export a session as CSV and count the objects left to copy.
+     $ {{.HelpName}} --format csv ygVIpSJs | grep -c ',pending$'
+`,
+}
+
+// sessionExportRecord - an object planned by a session.
+type sessionExportRecord struct {
+	Version   string `json:"version"`
+	SessionID string `json:"sessionId"`
+	Source    string `json:"source"`
+	Target    string `json:"target"`
+	Size      int64  `json:"size"`
+	Status    string `json:"status"` // "copied" or "pending"
+}
+
+// Columns of records exported as CSV.
+var sessionExportCSVHeader = []string{"version", "sessionId", "source", "target", "size", "status"}
+
+// sessionRecords - sends the records of all objects planned by s, the
+// objects up to the last copied one are copied.
+func sessionRecords(s *sessionV8, recordFn func(sessionExportRecord) *probe.Error) *probe.Error {
+	lastCopied, err := s.decodeKey(s.Header.LastCopied)
+	if err != nil {
+		return err.Trace(s.Header.LastCopied)
+	}
+	isCopied := isLastFactory(lastCopied)
+
+	scanner := bufio.NewScanner(s.NewDataReader())
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		urls, err := s.unmarshalURLs(scanner.Bytes())
+		if err != nil {
+			return err.Trace(s.SessionID)
+		}
+		if urls.SourceContent == nil || urls.TargetContent == nil {
+			continue
+		}
+		record := sessionExportRecord{
+			Version:   sessionExportVersion,
+			SessionID: s.SessionID,
+			Source:    urls.SourceContent.URL.String(),
+			Target:    urls.TargetContent.URL.String(),
+			Size:      urls.SourceContent.Size,
+			Status:    "pending",
+		}
+		if isCopied(record.Source) {
+			record.Status = "copied"
+		}
+		if err = recordFn(record); err != nil {
+			return err.Trace(s.SessionID)
+		}
+	}
+	if e := scanner.Err(); e != nil {
+		return probe.NewError(e).Trace(s.SessionID)
+	}
+	return nil
+}
+
+// exportSession - writes the records of s to w in format.
+func exportSession(s *sessionV8, format string, w io.Writer) *probe.Error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		return sessionRecords(s, func(record sessionExportRecord) *probe.Error {
+			return probe.NewError(encoder.Encode(record))
+		})
+	case "csv":
+		writer := csv.NewWriter(w)
+		if e := writer.Write(sessionExportCSVHeader); e != nil {
+			return probe.NewError(e)
+		}
+		err := sessionRecords(s, func(r sessionExportRecord) *probe.Error {
+			return probe.NewError(writer.Write([]string{r.Version, r.SessionID, r.Source, r.Target, strconv.FormatInt(r.Size, 10), r.Status}))
+		})
+		if err != nil {
+			return err.Trace(format)
+		}
+		writer.Flush()
+		return probe.NewError(writer.Error())
+	}
+	return errInvalidArgument().Trace(format)
+}
+
+// checkSessionExportSyntax - Validate session export command.
+func checkSessionExportSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "export", 1) // last argument is exit code
+	}
+	switch ctx.String("format") {
+	case "json", "csv":
+	default:
+		fatalIf(errInvalidArgument().Trace(ctx.String("format")), "Unknown format, use ‘--format json’ or ‘--format csv’.")
+	}
+}
+
+// mainSessionExport - Main session export function.
+func mainSessionExport(ctx *cli.Context) error {
+	// Validate session export syntax.
+	checkSessionExportSyntax(ctx)
+
+	sessionID := ctx.Args().Get(0)
+	if !isSessionDirExists() || !isSessionExists(sessionID) {
+		fatalIf(errDummy().Trace(sessionID), "Session `"+sessionID+"` not found.")
+	}
+	session, err := loadSessionV8(sessionID)
+	fatalIf(err.Trace(sessionID), "Unable to load session `"+sessionID+"`.")
+	defer session.Close()
+
+	err = exportSession(session, ctx.String("format"), os.Stdout)
+	fatalIf(err.Trace(sessionID), "Unable to export session `"+sessionID+"`.")
+	return nil
+}
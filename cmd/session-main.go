@@ -36,6 +36,7 @@ var sessionCmd = cli.Command{
 		sessionList,
 		sessionClear,
 		sessionResume,
+		sessionExport,
 	},
 }
 
@@ -43,5 +44,5 @@ var sessionCmd = cli.Command{
 func mainSession(ctx *cli.Context) error {
 	cli.ShowCommandHelp(ctx, ctx.Args().First())
 	return nil
-	// Sub-commands like "list", "clear", "resume" have their own main.
+	// Sub-commands like "list", "clear", "resume", "export" have their own main.
 }
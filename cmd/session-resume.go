@@ -28,11 +28,19 @@ import (
 	"github.com/minio/minio/pkg/trie"
 )
 
+var sessionResumeFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "on-source-change",
+		Value: sourceChangeRequeue,
+		Usage: "sources changed since the session was saved: 'requeue' to copy them again or 'skip' to leave them out",
+	},
+}
+
 var sessionResume = cli.Command{
 	Name:   "resume",
 	Usage:  "resume interrupted session",
 	Action: mainSessionResume,
-	Flags:  globalFlags,
+	Flags:  append(sessionResumeFlags, globalFlags...),
 	Before: setGlobalsFromContext,
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
@@ -46,9 +54,18 @@ SESSION-ID:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+DESCRIPTION:
+  Sources are checked again before they are copied, those which changed
+  in size or modification time since the session was saved are copied
+  again with their current content, or left out and reported with
+  '--on-source-change skip'.
+
 EXAMPLES:
   1. Resume session.
      $ {{.HelpName}} ygVIpSJs
+
+  2. Resume session, leaving out and reporting sources changed since it was saved.
+     $ {{.HelpName}} --on-source-change skip ygVIpSJs
 `,
 }
 
@@ -60,13 +77,13 @@ func (b bySessionWhen) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
 func (b bySessionWhen) Less(i, j int) bool { return b[i].Header.When.Before(b[j].Header.When) }
 
 // sessionExecute - run a given session.
-func sessionExecute(s *sessionV8) {
+func sessionExecute(s *sessionV8, onSourceChange string) {
 	switch s.Header.CommandType {
 	case "cp":
 		sseKeys := s.Header.CommandStringFlags["encrypt-key"]
 		sseServer := s.Header.CommandStringFlags["encrypt"]
 		encKeyDB, _ := parseAndValidateEncryptionKeys(sseKeys, sseServer)
-		doCopySession(s, encKeyDB)
+		doCopySession(s, encKeyDB, onSourceChange)
 	}
 }
 
@@ -95,6 +112,10 @@ func checkSessionResumeSyntax(ctx *cli.Context) {
 func mainSessionResume(ctx *cli.Context) error {
 	// Validate session resume syntax.
 	checkSessionResumeSyntax(ctx)
+	onSourceChange := ctx.String("on-source-change")
+	if onSourceChange != sourceChangeRequeue && onSourceChange != sourceChangeSkip {
+		fatalIf(errInvalidArgument().Trace(onSourceChange), "Unknown source change policy `"+onSourceChange+"`, must be one of requeue or skip.")
+	}
 
 	// Additional command specific theme customization.
 	console.SetColor("Command", color.New(color.FgWhite, color.Bold))
@@ -119,12 +140,12 @@ func mainSessionResume(ctx *cli.Context) error {
 		}
 		fatalIf(errDummy().Trace(sessionID), errorMsg)
 	}
-	resumeSession(sessionID)
+	resumeSession(sessionID, onSourceChange)
 	return nil
 }
 
 // resumeSession - Resumes a session specified by sessionID.
-func resumeSession(sessionID string) {
+func resumeSession(sessionID, onSourceChange string) {
 	s, err := loadSessionV8(sessionID)
 	fatalIf(err.Trace(sessionID), "Unable to load session.")
 	// Restore the state of global variables from this previous session.
@@ -138,7 +159,7 @@ func resumeSession(sessionID string) {
 		e = os.Chdir(s.Header.RootPath)
 		fatalIf(probe.NewError(e), "Unable to change working folder to root path while resuming session.")
 	}
-	sessionExecute(s)
+	sessionExecute(s, onSourceChange)
 	err = s.Close()
 	fatalIf(err.Trace(), "Unable to close session file properly.")
 
@@ -15,13 +15,15 @@
  */
 
 // Package cmd - session V8 - Version 8 stores session header and session data in
-// two separate files. Session data contains fully prepared URL list.
+// two separate files. Session data contains fully prepared URL list. The format
+// is described in docs/session-file-format.md, update it along with this file.
 package cmd
 
 import (
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"strings"
 	"sync"
@@ -35,24 +37,30 @@ import (
 
 // sessionV8Header for resumable sessions.
 type sessionV8Header struct {
-	Version            string            `json:"version"`
-	When               time.Time         `json:"time"`
-	RootPath           string            `json:"workingFolder"`
-	GlobalBoolFlags    map[string]bool   `json:"globalBoolFlags"`
-	GlobalIntFlags     map[string]int    `json:"globalIntFlags"`
-	GlobalStringFlags  map[string]string `json:"globalStringFlags"`
-	CommandType        string            `json:"commandType"`
-	CommandArgs        []string          `json:"cmdArgs"`
-	CommandBoolFlags   map[string]bool   `json:"cmdBoolFlags"`
-	CommandIntFlags    map[string]int    `json:"cmdIntFlags"`
-	CommandStringFlags map[string]string `json:"cmdStringFlags"`
-	LastCopied         string            `json:"lastCopied"`
-	LastRemoved        string            `json:"lastRemoved"`
-	TotalBytes         int64             `json:"totalBytes"`
-	TotalObjects       int64             `json:"totalObjects"`
-	UserMetaData       map[string]string `json:"metaData"`
+	Version            string                   `json:"version"`
+	When               time.Time                `json:"time"`
+	RootPath           string                   `json:"workingFolder"`
+	GlobalBoolFlags    map[string]bool          `json:"globalBoolFlags"`
+	GlobalIntFlags     map[string]int           `json:"globalIntFlags"`
+	GlobalStringFlags  map[string]string        `json:"globalStringFlags"`
+	CommandType        string                   `json:"commandType"`
+	CommandArgs        []string                 `json:"cmdArgs"`
+	CommandBoolFlags   map[string]bool          `json:"cmdBoolFlags"`
+	CommandIntFlags    map[string]int           `json:"cmdIntFlags"`
+	CommandStringFlags map[string]string        `json:"cmdStringFlags"`
+	LastCopied         string                   `json:"lastCopied"`
+	LastRemoved        string                   `json:"lastRemoved"`
+	TotalBytes         int64                    `json:"totalBytes"`
+	TotalObjects       int64                    `json:"totalObjects"`
+	UserMetaData       map[string]string        `json:"metaData"`
+	PendingUploads     map[string]pendingUpload `json:"pendingUploads,omitempty"`
+	KeyEncoding        string                   `json:"keyEncoding,omitempty"`
 }
 
+// Object paths are URL encoded in the files of sessions with this key
+// encoding, older sessions store them as they are.
+const sessionKeyEncodingURL = "url"
+
 // sessionMessage container for session messages
 type sessionMessage struct {
 	Status      string    `json:"status"`
@@ -83,10 +91,17 @@ func (file *sessionDataFP) Write(p []byte) (int, error) {
 
 // String colorized session message.
 func (s sessionV8) String() string {
-	message := console.Colorize("SessionID", fmt.Sprintf("%s -> ", s.SessionID))
-	message = message + console.Colorize("SessionTime", fmt.Sprintf("[%s]", s.Header.When.Local().Format(printDate)))
-	message = message + console.Colorize("Command", fmt.Sprintf(" %s %s", s.Header.CommandType, strings.Join(s.Header.CommandArgs, " ")))
-	return message
+	id := s.SessionID + " ->"
+	when := "[" + s.Header.When.Local().Format(printDate) + "]"
+	command := s.Header.CommandType + " " + strings.Join(s.Header.CommandArgs, " ")
+	table := console.NewTableRenderer(" ",
+		console.Column{Theme: "SessionID", Width: console.StringWidth(id)},
+		console.Column{Theme: "SessionTime", Width: console.StringWidth(when)},
+		console.Column{Theme: "Command"},
+	)
+	// Commands are cut to fit in the terminal, --json prints them whole.
+	table.Fit([][]string{{id, when, command}}, getTermWidth())
+	return table.Row(id, when, command)
 }
 
 // JSON jsonified session message.
@@ -175,6 +190,7 @@ func newSessionV8() *sessionV8 {
 	s.Header.CommandIntFlags = make(map[string]int)
 	s.Header.CommandStringFlags = make(map[string]string)
 	s.Header.UserMetaData = make(map[string]string)
+	s.Header.KeyEncoding = sessionKeyEncodingURL
 	s.Header.When = UTCNow()
 	s.mutex = new(sync.Mutex)
 	s.SessionID = newRandomID(8)
@@ -243,6 +259,56 @@ func (s *sessionV8) Save() *probe.Error {
 	return nil
 }
 
+// setTotals records the size and number of objects to copy.
+func (s *sessionV8) setTotals(totalBytes, totalObjects int64) *probe.Error {
+	s.mutex.Lock()
+	s.Header.TotalBytes = totalBytes
+	s.Header.TotalObjects = totalObjects
+	s.mutex.Unlock()
+
+	return s.Save()
+}
+
+// setLastCopied records the last object copied, the session is resumed
+// after it.
+func (s *sessionV8) setLastCopied(lastCopied string) *probe.Error {
+	s.mutex.Lock()
+	s.Header.LastCopied = lastCopied
+	s.mutex.Unlock()
+
+	return s.Save()
+}
+
+// loadUpload returns the pending multipart upload to target, if any.
+func (s *sessionV8) loadUpload(target string) (pendingUpload, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	upload, ok := s.Header.PendingUploads[target]
+	return upload, ok
+}
+
+// saveUpload records the progress of a multipart upload to target.
+func (s *sessionV8) saveUpload(target string, upload pendingUpload) *probe.Error {
+	s.mutex.Lock()
+	if s.Header.PendingUploads == nil {
+		s.Header.PendingUploads = make(map[string]pendingUpload)
+	}
+	s.Header.PendingUploads[target] = upload
+	s.mutex.Unlock()
+
+	return s.Save()
+}
+
+// deleteUpload forgets a finished multipart upload to target.
+func (s *sessionV8) deleteUpload(target string) *probe.Error {
+	s.mutex.Lock()
+	delete(s.Header.PendingUploads, target)
+	s.mutex.Unlock()
+
+	return s.Save()
+}
+
 // setGlobals captures the state of global variables into session header.
 // Used by newSession.
 func (s *sessionV8) setGlobals() {
@@ -251,6 +317,17 @@ func (s *sessionV8) setGlobals() {
 	s.Header.GlobalBoolFlags["json"] = globalJSON
 	s.Header.GlobalBoolFlags["noColor"] = globalNoColor
 	s.Header.GlobalBoolFlags["insecure"] = globalInsecure
+	s.Header.GlobalBoolFlags["ascii"] = globalASCII
+	s.Header.GlobalBoolFlags["http1"] = globalHTTP1
+	s.Header.GlobalBoolFlags["readOnly"] = globalReadOnly
+	s.Header.GlobalBoolFlags["anonymous"] = globalAnonymous
+	s.Header.GlobalBoolFlags["ci"] = globalCI
+	s.Header.GlobalIntFlags["maxRPS"] = globalMaxRPS
+	s.Header.GlobalStringFlags["preferEndpoint"] = globalPreferEndpoint
+	s.Header.GlobalStringFlags["roleARN"] = globalRoleARN
+	s.Header.GlobalStringFlags["externalID"] = globalExternalID
+	s.Header.GlobalStringFlags["progressInterval"] = globalProgressInterval.String()
+	s.Header.GlobalIntFlags["progressWidth"] = globalProgressWidth
 }
 
 // RestoreGlobals restores the state of global variables.
@@ -261,7 +338,19 @@ func (s sessionV8) restoreGlobals() {
 	json := s.Header.GlobalBoolFlags["json"]
 	noColor := s.Header.GlobalBoolFlags["noColor"]
 	insecure := s.Header.GlobalBoolFlags["insecure"]
-	setGlobals(quiet, debug, json, noColor, insecure)
+	ascii := s.Header.GlobalBoolFlags["ascii"]
+	http1 := s.Header.GlobalBoolFlags["http1"]
+	readOnly := s.Header.GlobalBoolFlags["readOnly"]
+	anonymous := s.Header.GlobalBoolFlags["anonymous"]
+	ci := s.Header.GlobalBoolFlags["ci"]
+	maxRPS := s.Header.GlobalIntFlags["maxRPS"]
+	preferEndpoint := s.Header.GlobalStringFlags["preferEndpoint"]
+	roleARN := s.Header.GlobalStringFlags["roleARN"]
+	externalID := s.Header.GlobalStringFlags["externalID"]
+	// Sessions saved by older versions have no interval.
+	progressInterval, _ := time.ParseDuration(s.Header.GlobalStringFlags["progressInterval"])
+	progressWidth := s.Header.GlobalIntFlags["progressWidth"]
+	setGlobals(quiet, debug, json, noColor, insecure, ascii, http1, readOnly, anonymous, maxRPS, preferEndpoint, roleARN, externalID, progressInterval, progressWidth, ci)
 }
 
 // IsModified - returns if in memory session header has changed from
@@ -375,6 +464,64 @@ func (s sessionV8) CloseAndDie() {
 	console.Fatalln("Session safely terminated. To resume session `mc session resume " + s.SessionID + "`")
 }
 
+// encodeKey - encodes an object path for the session files, newlines,
+// control characters and invalid UTF-8 in keys would otherwise break or
+// be lost in the newline delimited JSON.
+func (s sessionV8) encodeKey(key string) string {
+	if s.Header.KeyEncoding != sessionKeyEncodingURL {
+		return key
+	}
+	return url.PathEscape(key)
+}
+
+// decodeKey - decodes an object path read from the session files.
+func (s sessionV8) decodeKey(key string) (string, *probe.Error) {
+	if s.Header.KeyEncoding != sessionKeyEncodingURL {
+		return key, nil
+	}
+	key, e := url.PathUnescape(key)
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	return key, nil
+}
+
+// marshalURLs - returns the line of the session data file for URLs.
+func (s sessionV8) marshalURLs(urls URLs) ([]byte, *probe.Error) {
+	for _, content := range []**clientContent{&urls.SourceContent, &urls.TargetContent} {
+		if *content == nil {
+			continue
+		}
+		encoded := **content
+		encoded.URL.Path = s.encodeKey(encoded.URL.Path)
+		*content = &encoded
+	}
+	data, e := json.Marshal(urls)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return data, nil
+}
+
+// unmarshalURLs - parses a line of the session data file.
+func (s sessionV8) unmarshalURLs(data []byte) (URLs, *probe.Error) {
+	var urls URLs
+	if e := json.Unmarshal(data, &urls); e != nil {
+		return urls, probe.NewError(e)
+	}
+	for _, content := range []*clientContent{urls.SourceContent, urls.TargetContent} {
+		if content == nil {
+			continue
+		}
+		path, err := s.decodeKey(content.URL.Path)
+		if err != nil {
+			return urls, err.Trace(content.URL.Path)
+		}
+		content.URL.Path = path
+	}
+	return urls, nil
+}
+
 // Create a factory function to simplify checking if
 // object was last operated on.
 func isLastFactory(lastURL string) func(string) bool {
@@ -17,6 +17,8 @@
 package cmd
 
 import (
+	"bytes"
+	"io/ioutil"
 	"os"
 	"regexp"
 
@@ -58,3 +60,55 @@ func (s *TestSuite) TestSession(c *C) {
 	_, e = os.Stat(session.DataFP.Name())
 	c.Assert(e, NotNil)
 }
+
+func (s *TestSuite) TestSessionURLsEncoding(c *C) {
+	session := sessionV8{Header: &sessionV8Header{KeyEncoding: sessionKeyEncodingURL}}
+	urls := URLs{
+		SourceAlias:   "play",
+		SourceContent: &clientContent{URL: *newClientURL("https://play.min.io/bucket/line\nbreak\r\x1b\xff")},
+		TargetContent: &clientContent{URL: *newClientURL("/tmp/line\nbreak\r\x1b\xff")},
+	}
+
+	data, err := session.marshalURLs(urls)
+	c.Assert(err, IsNil)
+	c.Assert(regexp.MustCompile(`^[\x20-\x7e]+$`).Match(data), Equals, true)
+
+	decoded, err := session.unmarshalURLs(data)
+	c.Assert(err, IsNil)
+	c.Assert(decoded.SourceContent.URL.Path, Equals, urls.SourceContent.URL.Path)
+	c.Assert(decoded.TargetContent.URL.Path, Equals, urls.TargetContent.URL.Path)
+}
+
+func (s *TestSuite) TestExportSession(c *C) {
+	dataFile, e := ioutil.TempFile("", "session-export")
+	c.Assert(e, IsNil)
+	defer os.Remove(dataFile.Name())
+	defer dataFile.Close()
+
+	session := &sessionV8{
+		Header:    &sessionV8Header{KeyEncoding: sessionKeyEncodingURL},
+		SessionID: "ygVIpSJs",
+		DataFP:    &sessionDataFP{false, dataFile},
+	}
+	for _, name := range []string{"a", "b,c"} {
+		data, err := session.marshalURLs(URLs{
+			SourceContent: &clientContent{URL: *newClientURL("/tmp/" + name), Size: 1},
+			TargetContent: &clientContent{URL: *newClientURL("https://play.min.io/bucket/" + name)},
+		})
+		c.Assert(err, IsNil)
+		_, e = dataFile.Write(append(data, '\n'))
+		c.Assert(e, IsNil)
+	}
+	session.Header.LastCopied = "/tmp/a"
+
+	var buf bytes.Buffer
+	c.Assert(exportSession(session, "csv", &buf), IsNil)
+	c.Assert(buf.String(), Equals, "version,sessionId,source,target,size,status\n"+
+		"1,ygVIpSJs,/tmp/a,https://play.min.io/bucket/a,1,copied\n"+
+		"1,ygVIpSJs,\"/tmp/b,c\",\"https://play.min.io/bucket/b,c\",1,pending\n")
+
+	buf.Reset()
+	c.Assert(exportSession(session, "json", &buf), IsNil)
+	c.Assert(bytes.Count(buf.Bytes(), []byte("\n")), Equals, 2)
+	c.Assert(bytes.Contains(buf.Bytes(), []byte(`"status":"pending"`)), Equals, true)
+}
@@ -0,0 +1,225 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// SHA256 of an empty payload.
+const emptySHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+var signFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "method",
+		Value: http.MethodGet,
+		Usage: "HTTP method of the request",
+	},
+	cli.StringFlag{
+		Name:  "url",
+		Usage: "alias/bucket/object the request is sent to",
+	},
+	cli.StringFlag{
+		Name:  "region",
+		Usage: "region to sign for, the bucket location by default",
+	},
+	cli.StringFlag{
+		Name:  "expire, E",
+		Usage: "presign the URL for this duration instead of signing headers",
+	},
+	cli.BoolFlag{
+		Name:  "print-canonical",
+		Usage: "print the canonical request and the string to sign",
+	},
+}
+
+// Sign a request to debug signature mismatches.
+var signCmd = cli.Command{
+	Name:   "sign",
+	Usage:  "sign a request and print its signature",
+	Action: mainSign,
+	Before: setGlobalsFromContext,
+	Flags:  append(signFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] --url TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+SIGNATURE:
+  Requests are signed with AWS signature V4 for path style URLs, the way
+  they are sent to the alias. Compare the canonical request with the one
+  logged by the server to find the cause of a signature mismatch.
+
+EXAMPLES:
+  1. Print the canonical request, string to sign and headers of a GET request.
+     $ {{.HelpName}} --method GET --url play/mybucket/myobject.txt --print-canonical
+
+  2. Print the headers of a HEAD request signed for region "eu-west-1".
+     $ {{.HelpName}} --method HEAD --region eu-west-1 --url s3/mybucket/myobject.txt
+
+  3. Print a URL presigned for 10 minutes along with its canonical request.
+     $ {{.HelpName}} --expire 10m --url play/mybucket/myobject.txt --print-canonical
+`,
+}
+
+// signMessage container for a signed request.
+type signMessage struct {
+	Status           string            `json:"status"`
+	Method           string            `json:"method"`
+	URL              string            `json:"url"`
+	Headers          map[string]string `json:"headers,omitempty"`
+	CanonicalRequest string            `json:"canonicalRequest,omitempty"`
+	StringToSign     string            `json:"stringToSign,omitempty"`
+}
+
+// String colorized signed request message.
+func (s signMessage) String() string {
+	var msg string
+	if s.CanonicalRequest != "" {
+		msg += console.Colorize("SignTitle", "Canonical request:") + "\n" + s.CanonicalRequest + "\n\n"
+		msg += console.Colorize("SignTitle", "String to sign:") + "\n" + s.StringToSign + "\n\n"
+	}
+	msg += console.Colorize("SignTitle", "Request:") + "\n"
+	msg += s.Method + " " + console.Colorize("SignURL", s.URL)
+	var headers []string
+	for header := range s.Headers {
+		headers = append(headers, header)
+	}
+	sort.Strings(headers)
+	for _, header := range headers {
+		msg += "\n" + console.Colorize("SignHeader", header+": ") + s.Headers[header]
+	}
+	return msg
+}
+
+// JSON jsonified signed request message.
+func (s signMessage) JSON() string {
+	s.Status = "success"
+	signJSONBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(signJSONBytes)
+}
+
+// checkSignSyntax - validate all the passed arguments
+func checkSignSyntax(ctx *cli.Context) {
+	if ctx.NArg() != 0 || ctx.String("url") == "" {
+		cli.ShowCommandHelpAndExit(ctx, "sign", 1) // last argument is exit code
+	}
+	if expireArg := ctx.String("expire"); expireArg != "" {
+		expiry, e := time.ParseDuration(expireArg)
+		fatalIf(probe.NewError(e), "Unable to parse expire=`"+expireArg+"`.")
+		if expiry.Seconds() < 1 || expiry.Seconds() > 604800 {
+			fatalIf(errInvalidArgument().Trace(expireArg), "Expiry must be between 1 second and 7 days.")
+		}
+	}
+}
+
+// signRequest - signs a request of method for targetURL, presigned for
+// expiry if not zero.
+func signRequest(method, targetURL, region string, expiry time.Duration) (signMessage, *probe.Error) {
+	alias, urlStr, hostCfg, err := expandAlias(targetURL)
+	if err != nil {
+		return signMessage{}, err.Trace(targetURL)
+	}
+	if hostCfg == nil {
+		return signMessage{}, probe.NewError(fmt.Errorf("`%s` is not on object storage", targetURL))
+	}
+	if strings.EqualFold(hostCfg.API, "S3v2") {
+		return signMessage{}, probe.NewError(fmt.Errorf("Alias `%s` uses signature V2, only V4 can be printed", alias))
+	}
+	clnt, err := newClientFromAlias(alias, urlStr)
+	if err != nil {
+		return signMessage{}, err.Trace(targetURL)
+	}
+	s3Clnt, ok := clnt.(*s3Client)
+	if !ok {
+		return signMessage{}, probe.NewError(fmt.Errorf("`%s` is not on object storage", targetURL))
+	}
+	if region == "" {
+		region = "us-east-1"
+		if bucket, _ := s3Clnt.url2BucketAndObject(); bucket != "" {
+			if location, e := s3Clnt.api.GetBucketLocation(bucket); e == nil && location != "" {
+				region = location
+			}
+		}
+	}
+
+	req, e := http.NewRequest(method, clnt.GetURL().String(), nil)
+	if e != nil {
+		return signMessage{}, probe.NewError(e)
+	}
+	msg := signMessage{Method: method}
+	if expiry != 0 {
+		msg.CanonicalRequest, msg.StringToSign = presignV4(req, hostCfg.AccessKey, hostCfg.SecretKey, region, expiry, UTCNow())
+	} else {
+		// Requests with a payload are signed without hashing it.
+		payload := unsignedPayload
+		switch method {
+		case http.MethodGet, http.MethodHead, http.MethodDelete:
+			payload = emptySHA256
+		}
+		req.Header.Set("X-Amz-Content-Sha256", payload)
+		msg.CanonicalRequest, msg.StringToSign = signV4(req, hostCfg.AccessKey, hostCfg.SecretKey, region, "s3",
+			[]string{"host", "x-amz-content-sha256", "x-amz-date"}, UTCNow())
+		msg.Headers = map[string]string{"Host": req.URL.Host}
+		for header := range req.Header {
+			msg.Headers[header] = req.Header.Get(header)
+		}
+	}
+	msg.URL = req.URL.String()
+	return msg, nil
+}
+
+// mainSign is the entry point for sign command.
+func mainSign(ctx *cli.Context) error {
+	checkSignSyntax(ctx)
+
+	// Additional command specific theme customization.
+	console.SetColor("SignTitle", color.New(color.FgYellow, color.Bold))
+	console.SetColor("SignURL", color.New(color.FgCyan))
+	console.SetColor("SignHeader", color.New(color.FgGreen))
+
+	var expiry time.Duration
+	if expireArg := ctx.String("expire"); expireArg != "" {
+		expiry, _ = time.ParseDuration(expireArg)
+	}
+	method := strings.ToUpper(ctx.String("method"))
+	targetURL := ctx.String("url")
+
+	msg, err := signRequest(method, targetURL, ctx.String("region"), expiry)
+	fatalIf(err.Trace(targetURL), "Unable to sign request for `"+targetURL+"`.")
+	if !ctx.Bool("print-canonical") {
+		msg.CanonicalRequest, msg.StringToSign = "", ""
+	}
+	printMsg(msg)
+	return nil
+}
@@ -0,0 +1,203 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v6/pkg/credentials"
+	"github.com/minio/minio-go/v6/pkg/s3utils"
+)
+
+const (
+	signV4Algorithm = "AWS4-HMAC-SHA256"
+	iso8601Format   = "20060102T150405Z"
+	yyyymmdd        = "20060102"
+
+	// Payload of chunk signed uploads, which cannot be signed again.
+	streamingPayload = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+
+	// Payload of presigned requests.
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// signV4 - signs req with AWS signature V4 in the Authorization header,
+// the payload hash is taken from X-Amz-Content-Sha256. Returns the
+// canonical request and the string to sign.
+func signV4(req *http.Request, accessKey, secretKey, region, service string, signedHeaders []string, now time.Time) (canonicalRequest, stringToSign string) {
+	now = now.UTC()
+	req.Header.Set("X-Amz-Date", now.Format(iso8601Format))
+	scope := credentialScopeV4(now, region, service)
+
+	sort.Strings(signedHeaders)
+	headers := strings.Join(signedHeaders, ";")
+	canonicalRequest = canonicalRequestV4(req, signedHeaders, req.Header.Get("X-Amz-Content-Sha256"))
+	stringToSign = stringToSignV4(canonicalRequest, scope, now)
+	signature := signatureV4(secretKey, region, service, stringToSign, now)
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signV4Algorithm, accessKey, scope, headers, signature))
+	return canonicalRequest, stringToSign
+}
+
+// resignV4 - signs req again for the given time with the current creds,
+// the headers it was signed with and the given headers, reports false if
+// req was not signed with AWS signature V4 or has a chunk signed payload.
+func resignV4(req *http.Request, creds *credentials.Credentials, now time.Time, headers ...string) bool {
+	auth := req.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, signV4Algorithm+" ") {
+		return false
+	}
+	payload := req.Header.Get("X-Amz-Content-Sha256")
+	if payload == "" || payload == streamingPayload {
+		return false
+	}
+
+	var scope []string
+	var signedHeaders string
+	for _, field := range strings.Split(strings.TrimPrefix(auth, signV4Algorithm+" "), ",") {
+		field = strings.TrimSpace(field)
+		switch {
+		case strings.HasPrefix(field, "Credential="):
+			scope = strings.Split(strings.TrimPrefix(field, "Credential="), "/")
+		case strings.HasPrefix(field, "SignedHeaders="):
+			signedHeaders = strings.TrimPrefix(field, "SignedHeaders=")
+		}
+	}
+	// Credential is ACCESSKEY/DATE/REGION/SERVICE/aws4_request.
+	if len(scope) != 5 || signedHeaders == "" {
+		return false
+	}
+	signed := strings.Split(signedHeaders, ";")
+	for _, header := range headers {
+		header = strings.ToLower(header)
+		found := false
+		for _, s := range signed {
+			found = found || s == header
+		}
+		if !found {
+			signed = append(signed, header)
+		}
+	}
+	value, e := creds.Get()
+	if e != nil {
+		return false
+	}
+	// Credentials renewed since req was signed come with their own token.
+	if req.Header.Get("X-Amz-Security-Token") != "" {
+		req.Header.Set("X-Amz-Security-Token", value.SessionToken)
+	}
+	signV4(req, value.AccessKeyID, value.SecretAccessKey, scope[2], scope[3], signed, now)
+	return true
+}
+
+// presignV4 - signs req with AWS signature V4 in its query string,
+// valid for expires. Returns the canonical request and the string to
+// sign.
+func presignV4(req *http.Request, accessKey, secretKey, region string, expires time.Duration, now time.Time) (canonicalRequest, stringToSign string) {
+	now = now.UTC()
+	scope := credentialScopeV4(now, region, "s3")
+
+	query := req.URL.Query()
+	query.Set("X-Amz-Algorithm", signV4Algorithm)
+	query.Set("X-Amz-Credential", accessKey+"/"+scope)
+	query.Set("X-Amz-Date", now.Format(iso8601Format))
+	query.Set("X-Amz-Expires", strconv.FormatInt(int64(expires/time.Second), 10))
+	query.Set("X-Amz-SignedHeaders", "host")
+	req.URL.RawQuery = query.Encode()
+
+	canonicalRequest = canonicalRequestV4(req, []string{"host"}, unsignedPayload)
+	stringToSign = stringToSignV4(canonicalRequest, scope, now)
+	signature := signatureV4(secretKey, region, "s3", stringToSign, now)
+
+	query.Set("X-Amz-Signature", signature)
+	req.URL.RawQuery = query.Encode()
+	return canonicalRequest, stringToSign
+}
+
+// credentialScopeV4 - returns DATE/REGION/SERVICE/aws4_request.
+func credentialScopeV4(now time.Time, region, service string) string {
+	return strings.Join([]string{now.Format(yyyymmdd), region, service, "aws4_request"}, "/")
+}
+
+// canonicalRequestV4 - returns the canonical form of req, signedHeaders
+// must be sorted.
+func canonicalRequestV4(req *http.Request, signedHeaders []string, payload string) string {
+	var canonicalHeaders bytes.Buffer
+	for _, header := range signedHeaders {
+		canonicalHeaders.WriteString(header + ":")
+		if header == "host" {
+			host := req.Host
+			if host == "" {
+				host = req.URL.Host
+			}
+			canonicalHeaders.WriteString(host)
+		} else {
+			var values []string
+			for _, value := range req.Header[http.CanonicalHeaderKey(header)] {
+				values = append(values, strings.Join(strings.Fields(value), " "))
+			}
+			canonicalHeaders.WriteString(strings.Join(values, ","))
+		}
+		canonicalHeaders.WriteByte('\n')
+	}
+
+	return strings.Join([]string{
+		req.Method,
+		s3utils.EncodePath(req.URL.Path),
+		strings.Replace(req.URL.Query().Encode(), "+", "%20", -1),
+		canonicalHeaders.String(),
+		strings.Join(signedHeaders, ";"),
+		payload,
+	}, "\n")
+}
+
+// stringToSignV4 - returns the string to sign of a canonical request.
+func stringToSignV4(canonicalRequest, scope string, now time.Time) string {
+	canonicalSum := sha256.Sum256([]byte(canonicalRequest))
+	return strings.Join([]string{
+		signV4Algorithm,
+		now.Format(iso8601Format),
+		scope,
+		hex.EncodeToString(canonicalSum[:]),
+	}, "\n")
+}
+
+// signatureV4 - returns the signature of stringToSign.
+func signatureV4(secretKey, region, service, stringToSign string, now time.Time) string {
+	signingKey := []byte("AWS4" + secretKey)
+	for _, part := range []string{now.Format(yyyymmdd), region, service, "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+	return hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+}
+
+// hmacSHA256 - returns the HMAC-SHA256 of data with key.
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,129 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Policies for sources which are special files, see specialFileKind.
+const (
+	specialFilesSkip  = "skip"
+	specialFilesError = "error"
+)
+
+// isValidSpecialFilesPolicy - returns true if policy is known, empty
+// policy skips special files.
+func isValidSpecialFilesPolicy(policy string) bool {
+	switch policy {
+	case "", specialFilesSkip, specialFilesError:
+		return true
+	}
+	return false
+}
+
+// isSpecialFileErr - returns true if err reports a special file source.
+func isSpecialFileErr(err *probe.Error) bool {
+	if err == nil {
+		return false
+	}
+	_, ok := err.ToGoError().(SpecialFile)
+	return ok
+}
+
+// specialFiles - applies the --special-files policy to sources found
+// to be special files, keeping count of them for the summary.
+type specialFiles struct {
+	policy string
+
+	mutex   sync.Mutex
+	skipped map[string]int
+	failed  int
+}
+
+// newSpecialFiles - returns the tracker of special files for policy.
+func newSpecialFiles(policy string) *specialFiles {
+	return &specialFiles{policy: policy, skipped: make(map[string]int)}
+}
+
+// skip - returns true if err reports a special file skipped by policy,
+// special files reported as errors are counted as failed.
+func (s *specialFiles) skip(err *probe.Error) bool {
+	if err == nil {
+		return false
+	}
+	special, ok := err.ToGoError().(SpecialFile)
+	if !ok {
+		return false
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.policy == specialFilesError {
+		s.failed++
+		return false
+	}
+	s.skipped[special.Kind]++
+	return true
+}
+
+// message - returns the summary of special files found so far.
+func (s *specialFiles) message() specialFilesMessage {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	msg := specialFilesMessage{Status: "success", Kinds: make(map[string]int), Failed: s.failed}
+	for kind, n := range s.skipped {
+		msg.Kinds[kind] = n
+		msg.Skipped += n
+	}
+	return msg
+}
+
+// specialFilesMessage - special files skipped by a copy or mirror.
+type specialFilesMessage struct {
+	Status  string         `json:"status"`
+	Skipped int            `json:"skipped"`
+	Kinds   map[string]int `json:"kinds"`
+	Failed  int            `json:"failed,omitempty"`
+}
+
+// String colorized special files message.
+func (s specialFilesMessage) String() string {
+	kinds := make([]string, 0, len(s.Kinds))
+	for kind := range s.Kinds {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for i, kind := range kinds {
+		kinds[i] = fmt.Sprintf("%d %s", s.Kinds[kind], kind)
+	}
+	return console.Colorize("SpecialFiles", fmt.Sprintf("Skipped %d special file(s): %s.", s.Skipped, strings.Join(kinds, ", ")))
+}
+
+// JSON jsonified special files message.
+func (s specialFilesMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
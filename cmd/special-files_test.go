@@ -0,0 +1,65 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestSpecialFileKind(t *testing.T) {
+	testCases := []struct {
+		mode os.FileMode
+		kind string
+	}{
+		{0644, ""},
+		{os.ModeDir | 0755, ""},
+		{os.ModeNamedPipe | 0644, "fifo"},
+		{os.ModeSocket | 0755, "socket"},
+		{os.ModeDevice | os.ModeCharDevice | 0666, "character device"},
+		{os.ModeDevice | 0660, "block device"},
+	}
+	for i, testCase := range testCases {
+		if kind := specialFileKind(testCase.mode); kind != testCase.kind {
+			t.Errorf("Test %d: expected `%s`, got `%s`", i+1, testCase.kind, kind)
+		}
+	}
+}
+
+func TestSpecialFilesSkip(t *testing.T) {
+	fifo := probe.NewError(SpecialFile{Path: "/tmp/fifo", Kind: "fifo"})
+	other := probe.NewError(errors.New("other"))
+
+	specials := newSpecialFiles(specialFilesSkip)
+	if !specials.skip(fifo) || !specials.skip(fifo) || specials.skip(other) || specials.skip(nil) {
+		t.Fatal("Only special files are expected to be skipped")
+	}
+	if msg := specials.message(); msg.Skipped != 2 || msg.Kinds["fifo"] != 2 || msg.Failed != 0 {
+		t.Fatalf("Unexpected summary %+v", msg)
+	}
+
+	specials = newSpecialFiles(specialFilesError)
+	if specials.skip(fifo) {
+		t.Fatal("Special files are not expected to be skipped")
+	}
+	if msg := specials.message(); msg.Skipped != 0 || msg.Failed != 1 {
+		t.Fatalf("Unexpected summary %+v", msg)
+	}
+}
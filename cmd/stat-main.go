@@ -29,7 +29,7 @@ var (
 	statFlags = []cli.Flag{
 		cli.BoolFlag{
 			Name:  "recursive, r",
-			Usage: "stat all objects recursively",
+			Usage: "stat all objects recursively, followed by their count, size, age and storage classes",
 		},
 	}
 )
@@ -65,6 +65,10 @@ EXAMPLES:
 
    4. Stat encrypted files on Amazon S3 cloud storage.
       $ {{.HelpName}} --encrypt-key "s3/personal-docs/=32byteslongsecretkeymustbegiven1" s3/personal-docs/2018-account_report.docx
+
+   5. Show the count, size, oldest and newest modification time and storage classes of all objects
+      under a prefix on Amazon S3 cloud storage, printed after the objects.
+      $ {{.HelpName}} --recursive --json s3/mybucket/logs/ | jq 'select(.type == "summary")'
 `,
 }
 
@@ -102,6 +106,7 @@ func mainStat(ctx *cli.Context) error {
 
 	console.SetColor("EncryptionHeaders", color.New(color.FgWhite))
 	console.SetColor("Metadata", color.New(color.FgWhite))
+	console.SetColor("Header", color.New(color.Bold, color.FgCyan))
 
 	// Parse encryption keys per command.
 	encKeyDB, err := getEncKeys(ctx)
@@ -121,17 +126,22 @@ func mainStat(ctx *cli.Context) error {
 
 	var cErr error
 	for _, targetURL := range args {
-		stats, err := statURL(targetURL, false, isRecursive, encKeyDB)
-		if err != nil {
-			fatalIf(err, "Unable to stat `"+targetURL+"`.")
-		}
-		for _, stat := range stats {
+		summary := newStatSummary(targetURL)
+		err := walkStat(targetURL, false, isRecursive, encKeyDB, func(stat *clientContent) {
+			summary.add(stat)
 			st := parseStat(stat)
 			if !globalJSON {
 				printStat(st)
 			} else {
 				console.Println(st.JSON())
 			}
+		})
+		if err != nil {
+			fatalIf(err, "Unable to stat `"+targetURL+"`.")
+		}
+		// Summarize the objects listed recursively.
+		if isRecursive {
+			printMsg(summary)
 		}
 	}
 	return cErr
@@ -0,0 +1,110 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// statSummary - aggregate of the objects listed by stat --recursive,
+// printed after them.
+type statSummary struct {
+	Status         string            `json:"status"`
+	Type           string            `json:"type"`
+	URL            string            `json:"url"`
+	Objects        int64             `json:"objects"`
+	Size           int64             `json:"size"`
+	Oldest         *time.Time        `json:"oldest,omitempty"`
+	Newest         *time.Time        `json:"newest,omitempty"`
+	StorageClasses []histogramBucket `json:"storageClasses,omitempty"`
+}
+
+// newStatSummary - returns an empty summary of the objects under url.
+func newStatSummary(url string) *statSummary {
+	return &statSummary{Type: "summary", URL: url}
+}
+
+// add - counts the object of stat, folders are not counted.
+func (s *statSummary) add(stat *clientContent) {
+	if stat.Type.IsDir() {
+		return
+	}
+	s.Objects++
+	s.Size += stat.Size
+
+	modTime := stat.Time
+	if s.Oldest == nil || modTime.Before(*s.Oldest) {
+		s.Oldest = &modTime
+	}
+	if s.Newest == nil || modTime.After(*s.Newest) {
+		s.Newest = &modTime
+	}
+
+	// Only objects have a storage class.
+	if stat.URL.Type != objectStorage {
+		return
+	}
+	class := strings.ToUpper(stat.Metadata["X-Amz-Storage-Class"])
+	if class == "" {
+		class = "STANDARD"
+	}
+	i := sort.Search(len(s.StorageClasses), func(i int) bool {
+		return s.StorageClasses[i].Name >= class
+	})
+	if i == len(s.StorageClasses) || s.StorageClasses[i].Name != class {
+		s.StorageClasses = append(s.StorageClasses, histogramBucket{})
+		copy(s.StorageClasses[i+1:], s.StorageClasses[i:])
+		s.StorageClasses[i] = histogramBucket{Name: class}
+	}
+	s.StorageClasses[i].Objects++
+	s.StorageClasses[i].Bytes += stat.Size
+}
+
+// String colorized stat summary message.
+func (s statSummary) String() string {
+	lines := []string{
+		console.Colorize("Name", fmt.Sprintf("%-10s: %s", "Summary", s.URL)),
+		fmt.Sprintf("%-10s: %d", "Objects", s.Objects),
+		fmt.Sprintf("%-10s: %s", "Size", humanize.IBytes(uint64(s.Size))),
+	}
+	if s.Oldest != nil {
+		lines = append(lines, fmt.Sprintf("%-10s: %s", "Oldest", s.Oldest.Local().Format(printDate)))
+		lines = append(lines, fmt.Sprintf("%-10s: %s", "Newest", s.Newest.Local().Format(printDate)))
+	}
+	message := strings.Join(lines, "\n")
+	if len(s.StorageClasses) > 0 {
+		message += "\n\n" + histogramTable("STORAGE CLASS", s.StorageClasses, s.Objects)
+	}
+	return message + "\n"
+}
+
+// JSON jsonified stat summary message.
+func (s statSummary) JSON() string {
+	s.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
@@ -0,0 +1,67 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStatSummary(t *testing.T) {
+	oldest := time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC)
+	newest := time.Date(2019, 10, 14, 0, 0, 0, 0, time.UTC)
+	object := func(size int64, modTime time.Time, class string) *clientContent {
+		content := &clientContent{URL: *newClientURL("https://s3.amazonaws.com/mybucket/object"), Size: size, Time: modTime, Metadata: map[string]string{}}
+		if class != "" {
+			content.Metadata["X-Amz-Storage-Class"] = class
+		}
+		return content
+	}
+
+	summary := newStatSummary("s3/mybucket")
+	for _, content := range []*clientContent{
+		object(100, newest.Add(-time.Hour), ""),
+		object(2000, oldest, "GLACIER"),
+		object(30, newest, "standard_ia"),
+		object(400, oldest.Add(time.Hour), "glacier"),
+		{URL: *newClientURL("https://s3.amazonaws.com/mybucket/prefix/"), Type: os.ModeDir, Time: oldest.Add(-time.Hour)},
+		{URL: *newClientURL("/var/lib/file"), Size: 5, Time: newest.Add(-2 * time.Hour)},
+	} {
+		summary.add(content)
+	}
+
+	if summary.Objects != 5 || summary.Size != 2535 {
+		t.Fatalf("Expected 5 objects of 2535 bytes, got %d objects of %d bytes", summary.Objects, summary.Size)
+	}
+	if !summary.Oldest.Equal(oldest) || !summary.Newest.Equal(newest) {
+		t.Fatalf("Expected objects modified from %s to %s, got %s to %s", oldest, newest, summary.Oldest, summary.Newest)
+	}
+	classes := []histogramBucket{
+		{Name: "GLACIER", Objects: 2, Bytes: 2400},
+		{Name: "STANDARD", Objects: 1, Bytes: 100},
+		{Name: "STANDARD_IA", Objects: 1, Bytes: 30},
+	}
+	if !reflect.DeepEqual(summary.StorageClasses, classes) {
+		t.Fatalf("Expected storage classes %v, got %v", classes, summary.StorageClasses)
+	}
+
+	if empty := newStatSummary("s3/mybucket"); empty.Oldest != nil || empty.StorageClasses != nil {
+		t.Fatalf("Expected no times nor storage classes for no objects, got %v", empty)
+	}
+}
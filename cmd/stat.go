@@ -37,6 +37,7 @@ type statMessage struct {
 	ETag              string            `json:"etag"`
 	Type              string            `json:"type"`
 	Expires           time.Time         `json:"expires"`
+	Checksum          *objectChecksum   `json:"checksum,omitempty"`
 	EncryptionHeaders map[string]string `json:"encryption,omitempty"`
 	Metadata          map[string]string `json:"metadata"`
 }
@@ -55,6 +56,9 @@ func printStat(stat statMessage) {
 	if !stat.Expires.IsZero() {
 		console.Println(fmt.Sprintf("%-10s: %s ", "Expires", stat.Expires.Format(printDate)))
 	}
+	if stat.Checksum != nil {
+		console.Println(fmt.Sprintf("%-10s: %s %s ", "Checksum", strings.ToUpper(stat.Checksum.Algorithm), stat.Checksum.Value))
+	}
 	var maxKey = 0
 	for k := range stat.Metadata {
 		if len(k) > maxKey {
@@ -105,6 +109,9 @@ func parseStat(c *clientContent) statMessage {
 	content.Size = c.Size
 	content.Key = getKey(c)
 	content.Metadata = c.Metadata
+	if checksum := takeChecksum(content.Metadata); checksum.Algorithm != "" {
+		content.Checksum = &checksum
+	}
 	content.ETag = strings.TrimPrefix(c.ETag, "\"")
 	content.ETag = strings.TrimSuffix(content.ETag, "\"")
 	content.Expires = c.Expires
@@ -115,10 +122,19 @@ func parseStat(c *clientContent) statMessage {
 // statURL - simple or recursive listing
 func statURL(targetURL string, isIncomplete, isRecursive bool, encKeyDB map[string][]prefixSSEPair) ([]*clientContent, *probe.Error) {
 	var stats []*clientContent
+	err := walkStat(targetURL, isIncomplete, isRecursive, encKeyDB, func(stat *clientContent) {
+		stats = append(stats, stat)
+	})
+	return stats, err
+}
+
+// walkStat - simple or recursive listing, calling fn with the stat of
+// each content as soon as it is listed.
+func walkStat(targetURL string, isIncomplete, isRecursive bool, encKeyDB map[string][]prefixSSEPair, fn func(stat *clientContent)) *probe.Error {
 	var clnt Client
 	clnt, err := newClient(targetURL)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	targetAlias, _, _ := mustExpandAlias(targetURL)
@@ -156,7 +172,7 @@ func statURL(targetURL string, isIncomplete, isRecursive bool, encKeyDB map[stri
 		url := targetAlias + getKey(content)
 
 		if !isRecursive && !strings.HasPrefix(url, targetURL) {
-			return nil, errTargetNotFound(targetURL)
+			return errTargetNotFound(targetURL)
 		}
 
 		_, stat, err := url2Stat(url, true, encKeyDB)
@@ -169,8 +185,8 @@ func statURL(targetURL string, isIncomplete, isRecursive bool, encKeyDB map[stri
 		// Trim prefix path from the content path.
 		contentURL = strings.TrimPrefix(contentURL, prefixPath)
 		stat.URL.Path = contentURL
-		stats = append(stats, stat)
+		fn(stat)
 	}
 
-	return stats, probe.NewError(cErr)
+	return probe.NewError(cErr)
 }
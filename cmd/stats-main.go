@@ -0,0 +1,46 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/minio/cli"
+)
+
+var (
+	statsFlags = []cli.Flag{}
+)
+
+// Manage cumulative transfer stats per alias.
+var statsCmd = cli.Command{
+	Name:            "stats",
+	Usage:           "show and reset cumulative transfer stats per alias",
+	Action:          mainStats,
+	Flags:           append(statsFlags, globalFlags...),
+	Before:          setGlobalsFromContext,
+	HideHelpCommand: true,
+	Subcommands: []cli.Command{
+		statsShowCmd,
+		statsResetCmd,
+	},
+}
+
+// mainStats - handle for the 'mc stats' command.
+func mainStats(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "show", "reset" have their own main.
+}
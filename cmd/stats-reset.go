@@ -0,0 +1,95 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var statsResetCmd = cli.Command{
+	Name:   "reset",
+	Usage:  "reset transfer stats",
+	Before: setGlobalsFromContext,
+	Action: mainStatsReset,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [ALIAS...]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Reset transfer stats of all aliases, for example at the start of a billing period.
+     $ {{.HelpName}}
+
+  2. Reset transfer stats of the "s3" alias only.
+     $ {{.HelpName}} s3
+`,
+}
+
+// statsResetMessage container for reset transfer stats.
+type statsResetMessage struct {
+	Status string `json:"status"`
+	Alias  string `json:"alias,omitempty"`
+}
+
+// String colorized stats reset message.
+func (s statsResetMessage) String() string {
+	if s.Alias == "" {
+		return console.Colorize("StatsReset", "Reset transfer stats of all aliases.")
+	}
+	return console.Colorize("StatsReset", "Reset transfer stats of `"+s.Alias+"`.")
+}
+
+// JSON jsonified stats reset message.
+func (s statsResetMessage) JSON() string {
+	s.Status = "success"
+	statsJSONBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(statsJSONBytes)
+}
+
+func mainStatsReset(ctx *cli.Context) error {
+	// Additional command specific theme customization.
+	console.SetColor("StatsReset", color.New(color.FgGreen, color.Bold))
+
+	stats, err := loadTransferStats()
+	fatalIf(err.Trace(), "Unable to load transfer stats.")
+
+	if len(ctx.Args()) == 0 {
+		stats.Since = UTCNow()
+		stats.Aliases = make(map[string]*aliasStats)
+		fatalIf(writeTransferStats(stats).Trace(), "Unable to reset transfer stats.")
+		printMsg(statsResetMessage{})
+		return nil
+	}
+	for _, arg := range ctx.Args() {
+		alias, _ := url2Alias(arg)
+		delete(stats.Aliases, alias)
+		fatalIf(writeTransferStats(stats).Trace(alias), "Unable to reset transfer stats of `"+alias+"`.")
+		printMsg(statsResetMessage{Alias: alias})
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var statsShowCmd = cli.Command{
+	Name:   "show",
+	Usage:  "show bytes transferred and requests sent per alias",
+	Before: setGlobalsFromContext,
+	Action: mainStatsShow,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [ALIAS...]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show transfers of all aliases since the stats were last reset.
+     $ {{.HelpName}}
+
+  2. Show transfers of the "s3" alias, bytes downloaded from it appear as egress on the bill.
+     $ {{.HelpName}} s3
+`,
+}
+
+// statsMessage container for the transfer stats of an alias.
+type statsMessage struct {
+	Status          string           `json:"status"`
+	Alias           string           `json:"alias"`
+	Since           time.Time        `json:"since"`
+	BytesUploaded   int64            `json:"bytesUploaded"`
+	BytesDownloaded int64            `json:"bytesDownloaded"`
+	Requests        map[string]int64 `json:"requests"`
+}
+
+// String colorized transfer stats message.
+func (s statsMessage) String() string {
+	var requestTypes []string
+	for requestType := range s.Requests {
+		requestTypes = append(requestTypes, requestType)
+	}
+	sort.Strings(requestTypes)
+	var requests []string
+	for _, requestType := range requestTypes {
+		requests = append(requests, fmt.Sprintf("%s %d", requestType, s.Requests[requestType]))
+	}
+
+	msg := console.Colorize("StatsAlias", s.Alias) + ": "
+	msg += console.Colorize("StatsBytes", humanize.IBytes(uint64(s.BytesUploaded))) + " uploaded, "
+	msg += console.Colorize("StatsBytes", humanize.IBytes(uint64(s.BytesDownloaded))) + " downloaded"
+	if len(requests) > 0 {
+		msg += ", requests: " + strings.Join(requests, ", ")
+	}
+	return msg
+}
+
+// JSON jsonified transfer stats message.
+func (s statsMessage) JSON() string {
+	s.Status = "success"
+	statsJSONBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(statsJSONBytes)
+}
+
+func mainStatsShow(ctx *cli.Context) error {
+	// Additional command specific theme customization.
+	console.SetColor("StatsAlias", color.New(color.FgCyan, color.Bold))
+	console.SetColor("StatsBytes", color.New(color.FgYellow))
+
+	stats, err := loadTransferStats()
+	fatalIf(err.Trace(), "Unable to load transfer stats.")
+
+	aliases := ctx.Args()
+	if len(aliases) == 0 {
+		for alias := range stats.Aliases {
+			aliases = append(aliases, alias)
+		}
+		sort.Strings(aliases)
+	}
+	for _, arg := range aliases {
+		alias, _ := url2Alias(arg)
+		counters, ok := stats.Aliases[alias]
+		if !ok {
+			counters = &aliasStats{}
+		}
+		printMsg(statsMessage{
+			Alias:           alias,
+			Since:           stats.Since,
+			BytesUploaded:   counters.BytesUploaded,
+			BytesDownloaded: counters.BytesDownloaded,
+			Requests:        counters.Requests,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+const (
+	// Limits of the size of the parts of a multipart upload.
+	minUploadPartSize = 5 * humanize.MiByte
+	maxUploadPartSize = 5 * humanize.GiByte
+)
+
+// sum specific flags.
+var (
+	sumFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "s3-etag",
+			Usage: "compute the ETag of the file uploaded to S3, in parts if larger than --part-size",
+		},
+		cli.StringFlag{
+			Name:  "part-size",
+			Value: "64MiB",
+			Usage: "with --s3-etag, size of the parts the file was uploaded in",
+		},
+	}
+)
+
+// Compute checksums of local files.
+var sumCmd = cli.Command{
+	Name:   "sum",
+	Usage:  "compute the MD5 or the S3 ETag of local files",
+	Action: mainSum,
+	Before: setGlobalsFromContext,
+	Flags:  append(sumFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] FILE [FILE ...]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Objects uploaded in parts have the MD5 of the MD5s of their parts as
+  ETag, followed by the number of parts. It only matches a local file
+  computed with the part size the object was uploaded with, which is
+  64MiB for objects uploaded by mc.
+
+EXAMPLES:
+  1. Compute the MD5 of a local file.
+     $ {{.HelpName}} backup.tar
+
+  2. Compute the ETag of a local file uploaded by mc, to compare it with the ETag shown by 'mc stat'.
+     $ {{.HelpName}} --s3-etag backup.tar
+
+  3. Compute the ETag of local files uploaded by another client in parts of 16MiB.
+     $ {{.HelpName}} --s3-etag --part-size 16MiB disk1.img disk2.img
+`,
+}
+
+// sumMessage container for checksum message structure.
+type sumMessage struct {
+	Status   string `json:"status"`
+	File     string `json:"file"`
+	Size     int64  `json:"size"`
+	Sum      string `json:"sum"`
+	PartSize int64  `json:"partSize,omitempty"`
+	Parts    int    `json:"parts,omitempty"`
+}
+
+// String colorized checksum message.
+func (s sumMessage) String() string {
+	return console.Colorize("Sum", s.Sum) + "  " + console.Colorize("File", s.File)
+}
+
+// JSON jsonified checksum message.
+func (s sumMessage) JSON() string {
+	s.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// parsePartSize - parses the size of the parts given to --part-size.
+func parsePartSize(size string) (int64, *probe.Error) {
+	n, e := humanize.ParseBytes(size)
+	if e != nil {
+		return 0, probe.NewError(e).Trace(size)
+	}
+	if n < minUploadPartSize || n > maxUploadPartSize {
+		return 0, errInvalidArgument().Trace(size)
+	}
+	return int64(n), nil
+}
+
+// partSizes - returns the sizes of the parts an object of size is
+// uploaded in, none if it is uploaded with a single request.
+func partSizes(size, partSize int64) []int64 {
+	if size <= partSize {
+		return nil
+	}
+	var sizes []int64
+	for ; size > partSize; size -= partSize {
+		sizes = append(sizes, partSize)
+	}
+	return append(sizes, size)
+}
+
+// s3ETag - returns the ETag of r of size uploaded in parts of partSize,
+// the MD5 of r if it fits in a single part.
+func s3ETag(r io.ReaderAt, size, partSize int64) (string, int, *probe.Error) {
+	sizes := partSizes(size, partSize)
+	if len(sizes) > estimateMaxParts {
+		return "", 0, probe.NewError(fmt.Errorf("%d parts are needed, uploads have %d at most, use a larger part size", len(sizes), estimateMaxParts))
+	}
+	sum, ok := localSum(r, size, sizes, md5.New)
+	if !ok {
+		return "", 0, probe.NewError(io.ErrUnexpectedEOF)
+	}
+	etag := hex.EncodeToString(sum)
+	if len(sizes) > 0 {
+		etag += fmt.Sprintf("-%d", len(sizes))
+	}
+	return etag, len(sizes), nil
+}
+
+// sumFile - computes the checksum of the file at path.
+func sumFile(path string, isETag bool, partSize int64) (sumMessage, *probe.Error) {
+	file, e := os.Open(path)
+	if e != nil {
+		return sumMessage{}, probe.NewError(e).Trace(path)
+	}
+	defer file.Close()
+
+	st, e := file.Stat()
+	if e != nil {
+		return sumMessage{}, probe.NewError(e).Trace(path)
+	}
+	if !st.Mode().IsRegular() {
+		return sumMessage{}, errInvalidArgument().Trace(path)
+	}
+	if !isETag {
+		// The MD5 of the whole file.
+		partSize = st.Size()
+	}
+	sum, parts, err := s3ETag(file, st.Size(), partSize)
+	if err != nil {
+		return sumMessage{}, err.Trace(path)
+	}
+	msg := sumMessage{File: path, Size: st.Size(), Sum: sum}
+	if parts > 0 {
+		msg.PartSize = partSize
+		msg.Parts = parts
+	}
+	return msg, nil
+}
+
+// mainSum - is a handler for mc sum command
+func mainSum(ctx *cli.Context) error {
+	if !ctx.Args().Present() {
+		cli.ShowCommandHelpAndExit(ctx, "sum", 1) // last argument is exit code
+	}
+	for _, arg := range ctx.Args() {
+		if strings.TrimSpace(arg) == "" {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...), "Unable to validate empty argument.")
+		}
+	}
+	partSize, err := parsePartSize(ctx.String("part-size"))
+	fatalIf(err, "Unable to parse ‘--part-size’, it must be between 5MiB and 5GiB.")
+
+	console.SetColor("Sum", color.New(color.FgGreen))
+	console.SetColor("File", color.New(color.Bold))
+
+	var cErr error
+	for _, path := range ctx.Args() {
+		msg, err := sumFile(path, ctx.Bool("s3-etag"), partSize)
+		if err != nil {
+			errorIf(err, "Unable to compute the checksum of `"+path+"`.")
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		printMsg(msg)
+	}
+	return cErr
+}
@@ -0,0 +1,58 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"testing"
+)
+
+func TestS3ETag(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 100)
+	partETag := func(parts ...[]byte) string {
+		var sums []byte
+		for _, part := range parts {
+			sum := md5.Sum(part)
+			sums = append(sums, sum[:]...)
+		}
+		sum := md5.Sum(sums)
+		return fmt.Sprintf("%s-%d", hex.EncodeToString(sum[:]), len(parts))
+	}
+	wholeSum := md5.Sum(data)
+
+	testCases := []struct {
+		partSize int64
+		etag     string
+	}{
+		{1000, hex.EncodeToString(wholeSum[:])},
+		{2000, hex.EncodeToString(wholeSum[:])},
+		{500, partETag(data[:500], data[500:])},
+		{400, partETag(data[:400], data[400:800], data[800:])},
+	}
+	for i, testCase := range testCases {
+		etag, _, err := s3ETag(bytes.NewReader(data), int64(len(data)), testCase.partSize)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if etag != testCase.etag {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.etag, etag)
+		}
+	}
+}
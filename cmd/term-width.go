@@ -0,0 +1,31 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import "sync/atomic"
+
+// Width of the terminal, updated when it is resized.
+var termWidth int64
+
+// getTermWidth - returns the width progress and scan bars are drawn in.
+func getTermWidth() int {
+	return int(atomic.LoadInt64(&termWidth))
+}
+
+// setTermWidth - sets the width progress and scan bars are drawn in.
+func setTermWidth(width int) {
+	atomic.StoreInt64(&termWidth, int64(width))
+}
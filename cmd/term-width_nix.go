@@ -0,0 +1,43 @@
+// +build !windows
+
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/cheggaaa/pb"
+)
+
+// watchTermWidth - follows the width of the terminal as it is resized,
+// unless the width is fixed with --progress-width.
+func watchTermWidth() {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGWINCH)
+	go func() {
+		for range sigCh {
+			if globalProgressWidth > 0 {
+				continue
+			}
+			if w, e := pb.GetTerminalWidth(); e == nil {
+				setTermWidth(w)
+			}
+		}
+	}()
+}
@@ -0,0 +1,213 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Version of the transfer stats file.
+const transferStatsVersion = "1"
+
+// aliasStats - cumulative transfers to and from one alias.
+type aliasStats struct {
+	BytesUploaded   int64            `json:"bytesUploaded"`
+	BytesDownloaded int64            `json:"bytesDownloaded"`
+	Requests        map[string]int64 `json:"requests"`
+}
+
+// add - adds the counters of other.
+func (a *aliasStats) add(other *aliasStats) {
+	a.BytesUploaded += other.BytesUploaded
+	a.BytesDownloaded += other.BytesDownloaded
+	if a.Requests == nil {
+		a.Requests = make(map[string]int64)
+	}
+	for requestType, n := range other.Requests {
+		a.Requests[requestType] += n
+	}
+}
+
+// transferStats - contents of the transfer stats file.
+type transferStats struct {
+	Version string                 `json:"version"`
+	Since   time.Time              `json:"since"`
+	Aliases map[string]*aliasStats `json:"aliases"`
+}
+
+// Counters of the running command, not saved yet.
+var pendingStats = struct {
+	sync.Mutex
+	aliases map[string]*aliasStats
+}{
+	aliases: make(map[string]*aliasStats),
+}
+
+// recordTransfer - accounts a request of requestType along with the
+// bytes it sent and received.
+func recordTransfer(alias, requestType string, uploaded, downloaded int64) {
+	pendingStats.Lock()
+	defer pendingStats.Unlock()
+	stats, ok := pendingStats.aliases[alias]
+	if !ok {
+		stats = &aliasStats{Requests: make(map[string]int64)}
+		pendingStats.aliases[alias] = stats
+	}
+	if requestType != "" {
+		stats.Requests[requestType]++
+	}
+	stats.BytesUploaded += uploaded
+	stats.BytesDownloaded += downloaded
+}
+
+// getTransferStatsFile - returns the path of the transfer stats file.
+func getTransferStatsFile() (string, *probe.Error) {
+	configDir, err := getMcConfigDir()
+	if err != nil {
+		return "", err.Trace()
+	}
+	return filepath.Join(configDir, globalTransferStatsFile), nil
+}
+
+// loadTransferStats - reads the transfer stats file, a missing file
+// returns empty stats.
+func loadTransferStats() (*transferStats, *probe.Error) {
+	stats := &transferStats{
+		Version: transferStatsVersion,
+		Since:   UTCNow(),
+		Aliases: make(map[string]*aliasStats),
+	}
+	statsFile, err := getTransferStatsFile()
+	if err != nil {
+		return nil, err.Trace()
+	}
+	data, e := ioutil.ReadFile(statsFile)
+	if e != nil {
+		if os.IsNotExist(e) {
+			return stats, nil
+		}
+		return nil, probe.NewError(e).Trace(statsFile)
+	}
+	if e = json.Unmarshal(data, stats); e != nil {
+		return nil, probe.NewError(e).Trace(statsFile)
+	}
+	if stats.Aliases == nil {
+		stats.Aliases = make(map[string]*aliasStats)
+	}
+	return stats, nil
+}
+
+// writeTransferStats - replaces the transfer stats file.
+func writeTransferStats(stats *transferStats) *probe.Error {
+	statsFile, err := getTransferStatsFile()
+	if err != nil {
+		return err.Trace()
+	}
+	data, e := json.MarshalIndent(stats, "", "\t")
+	if e != nil {
+		return probe.NewError(e)
+	}
+	tmpFile := statsFile + ".tmp"
+	if e = ioutil.WriteFile(tmpFile, data, 0600); e != nil {
+		return probe.NewError(e).Trace(tmpFile)
+	}
+	if e = os.Rename(tmpFile, statsFile); e != nil {
+		return probe.NewError(e).Trace(statsFile)
+	}
+	return nil
+}
+
+// saveTransferStats - adds the counters of the running command to the
+// transfer stats file. Accounting is best effort, errors are ignored.
+func saveTransferStats() {
+	pendingStats.Lock()
+	defer pendingStats.Unlock()
+	if len(pendingStats.aliases) == 0 {
+		return
+	}
+	stats, err := loadTransferStats()
+	if err != nil {
+		return
+	}
+	for alias, pending := range pendingStats.aliases {
+		if _, ok := stats.Aliases[alias]; !ok {
+			stats.Aliases[alias] = &aliasStats{}
+		}
+		stats.Aliases[alias].add(pending)
+	}
+	if writeTransferStats(stats) == nil {
+		pendingStats.aliases = make(map[string]*aliasStats)
+	}
+}
+
+// requestType - classifies requests for accounting, listings are told
+// apart from object downloads.
+func requestType(req *http.Request) string {
+	if req.Method == http.MethodGet {
+		query := req.URL.Query()
+		for _, param := range []string{"list-type", "prefix", "delimiter", "marker", "uploads"} {
+			if _, ok := query[param]; ok {
+				return "LIST"
+			}
+		}
+	}
+	return req.Method
+}
+
+// statsTransport - accounts all requests sent for an alias.
+type statsTransport struct {
+	alias     string
+	transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t statsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var uploaded int64
+	if req.ContentLength > 0 {
+		uploaded = req.ContentLength
+	}
+	recordTransfer(t.alias, requestType(req), uploaded, 0)
+	resp, e := t.transport.RoundTrip(req)
+	if e == nil && resp.Body != nil {
+		resp.Body = &statsReader{ReadCloser: resp.Body, alias: t.alias}
+	}
+	return resp, e
+}
+
+// statsReader - accounts downloaded bytes as they are read.
+type statsReader struct {
+	io.ReadCloser
+	alias string
+}
+
+// Read implements io.Reader.
+func (s *statsReader) Read(p []byte) (n int, err error) {
+	n, err = s.ReadCloser.Read(p)
+	if n > 0 {
+		recordTransfer(s.alias, "", 0, int64(n))
+	}
+	return n, err
+}
@@ -0,0 +1,153 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Objects directly under target are accounted to this prefix.
+const topLevelPrefix = "/"
+
+// prefixStat - transfers to one top-level prefix of target.
+type prefixStat struct {
+	Prefix  string  `json:"prefix"`
+	Objects int64   `json:"objects"`
+	Bytes   int64   `json:"bytes"`
+	Errors  int64   `json:"errors"`
+	Speed   float64 `json:"speed"`
+
+	first, last time.Time
+}
+
+// prefixStats - transfers of a run by top-level prefix of its target.
+type prefixStats struct {
+	targetURL string
+
+	mutex sync.Mutex
+	stats map[string]*prefixStat
+}
+
+// newPrefixStats - accounts transfers under the aliased URL targetURL.
+func newPrefixStats(targetURL string) *prefixStats {
+	_, expandedURL, _ := mustExpandAlias(targetURL)
+	console.SetColor("SummaryHeader", color.New(color.Bold))
+	return &prefixStats{
+		targetURL: filepath.ToSlash(expandedURL),
+		stats:     make(map[string]*prefixStat),
+	}
+}
+
+// topPrefix - returns the top-level prefix of the expanded URL urlStr
+// under target.
+func (p *prefixStats) topPrefix(urlStr string) string {
+	key := strings.TrimPrefix(strings.TrimPrefix(filepath.ToSlash(urlStr), p.targetURL), "/")
+	if i := strings.Index(key, "/"); i >= 0 {
+		return key[:i+1]
+	}
+	return topLevelPrefix
+}
+
+// record - accounts the transfer of urls, its target is unknown for
+// some errors.
+func (p *prefixStats) record(urls URLs) {
+	if urls.SourceContent == nil {
+		return
+	}
+	prefix := topLevelPrefix
+	if urls.TargetContent != nil {
+		prefix = p.topPrefix(urls.TargetContent.URL.String())
+	}
+	now := UTCNow()
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	stat, ok := p.stats[prefix]
+	if !ok {
+		stat = &prefixStat{Prefix: prefix, first: now}
+		p.stats[prefix] = stat
+	}
+	if urls.Error != nil {
+		stat.Errors++
+		return
+	}
+	if !urls.started.IsZero() && urls.started.Before(stat.first) {
+		stat.first = urls.started
+	}
+	stat.last = now
+	stat.Objects++
+	stat.Bytes += urls.SourceContent.Size
+}
+
+// message - returns the summary of the run, prefixes with the most bytes
+// transferred first.
+func (p *prefixStats) message() prefixSummaryMessage {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	var msg prefixSummaryMessage
+	for _, stat := range p.stats {
+		s := *stat
+		if elapsed := s.last.Sub(s.first).Seconds(); elapsed > 0 {
+			s.Speed = float64(s.Bytes) / elapsed
+		}
+		msg.Prefixes = append(msg.Prefixes, s)
+	}
+	sort.Slice(msg.Prefixes, func(i, j int) bool {
+		if msg.Prefixes[i].Bytes != msg.Prefixes[j].Bytes {
+			return msg.Prefixes[i].Bytes > msg.Prefixes[j].Bytes
+		}
+		return msg.Prefixes[i].Prefix < msg.Prefixes[j].Prefix
+	})
+	return msg
+}
+
+// prefixSummaryMessage container for the summary of a run by prefix.
+type prefixSummaryMessage struct {
+	Status   string       `json:"status"`
+	Prefixes []prefixStat `json:"prefixes"`
+}
+
+// String colorized summary message.
+func (s prefixSummaryMessage) String() string {
+	lines := []string{console.Colorize("SummaryHeader", fmt.Sprintf("%-24s %10s %10s %7s %12s", "PREFIX", "OBJECTS", "SIZE", "ERRORS", "SPEED"))}
+	for _, stat := range s.Prefixes {
+		size := strings.Join(strings.Fields(humanize.IBytes(uint64(stat.Bytes))), "")
+		speed := strings.Join(strings.Fields(humanize.IBytes(uint64(stat.Speed))), "") + "/s"
+		lines = append(lines, fmt.Sprintf("%-24s %10d %10s %7d %12s", printableKey(stat.Prefix), stat.Objects, size, stat.Errors, speed))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// JSON jsonified summary message.
+func (s prefixSummaryMessage) JSON() string {
+	s.Status = "success"
+	summaryMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(summaryMessageBytes)
+}
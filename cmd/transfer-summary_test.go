@@ -0,0 +1,54 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestPrefixStats(t *testing.T) {
+	p := &prefixStats{targetURL: "https://s3.amazonaws.com/bucket/backup", stats: make(map[string]*prefixStat)}
+	urls := func(key string, size int64) URLs {
+		return URLs{
+			SourceContent: &clientContent{Size: size},
+			TargetContent: &clientContent{URL: *newClientURL("https://s3.amazonaws.com/bucket/backup/" + key)},
+		}
+	}
+	p.record(urls("2019/a.tar", 10))
+	p.record(urls("2019/q1/b.tar", 20))
+	p.record(urls("2020/c.tar", 40))
+	p.record(urls("notes.txt", 1))
+	failed := urls("2019/d.tar", 5)
+	failed.Error = errDummy()
+	p.record(failed)
+
+	expected := []prefixStat{
+		{Prefix: "2020/", Objects: 1, Bytes: 40},
+		{Prefix: "2019/", Objects: 2, Bytes: 30, Errors: 1},
+		{Prefix: topLevelPrefix, Objects: 1, Bytes: 1},
+	}
+	msg := p.message()
+	if len(msg.Prefixes) != len(expected) {
+		t.Fatalf("Expected %d prefixes, got %d", len(expected), len(msg.Prefixes))
+	}
+	for i, stat := range msg.Prefixes {
+		e := expected[i]
+		if stat.Prefix != e.Prefix || stat.Objects != e.Objects || stat.Bytes != e.Bytes || stat.Errors != e.Errors {
+			t.Errorf("Test %d: expected %+v, got %+v", i+1, e, stat)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var transitionFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "storage-class, sc",
+		Usage: "storage class to move object(s) to, e.g. 'STANDARD_IA' or 'GLACIER'",
+	},
+	cli.BoolFlag{
+		Name:  "recursive, r",
+		Usage: "move all objects under the given prefixes",
+	},
+	cli.StringFlag{
+		Name:  "older-than",
+		Usage: "move objects older than L days, M hours and N minutes",
+	},
+}
+
+var transitionCmd = cli.Command{
+	Name:   "transition",
+	Usage:  "move object(s) to another storage class in place",
+	Action: mainTransition,
+	Before: setGlobalsFromContext,
+	Flags:  append(append(transitionFlags, ioFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET [TARGET ...]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Objects are copied onto themselves on the server with the storage class asked for,
+  keeping their metadata. Objects of more than 5GiB are not supported.
+
+EXAMPLES:
+  1. Move an object of bucket 'jazz-songs' to storage class 'STANDARD_IA'.
+     $ {{.HelpName}} --storage-class STANDARD_IA s3/jazz-songs/louis/wonderful-world.mp3
+
+  2. Archive all objects under prefix 'logs/2018/' of bucket 'mybucket' to Glacier.
+     $ {{.HelpName}} --storage-class GLACIER --recursive s3/mybucket/logs/2018/
+
+  3. Move the objects of bucket 'backups' older than 30 days to storage class 'STANDARD_IA'.
+     $ {{.HelpName}} --storage-class STANDARD_IA --recursive --older-than 30d s3/backups/
+
+`,
+}
+
+// transitionMessage container for transition message structure.
+type transitionMessage struct {
+	Status       string `json:"status"`
+	URL          string `json:"url"`
+	StorageClass string `json:"storageClass"`
+	Skipped      bool   `json:"skipped,omitempty"`
+}
+
+// String colorized transition message.
+func (t transitionMessage) String() string {
+	if t.Skipped {
+		return console.Colorize("TransitionSkipped", fmt.Sprintf("`%s` is in %s already, skipped.", printableKey(t.URL), t.StorageClass))
+	}
+	return console.Colorize("Transition", fmt.Sprintf("`%s` -> %s", printableKey(t.URL), t.StorageClass))
+}
+
+// JSON jsonified transition message.
+func (t transitionMessage) JSON() string {
+	t.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(t, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// transitionURL - moves the object at the aliased URL urlStr to
+// storageClass.
+func transitionURL(urlStr, storageClass string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	alias, expandedURL, _ := mustExpandAlias(urlStr)
+	clnt, err := newClientFromAlias(alias, expandedURL)
+	if err != nil {
+		return err.Trace(urlStr)
+	}
+	s3Clnt, ok := clnt.(*s3Client)
+	if !ok {
+		return probe.NewError(APINotImplemented{API: "Transition", APIType: "filesystem"}).Trace(urlStr)
+	}
+	sse := getSSE(filepath.ToSlash(filepath.Join(alias, clnt.GetURL().Path)), encKeyDB[alias])
+	moved, err := s3Clnt.transitionObject(storageClass, sse)
+	if err != nil {
+		return err.Trace(urlStr)
+	}
+	printMsg(transitionMessage{URL: urlStr, StorageClass: storageClass, Skipped: !moved})
+	return nil
+}
+
+// mainTransition is the handle for "mc transition" command.
+func mainTransition(ctx *cli.Context) error {
+	args := ctx.Args()
+	if len(args) == 0 {
+		cli.ShowCommandHelpAndExit(ctx, "transition", 1) // last argument is exit code
+	}
+	storageClass := strings.ToUpper(ctx.String("storage-class"))
+	if storageClass == "" {
+		fatalIf(errInvalidArgument(), "A storage class is needed, use ‘--storage-class’.")
+	}
+	encKeyDB, err := getEncKeys(ctx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	isRecursive := ctx.Bool("recursive")
+	olderThan := ctx.String("older-than")
+
+	console.SetColor("Transition", color.New(color.FgGreen, color.Bold))
+	console.SetColor("TransitionSkipped", color.New(color.FgYellow))
+
+	var rerr error
+	for _, targetURL := range args {
+		if !isRecursive {
+			if err = transitionURL(targetURL, storageClass, encKeyDB); err != nil {
+				errorIf(err, "Unable to move `"+targetURL+"` to "+storageClass+".")
+				rerr = exitStatus(globalErrorExitStatus)
+			}
+			continue
+		}
+
+		alias, _, _ := mustExpandAlias(targetURL)
+		clnt, err := newClient(targetURL)
+		fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
+		for content := range clnt.List(isRecursive, false, DirNone) {
+			if content.Err != nil {
+				// Objects on Glacier are only listed as errors.
+				if _, ok := content.Err.ToGoError().(ObjectOnGlacier); ok && storageClass == s3StorageClassGlacier {
+					continue
+				}
+				errorIf(content.Err.Trace(targetURL), "Unable to list `"+targetURL+"`.")
+				rerr = exitStatus(globalErrorExitStatus)
+				continue
+			}
+			if content.Type.IsDir() {
+				continue
+			}
+			if olderThan != "" && isOlder(content.Time, olderThan) {
+				continue
+			}
+			objectURL := filepath.ToSlash(filepath.Join(alias, content.URL.Path))
+			if err = transitionURL(objectURL, storageClass, encKeyDB); err != nil {
+				errorIf(err, "Unable to move `"+objectURL+"` to "+storageClass+".")
+				rerr = exitStatus(globalErrorExitStatus)
+			}
+		}
+	}
+	return rerr
+}
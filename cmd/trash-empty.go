@@ -0,0 +1,70 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var trashEmptyFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "older-than",
+		Usage: "remove objects moved to trash more than L days, M hours and N minutes ago",
+	},
+}
+
+var trashEmptyCmd = cli.Command{
+	Name:            "empty",
+	Usage:           "remove objects moved to trash for good",
+	Action:          mainTrashEmpty,
+	Before:          setGlobalsFromContext,
+	Flags:           append(append(trashEmptyFlags, removalConfirmFlags...), globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TRASH
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Remove all objects from the trash prefix of bucket 'jazz-songs'.
+     $ {{.HelpName}} s3/jazz-songs/.trash/
+
+  2. Remove the objects moved to a local trash folder more than 30 days ago, without asking for a confirmation.
+     $ {{.HelpName}} --older-than 30d --yes ~/.trash/
+
+`,
+}
+
+// mainTrashEmpty is the handle for "mc trash empty" command.
+func mainTrashEmpty(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "empty", 1) // last argument is exit code
+	}
+	trashURL := ctx.Args().Get(0)
+	olderThan := ctx.String("older-than")
+
+	console.SetColor("Remove", color.New(color.FgGreen, color.Bold))
+
+	confirmRecursiveRemoval(ctx, trashURL, false, olderThan, "", true)
+	return removeRecursive(trashURL, false, false, olderThan, "", "", nil, nil)
+}
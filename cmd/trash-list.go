@@ -0,0 +1,74 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var trashListCmd = cli.Command{
+	Name:            "list",
+	ShortName:       "ls",
+	Usage:           "list objects moved to trash",
+	Action:          mainTrashList,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TRASH
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. List the objects moved to the trash prefix of bucket 'jazz-songs'.
+     $ {{.HelpName}} s3/jazz-songs/.trash/
+
+  2. List the files moved to a local trash folder.
+     $ {{.HelpName}} ~/.trash/
+
+`,
+}
+
+// mainTrashList is the handle for "mc trash list" command.
+func mainTrashList(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "list", 1) // last argument is exit code
+	}
+	trashURL := ctx.Args().Get(0)
+
+	console.SetColor("Time", color.New(color.FgGreen))
+	console.SetColor("Size", color.New(color.FgYellow))
+	console.SetColor("File", color.New(color.Bold))
+
+	for item := range listTrash(trashURL) {
+		fatalIf(item.Err, "Unable to list trash `"+trashURL+"`.")
+		printMsg(trashMessage{
+			op:     "list",
+			Origin: item.Origin,
+			URL:    item.URL,
+			Time:   item.Content.Time,
+			Size:   item.Content.Size,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var trashCmd = cli.Command{
+	Name:            "trash",
+	Usage:           "list, restore and empty objects moved to trash by rm",
+	HideHelpCommand: true,
+	Action:          mainTrash,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	Subcommands: []cli.Command{
+		trashListCmd,
+		trashRestoreCmd,
+		trashEmptyCmd,
+	},
+}
+
+// mainTrash is the handle for "mc trash" command.
+func mainTrash(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "list", "restore", "empty" have their own main.
+}
+
+// trashMessage container for trash message structure.
+type trashMessage struct {
+	op     string
+	Status string    `json:"status"`
+	Origin string    `json:"origin"`
+	URL    string    `json:"url"`
+	Time   time.Time `json:"lastModified,omitempty"`
+	Size   int64     `json:"size"`
+}
+
+// String colorized trash message.
+func (t trashMessage) String() string {
+	switch t.op {
+	case "list":
+		size := strings.Join(strings.Fields(humanize.IBytes(uint64(t.Size))), "")
+		return console.NewTableRenderer(" ",
+			console.Column{Theme: "Time"},
+			console.Column{Theme: "Size"},
+			console.Column{Theme: "File"},
+		).Row("["+t.Time.Format(printDate)+"]", console.Pad(size, 7, true), printableKey(t.Origin))
+	case "restore":
+		return console.Colorize("Trash", fmt.Sprintf("Restoring `%s`.", printableKey(t.Origin)))
+	default:
+		return ""
+	}
+}
+
+// JSON jsonified trash message.
+func (t trashMessage) JSON() string {
+	t.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(t, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
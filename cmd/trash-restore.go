@@ -0,0 +1,132 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var trashRestoreFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "recursive, r",
+		Usage: "restore all objects removed under the given prefixes",
+	},
+	cli.BoolFlag{
+		Name:  "force",
+		Usage: "replace objects created again since their removal",
+	},
+}
+
+var trashRestoreCmd = cli.Command{
+	Name:            "restore",
+	Usage:           "move objects back from trash to where they were removed",
+	Action:          mainTrashRestore,
+	Before:          setGlobalsFromContext,
+	Flags:           append(append(trashRestoreFlags, ioFlags...), globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TRASH TARGET [TARGET ...]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Restore a removed object from the trash prefix of bucket 'jazz-songs'.
+     $ {{.HelpName}} s3/jazz-songs/.trash/ s3/jazz-songs/louis/wonderful-world.mp3
+
+  2. Restore all objects removed under prefix 'louis/' of bucket 'jazz-songs'.
+     $ {{.HelpName}} --recursive s3/jazz-songs/.trash/ s3/jazz-songs/louis/
+
+  3. Restore a local file, replacing the file created again since its removal.
+     $ {{.HelpName}} --force ~/.trash/ /home/user/notes.txt
+
+`,
+}
+
+// mainTrashRestore is the handle for "mc trash restore" command.
+func mainTrashRestore(ctx *cli.Context) error {
+	args := ctx.Args()
+	if len(args) < 2 {
+		cli.ShowCommandHelpAndExit(ctx, "restore", 1) // last argument is exit code
+	}
+	encKeyDB, err := getEncKeys(ctx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	trashURL := args.Get(0)
+	targets := args.Tail()
+	isRecursive := ctx.Bool("recursive")
+	isForce := ctx.Bool("force")
+
+	console.SetColor("Trash", color.New(color.FgGreen, color.Bold))
+
+	trashAlias, _, _ := mustExpandAlias(trashURL)
+	restored := make(map[string]bool)
+	var rerr error
+	for item := range listTrash(trashURL) {
+		fatalIf(item.Err, "Unable to list trash `"+trashURL+"`.")
+		target := ""
+		for _, t := range targets {
+			if item.Origin == t || (isRecursive && strings.HasPrefix(item.Origin, t)) {
+				target = t
+				break
+			}
+		}
+		if target == "" {
+			continue
+		}
+		restored[target] = true
+
+		if !isForce {
+			if _, _, err = url2Stat(item.Origin, false, encKeyDB); err == nil {
+				errorIf(errDummy().Trace(item.Origin), "`"+item.Origin+"` exists, use ‘--force’ to replace it.")
+				rerr = exitStatus(globalErrorExitStatus)
+				continue
+			}
+		}
+		printMsg(trashMessage{
+			op:     "restore",
+			Origin: item.Origin,
+			URL:    item.URL,
+			Time:   item.Content.Time,
+			Size:   item.Content.Size,
+		})
+		if err = copyObject(trashAlias, item.Content, item.Origin, encKeyDB); err != nil {
+			errorIf(err.Trace(item.URL, item.Origin), "Failed to restore `"+item.Origin+"`.")
+			rerr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		if err = removeObject(item.URL); err != nil {
+			errorIf(err.Trace(item.URL), "Failed to remove `"+item.URL+"` from trash.")
+			rerr = exitStatus(globalErrorExitStatus)
+		}
+	}
+
+	for _, t := range targets {
+		if !restored[t] {
+			errorIf(errDummy().Trace(t), "`"+t+"` is not found in trash `"+trashURL+"`.")
+			rerr = exitStatus(globalErrorExitStatus)
+		}
+	}
+	return rerr
+}
@@ -0,0 +1,146 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Removed objects are kept in the trash under the alias they were
+// removed from, local files under this folder which is not a valid
+// alias name.
+const trashLocalFolder = "_local"
+
+// trashItem - a removed object kept in the trash.
+type trashItem struct {
+	// Aliased URL of the object in the trash.
+	URL string
+	// Aliased URL the object was removed from.
+	Origin  string
+	Content *clientContent
+	Err     *probe.Error
+}
+
+// absObjectPath - returns the path of an object, local paths are made
+// absolute to be restored from anywhere.
+func absObjectPath(alias, objectPath string) string {
+	if alias == "" {
+		if absPath, e := filepath.Abs(objectPath); e == nil {
+			objectPath = absPath
+		}
+	}
+	return filepath.ToSlash(objectPath)
+}
+
+// trashObjectURL - returns the URL in trashURL where an object removed
+// from alias and objectPath is kept.
+func trashObjectURL(trashURL, alias, objectPath string) string {
+	objectPath = absObjectPath(alias, objectPath)
+	if alias == "" {
+		alias = trashLocalFolder
+	}
+	return urlJoinPath(trashURL, alias+"/"+strings.TrimPrefix(objectPath, "/"))
+}
+
+// trashOrigin - returns the aliased URL an object kept at relPath in
+// the trash was removed from.
+func trashOrigin(relPath string) string {
+	relPath = strings.TrimPrefix(filepath.ToSlash(relPath), "/")
+	if strings.HasPrefix(relPath, trashLocalFolder+"/") {
+		return filepath.FromSlash(strings.TrimPrefix(relPath, trashLocalFolder))
+	}
+	return relPath
+}
+
+// isInTrash - reports if the object at alias and objectPath is kept in
+// trashURL, such objects are never moved to the trash again.
+func isInTrash(trashURL, alias, objectPath string) bool {
+	trashAlias, trashPath, _ := mustExpandAlias(trashURL)
+	if trashAlias != alias {
+		return false
+	}
+	trashPath = strings.TrimSuffix(absObjectPath(alias, newClientURL(trashPath).Path), "/") + "/"
+	return strings.HasPrefix(absObjectPath(alias, objectPath), trashPath)
+}
+
+// copyObject - copies an object listed from alias to the aliased URL
+// targetURL.
+func copyObject(alias string, content *clientContent, targetURL string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	targetAlias, expandedURL, _ := mustExpandAlias(targetURL)
+	urls := URLs{
+		SourceAlias:   alias,
+		SourceContent: content,
+		TargetAlias:   targetAlias,
+		TargetContent: &clientContent{URL: *newClientURL(expandedURL)},
+	}
+	return uploadSourceToTargetURL(context.Background(), urls, nil, uploadOptions{}, encKeyDB).Error
+}
+
+// removeObject - removes the object at the aliased URL urlStr.
+func removeObject(urlStr string) *probe.Error {
+	alias, expandedURL, _ := mustExpandAlias(urlStr)
+	clnt, err := newClientFromAlias(alias, expandedURL)
+	if err != nil {
+		return err.Trace(urlStr)
+	}
+	contentCh := make(chan *clientContent, 1)
+	contentCh <- &clientContent{URL: *newClientURL(expandedURL)}
+	close(contentCh)
+	for err = range clnt.Remove(false, false, contentCh) {
+		if err != nil {
+			return err.Trace(urlStr)
+		}
+	}
+	return nil
+}
+
+// moveToTrash - copies an object about to be removed from alias to
+// trashURL.
+func moveToTrash(trashURL, alias string, content *clientContent, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	return copyObject(alias, content, trashObjectURL(trashURL, alias, content.URL.Path), encKeyDB)
+}
+
+// listTrash - lists all removed objects kept in trashURL.
+func listTrash(trashURL string) <-chan trashItem {
+	itemCh := make(chan trashItem)
+	go func() {
+		defer close(itemCh)
+		clnt, err := newClient(trashURL)
+		if err != nil {
+			itemCh <- trashItem{Err: err.Trace(trashURL)}
+			return
+		}
+		trashPath := clnt.GetURL().Path
+		for content := range clnt.List(true, false, DirNone) {
+			if content.Err != nil {
+				itemCh <- trashItem{Err: content.Err.Trace(trashURL)}
+				return
+			}
+			relPath := strings.TrimPrefix(content.URL.Path, trashPath)
+			itemCh <- trashItem{
+				URL:     urlJoinPath(trashURL, filepath.ToSlash(relPath)),
+				Origin:  trashOrigin(relPath),
+				Content: content,
+			}
+		}
+	}()
+	return itemCh
+}
@@ -31,11 +31,13 @@ var errDummy = func() *probe.Error {
 	return probe.NewError(dummyErr(errors.New(msg))).Untrace()
 }
 
-type invalidArgumentErr error
+type invalidArgumentErr struct {
+	error
+}
 
 var errInvalidArgument = func() *probe.Error {
 	msg := "Invalid arguments provided, please refer " + "`mc <command> -h` for relevant documentation."
-	return probe.NewError(invalidArgumentErr(errors.New(msg))).Untrace()
+	return probe.NewError(invalidArgumentErr{errors.New(msg)}).Untrace()
 }
 
 type unrecognizedDiffTypeErr error
@@ -125,3 +127,10 @@ var errConflictSSE = func(sseServer, sseKeys string) *probe.Error {
 	err := fmt.Errorf("SSE alias '%s' overlaps with SSE-C aliases '%s'", sseServer, sseKeys)
 	return probe.NewError(conflictSSEErr(err)).Untrace()
 }
+
+type profileNotFoundErr error
+
+var errProfileNotFound = func(profile string) *probe.Error {
+	msg := "Profile `" + profile + "` does not exist. Use `mc profile create " + profile + "` to create it."
+	return probe.NewError(profileNotFoundErr(errors.New(msg))).Untrace()
+}
@@ -0,0 +1,101 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Wildcards supported in remote URLs, see path.Match.
+const globChars = "*?["
+
+// globPrefix - returns the folder to list for matching a pattern, the
+// listing is recursive only if wildcards are used in folder names.
+func globPrefix(pattern string) (prefix string, isRecursive bool) {
+	i := strings.IndexAny(pattern, globChars)
+	if i < 0 {
+		return pattern, false
+	}
+	prefix = pattern[:strings.LastIndex(pattern[:i], "/")+1]
+	return prefix, strings.Contains(pattern[i:], "/")
+}
+
+// expandGlobURL - expands wildcards in a remote URL into the URLs of all
+// matching objects, wildcards never match a '/'. Local URLs are returned
+// as is since they are expanded by the shell.
+func expandGlobURL(urlStr string, encKeyDB map[string][]prefixSSEPair) ([]string, *probe.Error) {
+	if !strings.ContainsAny(urlStr, globChars) {
+		return []string{urlStr}, nil
+	}
+	if _, _, hostCfg, err := expandAlias(urlStr); err != nil || hostCfg == nil {
+		return []string{urlStr}, nil
+	}
+	// Object names may contain wildcard characters as well.
+	if _, _, err := url2Stat(urlStr, false, encKeyDB); err == nil {
+		return []string{urlStr}, nil
+	}
+	if _, e := path.Match(urlStr, ""); e != nil {
+		return nil, probe.NewError(e).Trace(urlStr)
+	}
+
+	prefix, isRecursive := globPrefix(urlStr)
+	clnt, err := newClient(prefix)
+	if err != nil {
+		return nil, err.Trace(prefix)
+	}
+	prefixPath := clnt.GetURL().Path
+
+	var matches []string
+	isIncomplete := false
+	for content := range clnt.List(isRecursive, isIncomplete, DirNone) {
+		if content.Err != nil {
+			return nil, content.Err.Trace(prefix)
+		}
+		if !content.Type.IsRegular() {
+			continue
+		}
+		objectURL := prefix + strings.TrimPrefix(strings.TrimPrefix(content.URL.Path, prefixPath), "/")
+		if ok, _ := path.Match(urlStr, objectURL); ok {
+			matches = append(matches, objectURL)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, probe.NewError(fmt.Errorf("No objects match `%s`", urlStr))
+	}
+	return matches, nil
+}
+
+// expandGlobURLs - expands wildcards in the source URLs of a command,
+// all arguments but the last one which is the target.
+func expandGlobURLs(args []string, encKeyDB map[string][]prefixSSEPair) ([]string, *probe.Error) {
+	if len(args) < 2 {
+		return args, nil
+	}
+	var expanded []string
+	for _, arg := range args[:len(args)-1] {
+		urls, err := expandGlobURL(arg, encKeyDB)
+		if err != nil {
+			return nil, err.Trace(arg)
+		}
+		expanded = append(expanded, urls...)
+	}
+	return append(expanded, args[len(args)-1]), nil
+}
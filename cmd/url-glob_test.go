@@ -0,0 +1,41 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestGlobPrefix(t *testing.T) {
+	testCases := []struct {
+		pattern     string
+		prefix      string
+		isRecursive bool
+	}{
+		{"s3/bucket/logs/2024-05-*.gz", "s3/bucket/logs/", false},
+		{"s3/bucket/logs/*/access.log", "s3/bucket/logs/", true},
+		{"s3/bucket/log?", "s3/bucket/", false},
+		{"s3/bucket/[ab]/c/*", "s3/bucket/", true},
+		{"s3/bucket/object", "s3/bucket/object", false},
+	}
+	for i, testCase := range testCases {
+		prefix, isRecursive := globPrefix(testCase.pattern)
+		if prefix != testCase.prefix || isRecursive != testCase.isRecursive {
+			t.Errorf("Test %d: expected (`%s`, %t), got (`%s`, %t)", i+1, testCase.prefix, testCase.isRecursive, prefix, isRecursive)
+		}
+	}
+}
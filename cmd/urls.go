@@ -17,6 +17,8 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/minio/mc/pkg/probe"
 )
 
@@ -30,6 +32,9 @@ type URLs struct {
 	TotalSize     int64
 	encKeyDB      map[string][]prefixSSEPair
 	Error         *probe.Error `json:"-"`
+
+	// Time the transfer started, for the summary of the run.
+	started time.Time
 }
 
 // WithError sets the error and returns object
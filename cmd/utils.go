@@ -46,6 +46,8 @@ func isErrIgnored(err *probe.Error) (ignored bool) {
 		ignored = true
 	case ObjectAlreadyExistsAsDirectory, BucketDoesNotExist, BucketInvalid, ObjectOnGlacier:
 		ignored = true
+	case SourceChanged:
+		ignored = true
 	default:
 		ignored = false
 	}
@@ -137,12 +139,21 @@ func newS3Config(urlStr string, hostCfg *hostConfigV9) *Config {
 	s3Config.AppComments = []string{os.Args[0], runtime.GOOS, runtime.GOARCH}
 	s3Config.Debug = globalDebug
 	s3Config.Insecure = globalInsecure
+	s3Config.HTTP1 = globalHTTP1
 
 	s3Config.HostURL = urlStr
 	if hostCfg != nil {
 		s3Config.AccessKey = hostCfg.AccessKey
 		s3Config.SecretKey = hostCfg.SecretKey
 		s3Config.Signature = hostCfg.API
+		if len(hostCfg.Endpoints) > 0 {
+			s3Config.Endpoint = selectEndpoint(hostCfg)
+		}
+	}
+	// Requests without credentials are sent unsigned.
+	if globalAnonymous {
+		s3Config.AccessKey = ""
+		s3Config.SecretKey = ""
 	}
 	s3Config.Lookup = getLookupType(hostCfg.Lookup)
 	return s3Config
@@ -162,6 +173,27 @@ func lineTrunc(content string, maxLen int) string {
 	return fstPart + "…" + sndPart
 }
 
+// Canned ACLs which can be applied to uploaded objects.
+var cannedACLs = []string{
+	"private",
+	"public-read",
+	"public-read-write",
+	"authenticated-read",
+	"aws-exec-read",
+	"bucket-owner-read",
+	"bucket-owner-full-control",
+}
+
+// isValidCannedACL returns true if acl is a known canned ACL.
+func isValidCannedACL(acl string) bool {
+	for _, cannedACL := range cannedACLs {
+		if acl == cannedACL {
+			return true
+		}
+	}
+	return false
+}
+
 // isOlder returns true if the passed object is older than olderRef
 func isOlder(ti time.Time, olderRef string) bool {
 	objectAge := UTCNow().Sub(ti)
@@ -186,6 +218,27 @@ func isNewer(ti time.Time, newerRef string) bool {
 	return objectAge >= newerThan
 }
 
+// checkAgeFilters - returns an error if --older-than or --newer-than
+// cannot be parsed, or if together they leave no object to transfer.
+func checkAgeFilters(olderRef, newerRef string) *probe.Error {
+	var olderThan, newerThan time.Duration
+	var e error
+	if olderRef != "" {
+		if olderThan, e = ioutils.ParseDurationTime(olderRef); e != nil {
+			return probe.NewError(e).Trace(olderRef)
+		}
+	}
+	if newerRef != "" {
+		if newerThan, e = ioutils.ParseDurationTime(newerRef); e != nil {
+			return probe.NewError(e).Trace(newerRef)
+		}
+	}
+	if olderRef != "" && newerRef != "" && olderThan >= newerThan {
+		return errInvalidArgument().Trace(olderRef, newerRef)
+	}
+	return nil
+}
+
 // getLookupType returns the minio.BucketLookupType for lookup
 // option entered on the command line
 func getLookupType(l string) minio.BucketLookupType {
@@ -317,3 +370,15 @@ func isURLContains(srcURL, tgtURL, sep string) bool {
 	}
 	return false
 }
+
+// isUTF8Locale - returns false if the locale set in the environment
+// uses a character set other than UTF-8.
+func isUTF8Locale() bool {
+	for _, env := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := strings.ToLower(os.Getenv(env)); locale != "" {
+			return strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8")
+		}
+	}
+	// No locale set, assume UTF-8.
+	return true
+}
@@ -159,3 +159,25 @@ func TestParseEncryptionKeys(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckAgeFilters(t *testing.T) {
+	testCases := []struct {
+		olderThan, newerThan string
+		success              bool
+	}{
+		{"", "", true},
+		{"7d", "", true},
+		{"", "7d10h30m", true},
+		{"7d", "14d", true},
+		{"14d", "7d", false},
+		{"7d", "7d", false},
+		{"7 days", "", false},
+		{"", "week", false},
+	}
+	for i, testCase := range testCases {
+		err := checkAgeFilters(testCase.olderThan, testCase.newerThan)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %t, got error %v", i+1, testCase.success, err)
+		}
+	}
+}
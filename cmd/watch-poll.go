@@ -0,0 +1,194 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Interval sources are listed at when they are watched by polling.
+const defaultWatchInterval = time.Minute
+
+// polledObject - what is known of an object from the last listing.
+type polledObject struct {
+	size    int64
+	etag    string
+	modTime time.Time
+}
+
+// listPolled - lists all objects under clnt, by URL.
+func listPolled(clnt Client) (map[string]polledObject, *probe.Error) {
+	objects := make(map[string]polledObject)
+	for content := range clnt.List(true, false, DirNone) {
+		if content.Err != nil {
+			return nil, content.Err
+		}
+		if content.Type.IsDir() {
+			continue
+		}
+		objects[content.URL.String()] = polledObject{
+			size:    content.Size,
+			etag:    content.ETag,
+			modTime: content.Time,
+		}
+	}
+	return objects, nil
+}
+
+// pollEvents - returns the events telling listed apart from known, in
+// the order of their paths.
+func pollEvents(known, listed map[string]polledObject) []EventInfo {
+	var events []EventInfo
+	for path, object := range listed {
+		if previous, ok := known[path]; ok && previous == object {
+			continue
+		}
+		events = append(events, EventInfo{
+			Time: object.modTime.Format(time.RFC3339Nano),
+			Size: object.size,
+			Path: path,
+			Type: EventCreate,
+		})
+	}
+	for path := range known {
+		if _, ok := listed[path]; !ok {
+			events = append(events, EventInfo{
+				Time: UTCNow().Format(time.RFC3339Nano),
+				Path: path,
+				Type: EventRemove,
+			})
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Path < events[j].Path
+	})
+	return events
+}
+
+// pollWatch - watches clnt by listing it at every interval, for servers
+// without bucket notifications. Objects found at the first listing are
+// known, only changes made afterwards are events. A listing failing is
+// retried at the next interval.
+func pollWatch(clnt Client, interval time.Duration) *watchObject {
+	wo := &watchObject{
+		eventInfoChan: make(chan EventInfo),
+		errorChan:     make(chan *probe.Error),
+		doneChan:      make(chan bool),
+	}
+	go func() {
+		defer close(wo.eventInfoChan)
+		defer close(wo.errorChan)
+
+		urlStr := clnt.GetURL().String()
+		known, err := listPolled(clnt)
+		if err != nil {
+			select {
+			case wo.errorChan <- err.Trace(urlStr):
+			case <-wo.doneChan:
+			}
+			return
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-wo.doneChan:
+				return
+			}
+			listed, err := listPolled(clnt)
+			if err != nil {
+				errorIf(err.Trace(urlStr), "Unable to list `%s` for changes, retrying in %s.", urlStr, interval)
+				continue
+			}
+			for _, event := range pollEvents(known, listed) {
+				select {
+				case wo.eventInfoChan <- event:
+				case <-wo.doneChan:
+					return
+				}
+			}
+			known = listed
+		}
+	}()
+	return wo
+}
+
+// watchWithPolling - watches clnt by its notifications, and by listing
+// it at every interval once the server tells it has none.
+func watchWithPolling(clnt Client, interval time.Duration) (*watchObject, *probe.Error) {
+	notified, err := clnt.Watch(watchParams{
+		recursive: true,
+		events:    []string{"put", "delete"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	wo := &watchObject{
+		eventInfoChan: make(chan EventInfo),
+		errorChan:     make(chan *probe.Error),
+		doneChan:      make(chan bool),
+	}
+	go func() {
+		defer close(wo.eventInfoChan)
+		defer close(wo.errorChan)
+
+		// The watcher of notifications closes itself once it fails.
+		current, isPolling := notified, false
+		for {
+			select {
+			case event, ok := <-current.Events():
+				if !ok {
+					return
+				}
+				select {
+				case wo.eventInfoChan <- event:
+				case <-wo.doneChan:
+					current.Close()
+					return
+				}
+			case err, ok := <-current.Errors():
+				if !ok {
+					return
+				}
+				if _, ok := err.ToGoError().(APINotImplemented); ok && !isPolling {
+					if !globalJSON {
+						console.Infoln(fmt.Sprintf("`%s` sends no notifications, listing it every %s for changes.", clnt.GetURL(), interval))
+					}
+					current, isPolling = pollWatch(clnt, interval), true
+					continue
+				}
+				select {
+				case wo.errorChan <- err:
+				case <-wo.doneChan:
+					current.Close()
+					return
+				}
+			case <-wo.doneChan:
+				current.Close()
+				return
+			}
+		}
+	}()
+	return wo, nil
+}
@@ -0,0 +1,59 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPollEvents(t *testing.T) {
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	known := map[string]polledObject{
+		"s3/bucket/a.txt": {size: 1, etag: "a", modTime: now},
+		"s3/bucket/b.txt": {size: 2, etag: "b", modTime: now},
+		"s3/bucket/c.txt": {size: 3, etag: "c", modTime: now},
+	}
+	listed := map[string]polledObject{
+		"s3/bucket/a.txt": {size: 1, etag: "a", modTime: now},
+		"s3/bucket/b.txt": {size: 4, etag: "b2", modTime: now.Add(time.Minute)},
+		"s3/bucket/d.txt": {size: 5, etag: "d", modTime: now},
+	}
+	expected := []struct {
+		path      string
+		eventType EventType
+		size      int64
+	}{
+		{"s3/bucket/b.txt", EventCreate, 4},
+		{"s3/bucket/c.txt", EventRemove, 0},
+		{"s3/bucket/d.txt", EventCreate, 5},
+	}
+
+	events := pollEvents(known, listed)
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d: %v", len(expected), len(events), events)
+	}
+	for i, e := range expected {
+		if events[i].Path != e.path || events[i].Type != e.eventType || events[i].Size != e.size {
+			t.Errorf("Event %d: expected %s %s of size %d, got %v", i+1, e.eventType, e.path, e.size, events[i])
+		}
+	}
+
+	if events = pollEvents(listed, listed); len(events) != 0 {
+		t.Errorf("Expected no events without changes, got %v", events)
+	}
+}
@@ -65,6 +65,8 @@ type watchObject struct {
 	errorChan chan *probe.Error
 	// will stop the watcher goroutines
 	doneChan chan bool
+	// closes doneChan once
+	closeOnce sync.Once
 }
 
 // Events returns the chan receiving events
@@ -79,7 +81,9 @@ func (w *watchObject) Errors() chan *probe.Error {
 
 // Close the watcher, will stop all goroutines
 func (w *watchObject) Close() {
-	close(w.doneChan)
+	w.closeOnce.Do(func() {
+		close(w.doneChan)
+	})
 }
 
 // Watcher can be used to have one or multiple clients watch for notifications
@@ -150,7 +154,29 @@ func (w *Watcher) Join(client Client, recursive bool) *probe.Error {
 	if err != nil {
 		return err
 	}
+	w.join(wo)
+	return nil
+}
 
+// JoinPolling the watcher with client, changes are found by listing
+// client at every interval instead of by notifications.
+func (w *Watcher) JoinPolling(client Client, interval time.Duration) {
+	w.join(pollWatch(client, interval))
+}
+
+// JoinFallback the watcher with client, listing client at every interval
+// once it is found to send no notifications.
+func (w *Watcher) JoinFallback(client Client, interval time.Duration) *probe.Error {
+	wo, err := watchWithPolling(client, interval)
+	if err != nil {
+		return err
+	}
+	w.join(wo)
+	return nil
+}
+
+// join - forwards the events and errors of wo.
+func (w *Watcher) join(wo *watchObject) {
 	w.o = append(w.o, wo)
 
 	// join monitoring waitgroup
@@ -178,6 +204,4 @@ func (w *Watcher) Join(client Client, recursive bool) *probe.Error {
 			}
 		}
 	}()
-
-	return nil
 }
@@ -0,0 +1,77 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package console
+
+import (
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// asciiOutput is true when output is restricted to plain ASCII.
+var asciiOutput = false
+
+// asciiReplacer replaces typographic and box drawing characters used in
+// messages with their closest plain ASCII lookalikes.
+var asciiReplacer = strings.NewReplacer(
+	"‘", "'", "’", "'", "“", "\"", "”", "\"", "…", "...",
+	"─", "-", "━", "-", "│", "|", "┃", "|",
+	"┌", "+", "┐", "+", "└", "+", "┘", "+", "┬", "+", "┴", "+",
+	"┏", "+", "┓", "+", "┗", "+", "┛", "+",
+	"●", "*", "█", "#", "▓", "#", "░", "-",
+)
+
+// asciiWriter - replaces non ASCII symbols before writing to w.
+type asciiWriter struct {
+	w io.Writer
+}
+
+// Write implements io.Writer.
+func (a asciiWriter) Write(p []byte) (int, error) {
+	if _, e := io.WriteString(a.w, ToASCII(string(p))); e != nil {
+		return 0, e
+	}
+	return len(p), nil
+}
+
+// SetASCII restricts all console output to plain ASCII characters,
+// useful for consoles and log collectors which do not handle UTF-8.
+func SetASCII() {
+	privateMutex.Lock()
+	defer privateMutex.Unlock()
+	if asciiOutput {
+		return
+	}
+	asciiOutput = true
+	color.Output = asciiWriter{color.Output}
+	stderrColoredOutput = asciiWriter{stderrColoredOutput}
+}
+
+// IsASCII returns true if console output is restricted to plain ASCII.
+func IsASCII() bool {
+	return asciiOutput
+}
+
+// ToASCII returns s with typographic and box drawing characters replaced
+// when console output is restricted to plain ASCII, s otherwise.
+func ToASCII(s string) string {
+	if !asciiOutput {
+		return s
+	}
+	return asciiReplacer.Replace(s)
+}
@@ -79,6 +79,12 @@ var (
 		os.Exit(1)
 	}
 
+	// FatalExitln print a error message with a new line and exit with status.
+	FatalExitln = func(status int, data ...interface{}) {
+		consolePrintln("Fatal", Theme["Fatal"], data...)
+		os.Exit(status)
+	}
+
 	// Error prints a error message.
 	Error = func(data ...interface{}) {
 		consolePrint("Error", Theme["Error"], data...)
@@ -377,8 +383,8 @@ func (t *Table) DisplayTable(rows [][]string) error {
 			return fmt.Errorf("col count and align-right mismatch")
 		}
 		for i, v := range row {
-			if len([]rune(v)) > maxColWidths[i] {
-				maxColWidths[i] = len([]rune(v))
+			if StringWidth(v) > maxColWidths[i] {
+				maxColWidths[i] = StringWidth(v)
 			}
 		}
 	}
@@ -388,14 +394,7 @@ func (t *Table) DisplayTable(rows [][]string) error {
 	for r, row := range rows {
 		paddedText[r] = make([]string, numCols)
 		for c, cell := range row {
-			if t.AlignRight[c] {
-				fmtStr := fmt.Sprintf("%%%ds", maxColWidths[c])
-				paddedText[r][c] = fmt.Sprintf(fmtStr, cell)
-			} else {
-				extraWidth := maxColWidths[c] - len([]rune(cell))
-				fmtStr := fmt.Sprintf("%%s%%%ds", extraWidth)
-				paddedText[r][c] = fmt.Sprintf(fmtStr, cell, "")
-			}
+			paddedText[r][c] = Pad(cell, maxColWidths[c], t.AlignRight[c])
 		}
 	}
 
@@ -406,23 +405,23 @@ func (t *Table) DisplayTable(rows [][]string) error {
 	}
 	indentText := strings.Repeat(" ", t.TableIndentWidth)
 	border := fmt.Sprintf("%s┌%s┐", indentText, strings.Join(segments, "┬"))
-	fmt.Println(border)
+	fmt.Println(ToASCII(border))
 
 	// Print the table with colors
 	for r, row := range paddedText {
-		fmt.Print(indentText + "│ ")
+		fmt.Print(indentText + ToASCII("│ "))
 		for c, text := range row {
 			t.RowColors[r].Print(text)
 			if c != numCols-1 {
-				fmt.Print(" │ ")
+				fmt.Print(ToASCII(" │ "))
 			}
 		}
-		fmt.Println(" │")
+		fmt.Println(ToASCII(" │"))
 	}
 
 	// Draw table bottom border
 	border = fmt.Sprintf("%s└%s┘", indentText, strings.Join(segments, "┴"))
-	fmt.Println(border)
+	fmt.Println(ToASCII(border))
 
 	return nil
 }
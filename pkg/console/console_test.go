@@ -40,3 +40,30 @@ func (s *MySuite) TestLock(c *C) {
 	Print("") // Test for deadlocks.
 	Unlock()
 }
+
+func (s *MySuite) TestASCIIReplacer(c *C) {
+	c.Assert(asciiReplacer.Replace("Retry with ‘--force’… ┌─┐ │ 本語"), Equals, "Retry with '--force'... +-+ | 本語")
+}
+
+func (s *MySuite) TestTruncate(c *C) {
+	c.Assert(StringWidth("日本語"), Equals, 6)
+	c.Assert(Truncate("my long content", 5), Equals, "my...")
+	c.Assert(Truncate("日本語の名前", 7), Equals, "日本...")
+	c.Assert(Truncate("short", 10), Equals, "short")
+	c.Assert(Pad("日本", 6, false), Equals, "日本  ")
+	c.Assert(Pad("42", 4, true), Equals, "  42")
+}
+
+func (s *MySuite) TestTableRenderer(c *C) {
+	rows := [][]string{{"id", "日本語"}, {"longer-id", "name"}}
+	t := NewTableRenderer(" | ", Column{}, Column{})
+	c.Assert(t.Render(rows), Equals, "id        | 日本語\nlonger-id | name  ")
+
+	t = NewTableRenderer("", Column{}, Column{})
+	t.Border = true
+	c.Assert(t.Render(rows), Equals, "┌───────────┬────────┐\n│ id        │ 日本語 │\n│ longer-id │ name   │\n└───────────┴────────┘")
+
+	t = NewTableRenderer(" ", Column{Width: 4, AlignRight: true}, Column{})
+	t.Fit([][]string{{"1", "a very long name"}}, 12)
+	c.Assert(t.Row("1", "a very long name"), Equals, "   1 a ve...")
+}
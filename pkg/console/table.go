@@ -0,0 +1,199 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package console
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// ellipsis ends text cut to fit its column.
+const ellipsis = "..."
+
+// StringWidth returns the number of terminal columns taken by s, wide
+// runes like CJK characters take two.
+func StringWidth(s string) int {
+	return runewidth.StringWidth(s)
+}
+
+// Truncate cuts s to at most width terminal columns, ending it with an
+// ellipsis when anything is cut.
+func Truncate(s string, width int) string {
+	if StringWidth(s) <= width {
+		return s
+	}
+	tail := ellipsis
+	if width < len(tail) {
+		tail = ""
+	}
+	w := 0
+	var b strings.Builder
+	for _, r := range s {
+		rw := runewidth.RuneWidth(r)
+		if w+rw > width-len(tail) {
+			break
+		}
+		b.WriteRune(r)
+		w += rw
+	}
+	return b.String() + tail
+}
+
+// Pad fills s with spaces up to width terminal columns, on the left when
+// alignRight is set.
+func Pad(s string, width int, alignRight bool) string {
+	n := width - StringWidth(s)
+	if n <= 0 {
+		return s
+	}
+	if alignRight {
+		return strings.Repeat(" ", n) + s
+	}
+	return s + strings.Repeat(" ", n)
+}
+
+// Column - configuration of a table column. Cells of a column with a
+// positive width are padded and cut to that many terminal columns, other
+// cells are printed as they are.
+type Column struct {
+	Theme      string
+	Width      int
+	AlignRight bool
+}
+
+// TableRenderer - renders rows with aligned columns, measured in terminal
+// columns so that long names and wide runes do not break alignment.
+type TableRenderer struct {
+	Columns   []Column
+	Separator string
+	Border    bool
+}
+
+// NewTableRenderer - returns a renderer of columns separated by separator.
+func NewTableRenderer(separator string, columns ...Column) *TableRenderer {
+	return &TableRenderer{Columns: columns, Separator: separator}
+}
+
+// Fit - sizes columns without a width to their widest cell in rows, then
+// shrinks the widest of them until a row fits in maxWidth terminal
+// columns. maxWidth <= 0 does not limit rows.
+func (t *TableRenderer) Fit(rows [][]string, maxWidth int) {
+	var fitted []int
+	for i := range t.Columns {
+		if t.Columns[i].Width > 0 {
+			continue
+		}
+		fitted = append(fitted, i)
+		width := 0
+		for _, row := range rows {
+			if i < len(row) && StringWidth(row[i]) > width {
+				width = StringWidth(row[i])
+			}
+		}
+		t.Columns[i].Width = width
+	}
+	if maxWidth <= 0 {
+		return
+	}
+	for t.width() > maxWidth {
+		widest := -1
+		for _, i := range fitted {
+			if t.Columns[i].Width > len(ellipsis) && (widest < 0 || t.Columns[i].Width > t.Columns[widest].Width) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			return
+		}
+		t.Columns[widest].Width--
+	}
+}
+
+// width - returns the terminal columns taken by a row of fixed widths.
+func (t *TableRenderer) width() int {
+	width := 0
+	for _, c := range t.Columns {
+		width += c.Width
+	}
+	if len(t.Columns) > 1 {
+		width += (len(t.Columns) - 1) * StringWidth(t.separator())
+	}
+	if t.Border {
+		width += 4
+	}
+	return width
+}
+
+// separator - returns the text between two cells.
+func (t *TableRenderer) separator() string {
+	if t.Border {
+		return ToASCII(" │ ")
+	}
+	return t.Separator
+}
+
+// Row - returns the line of cells, cells without a column are dropped.
+func (t *TableRenderer) Row(cells ...string) string {
+	n := len(cells)
+	if len(t.Columns) < n {
+		n = len(t.Columns)
+	}
+	texts := make([]string, n)
+	for i := 0; i < n; i++ {
+		c := t.Columns[i]
+		text := cells[i]
+		if c.Width > 0 {
+			text = Pad(Truncate(text, c.Width), c.Width, c.AlignRight)
+		}
+		if c.Theme != "" {
+			text = Colorize(c.Theme, text)
+		}
+		texts[i] = text
+	}
+	line := strings.Join(texts, t.separator())
+	if t.Border {
+		line = ToASCII("│ ") + line + ToASCII(" │")
+	}
+	return line
+}
+
+// Render - returns rows as lines, between a top and a bottom border when
+// enabled. Columns are fitted to rows first.
+func (t *TableRenderer) Render(rows [][]string) string {
+	t.Fit(rows, 0)
+	var lines []string
+	if t.Border {
+		lines = append(lines, t.rule("┌", "┬", "┐"))
+	}
+	for _, row := range rows {
+		lines = append(lines, t.Row(row...))
+	}
+	if t.Border {
+		lines = append(lines, t.rule("└", "┴", "┘"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// rule - returns a border line of the table.
+func (t *TableRenderer) rule(left, middle, right string) string {
+	segments := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		segments[i] = strings.Repeat("─", c.Width+2)
+	}
+	return ToASCII(left + strings.Join(segments, middle) + right)
+}
@@ -0,0 +1,50 @@
+// +build windows
+
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package console
+
+import (
+	"syscall"
+)
+
+// Console mode flag interpreting ANSI escape sequences, available
+// starting with Windows 10.
+const enableVirtualTerminalProcessing = 0x0004
+
+var procSetConsoleMode = syscall.NewLazyDLL("kernel32.dll").NewProc("SetConsoleMode")
+
+// EnableVirtualTerminal enables processing of ANSI escape sequences on
+// the standard output and error consoles, returns false if the console
+// does not support them. Redirected outputs are left untouched.
+func EnableVirtualTerminal() bool {
+	for _, handle := range []syscall.Handle{syscall.Stdout, syscall.Stderr} {
+		var mode uint32
+		if e := syscall.GetConsoleMode(handle, &mode); e != nil {
+			// Not a console.
+			continue
+		}
+		if mode&enableVirtualTerminalProcessing != 0 {
+			continue
+		}
+		r, _, _ := procSetConsoleMode.Call(uintptr(handle), uintptr(mode|enableVirtualTerminalProcessing))
+		if r == 0 {
+			return false
+		}
+	}
+	return true
+}